@@ -9,6 +9,7 @@ import (
     "path"
     "regexp"
     "strconv"
+    "strings"
     "unicode"
     "./src/java2go"
 )
@@ -17,7 +18,7 @@ func processArgs() (string, []string, bool) {
     usage := false
 
     dirp := flag.String("d", "", "ANT+ Fit Java source directory")
-    readp := flag.Bool("r", false, "If set, print readData() function")
+    readp := flag.Bool("r", false, "If set, print newMessage() function")
 
     flag.Parse()
 
@@ -84,9 +85,14 @@ func printMsgUnknown() {
     fmt.Println()
     fmt.Println("// unknown message")
     fmt.Println()
+    fmt.Println("// MsgUnknown holds a message whose global message number is not")
+    fmt.Println("// in the profile.  Data is the raw record; each field is also")
+    fmt.Println("// available from UnknownFields().")
     fmt.Println("type MsgUnknown struct {")
+    fmt.Println("    msgBase")
+    fmt.Println()
     fmt.Println("    global_num uint16")
-    fmt.Println("    data []byte")
+    fmt.Println("    Data []byte")
     fmt.Println("}")
     fmt.Println()
     fmt.Println("func (msg *MsgUnknown) Name() string {")
@@ -97,13 +103,54 @@ func printMsgUnknown() {
     fmt.Println("    return fmt.Sprintf(\"unknown#%d\", msg.global_num)")
     fmt.Println("}")
     fmt.Println()
+    fmt.Println("func (msg *MsgUnknown) IsSet(name string) bool {")
+    fmt.Println("    return false")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("func (msg *MsgUnknown) Scaled(name string) float64 {")
+    fmt.Println("    return math.NaN()")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("func (msg *MsgUnknown) Units(name string) string {")
+    fmt.Println("    return \"\"")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("// GlobalNum returns the message's global message number")
+    fmt.Println("func (msg *MsgUnknown) GlobalNum() uint16 {")
+    fmt.Println("    return msg.global_num")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("// FieldByNum returns the field numbered 'num'")
+    fmt.Println("func (msg *MsgUnknown) FieldByNum(num byte) Value {")
+    fmt.Println("    return msg.unknownValue(num)")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("// Field returns the developer field with the given name, as no other")
+    fmt.Println("// fields of an unknown message have names")
+    fmt.Println("func (msg *MsgUnknown) Field(name string) Value {")
+    fmt.Println("    return msg.developerValue(name)")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("// Fields returns every field of the message")
+    fmt.Println("func (msg *MsgUnknown) Fields() []Value {")
+    fmt.Println("    return fieldValues(msg, nil)")
+    fmt.Println("}")
+    fmt.Println()
     fmt.Println("func NewMsgUnknown(def *FitDefinition, data []byte,")
     fmt.Println("    global_num uint16) (*MsgUnknown, error) {")
     fmt.Println("    msg := new(MsgUnknown)")
     fmt.Println()
     fmt.Println("    msg.global_num = global_num")
-    fmt.Println("    msg.data = make([]byte, len(data))")
-    fmt.Println("    copy(msg.data, data)")
+    fmt.Println("    msg.Data = make([]byte, len(data))")
+    fmt.Println("    copy(msg.Data, data)")
+    fmt.Println()
+    fmt.Println("    // every field in an unknown message is an unknown field")
+    fmt.Println("    pos := 0")
+    fmt.Println("    for i := 0; i < len(def.Fields); i++ {")
+    fmt.Println("        fld := def.Fields[i]")
+    fmt.Println("        msg.addUnknown(def, fld, data[pos:pos + int(fld.Size)])")
+    fmt.Println("        pos += int(fld.Size)")
+    fmt.Println("    }")
     fmt.Println()
     fmt.Println("    return msg, nil")
     fmt.Println("}")
@@ -114,23 +161,42 @@ func printInitial() {
     fmt.Println()
 
     fmt.Println("import (")
-    fmt.Println("    \"errors\"")
     fmt.Println("    \"fmt\"")
+    fmt.Println("    \"math\"")
+    fmt.Println("    \"time\"")
     fmt.Println(")")
     fmt.Println()
+    fmt.Println("// FitFieldDefinition describes one field of a definition message")
     fmt.Println("type FitFieldDefinition struct {")
-    fmt.Println("    num byte")
-    fmt.Println("    size byte")
-    fmt.Println("    is_endian bool")
-    fmt.Println("    base_type byte")
+    fmt.Println("    Num byte")
+    fmt.Println("    Size byte")
+    fmt.Println("    IsEndian bool")
+    fmt.Println("    BaseType byte")
+    fmt.Println("}")
+    fmt.Println()
+    fmt.Println("// FitDevFieldDefinition describes a FIT 2.0 developer field")
+    fmt.Println("type FitDevFieldDefinition struct {")
+    fmt.Println("    Num byte")
+    fmt.Println("    Size byte")
+    fmt.Println("    DevIndex byte")
     fmt.Println("}")
     fmt.Println()
+    fmt.Println("// FitDefinition describes the layout of the data messages which use")
+    fmt.Println("// its local message type")
     fmt.Println("type FitDefinition struct {")
-    fmt.Println("    local_type byte")
-    fmt.Println("    little_endian bool")
-    fmt.Println("    global_num uint16")
-    fmt.Println("    fields []*FitFieldDefinition")
-    fmt.Println("    total_bytes uint16")
+    fmt.Println("    LocalType byte")
+    fmt.Println("    LittleEndian bool")
+    fmt.Println("    GlobalNum uint16")
+    fmt.Println("    Fields []*FitFieldDefinition")
+    fmt.Println("    DevFields []*FitDevFieldDefinition")
+    fmt.Println("    TotalBytes int")
+    fmt.Println()
+    fmt.Println("    // copy of this definition used for compressed-timestamp records")
+    fmt.Println("    timestamp_def *FitDefinition")
+    fmt.Println("    // copy of this definition with fields unpacked from components")
+    fmt.Println("    component_def *FitDefinition")
+    fmt.Println("    // fields which hold or restart running totals")
+    fmt.Println("    accum_plan *accumPlan")
     fmt.Println("}")
     fmt.Println()
     fmt.Println("// message interface")
@@ -138,6 +204,19 @@ func printInitial() {
     fmt.Println("type FitMsg interface {")
     fmt.Println("    Name() string")
     fmt.Println("    Text() string")
+    fmt.Println("    IsSet(name string) bool")
+    fmt.Println("    Scaled(name string) float64")
+    fmt.Println("    Units(name string) string")
+    fmt.Println("    Accumulated(name string) float64")
+    fmt.Println("    SubField(name string) string")
+    fmt.Println("    UnknownFields() []*FitUnknownField")
+    fmt.Println("    DeveloperFields() []*FitDeveloperField")
+    fmt.Println("    GlobalNum() uint16")
+    fmt.Println("    Field(name string) Value")
+    fmt.Println("    FieldByNum(num byte) Value")
+    fmt.Println("    Fields() []Value")
+    fmt.Println()
+    fmt.Println("    base() *msgBase")
     fmt.Println("}")
     fmt.Println()
 }
@@ -145,45 +224,130 @@ func printInitial() {
 func printMessages(dir string, list []*MesgNum) {
     printInitial()
 
+    components := make(map[int]string)
+    accumulated := make(map[int][]int)
     for _, m := range list {
         msg, err := java2go.NewMessage(dir, m.name)
         if err != nil {
             fmt.Fprintf(os.Stderr, "Cannot read %s: %s\n", m.name, err)
         } else {
+            msg.SetGlobalNum(m.num)
             msg.PrintFuncs()
+
+            if msg.HasComponents() {
+                components[m.num] = msg.ComponentTable()
+            }
+            if nums := msg.AccumulatedFields(); len(nums) > 0 {
+                accumulated[m.num] = nums
+            }
         }
     }
 
     printMsgUnknown()
+    printHandlers(list)
+    printEnums()
+    printComponentTable(list, components)
+    printAccumulatedTable(list, accumulated)
 }
 
-func printReadData(list []*MesgNum) {
+// messages newer than the SDK, implemented in developer.go
+var extra_messages = []string{"FieldDescription", "DeveloperDataId"}
+
+func printHandlers(list []*MesgNum) {
+    var names []string
+    for _, m := range list {
+        names = append(names, m.name)
+    }
+    names = append(names, extra_messages...)
+
     fmt.Println()
-    fmt.Println("func (ffile *FitFile) readData(def *FitDefinition,")
-    fmt.Println("    time_offset uint32, verbose bool) (FitMsg, error) {")
+    fmt.Println("// Handlers holds the callbacks used by FitFile.Decode().  All are")
+    fmt.Println("// optional.  OnMessage receives every data message which doesn't")
+    fmt.Println("// have a callback of its own, including unknown messages.")
+    fmt.Println("type Handlers struct {")
+    fmt.Println("    OnDefinition func(def *FitDefinition) error")
+    fmt.Println("    OnMessage func(msg FitMsg) error")
     fmt.Println()
-    fmt.Println("    buf := make([]byte, def.total_bytes)")
+    for _, name := range names {
+        fmt.Printf("    On%s func(msg *Msg%s) error\n", name, name)
+    }
+    fmt.Println("}")
     fmt.Println()
-    fmt.Println("    n, err := ffile.rdr.Read(buf)")
-    fmt.Println("    if err != nil {")
-    fmt.Println("        return nil, err")
-    fmt.Println("    } else if n != len(buf) {")
-    fmt.Println("        return nil, errors.New(fmt.Sprintf(\"Read %d" +
-        " bytes, not %d\", n,")
-    fmt.Println("            len(buf)))")
+    fmt.Println("// pass 'msg' to its callback")
+    fmt.Println("func (handlers *Handlers) dispatch(msg FitMsg) error {")
+    fmt.Println("    switch m := msg.(type) {")
+    for _, name := range names {
+        fmt.Printf("    case *Msg%s:\n", name)
+        fmt.Printf("        if handlers.On%s != nil {\n", name)
+        fmt.Printf("            return handlers.On%s(m)\n", name)
+        fmt.Println("        }")
+    }
     fmt.Println("    }")
     fmt.Println()
-    fmt.Println("    switch def.global_num {")
+    fmt.Println("    if handlers.OnMessage != nil {")
+    fmt.Println("        return handlers.OnMessage(msg)")
+    fmt.Println("    }")
+    fmt.Println()
+    fmt.Println("    return nil")
+    fmt.Println("}")
+}
+
+func printEnums() {
+    for _, enum := range java2go.Enums() {
+        fmt.Println()
+        enum.PrintType()
+    }
+}
+
+func printComponentTable(list []*MesgNum, components map[int]string) {
+    fmt.Println()
+    fmt.Println("// fields which are unpacked into other fields," +
+        " by global message number")
+    fmt.Println("var profile_components = map[uint16]map[byte][]*fitComponent{")
+    for _, m := range list {
+        if tbl, ok := components[m.num]; ok {
+            fmt.Printf("    %d: %s,\n", m.num, tbl)
+        }
+    }
+    fmt.Println("}")
+}
+
+func printNewMessage(list []*MesgNum) {
+    fmt.Println()
+    fmt.Println("// decode the data in 'buf' into the message described by 'def'")
+    fmt.Println("func newMessage(def *FitDefinition, buf []byte) (FitMsg, error) {")
+    fmt.Println("    switch def.GlobalNum {")
 
     for _, m := range list {
         fmt.Printf("    case %d: return NewMsg%s(def, buf)\n", m.num, m.name)
     }
 
-    fmt.Println("    default: return NewMsgUnknown(def, buf, def.global_num)")
+    // messages newer than the SDK, implemented in developer.go
+    fmt.Println("    case 206: return NewMsgFieldDescription(def, buf)")
+    fmt.Println("    case 207: return NewMsgDeveloperDataId(def, buf)")
+
+    fmt.Println("    default: return NewMsgUnknown(def, buf, def.GlobalNum)")
     fmt.Println("    }")
     fmt.Println("}")
 }
 
+func printAccumulatedTable(list []*MesgNum, accumulated map[int][]int) {
+    fmt.Println()
+    fmt.Println("// fields which hold running totals, by global message number")
+    fmt.Println("var profile_accumulated = map[uint16][]byte{")
+    for _, m := range list {
+        if nums, ok := accumulated[m.num]; ok {
+            var strs []string
+            for _, n := range nums {
+                strs = append(strs, fmt.Sprintf("%d", n))
+            }
+
+            fmt.Printf("    %d: []byte{%s},\n", m.num, strings.Join(strs, ", "))
+        }
+    }
+    fmt.Println("}")
+}
+
 func toClassName(mesgnum string) string {
     var class []rune
 
@@ -203,7 +367,7 @@ func toClassName(mesgnum string) string {
 }
 
 func main() {
-    dir, files, addReadDataFunc := processArgs()
+    dir, files, addNewMessageFunc := processArgs()
 
     if len(files) == 0 {
         if dir == "" {
@@ -215,18 +379,27 @@ func main() {
             } else {
                 printMessages(dir, list)
 
-                if addReadDataFunc {
-                    printReadData(list)
+                if addNewMessageFunc {
+                    printNewMessage(list)
                 }
             }
         }
     } else {
+        // global message numbers are only found in MesgNum
+        list, _ := readMessages(dir)
+
         for _, f := range files {
             msg, err := java2go.NewMessage(dir, f)
             if err != nil {
                 fmt.Fprintf(os.Stderr, "Cannot read %s: %s\n", f, err)
             }
 
+            for _, m := range list {
+                if m.name == msg.ClassName() {
+                    msg.SetGlobalNum(m.num)
+                }
+            }
+
             msg.PrintFuncs()
         }
     }
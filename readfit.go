@@ -1,6 +1,7 @@
 package main
 
 import (
+    "context"
     "flag"
     "fmt"
     "os"
@@ -8,11 +9,21 @@ import (
     "./src/ant-fit"
 )
 
-func readFit(filename string, verbose bool) error {
-    ffile, err := ant_fit.NewFitFile(filename)
+func readFit(filename string, verbose bool, recovery bool) error {
+    var ffile *ant_fit.FitFile
+    var err error
+
+    if filename == "-" {
+        ffile, err = ant_fit.NewDecoder(os.Stdin)
+    } else {
+        ffile, err = ant_fit.NewFitFile(filename)
+    }
     if err != nil {
         return err
     }
+    defer ffile.Close()
+
+    ffile.RecoverFromErrors(recovery)
 
     if verbose {
         fmt.Println(ffile.String())
@@ -27,19 +38,47 @@ func readFit(filename string, verbose bool) error {
         }
     }
 
+    for _, skip := range ffile.Skipped() {
+        fmt.Printf("!! %s: %s\n", filename, skip)
+    }
+
     return nil
 }
 
-func processArgs() (bool, []string) {
+// decode the files in parallel, printing a summary of each
+func batchFit(files []string, workers int, recovery bool) {
+    opts := &ant_fit.BatchOptions{Workers: workers, Recover: recovery}
+
+    for res := range ant_fit.DecodeFiles(context.Background(), files, opts) {
+        if res.Err != nil {
+            fmt.Printf("!! Cannot read %s: %s\n", res.Path, res.Err)
+            continue
+        }
+
+        fmt.Printf("%s: %s\n", res.Path, res.Summary)
+        for _, skip := range res.Summary.Skipped {
+            fmt.Printf("!! %s: %s\n", res.Path, skip)
+        }
+    }
+}
+
+func processArgs() (bool, bool, int, []string) {
     usage := false
 
     verbosep := flag.Bool("verbose", false, "Verbose mode")
+    recoverp := flag.Bool("recover", false,
+        "Skip damaged data instead of stopping")
+    jobsp := flag.Int("jobs", 0,
+        "Decode this many files at once and summarize each of them")
 
     flag.Parse()
 
     files := make([]string, 0)
     for _, f := range flag.Args() {
-        if _, err := os.Stat(f); os.IsNotExist(err) {
+        if f == "-" {
+            // read from standard input
+            files = append(files, f)
+        } else if _, err := os.Stat(f); os.IsNotExist(err) {
             fmt.Println("File ", f, " does not exist")
             usage = true
         } else {
@@ -50,20 +89,27 @@ func processArgs() (bool, []string) {
     if usage {
         fmt.Print("Usage: readfit.go")
         fmt.Print("[-verbose]")
+        fmt.Print("[-recover]")
+        fmt.Print("[-jobs workers]")
         fmt.Print("file [file ...]")
         fmt.Println()
 
         os.Exit(1)
     }
 
-    return *verbosep, files
+    return *verbosep, *recoverp, *jobsp, files
 }
 
 func main() {
-    verbose, files := processArgs()
+    verbose, recovery, workers, files := processArgs()
+
+    if workers > 0 {
+        batchFit(files, workers, recovery)
+        return
+    }
 
     for _, f := range files {
-        err := readFit(f, verbose)
+        err := readFit(f, verbose, recovery)
         if err != nil {
             fmt.Printf("!! Cannot read %s: %s\n", f, err)
         }
@@ -0,0 +1,259 @@
+package ant_fit
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "io/fs"
+    "path"
+    "runtime"
+    "strings"
+    "time"
+)
+
+// BatchOptions controls how DecodeFiles() and DecodeFS() decode files
+type BatchOptions struct {
+    // number of files decoded at once; zero means one per CPU
+    Workers int
+    // skip damaged data instead of failing; see RecoverFromErrors()
+    Recover bool
+}
+
+// BatchResult is the outcome of decoding one file in a batch.  Exactly
+// one of Summary and Err is set.
+type BatchResult struct {
+    // the path as it was passed to DecodeFiles(), or the path within the
+    // file system passed to DecodeFS()
+    Path string
+    Summary *Summary
+    Err error
+}
+
+// Summary describes a decoded file without holding on to its messages
+type Summary struct {
+    // file_id message of each FIT file in the stream which had one
+    FileIds []*MsgFileId
+    // number of data messages, in total and for each message number
+    Messages int
+    Counts map[MesgNum]int
+    // earliest and latest timestamps of the messages, in UTC, or the zero
+    // time if no message had one
+    Start time.Time
+    End time.Time
+    // bytes skipped in recovery mode
+    Skipped []*SkippedRange
+}
+
+// a file to be decoded, and a channel for its result
+type batchJob struct {
+    path string
+    open func() (*FitFile, error)
+    result chan *BatchResult
+}
+
+// DecodeFiles decodes the named files using a pool of workers, and sends
+// a result for each of them on the returned channel in the order they
+// were named.  The channel is closed after the last result, or as soon
+// as 'ctx' is cancelled; the caller must either read it until it is
+// closed or cancel 'ctx'.
+func DecodeFiles(ctx context.Context, paths []string,
+    opts *BatchOptions) <-chan *BatchResult {
+    jobs := make([]*batchJob, len(paths))
+    for i, name := range paths {
+        name := name
+        jobs[i] = &batchJob{path: name, open: func() (*FitFile, error) {
+            return NewFitFile(name)
+        }}
+    }
+
+    return runBatch(ctx, jobs, opts)
+}
+
+// DecodeFS decodes every file in 'fsys' whose name ends in ".fit", in any
+// case, like DecodeFiles().  Results are sent in the lexical order of the
+// paths, and an unreadable directory gets a result of its own.
+func DecodeFS(ctx context.Context, fsys fs.FS,
+    opts *BatchOptions) <-chan *BatchResult {
+    var jobs []*batchJob
+    fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry,
+        err error) error {
+        if err != nil {
+            jobs = append(jobs, &batchJob{path: name,
+                open: func() (*FitFile, error) {
+                    return nil, err
+                }})
+        } else if !entry.IsDir() &&
+            strings.EqualFold(path.Ext(name), ".fit") {
+            jobs = append(jobs, &batchJob{path: name,
+                open: func() (*FitFile, error) {
+                    return openFS(fsys, name)
+                }})
+        }
+
+        // keep going after an error
+        return nil
+    })
+
+    return runBatch(ctx, jobs, opts)
+}
+
+// open the named file in 'fsys' and return a decoder which reads from it
+func openFS(fsys fs.FS, name string) (*FitFile, error) {
+    file, err := fsys.Open(name)
+    if err != nil {
+        return nil, fmt.Errorf("Cannot open \"%s\": %w", name, err)
+    }
+
+    ffile, err := NewDecoder(file)
+    if err != nil {
+        file.Close()
+        return nil, err
+    }
+
+    ffile.filename = name
+    ffile.closer = file
+
+    return ffile, nil
+}
+
+func runBatch(ctx context.Context, jobs []*batchJob,
+    opts *BatchOptions) <-chan *BatchResult {
+    if opts == nil {
+        opts = &BatchOptions{}
+    }
+
+    workers := opts.Workers
+    if workers <= 0 {
+        workers = runtime.NumCPU()
+    }
+
+    // jobs in the order their results are sent, which also limits how far
+    // the workers can get ahead of the caller
+    ordered := make(chan *batchJob, workers)
+    work := make(chan *batchJob)
+    results := make(chan *BatchResult)
+
+    go func() {
+        defer close(ordered)
+        defer close(work)
+
+        for _, job := range jobs {
+            job.result = make(chan *BatchResult, 1)
+
+            select {
+            case ordered <- job:
+            case <-ctx.Done():
+                return
+            }
+
+            select {
+            case work <- job:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    for i := 0; i < workers; i++ {
+        go func() {
+            for job := range work {
+                job.result <- job.decode(ctx, opts)
+            }
+        }()
+    }
+
+    go func() {
+        defer close(results)
+
+        for job := range ordered {
+            var res *BatchResult
+            select {
+            case res = <-job.result:
+            case <-ctx.Done():
+                return
+            }
+
+            select {
+            case results <- res:
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+
+    return results
+}
+
+func (job *batchJob) decode(ctx context.Context,
+    opts *BatchOptions) *BatchResult {
+    res := &BatchResult{Path: job.path}
+
+    ffile, err := job.open()
+    if err != nil {
+        res.Err = err
+        return res
+    }
+    defer ffile.Close()
+
+    ffile.RecoverFromErrors(opts.Recover)
+
+    res.Summary, res.Err = ffile.summarize(ctx)
+    return res
+}
+
+// read the rest of the stream without keeping the messages, and summarize
+// what was read
+func (ffile *FitFile) summarize(ctx context.Context) (*Summary, error) {
+    ffile.RetainMessages(false)
+
+    sum := &Summary{Counts: make(map[MesgNum]int)}
+    for {
+        if err := ctx.Err(); err != nil {
+            return nil, err
+        }
+
+        msg, err := ffile.Next()
+        if err == io.EOF {
+            break
+        } else if err != nil {
+            return nil, err
+        }
+
+        sum.Messages++
+        sum.Counts[MesgNum(msg.GlobalNum())]++
+
+        if val := msg.FieldByNum(253); val.Valid {
+            ts, ok := val.Raw.(uint32)
+            if tm := DateTime(ts); ok && !tm.IsZero() {
+                if sum.Start.IsZero() || tm.Before(sum.Start) {
+                    sum.Start = tm
+                }
+                if tm.After(sum.End) {
+                    sum.End = tm
+                }
+            }
+        }
+    }
+
+    for _, sub := range ffile.files {
+        if sub.file_id != nil {
+            sum.FileIds = append(sum.FileIds, sub.file_id)
+        }
+    }
+    sum.Skipped = ffile.Skipped()
+
+    return sum, nil
+}
+
+func (sum *Summary) String() string {
+    str := fmt.Sprintf("%d messages", sum.Messages)
+    if !sum.Start.IsZero() {
+        str += fmt.Sprintf(" from %s to %s",
+            sum.Start.Format(time.RFC3339), sum.End.Format(time.RFC3339))
+    }
+    if len(sum.Skipped) > 0 {
+        str += fmt.Sprintf(", %d damaged ranges skipped", len(sum.Skipped))
+    }
+
+    return str
+}
@@ -0,0 +1,251 @@
+package ant_fit
+
+import (
+    "context"
+    "errors"
+    "io/fs"
+    "os"
+    "path/filepath"
+    "runtime"
+    "testing"
+    "testing/fstest"
+    "time"
+)
+
+// write each of 'files' to a temporary directory, returning their paths
+func writeTestFiles(t *testing.T, files ...[]byte) []string {
+    t.Helper()
+
+    dir := t.TempDir()
+    paths := make([]string, len(files))
+    for i, data := range files {
+        paths[i] = filepath.Join(dir, string(rune('a' + i)) + ".fit")
+        if err := os.WriteFile(paths[i], data, 0644); err != nil {
+            t.Fatal(err)
+        }
+    }
+
+    return paths
+}
+
+// read every result from 'results', failing if the channel is not closed
+// in good time
+func collectResults(t *testing.T, results <-chan *BatchResult) []*BatchResult {
+    t.Helper()
+
+    var list []*BatchResult
+    timeout := time.After(10 * time.Second)
+    for {
+        select {
+        case res, ok := <-results:
+            if !ok {
+                return list
+            }
+            list = append(list, res)
+        case <-timeout:
+            t.Fatalf("results not closed after %d of them", len(list))
+        }
+    }
+}
+
+// wait for the goroutines started since 'baseline' was counted to exit
+func checkGoroutines(t *testing.T, baseline int) {
+    t.Helper()
+
+    deadline := time.Now().Add(5 * time.Second)
+    for runtime.NumGoroutine() > baseline {
+        if time.Now().After(deadline) {
+            t.Fatalf("%d goroutines still running, not %d",
+                runtime.NumGoroutine(), baseline)
+        }
+        time.Sleep(10 * time.Millisecond)
+    }
+}
+
+// results come back in the order the files were named, whichever file
+// finishes first, and a bad file does not stop the others
+func TestDecodeFilesOrder(t *testing.T) {
+    counts := []int{3000, 10, 0, 200, 20, -1, 1}
+
+    var files [][]byte
+    for _, count := range counts {
+        switch count {
+        case 0:
+            files = append(files, []byte("this is not a FIT file"))
+        case -1:
+            // the header claims more data than there is
+            data := testLargeActivity(100, 50)
+            files = append(files, data[:len(data) / 2])
+        default:
+            files = append(files, testLargeActivity(count, 50))
+        }
+    }
+
+    paths := writeTestFiles(t, files...)
+    missing := filepath.Join(filepath.Dir(paths[0]), "missing.fit")
+    paths = append(paths, missing)
+
+    baseline := runtime.NumGoroutine()
+    results := collectResults(t, DecodeFiles(context.Background(), paths,
+        &BatchOptions{Workers: 4}))
+    checkGoroutines(t, baseline)
+
+    if len(results) != len(paths) {
+        t.Fatalf("%d results, not %d", len(results), len(paths))
+    }
+
+    for i, res := range results {
+        if res.Path != paths[i] {
+            t.Fatalf("result %d is for %s, not %s", i, res.Path, paths[i])
+        }
+        if (res.Summary == nil) == (res.Err == nil) {
+            t.Errorf("%s: summary %v and error %v", res.Path, res.Summary,
+                res.Err)
+        }
+    }
+
+    for i, count := range counts {
+        res := results[i]
+        switch count {
+        case 0:
+            var herr *HeaderError
+            if !errors.As(res.Err, &herr) {
+                t.Errorf("%s: garbled file gave %v", res.Path, res.Err)
+            }
+        case -1:
+            var terr *TruncatedError
+            if !errors.As(res.Err, &terr) {
+                t.Errorf("%s: truncated file gave %v", res.Path, res.Err)
+            }
+        default:
+            if res.Summary == nil {
+                t.Errorf("%s: %v", res.Path, res.Err)
+            } else if res.Summary.Counts[MesgNum(20)] != count ||
+                len(res.Summary.FileIds) != 1 ||
+                res.Summary.End.Sub(res.Summary.Start) !=
+                    time.Duration(count) * time.Second {
+                t.Errorf("%s: summary %s, %d records", res.Path, res.Summary,
+                    res.Summary.Counts[MesgNum(20)])
+            }
+        }
+    }
+
+    last := results[len(results) - 1]
+    if !errors.Is(last.Err, fs.ErrNotExist) {
+        t.Errorf("missing file gave %v", last.Err)
+    }
+}
+
+// recovery mode applies to each file in the batch
+func TestDecodeFilesRecover(t *testing.T) {
+    data := testLargeActivity(100, 50)
+    good := len(data)
+    data = append(data, 0x0f, 0x1f, 0x3f)
+
+    paths := writeTestFiles(t, data)
+
+    res := collectResults(t, DecodeFiles(context.Background(), paths, nil))
+    if len(res) != 1 || res[0].Err == nil {
+        t.Fatalf("trailing bytes gave %v", res)
+    }
+
+    res = collectResults(t, DecodeFiles(context.Background(), paths,
+        &BatchOptions{Recover: true}))
+    if len(res) != 1 || res[0].Err != nil {
+        t.Fatalf("recovering from trailing bytes gave %v", res)
+    }
+
+    sum := res[0].Summary
+    if sum.Counts[MesgNum(20)] != 100 || len(sum.Skipped) != 1 ||
+        sum.Skipped[0].Offset != int64(good) {
+        t.Errorf("summary %s, skipped %v", sum, sum.Skipped)
+    }
+}
+
+// cancelling the context closes the results and stops the workers
+func TestDecodeFilesCancel(t *testing.T) {
+    data := testLargeActivity(1000, 100)
+    files := make([][]byte, 64)
+    for i := range files {
+        files[i] = data
+    }
+    paths := writeTestFiles(t, files...)
+
+    baseline := runtime.NumGoroutine()
+
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+
+    results := DecodeFiles(ctx, paths, &BatchOptions{Workers: 2})
+    if res := <-results; res == nil || res.Err != nil {
+        t.Fatalf("first result %v", res)
+    }
+    cancel()
+
+    rest := collectResults(t, results)
+    if len(rest) + 1 >= len(paths) {
+        t.Errorf("%d results after cancelling", len(rest) + 1)
+    }
+
+    checkGoroutines(t, baseline)
+}
+
+// a context which is already cancelled yields no more than the results
+// in flight
+func TestDecodeFilesCancelled(t *testing.T) {
+    paths := writeTestFiles(t, testLargeActivity(10, 5))
+    paths = append(paths, paths[0], paths[0], paths[0])
+
+    baseline := runtime.NumGoroutine()
+
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+
+    results := collectResults(t, DecodeFiles(ctx, paths, nil))
+    for _, res := range results {
+        if res.Err != nil && !errors.Is(res.Err, context.Canceled) {
+            t.Errorf("%s: %v", res.Path, res.Err)
+        }
+    }
+
+    checkGoroutines(t, baseline)
+}
+
+// only files ending in ".fit" are decoded, in the lexical order of their
+// paths
+func TestDecodeFS(t *testing.T) {
+    fsys := fstest.MapFS{
+        "c.fit": &fstest.MapFile{Data: []byte("this is not a FIT file")},
+        "b/ride.FIT": &fstest.MapFile{Data: testLargeActivity(20, 10)},
+        "a.fit": &fstest.MapFile{Data: testLargeActivity(30, 10)},
+        "b/notes.txt": &fstest.MapFile{Data: []byte("notes")},
+        "d.fit.bak": &fstest.MapFile{Data: testLargeActivity(5, 5)},
+    }
+
+    results := collectResults(t, DecodeFS(context.Background(), fsys,
+        &BatchOptions{Workers: 2}))
+
+    want := []string{"a.fit", "b/ride.FIT", "c.fit"}
+    if len(results) != len(want) {
+        t.Fatalf("%d results, not %d", len(results), len(want))
+    }
+    for i, res := range results {
+        if res.Path != want[i] {
+            t.Errorf("result %d is for %s, not %s", i, res.Path, want[i])
+        }
+    }
+
+    if sum := results[0].Summary; sum == nil ||
+        sum.Counts[MesgNum(20)] != 30 {
+        t.Errorf("a.fit: summary %v, error %v", sum, results[0].Err)
+    }
+    if sum := results[1].Summary; sum == nil ||
+        sum.Counts[MesgNum(20)] != 20 {
+        t.Errorf("b/ride.FIT: summary %v, error %v", sum, results[1].Err)
+    }
+
+    var herr *HeaderError
+    if !errors.As(results[2].Err, &herr) {
+        t.Errorf("c.fit gave %v", results[2].Err)
+    }
+}
@@ -0,0 +1,121 @@
+package ant_fit
+
+import (
+    "bytes"
+    "io"
+    "math"
+    "testing"
+)
+
+// an hour's ride
+var benchActivity = testLargeActivity(3600, 600)
+
+// decode 'data' with Next(), without keeping the messages, and return the
+// number of messages
+func decodeStream(tb testing.TB, data []byte) int {
+    ffile, err := NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        tb.Fatal(err)
+    }
+
+    ffile.RetainMessages(false)
+
+    count := 0
+    for {
+        _, err := ffile.Next()
+        if err == io.EOF {
+            return count
+        } else if err != nil {
+            tb.Fatal(err)
+        }
+
+        count++
+    }
+}
+
+func TestLargeActivity(t *testing.T) {
+    ffile := mustDecode(t, benchActivity)
+
+    if n := len(testMessages(ffile, 20)); n != 3600 {
+        t.Errorf("%d records, not 3600", n)
+    }
+    if n := len(testMessages(ffile, 19)); n != 6 {
+        t.Errorf("%d laps, not 6", n)
+    }
+
+    sess := testMessages(ffile, 18)
+    if len(sess) != 1 || sess[0].Field("total_elapsed_time").Scaled != 3600 {
+        t.Errorf("sessions %v", sess)
+    }
+}
+
+// apart from the message itself, decoding a record doesn't allocate
+func TestDecodeAllocations(t *testing.T) {
+    records := decodeStream(t, benchActivity)
+
+    allocs := testing.AllocsPerRun(5, func() {
+        decodeStream(t, benchActivity)
+    })
+
+    if per := allocs / float64(records); per > 1.05 {
+        t.Errorf("%.2f allocations per message", per)
+    }
+}
+
+// unpacking components doesn't allocate either, though the message keeps
+// its own copy of the packed bytes
+func TestComponentAllocations(t *testing.T) {
+    data := testSensorActivity(3600)
+
+    recs := testMessages(mustDecode(t, data), 20)
+    if len(recs) != 3600 {
+        t.Fatalf("%d records, not 3600", len(recs))
+    }
+
+    last := 0.0
+    for i, msg := range recs {
+        rec := msg.(*MsgRecord)
+        speed := float64(700 + i % 97 * 2) / 100
+        if math.Abs(rec.SpeedScaled() - speed) > 0.001 ||
+            rec.DistanceScaled() <= last {
+            t.Fatalf("record %d: speed %v distance %v after %v", i,
+                rec.SpeedScaled(), rec.DistanceScaled(), last)
+        }
+        last = rec.DistanceScaled()
+    }
+
+    records := decodeStream(t, data)
+
+    allocs := testing.AllocsPerRun(5, func() {
+        decodeStream(t, data)
+    })
+
+    if per := allocs / float64(records); per > 2.05 {
+        t.Errorf("%.2f allocations per message", per)
+    }
+}
+
+func BenchmarkDecode(b *testing.B) {
+    b.ReportAllocs()
+    b.SetBytes(int64(len(benchActivity)))
+
+    records := 0
+    for i := 0; i < b.N; i++ {
+        records += decodeStream(b, benchActivity)
+    }
+
+    b.ReportMetric(float64(b.Elapsed().Nanoseconds()) / float64(records),
+        "ns/record")
+}
+
+func BenchmarkDecodeRetained(b *testing.B) {
+    b.ReportAllocs()
+    b.SetBytes(int64(len(benchActivity)))
+
+    for i := 0; i < b.N; i++ {
+        _, err := decodeTest(benchActivity)
+        if err != nil {
+            b.Fatal(err)
+        }
+    }
+}
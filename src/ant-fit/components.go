@@ -0,0 +1,320 @@
+package ant_fit
+
+import (
+    "bytes"
+    "encoding/binary"
+    "math"
+)
+
+// fitComponent describes some of the bits of a field which are unpacked
+// into another field of the same message
+type fitComponent struct {
+    num byte
+    base_type byte
+    accumulate bool
+    bits uint
+    scale float64
+    offset float64
+
+    // scale and offset of the destination field
+    dest_scale float64
+    dest_offset float64
+}
+
+// running total for an accumulated component
+type accumulator struct {
+    last uint64
+    total uint64
+}
+
+// key for the accumulator used for a field's running total
+func accumKey(global_num uint16, num byte) uint32 {
+    return uint32(global_num) << 8 | uint32(num)
+}
+
+// key for the accumulator used for a component which is unpacked into a
+// field, whose values are in the component's units rather than the field's
+func componentKey(global_num uint16, num byte) uint32 {
+    return 1 << 24 | accumKey(global_num, num)
+}
+
+func (ffile *FitFile) accumulator(key uint32) *accumulator {
+    if ffile.accum == nil {
+        ffile.accum = make(map[uint32]*accumulator)
+    }
+
+    acc, ok := ffile.accum[key]
+    if !ok {
+        acc = new(accumulator)
+        ffile.accum[key] = acc
+    }
+
+    return acc
+}
+
+// add the change since the last value of a rolling 'bits'-bit counter to
+// its running total
+func (ffile *FitFile) accumulate(key uint32, val uint64, bits uint) uint64 {
+    acc := ffile.accumulator(key)
+
+    mask := uint64(1) << bits - 1
+
+    acc.total += (val - acc.last) & mask
+    acc.last = val
+
+    return acc.total
+}
+
+// restart a running total from a known value
+func (ffile *FitFile) setAccumulated(key uint32, val uint64) {
+    acc := ffile.accumulator(key)
+
+    acc.total = val
+    acc.last = val
+}
+
+// a field of a definition which holds a running total, or restarts the
+// running total of the accumulated component 'comp'
+type accumField struct {
+    fld *FitFieldDefinition
+    pos int
+    comp *fitComponent
+}
+
+// the fields of a definition which take part in running totals, found
+// the first time the definition is used
+type accumPlan struct {
+    totals []accumField
+    resync []accumField
+}
+
+func (def *FitDefinition) accumulation() *accumPlan {
+    if def.accum_plan != nil {
+        return def.accum_plan
+    }
+
+    nums := profile_accumulated[def.GlobalNum]
+    comps := profile_components[def.GlobalNum]
+
+    plan := new(accumPlan)
+
+    pos := 0
+    for _, fld := range def.Fields {
+        if bytes.IndexByte(nums, fld.Num) >= 0 {
+            plan.totals = append(plan.totals, accumField{fld: fld, pos: pos})
+        }
+
+        for _, clist := range comps {
+            for _, comp := range clist {
+                if comp.accumulate && comp.num == fld.Num {
+                    plan.resync = append(plan.resync, accumField{fld: fld,
+                        pos: pos, comp: comp})
+                }
+            }
+        }
+
+        pos += int(fld.Size)
+    }
+
+    def.accum_plan = plan
+    return plan
+}
+
+// when a message holds a full value for a field which is also unpacked
+// from accumulated components, restart the components' running totals
+// from it so later messages carry on from the same point
+func (ffile *FitFile) resyncComponents(def *FitDefinition, buf []byte) {
+    for _, af := range def.accumulation().resync {
+        fld, comp := af.fld, af.comp
+        fbuf := buf[af.pos:af.pos + int(fld.Size)]
+
+        order := def.fieldOrder(fld)
+        if !is_valid_raw(fbuf, fld.BaseType, order) {
+            continue
+        }
+
+        raw := float64(get_raw_int(fbuf, fld.BaseType, order))
+        phys := raw / comp.dest_scale - comp.dest_offset
+        val := (phys + comp.offset) * comp.scale
+
+        ffile.setAccumulated(componentKey(def.GlobalNum, comp.num),
+            uint64(math.Floor(val + 0.5)))
+    }
+}
+
+// record the running totals of the accumulated fields in a message
+func (ffile *FitFile) addTotals(msg FitMsg, def *FitDefinition, buf []byte) {
+    plan := def.accumulation()
+    if len(plan.totals) == 0 {
+        return
+    }
+
+    base := msg.base()
+    base.totals = ffile.newTotals(len(plan.totals))
+
+    for _, af := range plan.totals {
+        fld := af.fld
+        fbuf := buf[af.pos:af.pos + int(fld.Size)]
+
+        order := def.fieldOrder(fld)
+        if !is_valid_raw(fbuf, fld.BaseType, order) {
+            continue
+        }
+
+        bits := uint(get_base_size(fld.BaseType) * 8)
+        val := uint64(get_raw_int(fbuf, fld.BaseType, order)) &
+            (uint64(1) << bits - 1)
+
+        base.totals = append(base.totals, fieldTotal{num: fld.Num,
+            val: ffile.accumulate(accumKey(def.GlobalNum, fld.Num), val,
+                bits)})
+    }
+}
+
+// return an empty slice with room for 'n' totals.  Messages share blocks
+// of totals rather than each allocating their own.
+func (ffile *FitFile) newTotals(n int) []fieldTotal {
+    const block_size = 256
+
+    if cap(ffile.totals) - len(ffile.totals) < n {
+        size := block_size
+        if n > size {
+            size = n
+        }
+        ffile.totals = make([]fieldTotal, 0, size)
+    }
+
+    start := len(ffile.totals)
+    ffile.totals = ffile.totals[:start + n]
+
+    return ffile.totals[start:start:start + n]
+}
+
+// definition used for messages with component fields: a field for each
+// component followed by the original fields, so that a value which is in
+// the message itself takes precedence over one unpacked from a component
+func (def *FitDefinition) withComponents(comps map[byte][]*fitComponent) *FitDefinition {
+    if def.component_def != nil {
+        return def.component_def
+    }
+
+    var cflds []*FitFieldDefinition
+    var cbytes int
+    for _, fld := range def.Fields {
+        for _, comp := range comps[fld.Num] {
+            cfld := new(FitFieldDefinition)
+            cfld.Num = comp.num
+            cfld.Size = byte(get_base_size(comp.base_type))
+            cfld.IsEndian = cfld.Size > 1
+            cfld.BaseType = comp.base_type
+
+            cflds = append(cflds, cfld)
+            cbytes += int(cfld.Size)
+        }
+    }
+
+    if len(cflds) == 0 {
+        def.component_def = def
+        return def
+    }
+
+    cdef := new(FitDefinition)
+    *cdef = *def
+
+    cdef.Fields = append(cflds, def.Fields...)
+    cdef.DevFields = nil
+    cdef.TotalBytes = def.fieldBytes() + cbytes
+    cdef.timestamp_def = nil
+    cdef.component_def = cdef
+    cdef.accum_plan = nil
+
+    def.component_def = cdef
+    return cdef
+}
+
+// unpack the component fields in 'buf', returning a definition and data
+// which include the unpacked fields
+func (ffile *FitFile) expandComponents(def *FitDefinition,
+    buf []byte) (*FitDefinition, []byte) {
+    comps, ok := profile_components[def.GlobalNum]
+    if !ok {
+        return def, buf
+    }
+
+    cdef := def.withComponents(comps)
+    if cdef == def {
+        return def, buf
+    }
+
+    order := def.byteOrder()
+
+    cbuf := reuseBuffer(&ffile.comp_buf, cdef.TotalBytes)
+    copy(cbuf[len(cbuf) - len(buf):], buf)
+
+    out := 0
+    pos := 0
+    for _, fld := range def.Fields {
+        fbuf := buf[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        if len(comps[fld.Num]) == 0 {
+            continue
+        }
+
+        valid := is_valid_component_source(fbuf, fld, def.fieldOrder(fld))
+        val := get_component_source(fbuf, fld, def.fieldOrder(fld))
+
+        for _, comp := range comps[fld.Num] {
+            size := get_base_size(comp.base_type)
+            dbuf := cbuf[out:out + size]
+            out += size
+
+            if !valid {
+                put_raw_uint(dbuf, order, base_type_invalid[comp.base_type])
+                continue
+            }
+
+            raw := val & (uint64(1) << comp.bits - 1)
+            val >>= comp.bits
+
+            if comp.accumulate {
+                raw = ffile.accumulate(componentKey(def.GlobalNum, comp.num),
+                    raw, comp.bits)
+            }
+
+            phys := float64(raw) / comp.scale - comp.offset
+            dest := (phys + comp.dest_offset) * comp.dest_scale
+
+            put_raw_uint(dbuf, order, uint64(math.Floor(dest + 0.5)))
+        }
+    }
+
+    return cdef, cbuf
+}
+
+// byte fields are checked in place rather than with get_byte_array(), which
+// would copy them
+func is_valid_component_source(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) bool {
+    if fld.BaseType == 13 {
+        return is_valid_array(buf, fld, order)
+    }
+
+    return is_valid_raw(buf, fld.BaseType, order)
+}
+
+// components are packed starting with the least significant bit, so byte
+// fields are treated as little-endian integers
+func get_component_source(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint64 {
+    if fld.BaseType != 13 {
+        return uint64(get_raw_int(buf, fld.BaseType, order))
+    }
+
+    var val uint64
+    for i := len(buf) - 1; i >= 0; i-- {
+        val = val << 8 | uint64(buf[i])
+    }
+
+    return val
+}
@@ -0,0 +1,206 @@
+package ant_fit
+
+import (
+    "testing"
+)
+
+// pack a speed and distance into a record's compressed_speed_distance
+func packSpeedDistance(speed int, dist int) []byte {
+    packed := speed | dist << 12
+    return []byte{byte(packed), byte(packed >> 8), byte(packed >> 16)}
+}
+
+func TestSpeedDistanceComponents(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{8, 3, 0x0d},
+        testField{52, 2, 0x84})
+    w.data(0, 800000000, packSpeedDistance(305, 4000), 90 * 256 + 128)
+    w.data(0, 800000001, packSpeedDistance(306, 4090), 0xffff)
+    // the 12-bit distance rolls over
+    w.data(0, 800000002, packSpeedDistance(307, 100), 0xffff)
+    w.data(0, 800000003, []byte{0xff, 0xff, 0xff}, 0xffff)
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != 4 {
+        t.Fatalf("%d records, not 4", len(recs))
+    }
+
+    // speed is in 1/100 m/s and distance in 1/16 m in the component, but
+    // 1/1000 m/s and 1/100 m in the destination fields
+    want := []struct {
+        speed uint16
+        dist uint32
+    }{
+        {3050, 25000},
+        {3060, 25563},
+        {3070, 26225},
+        {invalid_uint16, invalid_uint32},
+    }
+
+    for i, msg := range recs {
+        rec := msg.(*MsgRecord)
+        if rec.Speed != want[i].speed || rec.Distance != want[i].dist {
+            t.Errorf("record %d: speed %d distance %d, not %d and %d", i,
+                rec.Speed, rec.Distance, want[i].speed, want[i].dist)
+        }
+    }
+
+    // cadence256 is unpacked into cadence, rounding to the nearest rpm
+    if rec := recs[0].(*MsgRecord); rec.Cadence != 91 ||
+        rec.Cadence256 != 90 * 256 + 128 {
+        t.Errorf("cadence %d, cadence256 %d", rec.Cadence, rec.Cadence256)
+    }
+}
+
+func TestEventDataComponent(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 21, true, testField{253, 4, 0x86}, testField{0, 1, 0x00},
+        testField{1, 1, 0x00}, testField{2, 2, 0x84})
+    w.data(0, 800000000, 0, 0, 1234)
+    // a data field in the message itself wins over the component
+    w.define(1, 21, true, testField{253, 4, 0x86}, testField{0, 1, 0x00},
+        testField{1, 1, 0x00}, testField{2, 2, 0x84}, testField{3, 4, 0x86})
+    w.data(1, 800000001, 0, 0, 1234, 567890)
+
+    events := testMessages(mustDecode(t, w.build()), 21)
+    if len(events) != 2 {
+        t.Fatalf("%d events, not 2", len(events))
+    }
+
+    if evt := events[0].(*MsgEvent); evt.Data16 != 1234 || evt.Data != 1234 {
+        t.Errorf("data16 %d unpacked into data %d", evt.Data16, evt.Data)
+    }
+    if evt := events[1].(*MsgEvent); evt.Data != 567890 {
+        t.Errorf("data %d, not 567890", evt.Data)
+    }
+}
+
+func TestAccumulateRollover(t *testing.T) {
+    tests := []struct {
+        bits uint
+        vals []uint64
+        want []uint64
+    }{
+        {8, []uint64{250, 253, 2, 1}, []uint64{250, 253, 258, 513}},
+        {16, []uint64{65000, 65500, 200}, []uint64{65000, 65500, 65736}},
+        {32, []uint64{0xfffffff0, 0x10}, []uint64{0xfffffff0, 0x100000010}},
+    }
+
+    for _, tst := range tests {
+        ffile := new(FitFile)
+        for i, val := range tst.vals {
+            got := ffile.accumulate(1, val, tst.bits)
+            if got != tst.want[i] {
+                t.Errorf("%d bits: value %d gave total %d, not %d",
+                    tst.bits, val, got, tst.want[i])
+            }
+        }
+    }
+}
+
+func TestAccumulatedFields(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{5, 4, 0x86},
+        testField{18, 1, 0x02}, testField{28, 2, 0x84})
+    w.data(0, 800000000, 0xfffffff0, 250, 65000)
+    w.data(0, 800000001, 0xfffffffa, 253, 65500)
+    // each of the 32-, 8- and 16-bit values rolls over
+    w.data(0, 800000002, 0x10, 2, 200)
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != 3 {
+        t.Fatalf("%d records, not 3", len(recs))
+    }
+
+    want := []struct {
+        dist float64
+        cycles uint32
+        power uint32
+    }{
+        {0xfffffff0 / 100.0, 250, 65000},
+        {0xfffffffa / 100.0, 253, 65500},
+        {0x100000010 / 100.0, 258, 65736},
+    }
+
+    for i, msg := range recs {
+        rec := msg.(*MsgRecord)
+        if dist := rec.Accumulated("distance"); dist != want[i].dist {
+            t.Errorf("record %d: accumulated distance %f, not %f", i, dist,
+                want[i].dist)
+        }
+        if rec.TotalCycles != want[i].cycles ||
+            rec.AccumulatedPower != want[i].power {
+            t.Errorf("record %d: total_cycles %d accumulated_power %d", i,
+                rec.TotalCycles, rec.AccumulatedPower)
+        }
+    }
+}
+
+// a full-width value restarts the running total of the components which
+// are unpacked into the same field
+func TestAccumulatedResync(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{8, 3, 0x0d},
+        testField{18, 1, 0x02})
+    w.define(1, 20, false, testField{253, 4, 0x86}, testField{5, 4, 0x86},
+        testField{19, 4, 0x86})
+
+    w.data(0, 800000000, packSpeedDistance(300, 1600), 10)
+    // 1000 m, or 16000 in the component's 1/16 m units
+    w.data(1, 800000001, 100000, 65500)
+    w.data(0, 800000002, packSpeedDistance(300, (16000 + 50) & 0xfff),
+        (65500 + 10) & 0xff)
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != 3 {
+        t.Fatalf("%d records, not 3", len(recs))
+    }
+
+    want := []struct {
+        dist uint32
+        cycles uint32
+    }{
+        {10000, 10},
+        {100000, 65500},
+        {100313, 65510},
+    }
+
+    for i, msg := range recs {
+        rec := msg.(*MsgRecord)
+        if rec.Distance != want[i].dist || rec.TotalCycles != want[i].cycles {
+            t.Errorf("record %d: distance %d total_cycles %d, not %d and %d",
+                i, rec.Distance, rec.TotalCycles, want[i].dist,
+                want[i].cycles)
+        }
+    }
+}
+
+// the fields which take part in running totals are found once for each
+// definition
+func TestAccumulationPlan(t *testing.T) {
+    def := &FitDefinition{GlobalNum: 20, LittleEndian: true,
+        Fields: []*FitFieldDefinition{
+            {Num: 253, Size: 4, IsEndian: true, BaseType: 6},
+            {Num: 5, Size: 4, IsEndian: true, BaseType: 6},
+            {Num: 3, Size: 1, BaseType: 2},
+            {Num: 29, Size: 4, IsEndian: true, BaseType: 6},
+        }}
+
+    plan := def.accumulation()
+    if def.accumulation() != plan {
+        t.Error("plan was not cached")
+    }
+
+    if len(plan.totals) != 2 || plan.totals[0].pos != 4 ||
+        plan.totals[1].pos != 9 {
+        t.Errorf("totals %+v", plan.totals)
+    }
+    if len(plan.resync) != 2 {
+        t.Errorf("resync %+v", plan.resync)
+    }
+
+    // the copy used for compressed timestamps has its own plan
+    if def.withTimestamp().accumulation() == plan {
+        t.Error("timestamp definition shares the plan")
+    }
+}
@@ -0,0 +1,624 @@
+package ant_fit
+
+import (
+    "encoding/binary"
+    "fmt"
+    "math"
+)
+
+// FIT 2.0 developer data
+//
+// The developer_data_id and field_description messages are newer than the
+// SDK release msgs.go is generated from, so they are written by hand here
+// in the same form as the generated messages.
+
+// FitDeveloperField holds a developer data field's value along with the
+// name, units and scaling from its field_description message
+type FitDeveloperField struct {
+    DeveloperIndex byte
+    Num byte
+    Name string
+    Units string
+    BaseType byte
+    Scale float64
+    Offset float64
+    Data []byte
+
+    order binary.ByteOrder
+}
+
+// Values returns the field's scaled values, with invalid values as NaN
+func (fld *FitDeveloperField) Values() []float64 {
+    if fld.BaseType == 7 {
+        return nil
+    }
+
+    size := get_base_size(fld.BaseType)
+
+    vals := make([]float64, len(fld.Data) / size)
+    for i := 0; i < len(vals); i++ {
+        buf := fld.Data[i * size:]
+        if !is_valid_raw(buf, fld.BaseType, fld.order) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = get_raw_float(buf, fld.BaseType, fld.order) /
+                fld.Scale - fld.Offset
+        }
+    }
+
+    return vals
+}
+
+// Value returns the field's first scaled value, or NaN if it is invalid
+func (fld *FitDeveloperField) Value() float64 {
+    vals := fld.Values()
+    if len(vals) == 0 {
+        return math.NaN()
+    }
+
+    return vals[0]
+}
+
+// StringValue returns the value of a string field
+func (fld *FitDeveloperField) StringValue() string {
+    return get_string(fld.Data, nil, fld.order)
+}
+
+func (fld *FitDeveloperField) String() string {
+    name := fld.Name
+    if name == "" {
+        name = fmt.Sprintf("dev%d#%d", fld.DeveloperIndex, fld.Num)
+    }
+
+    var valstr string
+    if fld.BaseType == 7 {
+        valstr = fld.StringValue()
+    } else {
+        vals := fld.Values()
+        if len(vals) == 1 {
+            valstr = fmt.Sprintf("%v", vals[0])
+        } else {
+            valstr = fmt.Sprintf("%v", vals)
+        }
+    }
+
+    if fld.Units == "" {
+        return fmt.Sprintf("%s %s", name, valstr)
+    }
+
+    return fmt.Sprintf("%s %s %s", name, valstr, fld.Units)
+}
+
+// total size of the profile fields described by a definition, which are
+// followed by the developer fields
+func (def *FitDefinition) fieldBytes() int {
+    total := def.TotalBytes
+    for _, dfld := range def.DevFields {
+        total -= int(dfld.Size)
+    }
+
+    return total
+}
+
+func (ffile *FitFile) readDevFieldDefs(def *FitDefinition, buf []byte) error {
+    err := ffile.read(buf[:1])
+    if err != nil {
+        return err
+    }
+
+    num := int(buf[0])
+
+    def.DevFields = make([]*FitDevFieldDefinition, num)
+    for i := 0; i < num; i++ {
+        err = ffile.read(buf[:3])
+        if err != nil {
+            return err
+        }
+
+        dfld := new(FitDevFieldDefinition)
+        dfld.Num = buf[0]
+        dfld.Size = buf[1]
+        dfld.DevIndex = buf[2]
+
+        def.DevFields[i] = dfld
+        def.TotalBytes += int(dfld.Size)
+    }
+
+    return nil
+}
+
+// remember a developer field's description so its values can be decoded
+func (ffile *FitFile) addFieldDescription(desc *MsgFieldDescription) {
+    if ffile.dev_descs == nil {
+        ffile.dev_descs = make(map[uint16]*MsgFieldDescription)
+    }
+
+    key := uint16(desc.DeveloperDataIndex) << 8 |
+        uint16(desc.FieldDefinitionNumber)
+    ffile.dev_descs[key] = desc
+}
+
+// decode the developer fields in 'data' and attach them to 'msg'
+func (ffile *FitFile) addDeveloperFields(msg FitMsg, def *FitDefinition,
+    data []byte) {
+    base := msg.base()
+
+    pos := 0
+    for _, dfld := range def.DevFields {
+        fld := new(FitDeveloperField)
+
+        fld.DeveloperIndex = dfld.DevIndex
+        fld.Num = dfld.Num
+        fld.BaseType = 13
+        fld.Scale = 1
+        fld.Data = make([]byte, dfld.Size)
+        copy(fld.Data, data[pos:pos + int(dfld.Size)])
+        pos += int(dfld.Size)
+
+        key := uint16(dfld.DevIndex) << 8 | uint16(dfld.Num)
+        if desc, ok := ffile.dev_descs[key]; ok {
+            fld.Name = desc.FieldName
+            fld.Units = desc.FieldUnits
+            fld.BaseType = desc.FitBaseTypeID & 0x1f
+            if is_valid_uint8(desc.Scale) && desc.Scale != 0 {
+                fld.Scale = float64(desc.Scale)
+            }
+            if is_valid_int8(desc.Offset) {
+                fld.Offset = float64(desc.Offset)
+            }
+        }
+
+        // only multi-byte values depend on the architecture
+        if get_base_size(fld.BaseType) > 1 {
+            fld.order = def.byteOrder()
+        } else {
+            fld.order = binary.LittleEndian
+        }
+
+        base.developer = append(base.developer, fld)
+    }
+}
+
+// developer_data_id message
+
+// MsgDeveloperDataId holds the fields of a developer_data_id message.
+// Fields which were not in the message hold FIT's invalid value; see IsSet().
+type MsgDeveloperDataId struct {
+    msgBase
+
+    DeveloperID []byte
+    ApplicationID []byte
+    ManufacturerID Manufacturer
+    DeveloperDataIndex uint8
+    ApplicationVersion uint32
+}
+
+func (msg *MsgDeveloperDataId) Name() string {
+    return "developer_data_id"
+}
+
+func (msg *MsgDeveloperDataId) Text() string {
+    txt := "developer_data_id"
+    if len(msg.DeveloperID) > 0 {
+        txt += fmt.Sprintf(" devid %x", msg.DeveloperID)
+    }
+    if len(msg.ApplicationID) > 0 {
+        txt += fmt.Sprintf(" appid %x", msg.ApplicationID)
+    }
+    if is_valid_uint16(uint16(msg.ManufacturerID)) {
+        txt += fmt.Sprintf(" mfctid %s", msg.ManufacturerID)
+    }
+    if is_valid_uint8(msg.DeveloperDataIndex) {
+        txt += fmt.Sprintf(" devdataidx %d", msg.DeveloperDataIndex)
+    }
+    if is_valid_uint32(msg.ApplicationVersion) {
+        txt += fmt.Sprintf(" appvers %d", msg.ApplicationVersion)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgDeveloperDataId) IsSet(name string) bool {
+    switch name {
+    case "developer_id": return len(msg.DeveloperID) > 0
+    case "application_id": return len(msg.ApplicationID) > 0
+    case "manufacturer_id": return is_valid_uint16(uint16(msg.ManufacturerID))
+    case "developer_data_index": return is_valid_uint8(msg.DeveloperDataIndex)
+    case "application_version": return is_valid_uint32(msg.ApplicationVersion)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgDeveloperDataId) Scaled(name string) float64 {
+    switch name {
+    case "manufacturer_id":
+        if is_valid_uint16(uint16(msg.ManufacturerID)) {
+            return float64(msg.ManufacturerID)
+        }
+    case "developer_data_index":
+        if is_valid_uint8(msg.DeveloperDataIndex) {
+            return float64(msg.DeveloperDataIndex)
+        }
+    case "application_version":
+        if is_valid_uint32(msg.ApplicationVersion) {
+            return float64(msg.ApplicationVersion)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgDeveloperDataId) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgDeveloperDataId) GlobalNum() uint16 {
+    return 207
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgDeveloperDataId) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "developer_id", Num: 0, BaseType: 13,
+            Raw: msg.DeveloperID, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.DeveloperID) > 0}
+    case 1:
+        return Value{Name: "application_id", Num: 1, BaseType: 13,
+            Raw: msg.ApplicationID, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.ApplicationID) > 0}
+    case 2:
+        return Value{Name: "manufacturer_id", Num: 2, BaseType: 4,
+            Raw: msg.ManufacturerID, Scaled: msg.Scaled("manufacturer_id"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.ManufacturerID))}
+    case 3:
+        return Value{Name: "developer_data_index", Num: 3, BaseType: 2,
+            Raw: msg.DeveloperDataIndex,
+            Scaled: msg.Scaled("developer_data_index"),
+            Units: "", Valid: is_valid_uint8(msg.DeveloperDataIndex)}
+    case 4:
+        return Value{Name: "application_version", Num: 4, BaseType: 6,
+            Raw: msg.ApplicationVersion,
+            Scaled: msg.Scaled("application_version"),
+            Units: "", Valid: is_valid_uint32(msg.ApplicationVersion)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field, or the developer field with that name
+func (msg *MsgDeveloperDataId) Field(name string) Value {
+    switch name {
+    case "developer_id": return msg.FieldByNum(0)
+    case "application_id": return msg.FieldByNum(1)
+    case "manufacturer_id": return msg.FieldByNum(2)
+    case "developer_data_index": return msg.FieldByNum(3)
+    case "application_version": return msg.FieldByNum(4)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgDeveloperDataId) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 2, 3, 4})
+}
+
+func NewMsgDeveloperDataId(def *FitDefinition, data []byte) (*MsgDeveloperDataId, error) {
+    msg := new(MsgDeveloperDataId)
+
+    msg.ManufacturerID = Manufacturer(invalid_uint16)
+    msg.DeveloperDataIndex = invalid_uint8
+    msg.ApplicationVersion = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.DeveloperID = get_byte_array(buf, fld, order)
+        case 1: msg.ApplicationID = get_byte_array(buf, fld, order)
+        case 2: msg.ManufacturerID = Manufacturer(get_uint16(buf, fld, order))
+        case 3: msg.DeveloperDataIndex = get_uint8(buf, fld, order)
+        case 4: msg.ApplicationVersion = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// field_description message
+
+// MsgFieldDescription holds the fields of a field_description message.
+// Fields which were not in the message hold FIT's invalid value; see IsSet().
+// The units field is FieldUnits, as Units() is the FitMsg method.
+type MsgFieldDescription struct {
+    msgBase
+
+    DeveloperDataIndex uint8
+    FieldDefinitionNumber uint8
+    FitBaseTypeID uint8
+    FieldName string
+    Array uint8
+    Components string
+    Scale uint8
+    Offset int8
+    FieldUnits string
+    Bits string
+    Accumulate string
+    FitBaseUnitID uint16
+    NativeMesgNum uint16
+    NativeFieldNum uint8
+}
+
+func (msg *MsgFieldDescription) Name() string {
+    return "field_description"
+}
+
+func (msg *MsgFieldDescription) Text() string {
+    txt := "field_description"
+    if is_valid_uint8(msg.DeveloperDataIndex) {
+        txt += fmt.Sprintf(" devdataidx %d", msg.DeveloperDataIndex)
+    }
+    if is_valid_uint8(msg.FieldDefinitionNumber) {
+        txt += fmt.Sprintf(" fielddefinition# %d", msg.FieldDefinitionNumber)
+    }
+    if is_valid_uint8(msg.FitBaseTypeID) {
+        txt += fmt.Sprintf(" fitbasetypid %d", msg.FitBaseTypeID)
+    }
+    if is_valid_string(msg.FieldName) {
+        txt += fmt.Sprintf(" fieldname %s", msg.FieldName)
+    }
+    if is_valid_uint8(msg.Array) {
+        txt += fmt.Sprintf(" array %d", msg.Array)
+    }
+    if is_valid_string(msg.Components) {
+        txt += fmt.Sprintf(" components %s", msg.Components)
+    }
+    if is_valid_uint8(msg.Scale) {
+        txt += fmt.Sprintf(" scale %d", msg.Scale)
+    }
+    if is_valid_int8(msg.Offset) {
+        txt += fmt.Sprintf(" offset %d", msg.Offset)
+    }
+    if is_valid_string(msg.FieldUnits) {
+        txt += fmt.Sprintf(" units %s", msg.FieldUnits)
+    }
+    if is_valid_string(msg.Bits) {
+        txt += fmt.Sprintf(" bits %s", msg.Bits)
+    }
+    if is_valid_string(msg.Accumulate) {
+        txt += fmt.Sprintf(" accumulate %s", msg.Accumulate)
+    }
+    if is_valid_uint16(msg.FitBaseUnitID) {
+        txt += fmt.Sprintf(" fitbaseunitid %d", msg.FitBaseUnitID)
+    }
+    if is_valid_uint16(msg.NativeMesgNum) {
+        txt += fmt.Sprintf(" nativemesg# %d", msg.NativeMesgNum)
+    }
+    if is_valid_uint8(msg.NativeFieldNum) {
+        txt += fmt.Sprintf(" nativefield# %d", msg.NativeFieldNum)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgFieldDescription) IsSet(name string) bool {
+    switch name {
+    case "developer_data_index": return is_valid_uint8(msg.DeveloperDataIndex)
+    case "field_definition_number": return is_valid_uint8(msg.FieldDefinitionNumber)
+    case "fit_base_type_id": return is_valid_uint8(msg.FitBaseTypeID)
+    case "field_name": return is_valid_string(msg.FieldName)
+    case "array": return is_valid_uint8(msg.Array)
+    case "components": return is_valid_string(msg.Components)
+    case "scale": return is_valid_uint8(msg.Scale)
+    case "offset": return is_valid_int8(msg.Offset)
+    case "units": return is_valid_string(msg.FieldUnits)
+    case "bits": return is_valid_string(msg.Bits)
+    case "accumulate": return is_valid_string(msg.Accumulate)
+    case "fit_base_unit_id": return is_valid_uint16(msg.FitBaseUnitID)
+    case "native_mesg_num": return is_valid_uint16(msg.NativeMesgNum)
+    case "native_field_num": return is_valid_uint8(msg.NativeFieldNum)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgFieldDescription) Scaled(name string) float64 {
+    switch name {
+    case "developer_data_index":
+        if is_valid_uint8(msg.DeveloperDataIndex) {
+            return float64(msg.DeveloperDataIndex)
+        }
+    case "field_definition_number":
+        if is_valid_uint8(msg.FieldDefinitionNumber) {
+            return float64(msg.FieldDefinitionNumber)
+        }
+    case "fit_base_type_id":
+        if is_valid_uint8(msg.FitBaseTypeID) {
+            return float64(msg.FitBaseTypeID)
+        }
+    case "array":
+        if is_valid_uint8(msg.Array) {
+            return float64(msg.Array)
+        }
+    case "scale":
+        if is_valid_uint8(msg.Scale) {
+            return float64(msg.Scale)
+        }
+    case "offset":
+        if is_valid_int8(msg.Offset) {
+            return float64(msg.Offset)
+        }
+    case "fit_base_unit_id":
+        if is_valid_uint16(msg.FitBaseUnitID) {
+            return float64(msg.FitBaseUnitID)
+        }
+    case "native_mesg_num":
+        if is_valid_uint16(msg.NativeMesgNum) {
+            return float64(msg.NativeMesgNum)
+        }
+    case "native_field_num":
+        if is_valid_uint8(msg.NativeFieldNum) {
+            return float64(msg.NativeFieldNum)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgFieldDescription) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgFieldDescription) GlobalNum() uint16 {
+    return 206
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgFieldDescription) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "developer_data_index", Num: 0, BaseType: 2,
+            Raw: msg.DeveloperDataIndex,
+            Scaled: msg.Scaled("developer_data_index"),
+            Units: "", Valid: is_valid_uint8(msg.DeveloperDataIndex)}
+    case 1:
+        return Value{Name: "field_definition_number", Num: 1, BaseType: 2,
+            Raw: msg.FieldDefinitionNumber,
+            Scaled: msg.Scaled("field_definition_number"),
+            Units: "", Valid: is_valid_uint8(msg.FieldDefinitionNumber)}
+    case 2:
+        return Value{Name: "fit_base_type_id", Num: 2, BaseType: 2,
+            Raw: msg.FitBaseTypeID, Scaled: msg.Scaled("fit_base_type_id"),
+            Units: "", Valid: is_valid_uint8(msg.FitBaseTypeID)}
+    case 3:
+        return Value{Name: "field_name", Num: 3, BaseType: 7,
+            Raw: msg.FieldName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.FieldName)}
+    case 4:
+        return Value{Name: "array", Num: 4, BaseType: 2,
+            Raw: msg.Array, Scaled: msg.Scaled("array"),
+            Units: "", Valid: is_valid_uint8(msg.Array)}
+    case 5:
+        return Value{Name: "components", Num: 5, BaseType: 7,
+            Raw: msg.Components, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.Components)}
+    case 6:
+        return Value{Name: "scale", Num: 6, BaseType: 2,
+            Raw: msg.Scale, Scaled: msg.Scaled("scale"),
+            Units: "", Valid: is_valid_uint8(msg.Scale)}
+    case 7:
+        return Value{Name: "offset", Num: 7, BaseType: 1,
+            Raw: msg.Offset, Scaled: msg.Scaled("offset"),
+            Units: "", Valid: is_valid_int8(msg.Offset)}
+    case 8:
+        return Value{Name: "units", Num: 8, BaseType: 7,
+            Raw: msg.FieldUnits, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.FieldUnits)}
+    case 9:
+        return Value{Name: "bits", Num: 9, BaseType: 7,
+            Raw: msg.Bits, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.Bits)}
+    case 10:
+        return Value{Name: "accumulate", Num: 10, BaseType: 7,
+            Raw: msg.Accumulate, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.Accumulate)}
+    case 13:
+        return Value{Name: "fit_base_unit_id", Num: 13, BaseType: 4,
+            Raw: msg.FitBaseUnitID, Scaled: msg.Scaled("fit_base_unit_id"),
+            Units: "", Valid: is_valid_uint16(msg.FitBaseUnitID)}
+    case 14:
+        return Value{Name: "native_mesg_num", Num: 14, BaseType: 4,
+            Raw: msg.NativeMesgNum, Scaled: msg.Scaled("native_mesg_num"),
+            Units: "", Valid: is_valid_uint16(msg.NativeMesgNum)}
+    case 15:
+        return Value{Name: "native_field_num", Num: 15, BaseType: 2,
+            Raw: msg.NativeFieldNum, Scaled: msg.Scaled("native_field_num"),
+            Units: "", Valid: is_valid_uint8(msg.NativeFieldNum)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field, or the developer field with that name
+func (msg *MsgFieldDescription) Field(name string) Value {
+    switch name {
+    case "developer_data_index": return msg.FieldByNum(0)
+    case "field_definition_number": return msg.FieldByNum(1)
+    case "fit_base_type_id": return msg.FieldByNum(2)
+    case "field_name": return msg.FieldByNum(3)
+    case "array": return msg.FieldByNum(4)
+    case "components": return msg.FieldByNum(5)
+    case "scale": return msg.FieldByNum(6)
+    case "offset": return msg.FieldByNum(7)
+    case "units": return msg.FieldByNum(8)
+    case "bits": return msg.FieldByNum(9)
+    case "accumulate": return msg.FieldByNum(10)
+    case "fit_base_unit_id": return msg.FieldByNum(13)
+    case "native_mesg_num": return msg.FieldByNum(14)
+    case "native_field_num": return msg.FieldByNum(15)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgFieldDescription) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 13, 14, 15})
+}
+
+func NewMsgFieldDescription(def *FitDefinition, data []byte) (*MsgFieldDescription, error) {
+    msg := new(MsgFieldDescription)
+
+    msg.DeveloperDataIndex = invalid_uint8
+    msg.FieldDefinitionNumber = invalid_uint8
+    msg.FitBaseTypeID = invalid_uint8
+    msg.Array = invalid_uint8
+    msg.Scale = invalid_uint8
+    msg.Offset = invalid_int8
+    msg.FitBaseUnitID = invalid_uint16
+    msg.NativeMesgNum = invalid_uint16
+    msg.NativeFieldNum = invalid_uint8
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.DeveloperDataIndex = get_uint8(buf, fld, order)
+        case 1: msg.FieldDefinitionNumber = get_uint8(buf, fld, order)
+        case 2: msg.FitBaseTypeID = get_uint8(buf, fld, order)
+        case 3: msg.FieldName = get_string(buf, fld, order)
+        case 4: msg.Array = get_uint8(buf, fld, order)
+        case 5: msg.Components = get_string(buf, fld, order)
+        case 6: msg.Scale = get_uint8(buf, fld, order)
+        case 7: msg.Offset = get_int8(buf, fld, order)
+        case 8: msg.FieldUnits = get_string(buf, fld, order)
+        case 9: msg.Bits = get_string(buf, fld, order)
+        case 10: msg.Accumulate = get_string(buf, fld, order)
+        case 13: msg.FitBaseUnitID = get_uint16(buf, fld, order)
+        case 14: msg.NativeMesgNum = get_uint16(buf, fld, order)
+        case 15: msg.NativeFieldNum = get_uint8(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
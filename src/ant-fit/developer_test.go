@@ -0,0 +1,152 @@
+package ant_fit
+
+import (
+    "encoding/binary"
+    "math"
+    "testing"
+)
+
+// developer fields are named, scaled and given units by the matching
+// field_description message, in either byte order
+func TestDeveloperFields(t *testing.T) {
+    for _, big := range []bool{false, true} {
+        order := binary.ByteOrder(binary.LittleEndian)
+        if big {
+            order = binary.BigEndian
+        }
+        power := func(val uint16) []byte {
+            return encodeTestValue(testField{0, 2, 0x84}, order, int(val))
+        }
+
+        w := new(testWriter)
+        w.define(0, 207, big, testField{0, 16, 0x0d}, testField{1, 16, 0x0d},
+            testField{2, 2, 0x84}, testField{3, 1, 0x02},
+            testField{4, 4, 0x86})
+        w.data(0, []byte("developer-id-001"), []byte("application-0001"),
+            255, 0, 12)
+
+        w.define(1, 206, big, testField{0, 1, 0x02}, testField{1, 1, 0x02},
+            testField{2, 1, 0x02}, testField{3, 16, 0x07},
+            testField{6, 1, 0x02}, testField{7, 1, 0x01},
+            testField{8, 8, 0x07})
+        w.data(1, 0, 0, 0x84, "Power2", 10, 0, "watts")
+        w.data(1, 0, 1, 0x07, "Mode", 0xff, 0x7f, "")
+
+        w.defineDev(2, 20, big,
+            []testField{{253, 4, 0x86}, {3, 1, 0x02}},
+            []testField{{0, 2, 0}, {1, 8, 0}, {5, 1, 1}})
+        w.data(2, 800000000, 140, power(2505), []byte("race"), []byte{7})
+        w.data(2, 800000001, 141, power(0xffff), []byte(""), []byte{7})
+
+        ffile := mustDecode(t, w.build())
+
+        if ids := testMessages(ffile, 207); len(ids) != 1 ||
+            ids[0].(*MsgDeveloperDataId).ApplicationVersion != 12 ||
+            ids[0].(*MsgDeveloperDataId).ManufacturerID != 255 {
+            t.Fatalf("big %v: developer_data_id %v", big, ids)
+        }
+        if descs := testMessages(ffile, 206); len(descs) != 2 ||
+            descs[0].(*MsgFieldDescription).FieldName != "Power2" ||
+            descs[0].(*MsgFieldDescription).FieldUnits != "watts" {
+            t.Fatalf("big %v: field_description %v", big, descs)
+        }
+
+        recs := testMessages(ffile, 20)
+        if len(recs) != 2 {
+            t.Fatalf("big %v: %d records, not 2", big, len(recs))
+        }
+
+        rec := recs[0].(*MsgRecord)
+        if rec.Timestamp != 800000000 || rec.HeartRate != 140 {
+            t.Errorf("big %v: record %s", big, rec.Text())
+        }
+
+        dev := rec.DeveloperFields()
+        if len(dev) != 3 {
+            t.Fatalf("big %v: %d developer fields, not 3", big, len(dev))
+        }
+        if dev[0].Name != "Power2" || dev[0].Units != "watts" ||
+            dev[0].BaseType != 4 || dev[0].Scale != 10 ||
+            dev[0].Value() != 250.5 {
+            t.Errorf("big %v: power field %+v", big, dev[0])
+        }
+        if dev[1].Name != "Mode" || dev[1].StringValue() != "race" ||
+            dev[1].Scale != 1 || dev[1].Offset != 0 {
+            t.Errorf("big %v: mode field %+v", big, dev[1])
+        }
+        // with no description the field is raw bytes
+        if dev[2].Name != "" || dev[2].DeveloperIndex != 1 || dev[2].Num != 5 ||
+            dev[2].String() != "dev1#5 7" {
+            t.Errorf("big %v: undescribed field %+v", big, dev[2])
+        }
+
+        val := rec.Field("Power2")
+        if !val.Valid || val.Scaled != 250.5 || val.Units != "watts" ||
+            val.String() != "Power2 250.5 watts" {
+            t.Errorf("big %v: Field(\"Power2\") is %+v", big, val)
+        }
+        if val := rec.Field("Mode"); !val.Valid || val.Raw != "race" {
+            t.Errorf("big %v: Field(\"Mode\") is %+v", big, val)
+        }
+        if val := rec.Field("Cadence2"); val.Valid ||
+            !math.IsNaN(val.Scaled) {
+            t.Errorf("big %v: missing developer field is %+v", big, val)
+        }
+
+        // invalid values are not set
+        rec = recs[1].(*MsgRecord)
+        if val := rec.Field("Power2"); val.Valid || !math.IsNaN(val.Scaled) {
+            t.Errorf("big %v: invalid power is %+v", big, val)
+        }
+        if val := rec.Field("Mode"); val.Valid {
+            t.Errorf("big %v: empty mode is %+v", big, val)
+        }
+    }
+}
+
+// a record may be larger than 64KiB once developer fields are added
+func TestLargeDefinition(t *testing.T) {
+    fields := make([]testField, 255)
+    values := make([]interface{}, 255 + 3)
+    for i := range fields {
+        fields[i] = testField{byte(i), 255, 0x0d}
+        values[i] = []byte{byte(i)}
+    }
+
+    dev := []testField{{0, 255, 0}, {1, 255, 0}, {2, 255, 0}}
+    for i := range dev {
+        values[len(fields) + i] = []byte{0xa0 + byte(i)}
+    }
+
+    w := new(testWriter)
+    w.defineDev(0, 0xff00, false, fields, dev)
+    w.data(0, values...)
+    w.define(1, 20, false, testField{253, 4, 0x86}, testField{3, 1, 0x02})
+    w.data(1, 800000000, 140)
+
+    ffile := mustDecode(t, w.build())
+
+    msgs := ffile.Messages()
+    if len(msgs) != 2 {
+        t.Fatalf("%d messages, not 2", len(msgs))
+    }
+
+    big := msgs[0]
+    if n := len(big.UnknownFields()); n != 255 {
+        t.Errorf("%d unknown fields, not 255", n)
+    }
+    if val := big.FieldByNum(254); !val.Valid ||
+        val.Raw.([]byte)[0] != 254 {
+        t.Errorf("last field is %v", val)
+    }
+    if dev := big.DeveloperFields(); len(dev) != 3 ||
+        len(dev[2].Data) != 255 || dev[2].Data[0] != 0xa2 {
+        t.Errorf("developer fields %v", dev)
+    }
+
+    // the next record is still in sync
+    if rec := msgs[1].(*MsgRecord); rec.Timestamp != 800000000 ||
+        rec.HeartRate != 140 {
+        t.Errorf("record %s", rec.Text())
+    }
+}
@@ -0,0 +1,134 @@
+package ant_fit
+
+import (
+    "errors"
+    "fmt"
+    "io"
+)
+
+// reasons for a *HeaderError or *RecordError, for use with errors.Is()
+var (
+    ErrHeaderSize = errors.New("unexpected header size")
+    ErrSignature = errors.New("bad signature")
+    ErrArchitecture = errors.New("bad architecture")
+    ErrDataSize = errors.New("record ends past the data size")
+)
+
+// ErrorContext describes where in the stream a decoding error was found.
+// It is part of every error type returned by the decoder.
+type ErrorContext struct {
+    // byte offset from the start of the stream of the header, record or
+    // CRC being read
+    Offset int64
+    // index of the FIT file within a stream of chained files
+    File int
+    // index of the record within its file, or -1 for the header and CRC
+    Record int
+    // local and global message numbers of the record, or -1 if unknown
+    LocalType int
+    GlobalNum int
+}
+
+func (ctx ErrorContext) describe() string {
+    str := fmt.Sprintf("offset %d", ctx.Offset)
+    if ctx.File > 0 {
+        str += fmt.Sprintf(", file #%d", ctx.File + 1)
+    }
+    if ctx.Record >= 0 {
+        str += fmt.Sprintf(", record %d", ctx.Record)
+    }
+    if ctx.LocalType >= 0 {
+        str += fmt.Sprintf(", local type %d", ctx.LocalType)
+    }
+    if ctx.GlobalNum >= 0 {
+        str += fmt.Sprintf(", global %d", ctx.GlobalNum)
+    }
+
+    return str
+}
+
+// HeaderError is returned when a file header has an unexpected size or
+// signature.  Err is ErrHeaderSize or ErrSignature.
+type HeaderError struct {
+    ErrorContext
+    Err error
+    Size byte
+    Signature string
+}
+
+func (e *HeaderError) Error() string {
+    if e.Err == ErrSignature {
+        return fmt.Sprintf("Bad signature %q (%s)", e.Signature,
+            e.describe())
+    }
+
+    return fmt.Sprintf("Unexpected header size %d (%s)", e.Size,
+        e.describe())
+}
+
+func (e *HeaderError) Unwrap() error {
+    return e.Err
+}
+
+// CRCError is returned when a header or file CRC does not match the data
+type CRCError struct {
+    ErrorContext
+    Header bool
+    Expected uint16
+    Computed uint16
+}
+
+func (e *CRCError) Error() string {
+    what := "file"
+    if e.Header {
+        what = "header"
+    }
+
+    return fmt.Sprintf("Bad %s CRC: %04x != %04x (%s)", what, e.Computed,
+        e.Expected, e.describe())
+}
+
+// TruncatedError is returned when the data ends before the header's data
+// size (or the current record) says it should.  It wraps
+// io.ErrUnexpectedEOF.
+type TruncatedError struct {
+    ErrorContext
+    Wanted int
+    Read int
+}
+
+func (e *TruncatedError) Error() string {
+    return fmt.Sprintf("Truncated file: read %d bytes, not %d (%s)", e.Read,
+        e.Wanted, e.describe())
+}
+
+func (e *TruncatedError) Unwrap() error {
+    return io.ErrUnexpectedEOF
+}
+
+// UndefinedLocalTypeError is returned for a data record whose local
+// message type has no definition
+type UndefinedLocalTypeError struct {
+    ErrorContext
+}
+
+func (e *UndefinedLocalTypeError) Error() string {
+    return fmt.Sprintf("Undefined local type %d (%s)", e.LocalType,
+        e.describe())
+}
+
+// RecordError is returned for a record which cannot be decoded.  Err is
+// ErrArchitecture for a definition with an unknown byte order, or
+// ErrDataSize for a record which runs past the header's data size.
+type RecordError struct {
+    ErrorContext
+    Err error
+}
+
+func (e *RecordError) Error() string {
+    return fmt.Sprintf("Bad record: %s (%s)", e.Err, e.describe())
+}
+
+func (e *RecordError) Unwrap() error {
+    return e.Err
+}
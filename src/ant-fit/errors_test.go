@@ -0,0 +1,107 @@
+package ant_fit
+
+import (
+    "bytes"
+    "errors"
+    "io"
+    "testing"
+)
+
+func TestEmptyInput(t *testing.T) {
+    _, err := NewDecoder(bytes.NewReader(nil))
+
+    var terr *TruncatedError
+    if !errors.As(err, &terr) || terr.Offset != 0 || terr.Read != 0 ||
+        terr.Wanted != 12 || !errors.Is(err, io.ErrUnexpectedEOF) {
+        t.Fatalf("empty input gave %v", err)
+    }
+}
+
+func TestShortHeader(t *testing.T) {
+    data := testActivity(false).build()
+
+    for _, size := range []int{5, 13} {
+        _, err := NewDecoder(bytes.NewReader(data[:size]))
+
+        var terr *TruncatedError
+        if !errors.As(err, &terr) || terr.Offset != 0 || terr.File != 0 {
+            t.Errorf("%d-byte header gave %v", size, err)
+        }
+    }
+}
+
+func TestHeaderErrors(t *testing.T) {
+    data := testActivity(false).build()
+    data[0] = 20
+
+    _, err := NewDecoder(bytes.NewReader(data))
+    var herr *HeaderError
+    if !errors.As(err, &herr) || !errors.Is(err, ErrHeaderSize) ||
+        herr.Size != 20 {
+        t.Errorf("bad header size gave %v", err)
+    }
+
+    data = testActivity(false).build()
+    copy(data[8:], "FIT.")
+
+    _, err = NewDecoder(bytes.NewReader(data))
+    if !errors.As(err, &herr) || !errors.Is(err, ErrSignature) ||
+        herr.Signature != "FIT." {
+        t.Errorf("bad signature gave %v", err)
+    }
+}
+
+func TestTruncatedRecord(t *testing.T) {
+    w := testActivity(false)
+    data := w.build()
+
+    // cut the last record short, leaving 5 of its 22 bytes
+    cut := len(data) - 2 - 17
+    _, err := decodeTest(data[:cut])
+
+    var terr *TruncatedError
+    if !errors.As(err, &terr) || terr.Offset != int64(cut - 5) ||
+        terr.Record != 7 || terr.LocalType != 1 || terr.GlobalNum != 20 ||
+        terr.Wanted != 21 || terr.Read != 4 {
+        t.Fatalf("truncated record gave %v", err)
+    }
+}
+
+func TestUndefinedLocalType(t *testing.T) {
+    w := testActivity(false)
+    size := 14 + len(w.recs)
+    w.raw(0x05, 0x00)
+
+    _, err := decodeTest(w.build())
+
+    var uerr *UndefinedLocalTypeError
+    if !errors.As(err, &uerr) || uerr.LocalType != 5 ||
+        uerr.Offset != int64(size) || uerr.Record != 8 {
+        t.Fatalf("undefined local type gave %v", err)
+    }
+}
+
+func TestRecordErrors(t *testing.T) {
+    w := testActivity(false)
+    size := 14 + len(w.recs)
+    // architecture 2 is neither little- nor big-endian
+    w.raw(0x42, 0, 2, 0, 0, 0)
+
+    _, err := decodeTest(w.build())
+    var rerr *RecordError
+    if !errors.As(err, &rerr) || !errors.Is(err, ErrArchitecture) ||
+        rerr.Offset != int64(size) || rerr.LocalType != 2 {
+        t.Errorf("bad architecture gave %v", err)
+    }
+
+    // a data size which ends in the middle of the last record
+    data := testActivity(false).build()
+    data[4] -= 3
+    data[12], data[13] = 0, 0
+
+    _, err = decodeTest(data)
+    if !errors.As(err, &rerr) || !errors.Is(err, ErrDataSize) ||
+        rerr.Record != 7 || rerr.GlobalNum != 20 {
+        t.Errorf("record past the data size gave %v", err)
+    }
+}
@@ -3,59 +3,401 @@ package ant_fit
 import (
     "bytes"
     "encoding/binary"
+    "math"
 )
 
 // data buffer extraction functions
+//
+// These read straight from the buffer, so decoding a field never
+// allocates.
 
-func get_byte_pos(data []byte, pos int) (byte, int) {
+// byte order of multi-byte values described by this definition
+func (def *FitDefinition) byteOrder() binary.ByteOrder {
+    if def.LittleEndian {
+        return binary.LittleEndian
+    }
+
+    return binary.BigEndian
+}
+
+// byte order of a single field's value; fields which are not flagged as
+// endian-capable hold single-byte values, so their order is irrelevant
+func (def *FitDefinition) fieldOrder(fld *FitFieldDefinition) binary.ByteOrder {
+    if !fld.IsEndian {
+        return binary.LittleEndian
+    }
+
+    return def.byteOrder()
+}
+
+func get_byte_pos(data []byte, pos int, order binary.ByteOrder) (byte, int) {
     return data[pos], pos + 1
 }
 
-func get_int8_pos(data []byte, pos int) (int8, int) {
+func get_int8_pos(data []byte, pos int, order binary.ByteOrder) (int8, int) {
     return int8(data[pos]), pos + 1
 }
 
-func get_int16_pos(data []byte, pos int) (int16, int) {
-    var ret int16
-    buf := bytes.NewBuffer(data[pos:pos + 2])
-    binary.Read(buf, binary.LittleEndian, &ret)
-    return ret, pos + 2
+func get_int16_pos(data []byte, pos int, order binary.ByteOrder) (int16, int) {
+    return int16(order.Uint16(data[pos:])), pos + 2
+}
+
+func get_int32_pos(data []byte, pos int, order binary.ByteOrder) (int32, int) {
+    return int32(order.Uint32(data[pos:])), pos + 4
+}
+
+func get_uint8_pos(data []byte, pos int, order binary.ByteOrder) (uint8, int) {
+    return data[pos], pos + 1
+}
+
+func get_uint16_pos(data []byte, pos int, order binary.ByteOrder) (uint16, int) {
+    return order.Uint16(data[pos:]), pos + 2
+}
+
+func get_uint32_pos(data []byte, pos int, order binary.ByteOrder) (uint32, int) {
+    return order.Uint32(data[pos:]), pos + 4
+}
+
+func get_uint64_pos(data []byte, pos int, order binary.ByteOrder) (uint64, int) {
+    return order.Uint64(data[pos:]), pos + 8
+}
+
+// store 'val' in 'buf' as an unsigned value of len(buf) bytes
+func put_raw_uint(buf []byte, order binary.ByteOrder, val uint64) {
+    switch len(buf) {
+    case 1:
+        buf[0] = byte(val)
+    case 2:
+        order.PutUint16(buf, uint16(val))
+    case 4:
+        order.PutUint32(buf, uint32(val))
+    case 8:
+        order.PutUint64(buf, val)
+    }
+}
+
+// field value extraction functions
+//
+// Each of these decodes a field using the base type and size from its
+// definition rather than the type found in the profile, so fields which
+// a device has widened or turned into arrays don't upset the decoding of
+// the fields which follow them.
+
+// size in bytes of a single value of each base type
+var base_type_sizes = [17]int{1, 1, 1, 2, 2, 4, 4, 1, 4, 8, 1, 2, 4, 1,
+    8, 8, 8}
+
+func get_base_size(base_type byte) int {
+    if int(base_type) < len(base_type_sizes) {
+        return base_type_sizes[base_type]
+    }
+
+    return 1
+}
+
+// extract the first value of type 'base_type' from 'buf' as an integer
+func get_raw_int(buf []byte, base_type byte, order binary.ByteOrder) int64 {
+    if len(buf) < get_base_size(base_type) {
+        return 0
+    }
+
+    switch base_type {
+    case 1:
+        val, _ := get_int8_pos(buf, 0, order)
+        return int64(val)
+    case 3:
+        val, _ := get_int16_pos(buf, 0, order)
+        return int64(val)
+    case 4, 11:
+        val, _ := get_uint16_pos(buf, 0, order)
+        return int64(val)
+    case 5:
+        val, _ := get_int32_pos(buf, 0, order)
+        return int64(val)
+    case 6, 12:
+        val, _ := get_uint32_pos(buf, 0, order)
+        return int64(val)
+    case 8, 9:
+        return int64(get_raw_float(buf, base_type, order))
+    case 14, 15, 16:
+        val, _ := get_uint64_pos(buf, 0, order)
+        return int64(val)
+    default:
+        val, _ := get_byte_pos(buf, 0, order)
+        return int64(val)
+    }
+}
+
+// report whether the first value in 'buf' is something other than the
+// invalid value for 'base_type'
+func is_valid_raw(buf []byte, base_type byte, order binary.ByteOrder) bool {
+    if int(base_type) >= len(base_type_invalid) {
+        return true
+    }
+
+    size := get_base_size(base_type)
+    if len(buf) < size {
+        return false
+    }
+
+    var bits uint64
+    switch size {
+    case 2:
+        val, _ := get_uint16_pos(buf, 0, order)
+        bits = uint64(val)
+    case 4:
+        val, _ := get_uint32_pos(buf, 0, order)
+        bits = uint64(val)
+    case 8:
+        bits, _ = get_uint64_pos(buf, 0, order)
+    default:
+        bits = uint64(buf[0])
+    }
+
+    return bits != base_type_invalid[base_type]
+}
+
+func get_enum(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) byte {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_enum
+    }
+
+    return byte(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_int8(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) int8 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_int8
+    }
+
+    return int8(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint8(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint8 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint8
+    }
+
+    return uint8(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_int16(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) int16 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_int16
+    }
+
+    return int16(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint16(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint16 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint16
+    }
+
+    return uint16(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_int32(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) int32 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_int32
+    }
+
+    return int32(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint32(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint32 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint32
+    }
+
+    return uint32(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint8z(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint8 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint8z
+    }
+
+    return uint8(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint16z(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint16 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint16z
+    }
+
+    return uint16(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_uint32z(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) uint32 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_uint32z
+    }
+
+    return uint32(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_byte(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) byte {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_byte
+    }
+
+    return byte(get_raw_int(buf, fld.BaseType, order))
+}
+
+func get_float32(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) float32 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_float32
+    }
+
+    return float32(get_raw_float(buf, fld.BaseType, order))
+}
+
+func get_float64(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) float64 {
+    if !is_valid_raw(buf, fld.BaseType, order) {
+        return invalid_float64
+    }
+
+    return get_raw_float(buf, fld.BaseType, order)
+}
+
+// extract the first value of type 'base_type' from 'buf' as a float
+func get_raw_float(buf []byte, base_type byte,
+    order binary.ByteOrder) float64 {
+    switch base_type {
+    case 8:
+        if len(buf) < 4 {
+            return 0
+        }
+        val, _ := get_uint32_pos(buf, 0, order)
+        return float64(math.Float32frombits(val))
+    case 9:
+        if len(buf) < 8 {
+            return 0
+        }
+        val, _ := get_uint64_pos(buf, 0, order)
+        return math.Float64frombits(val)
+    case 15, 16:
+        if len(buf) < 8 {
+            return 0
+        }
+        val, _ := get_uint64_pos(buf, 0, order)
+        return float64(val)
+    default:
+        return float64(get_raw_int(buf, base_type, order))
+    }
+}
+
+// strings occupy the entire field, padded with NUL bytes
+func get_string(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) string {
+    n := bytes.IndexByte(buf, 0)
+    if n < 0 {
+        n = len(buf)
+    }
+
+    return string(buf[:n])
 }
 
-func get_int32_pos(data []byte, pos int) (int32, int) {
-    var ret int32
-    buf := bytes.NewBuffer(data[pos:pos + 4])
-    binary.Read(buf, binary.LittleEndian, &ret)
-    return ret, pos + 4
+// array fields hold as many values as fit in the field's size
+
+func get_array_len(buf []byte, fld *FitFieldDefinition) int {
+    return len(buf) / get_base_size(fld.BaseType)
 }
 
-func get_string_pos(data []byte, pos int) (string, int) {
-    n := pos
-    for n < len(data) {
-        if data[n] == 0 {
-            n++
-            break
+// report whether any element of an array is set; like a byte array, an
+// array is only invalid if every element is invalid
+func is_valid_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) bool {
+    size := get_base_size(fld.BaseType)
+    for pos := 0; pos + size <= len(buf); pos += size {
+        if is_valid_raw(buf[pos:], fld.BaseType, order) {
+            return true
         }
-        n++
     }
 
-    return string(data[pos:n]), n
+    return false
 }
 
-func get_uint8_pos(data []byte, pos int) (uint8, int) {
-    return data[pos], pos + 1
+func get_byte_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) []byte {
+    for i := 0; i < len(buf); i++ {
+        if buf[i] != invalid_byte {
+            arr := make([]byte, len(buf))
+            copy(arr, buf)
+            return arr
+        }
+    }
+
+    // a byte array is only invalid if every byte is invalid
+    return nil
 }
 
-func get_uint16_pos(data []byte, pos int) (uint16, int) {
-    var ret uint16
-    buf := bytes.NewBuffer(data[pos:pos + 2])
-    binary.Read(buf, binary.LittleEndian, &ret)
-    return ret, pos + 2
+func get_uint8_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) []uint8 {
+    if !is_valid_array(buf, fld, order) {
+        return nil
+    }
+
+    size := get_base_size(fld.BaseType)
+    arr := make([]uint8, get_array_len(buf, fld))
+    for i := 0; i < len(arr); i++ {
+        arr[i] = get_uint8(buf[i * size:], fld, order)
+    }
+    return arr
+}
+
+func get_uint8z_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) []uint8 {
+    if !is_valid_array(buf, fld, order) {
+        return nil
+    }
+
+    size := get_base_size(fld.BaseType)
+    arr := make([]uint8, get_array_len(buf, fld))
+    for i := 0; i < len(arr); i++ {
+        arr[i] = get_uint8z(buf[i * size:], fld, order)
+    }
+    return arr
 }
 
-func get_uint32_pos(data []byte, pos int) (uint32, int) {
-    var ret uint32
-    buf := bytes.NewBuffer(data[pos:pos + 4])
-    binary.Read(buf, binary.LittleEndian, &ret)
-    return ret, pos + 4
+func get_uint16_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) []uint16 {
+    if !is_valid_array(buf, fld, order) {
+        return nil
+    }
+
+    size := get_base_size(fld.BaseType)
+    arr := make([]uint16, get_array_len(buf, fld))
+    for i := 0; i < len(arr); i++ {
+        arr[i] = get_uint16(buf[i * size:], fld, order)
+    }
+    return arr
+}
+
+func get_uint32_array(buf []byte, fld *FitFieldDefinition,
+    order binary.ByteOrder) []uint32 {
+    if !is_valid_array(buf, fld, order) {
+        return nil
+    }
+
+    size := get_base_size(fld.BaseType)
+    arr := make([]uint32, get_array_len(buf, fld))
+    for i := 0; i < len(arr); i++ {
+        arr[i] = get_uint32(buf[i * size:], fld, order)
+    }
+    return arr
 }
@@ -0,0 +1,160 @@
+package ant_fit
+
+import (
+    "math"
+    "testing"
+)
+
+// an array field is unset only if every element is invalid
+func TestInvalidArrays(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{17, 3, 0x02})
+    w.data(0, 800000000, []int{0xff, 0xff, 0xff})
+    w.data(0, 800000001, []int{0xff, 32, 0xff})
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != 2 {
+        t.Fatalf("%d records, not 2", len(recs))
+    }
+
+    unset := recs[0].(*MsgRecord)
+    if unset.Speed1s != nil || unset.IsSet("speed_1s") ||
+        unset.Field("speed_1s").Valid {
+        t.Errorf("all-invalid speed_1s is set: %v", unset.Speed1s)
+    }
+
+    set := recs[1].(*MsgRecord)
+    if !set.IsSet("speed_1s") || len(set.Speed1s) != 3 ||
+        set.Speed1s[1] != 32 || set.Speed1s[0] != 0xff {
+        t.Errorf("speed_1s is %v", set.Speed1s)
+    }
+}
+
+func TestIsValidArray(t *testing.T) {
+    fld := &FitFieldDefinition{Num: 1, Size: 4, IsEndian: true, BaseType: 4}
+    order := (&FitDefinition{LittleEndian: true}).byteOrder()
+
+    if is_valid_array([]byte{0xff, 0xff, 0xff, 0xff}, fld, order) {
+        t.Error("uint16 array of invalid values is valid")
+    }
+    if !is_valid_array([]byte{0xff, 0xff, 0x01, 0x00}, fld, order) {
+        t.Error("uint16 array with a value is invalid")
+    }
+
+    // zero is the invalid value of the 'z' types
+    zfld := &FitFieldDefinition{Num: 1, Size: 2, BaseType: 10}
+    if is_valid_array([]byte{0, 0}, zfld, order) {
+        t.Error("uint8z array of zeros is valid")
+    }
+}
+
+// fields are decoded using the size and base type in the definition, so
+// a field which differs from the profile doesn't shift the ones after it
+func TestFieldSizes(t *testing.T) {
+    for _, big := range []bool{false, true} {
+        w := new(testWriter)
+        w.define(0, 20, big, testField{253, 4, 0x86},
+            // heart_rate is a uint8 in the profile
+            testField{3, 2, 0x84},
+            testField{4, 1, 0x02},
+            // distance is a uint32
+            testField{5, 2, 0x84},
+            // a single-value field sent as an array
+            testField{13, 3, 0x01},
+            testField{6, 2, 0x84})
+        w.data(0, 800000000, 150, 90, 4000, []int{21, 22, 23}, 3000)
+        w.data(0, 800000001, 0xffff, 91, 0xffff, []int{-2, 0x7f, 0x7f}, 3001)
+
+        recs := testMessages(mustDecode(t, w.build()), 20)
+        if len(recs) != 2 {
+            t.Fatalf("big %v: %d records, not 2", big, len(recs))
+        }
+
+        rec := recs[0].(*MsgRecord)
+        if rec.Timestamp != 800000000 || rec.HeartRate != 150 ||
+            rec.Cadence != 90 || rec.Distance != 4000 ||
+            rec.Temperature != 21 || rec.Speed != 3000 {
+            t.Errorf("big %v: record %s", big, rec.Text())
+        }
+
+        // invalid values of the wider type are invalid in the profile type
+        rec = recs[1].(*MsgRecord)
+        if rec.IsSet("heart_rate") || rec.IsSet("distance") ||
+            rec.Cadence != 91 || rec.Temperature != -2 || rec.Speed != 3001 {
+            t.Errorf("big %v: record %s", big, rec.Text())
+        }
+    }
+}
+
+// strings fill their field and are cut at the first NUL
+func TestStringField(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 3, false, testField{0, 16, 0x07}, testField{1, 1, 0x00},
+        testField{2, 1, 0x02})
+    w.data(0, "Alex", 1, 42)
+    w.data(0, []byte("Sam\x00\x00garbage"), 0, 35)
+    w.data(0, "", 0, 36)
+    w.data(0, "sixteen-byte-str", 1, 37)
+
+    profiles := testMessages(mustDecode(t, w.build()), 3)
+    want := []struct {
+        name string
+        age uint8
+    }{
+        {"Alex", 42},
+        {"Sam", 35},
+        {"", 36},
+        {"sixteen-byte-str", 37},
+    }
+    if len(profiles) != len(want) {
+        t.Fatalf("%d user profiles, not %d", len(profiles), len(want))
+    }
+
+    for i, msg := range profiles {
+        prof := msg.(*MsgUserProfile)
+        if prof.FriendlyName != want[i].name || prof.Age != want[i].age {
+            t.Errorf("profile %d: %s", i, prof.Text())
+        }
+        if prof.IsSet("friendly_name") != (want[i].name != "") {
+            t.Errorf("profile %d: friendly_name set is %v", i,
+                prof.IsSet("friendly_name"))
+        }
+    }
+}
+
+// array fields hold as many values as fit in their size
+func TestArrayField(t *testing.T) {
+    for _, big := range []bool{false, true} {
+        w := new(testWriter)
+        w.define(0, 78, big, testField{0, 10, 0x84})
+        w.data(0, []int{812, 790, 0xffff, 1005, 64000})
+        w.define(1, 78, big, testField{0, 2, 0x84})
+        w.data(1, 801)
+
+        hrvs := testMessages(mustDecode(t, w.build()), 78)
+        if len(hrvs) != 2 {
+            t.Fatalf("big %v: %d hrv messages, not 2", big, len(hrvs))
+        }
+
+        hrv := hrvs[0].(*MsgHrv)
+        want := []uint16{812, 790, 0xffff, 1005, 64000}
+        if len(hrv.Time) != len(want) {
+            t.Fatalf("big %v: hrv time %v", big, hrv.Time)
+        }
+        for i := range want {
+            if hrv.Time[i] != want[i] {
+                t.Errorf("big %v: hrv time %v, not %v", big, hrv.Time, want)
+                break
+            }
+        }
+
+        scaled := hrv.TimeScaled()
+        if scaled[0] != 0.812 || !math.IsNaN(scaled[2]) || scaled[4] != 64 {
+            t.Errorf("big %v: scaled hrv time %v", big, scaled)
+        }
+
+        if hrv := hrvs[1].(*MsgHrv); len(hrv.Time) != 1 || hrv.Time[0] != 801 {
+            t.Errorf("big %v: single hrv time %v", big, hrv.Time)
+        }
+    }
+}
@@ -2,7 +2,7 @@ package ant_fit
 
 import (
     "bufio"
-    "errors"
+    "encoding/binary"
     "fmt"
     "io"
     "os"
@@ -11,104 +11,427 @@ import (
 
 type FitFile struct {
     filename string
-    rdr io.Reader
-
-    proto byte
-    profile uint16
-    datasize uint32
-
-    defs []*FitDefinition
-    data []FitMsg
+    closer io.Closer
+    rdr *bufio.Reader
+
+    // FIT files found in the stream so far; the last is being decoded
+    files []*FitSubFile
+    cur *FitSubFile
+
+    // number of record bytes read so far and their running CRC, which
+    // starts with the header bytes
+    data_read uint32
+    crc uint16
+
+    // reference time for compressed-timestamp records
+    last_timestamp uint32
+    have_timestamp bool
+
+    // developer field descriptions, keyed by developer index and field number
+    dev_descs map[uint16]*MsgFieldDescription
+
+    // running totals for accumulated fields
+    accum map[uint32]*accumulator
+    // block from which messages' totals are taken; see newTotals()
+    totals []fieldTotal
+
+    // current definition for each local message type
+    local_defs [16]*FitDefinition
+
+    // if true, every definition read is kept in the sub-file
+    keep_defs bool
+    // if true, data messages are not kept in the sub-file
+    no_retain bool
+    // global message numbers to decode or to skip; see SelectMessages()
+    select_msgs map[uint16]bool
+    ignore_msgs map[uint16]bool
+    // if true, only file_id messages are decoded
+    peeking bool
+
+    // buffers reused from one record to the next, for the record header,
+    // the record's data, and the data with a compressed timestamp or
+    // components expanded; messages copy anything they keep
+    rec_hdr [1]byte
+    scratch []byte
+    ts_buf []byte
+    comp_buf []byte
+
+    // number of bytes read from the stream, including headers and CRCs
+    offset int64
+    // number of records read from the current file
+    records int
+
+    // recovery state; see RecoverFromErrors()
+    recover_errs bool
+    done bool
+    skipped []*SkippedRange
+    // bytes pushed back onto the stream while resynchronizing, and the
+    // buffer used to search them with the bytes which follow
+    pending []byte
+    window []byte
+    // bytes of the record being read, and the CRC and data count before it
+    rec_bytes []byte
+    rec_crc uint16
+    rec_data_read uint32
+
+    // where the header, record or CRC being read starts, for errors
+    ctx ErrorContext
 }
 
+// NewFitFile opens the named file and returns a decoder which reads from it.
+// The file stays open until Close() is called.
 func NewFitFile(filename string) (*FitFile, error) {
     file, err := os.Open(filename)
     if err != nil {
-        return nil, errors.New(fmt.Sprintf("Cannot open \"%s\"\n", filename))
+        return nil, fmt.Errorf("Cannot open \"%s\": %w", filename, err)
     }
-    defer file.Close()
 
-    ffile := new(FitFile)
+    ffile, err := NewDecoder(file)
+    if err != nil {
+        file.Close()
+        return nil, err
+    }
 
     ffile.filename = filename
-    ffile.rdr = bufio.NewReader(file)
+    ffile.closer = file
 
-    const minHeaderLen byte = 12
+    return ffile, nil
+}
 
-    buf := make([]byte, minHeaderLen)
+// NewDecoder reads the FIT file header from 'rdr' and returns a decoder
+// for the records which follow it.  The reader must remain usable for the
+// lifetime of the decoder, and is not closed by it.
+func NewDecoder(rdr io.Reader) (*FitFile, error) {
+    ffile := new(FitFile)
+
+    ffile.rdr = bufio.NewReader(rdr)
 
-    n, err := ffile.rdr.Read(buf)
+    err := ffile.readHeader()
     if err != nil {
         return nil, err
-    } else if n != int(minHeaderLen) {
-        errfmt := "Tried to read %d byte header, only read %d bytes"
-        return nil, errors.New(fmt.Sprintf(errfmt, minHeaderLen, n))
+    }
+
+    return ffile, nil
+}
+
+// read a FIT file header and start decoding a new sub-file
+func (ffile *FitFile) readHeader() error {
+    const minHeaderLen byte = 12
+
+    buf := make([]byte, minHeaderLen)
+
+    ffile.ctx = ErrorContext{Offset: ffile.offset, File: len(ffile.files),
+        Record: -1, LocalType: -1, GlobalNum: -1}
+
+    n, err := ffile.readFull(buf)
+    ffile.offset += int64(n)
+    if err == io.ErrUnexpectedEOF || err == io.EOF {
+        return &TruncatedError{ErrorContext: ffile.ctx,
+            Wanted: int(minHeaderLen), Read: n}
+    } else if err != nil {
+        return err
     }
 
     size := buf[0]
 
     needCRC := size == minHeaderLen + 2
     if size != minHeaderLen && !needCRC {
-        return nil, errors.New(fmt.Sprintf("Unexpected header size %d", size))
+        return &HeaderError{ErrorContext: ffile.ctx, Err: ErrHeaderSize,
+            Size: size}
     }
 
     // verify that the ASCII signature is correct
-    signature := []byte(".FIT")
-    for i := 0; i < 4; i++ {
-        if buf[8 + i] != signature[i] {
-            errfmt := "Bad signature char #%d: '%c' should be '%c'\n"
-            return nil, errors.New(fmt.Sprintf(errfmt, i, buf[8 + i],
-                signature[i]))
-        }
+    if string(buf[8:12]) != ".FIT" {
+        return &HeaderError{ErrorContext: ffile.ctx, Err: ErrSignature,
+            Size: size, Signature: string(buf[8:12])}
     }
 
+    crc := computeCRC(0, buf)
+
     // verify that the CRC is correct (if present)
     if needCRC {
-        err = checkCRC(ffile.rdr, buf)
-        if err != nil {
-            return nil, err
+        crcbuf := make([]byte, 2)
+
+        n, err = ffile.readFull(crcbuf)
+        ffile.offset += int64(n)
+        if err == io.ErrUnexpectedEOF || err == io.EOF {
+            return &TruncatedError{ErrorContext: ffile.ctx,
+                Wanted: len(crcbuf), Read: n}
+        } else if err != nil {
+            return err
+        }
+
+        err = checkCRC(buf, crcbuf)
+        if cerr, ok := err.(*CRCError); ok {
+            cerr.ErrorContext = ffile.ctx
+            return cerr
         }
+
+        // the file CRC covers the entire header
+        crc = computeCRC(crc, crcbuf)
     }
 
-    ffile.proto = buf[1]
-    ffile.profile, _ = get_uint16_pos(buf, 2)
-    ffile.datasize, _ = get_uint32_pos(buf, 4)
+    sub := new(FitSubFile)
 
-    ffile.defs = make([]*FitDefinition, 0)
-    ffile.data = make([]FitMsg, 0)
-    return ffile, nil
+    sub.proto = buf[1]
+    // the file header is always little-endian
+    sub.profile, _ = get_uint16_pos(buf, 2, binary.LittleEndian)
+    sub.datasize, _ = get_uint32_pos(buf, 4, binary.LittleEndian)
+
+    sub.data = make([]FitMsg, 0)
+
+    // nothing carries over from a previous file in the stream
+    ffile.crc = crc
+    ffile.data_read = 0
+    ffile.last_timestamp = 0
+    ffile.have_timestamp = false
+    ffile.dev_descs = nil
+    ffile.accum = nil
+    ffile.local_defs = [16]*FitDefinition{}
+    ffile.records = 0
+
+    ffile.files = append(ffile.files, sub)
+    ffile.cur = sub
+
+    return nil
 }
 
-func (ffile *FitFile) findDefinition(local_type byte) (*FitDefinition, error) {
-    var def *FitDefinition
-    for i := 0; i < len(ffile.defs); i++ {
-        if ffile.defs[i].local_type == local_type {
-            def = ffile.defs[i]
-        }
+// read len(buf) bytes, starting with any which were pushed back while
+// resynchronizing after a garbled record
+func (ffile *FitFile) readFull(buf []byte) (int, error) {
+    n := copy(buf, ffile.pending)
+    ffile.pending = ffile.pending[n:]
+    if n == len(buf) {
+        return n, nil
+    }
+
+    m, err := io.ReadFull(ffile.rdr, buf[n:])
+    if err == io.EOF && n > 0 {
+        err = io.ErrUnexpectedEOF
     }
 
-    var err error
+    return n + m, err
+}
+
+// report whether there is more data after the end of the current file
+func (ffile *FitFile) atEOF() (bool, error) {
+    if len(ffile.pending) > 0 {
+        return false, nil
+    }
+
+    _, err := ffile.rdr.Peek(1)
+    if err == io.EOF {
+        return true, nil
+    }
+
+    return false, err
+}
+
+// Close releases the file opened by NewFitFile.  It does nothing for
+// decoders created with NewDecoder.
+func (ffile *FitFile) Close() error {
+    if ffile.closer == nil {
+        return nil
+    }
+
+    err := ffile.closer.Close()
+    ffile.closer = nil
+    return err
+}
+
+// read exactly len(buf) bytes of record data from the underlying reader,
+// adding them to the file CRC
+func (ffile *FitFile) read(buf []byte) error {
+    n, err := ffile.readFull(buf)
+    ffile.offset += int64(n)
+    if err == io.ErrUnexpectedEOF || err == io.EOF {
+        return &TruncatedError{ErrorContext: ffile.ctx, Wanted: len(buf),
+            Read: n}
+    } else if err != nil {
+        return err
+    }
+
+    ffile.crc = computeCRC(ffile.crc, buf)
+    ffile.data_read += uint32(n)
+    if ffile.recover_errs {
+        ffile.rec_bytes = append(ffile.rec_bytes, buf...)
+    }
+
+    if ffile.data_read > ffile.cur.datasize {
+        return &RecordError{ErrorContext: ffile.ctx, Err: ErrDataSize}
+    }
+
+    return nil
+}
+
+// read the CRC which follows the records and check it against the data
+func (ffile *FitFile) readFileCRC() error {
+    buf := make([]byte, 2)
+
+    ffile.ctx = ErrorContext{Offset: ffile.offset, File: len(ffile.files) - 1,
+        Record: -1, LocalType: -1, GlobalNum: -1}
+
+    n, err := ffile.readFull(buf)
+    ffile.offset += int64(n)
+    if err == io.ErrUnexpectedEOF || err == io.EOF {
+        return &TruncatedError{ErrorContext: ffile.ctx, Wanted: len(buf),
+            Read: n}
+    } else if err != nil {
+        return err
+    }
+
+    goodCRC, _ := get_uint16_pos(buf, 0, binary.LittleEndian)
+    if goodCRC != ffile.crc {
+        return &CRCError{ErrorContext: ffile.ctx, Expected: goodCRC,
+            Computed: ffile.crc}
+    }
+
+    return nil
+}
+
+// KeepDefinitions controls whether every definition message is retained,
+// including those which have been replaced by a later definition for the
+// same local message type.  This is only useful for diagnostics.
+func (ffile *FitFile) KeepDefinitions(keep bool) {
+    ffile.keep_defs = keep
+}
+
+func (ffile *FitFile) findDefinition(local_type byte) (*FitDefinition, error) {
+    def := ffile.local_defs[local_type & 0x0f]
     if def == nil {
-        err = errors.New(fmt.Sprintf("Unknown local_type %d", local_type))
+        return nil, &UndefinedLocalTypeError{ErrorContext: ffile.ctx}
     }
 
-    return def, err
+    return def, nil
 }
 
-func (ffile *FitFile) readData(def *FitDefinition,
+func (ffile *FitFile) readData(def *FitDefinition, compressed bool,
     time_offset uint32, verbose bool) (FitMsg, error) {
 
-    buf := make([]byte, def.total_bytes)
+    buf := reuseBuffer(&ffile.scratch, def.TotalBytes)
+
+    err := ffile.read(buf)
+    if err != nil {
+        return nil, err
+    }
+
+    // developer fields follow the profile fields
+    dev_pos := def.fieldBytes()
+
+    mdef := def
+    fbuf := buf[:dev_pos]
+    if compressed && ffile.have_timestamp {
+        // decode the message as if it had a full timestamp field
+        mdef = def.withTimestamp()
+
+        fbuf = reuseBuffer(&ffile.ts_buf, mdef.TotalBytes)
+        copy(fbuf, buf[:dev_pos])
+
+        ffile.last_timestamp = expandTimestamp(ffile.last_timestamp,
+            time_offset)
+        mdef.byteOrder().PutUint32(fbuf[dev_pos:], ffile.last_timestamp)
+    } else if !compressed {
+        ffile.trackTimestamp(def, buf)
+    }
+
+    ffile.resyncComponents(mdef, fbuf)
+    mdef, fbuf = ffile.expandComponents(mdef, fbuf)
 
-    n, err := ffile.rdr.Read(buf)
+    msg, err := newMessage(mdef, fbuf)
     if err != nil {
         return nil, err
-    } else if n != len(buf) {
-        return nil, errors.New(fmt.Sprintf("Read %d bytes, not %d", n,
-            len(buf)))
     }
 
-    switch def.global_num {
+    ffile.addTotals(msg, mdef, fbuf)
+
+    if len(def.DevFields) > 0 {
+        ffile.addDeveloperFields(msg, def, buf[dev_pos:])
+    }
+
+    if desc, ok := msg.(*MsgFieldDescription); ok {
+        ffile.addFieldDescription(desc)
+    }
+
+    ffile.cur.trackTime(msg)
+
+    return msg, nil
+}
+
+// return the first 'size' bytes of '*buf', replacing it with a larger
+// buffer if it is too small
+func reuseBuffer(buf *[]byte, size int) []byte {
+    if cap(*buf) < size {
+        *buf = make([]byte, size)
+    }
+
+    return (*buf)[:size]
+}
+
+// remember the most recent full timestamp as the reference point for
+// compressed-timestamp records
+func (ffile *FitFile) trackTimestamp(def *FitDefinition, buf []byte) {
+    pos := 0
+    for _, fld := range def.Fields {
+        if fld.Num == 253 {
+            ts := get_uint32(buf[pos:pos + int(fld.Size)], fld,
+                def.fieldOrder(fld))
+            if is_valid_uint32(ts) {
+                ffile.last_timestamp = ts
+                ffile.have_timestamp = true
+            }
+        }
+
+        pos += int(fld.Size)
+    }
+}
+
+// compute the full timestamp for a compressed-timestamp record from the
+// last full timestamp and the record's 5-bit time offset
+func expandTimestamp(last uint32, time_offset uint32) uint32 {
+    const mask uint32 = 0x1f
+
+    ts := (last &^ mask) + time_offset
+    if time_offset < last & mask {
+        // the offset rolled over
+        ts += mask + 1
+    }
+
+    return ts
+}
+
+// definition used for compressed-timestamp records: the original fields
+// followed by a timestamp field holding the computed time
+func (def *FitDefinition) withTimestamp() *FitDefinition {
+    if def.timestamp_def == nil {
+        tdef := new(FitDefinition)
+        *tdef = *def
+
+        tdef.Fields = make([]*FitFieldDefinition, len(def.Fields),
+            len(def.Fields) + 1)
+        copy(tdef.Fields, def.Fields)
+
+        fld := new(FitFieldDefinition)
+        fld.Num = 253
+        fld.Size = 4
+        fld.IsEndian = true
+        fld.BaseType = 6
+
+        tdef.Fields = append(tdef.Fields, fld)
+        tdef.DevFields = nil
+        tdef.timestamp_def = nil
+        tdef.component_def = nil
+        tdef.accum_plan = nil
+        tdef.TotalBytes = def.fieldBytes() + int(fld.Size)
+
+        def.timestamp_def = tdef
+    }
+
+    return def.timestamp_def
+}
+
+// decode the data in 'buf' into the message described by 'def'
+func newMessage(def *FitDefinition, buf []byte) (FitMsg, error) {
+    switch def.GlobalNum {
     case 0: return NewMsgFileId(def, buf)
     case 1: return NewMsgCapabilities(def, buf)
     case 2: return NewMsgDeviceSettings(def, buf)
@@ -149,48 +472,64 @@ func (ffile *FitFile) readData(def *FitDefinition,
     case 105: return NewMsgPad(def, buf)
     case 106: return NewMsgSlaveDevice(def, buf)
     case 131: return NewMsgCadenceZone(def, buf)
-    default: return NewMsgUnknown(def, buf, def.global_num)
+    case 206: return NewMsgFieldDescription(def, buf)
+    case 207: return NewMsgDeveloperDataId(def, buf)
+    default: return NewMsgUnknown(def, buf, def.GlobalNum)
     }
 }
 
-func (ffile *FitFile) readDefinition(local_type byte,
+func (ffile *FitFile) readDefinition(local_type byte, has_dev bool,
     verbose bool) (*FitDefinition, error) {
     buf := make([]byte, 5)
 
-    n, err := ffile.rdr.Read(buf)
+    err := ffile.read(buf)
     if err != nil {
         return nil, err
-    } else if n != len(buf) {
-        return nil, errors.New(fmt.Sprintf("Read %d bytes, not %d", n,
-            len(buf)))
     }
 
     def := new(FitDefinition)
 
-    def.local_type = local_type
-    def.little_endian = buf[1] == 0
-    def.global_num, _ = get_uint16_pos(buf, 2)
-    def.total_bytes = 0
+    if buf[1] > 1 {
+        return nil, &RecordError{ErrorContext: ffile.ctx,
+            Err: ErrArchitecture}
+    }
+
+    def.LocalType = local_type
+    def.LittleEndian = buf[1] == 0
+    def.GlobalNum, _ = get_uint16_pos(buf, 2, def.byteOrder())
+    ffile.ctx.GlobalNum = int(def.GlobalNum)
+    def.TotalBytes = 0
 
     num := int(buf[4])
 
-    def.fields = make([]*FitFieldDefinition, num)
+    def.Fields = make([]*FitFieldDefinition, num)
     for i := 0; i < num; i++ {
-        def.fields[i], err = ffile.readFieldDef(buf)
+        def.Fields[i], err = ffile.readFieldDef(buf)
+        if err != nil {
+            return nil, err
+        }
+        def.TotalBytes += int(def.Fields[i].Size)
+    }
+    //sort.Sort(ByNum{def.Fields})
+
+    if has_dev {
+        err = ffile.readDevFieldDefs(def, buf)
         if err != nil {
             return nil, err
         }
-        def.total_bytes += uint16(def.fields[i].size)
     }
-    //sort.Sort(ByNum{def.fields})
 
     if verbose {
         fmt.Printf("  def: ltyp %v little_endian %v glbl %d\n",
-            def.local_type, def.little_endian, def.global_num)
-        for i := 0; i < len(def.fields); i++ {
+            def.LocalType, def.LittleEndian, def.GlobalNum)
+        for i := 0; i < len(def.Fields); i++ {
             fmt.Printf("       :: num %d sz %d endian %v type %s\n",
-                def.fields[i].num, def.fields[i].size, def.fields[i].is_endian,
-                get_type_name(def.fields[i]))
+                def.Fields[i].Num, def.Fields[i].Size, def.Fields[i].IsEndian,
+                get_type_name(def.Fields[i]))
+        }
+        for _, dfld := range def.DevFields {
+            fmt.Printf("       :: dev %d num %d sz %d\n", dfld.DevIndex,
+                dfld.Num, dfld.Size)
         }
     }
 
@@ -198,78 +537,196 @@ func (ffile *FitFile) readDefinition(local_type byte,
 }
 
 func (ffile *FitFile) readFieldDef(buf []byte) (*FitFieldDefinition, error) {
-    n, err := ffile.rdr.Read(buf[:3])
+    err := ffile.read(buf[:3])
     if err != nil {
         return nil, err
-    } else if n != 3 {
-        return nil, errors.New(fmt.Sprintf("Read %d bytes, not %d", n, 3))
     }
 
     fld := new(FitFieldDefinition)
 
-    fld.num = buf[0]
-    fld.size = buf[1]
-    fld.is_endian = buf[2] & 0x80 == 0x80
-    fld.base_type = buf[2] & 0xf
+    fld.Num = buf[0]
+    fld.Size = buf[1]
+    fld.IsEndian = buf[2] & 0x80 == 0x80
+    fld.BaseType = buf[2] & 0x1f
 
     return fld, nil
 }
 
+// ReadMessage reads the next record.  Once all the records in the header's
+// data size have been read and the file CRC has been verified, it starts
+// on the next file if several have been chained together in the stream.
+// It returns false at the end of the last file.  Errors are a *HeaderError,
+// *CRCError, *TruncatedError, *UndefinedLocalTypeError or *RecordError,
+// each of which gives the stream offset, record index and message numbers
+// of the problem.
+//
+// In recovery mode (see RecoverFromErrors) garbled records are skipped
+// rather than returned as errors.
 func (ffile *FitFile) ReadMessage(verbose bool) (bool, error) {
-    buf := make([]byte, 1)
+    more, _, _, err := ffile.readNext(verbose)
+    return more, err
+}
+
+// read the next record, recovering from errors in recovery mode
+func (ffile *FitFile) readNext(verbose bool) (bool, *FitDefinition, FitMsg,
+    error) {
+    if ffile.done {
+        return false, nil, nil, nil
+    }
 
-    n, err := ffile.rdr.Read(buf)
+    more, def, msg, err := ffile.readRecord(verbose)
+    if err != nil && ffile.recover_errs {
+        more, err = ffile.recoverFrom(err, verbose)
+    }
+    if !more && err == nil {
+        ffile.done = true
+    }
+
+    return more, def, msg, err
+}
+
+// read the next record, or the file CRC and the header of any file which
+// follows it.  The definition or data message read is returned.
+func (ffile *FitFile) readRecord(verbose bool) (bool, *FitDefinition,
+    FitMsg, error) {
+    if ffile.data_read >= ffile.cur.datasize {
+        err := ffile.readFileCRC()
+        if err != nil {
+            return false, nil, nil, err
+        }
+
+        more, err := ffile.nextFile(verbose)
+        return more, nil, nil, err
+    }
+
+    buf := ffile.rec_hdr[:]
+
+    // records are numbered from zero within each file
+    ffile.ctx = ErrorContext{Offset: ffile.offset, File: len(ffile.files) - 1,
+        Record: ffile.records, LocalType: -1, GlobalNum: -1}
+    ffile.records++
+
+    ffile.rec_bytes = ffile.rec_bytes[:0]
+    ffile.rec_crc = ffile.crc
+    ffile.rec_data_read = ffile.data_read
+
+    err := ffile.read(buf)
     if err != nil {
-        return false, err
-    } else if n != len(buf) {
-        return false, errors.New(fmt.Sprintf("Read %d bytes, not %d", n,
-            len(buf)))
+        return false, nil, nil, err
     }
 
     var is_def bool
+    var has_dev bool
     var local_type byte
     var time_offset uint32
 
     compressed := buf[0] & 0x80 == 0x80
     if !compressed {
         is_def = buf[0] & 0x40 == 0x40
+        has_dev = is_def && buf[0] & 0x20 == 0x20
         local_type = buf[0] & 0x0f
         time_offset = 0
     } else {
         is_def = false
-        local_type = (buf[0] >> 4) & 0x3
-        time_offset = uint32(buf[0] & 0xf)
+        has_dev = false
+        local_type = (buf[0] >> 5) & 0x3
+        time_offset = uint32(buf[0] & 0x1f)
     }
 
+    ffile.ctx.LocalType = int(local_type)
+
     if is_def {
-        def, derr := ffile.readDefinition(local_type, verbose)
+        def, derr := ffile.readDefinition(local_type, has_dev,
+            verbose)
         if derr != nil {
-            return false, derr
+            return false, nil, nil, derr
+        }
+
+        // a new definition replaces any earlier one for the local type
+        ffile.local_defs[local_type] = def
+        if ffile.keep_defs {
+            ffile.cur.defs = append(ffile.cur.defs, def)
         }
 
-        ffile.defs = append(ffile.defs, def)
+        return true, def, nil, nil
     } else {
         def, err2 := ffile.findDefinition(local_type)
         if err2 != nil {
-            return false, err2
+            return false, nil, nil, err2
         }
+        ffile.ctx.GlobalNum = int(def.GlobalNum)
 
-        data, err3 := ffile.readData(def, time_offset, verbose)
+        wanted := ffile.isWanted(def.GlobalNum)
+        if !wanted && !internal_msgs[def.GlobalNum] {
+            err3 := ffile.skipData(def, compressed, time_offset)
+            if err3 != nil {
+                return false, nil, nil, err3
+            }
+
+            return true, nil, nil, nil
+        }
+
+        data, err3 := ffile.readData(def, compressed, time_offset,
+            verbose)
         if err3 != nil {
-            return false, err3
+            return false, nil, nil, err3
+        }
+
+        if fid, ok := data.(*MsgFileId); ok && ffile.cur.file_id == nil {
+            ffile.cur.file_id = fid
+        }
+        if !wanted {
+            return true, nil, nil, nil
         }
 
         if verbose {
             fmt.Println("  data:", data.Text())
+            for _, ufld := range data.UnknownFields() {
+                fmt.Println("       :: unknown", ufld)
+            }
+            for _, dfld := range data.DeveloperFields() {
+                fmt.Println("       :: developer", dfld)
+            }
+        }
+
+        if !ffile.no_retain {
+            ffile.cur.data = append(ffile.cur.data, data)
         }
 
-        ffile.data = append(ffile.data, data)
+        return true, nil, data, nil
+    }
+}
+
+// start on the file which follows the current one, if there is one
+func (ffile *FitFile) nextFile(verbose bool) (bool, error) {
+    done, err := ffile.atEOF()
+    if err != nil {
+        return false, err
+    } else if done {
+        return false, nil
+    }
+
+    err = ffile.readHeader()
+    if err != nil {
+        return false, err
+    }
+
+    if verbose {
+        fmt.Println(ffile.String())
     }
 
     return true, nil
 }
 
 func (ffile *FitFile) String() string {
-    return fmt.Sprintf("%s: proto %d profile %d data %d", ffile.filename,
-        ffile.proto, ffile.profile, ffile.datasize)
+    name := ffile.filename
+    if name == "" {
+        name = "<stream>"
+    }
+
+    if len(ffile.files) > 1 {
+        name = fmt.Sprintf("%s#%d", name, len(ffile.files))
+    }
+
+    return fmt.Sprintf("%s: %s", name, ffile.cur.String())
 }
@@ -0,0 +1,289 @@
+package ant_fit
+
+import (
+    "bytes"
+    "encoding/binary"
+    "errors"
+    "io"
+    "testing"
+)
+
+// the same records decode identically whichever byte order they use
+func TestByteOrder(t *testing.T) {
+    for _, big := range []bool{false, true} {
+        ffile := mustDecode(t, testActivity(big).build())
+
+        sub := ffile.SubFiles()[0]
+        fid := sub.FileId()
+        if fid == nil {
+            t.Fatalf("big %v: no file_id", big)
+        }
+        if fid.Manufacturer != 1 || fid.Product != 1036 ||
+            fid.SerialNumber != 3812345678 || fid.TimeCreated != 800000000 {
+            t.Errorf("big %v: file_id %s", big, fid.Text())
+        }
+
+        recs := testMessages(ffile, 20)
+        if len(recs) != 5 {
+            t.Fatalf("big %v: %d records, not 5", big, len(recs))
+        }
+
+        for i, msg := range recs {
+            rec := msg.(*MsgRecord)
+            if rec.Timestamp != uint32(800000000 + i) ||
+                rec.PositionLat != int32(500000000 + i * 100) ||
+                rec.PositionLong != int32(-900000000 - i * 100) ||
+                rec.Altitude != uint16(3000 + i) ||
+                rec.Distance != uint32(i * 500) ||
+                rec.Speed != uint16(3000 + i) {
+                t.Errorf("big %v: record %d is %s", big, i, rec.Text())
+            }
+        }
+    }
+}
+
+// the global message number is in the definition's byte order
+func TestGlobalNumByteOrder(t *testing.T) {
+    for _, big := range []bool{false, true} {
+        w := new(testWriter)
+        w.define(0, 0x1234, big, testField{1, 2, 0x84})
+        w.data(0, 0xabcd)
+
+        ffile := mustDecode(t, w.build())
+        msgs := ffile.Messages()
+        if len(msgs) != 1 || msgs[0].GlobalNum() != 0x1234 {
+            t.Fatalf("big %v: messages %v", big, msgs)
+        }
+
+        unknown := msgs[0].UnknownFields()
+        if len(unknown) != 1 || unknown[0].LittleEndian == big {
+            t.Fatalf("big %v: unknown fields %v", big, unknown)
+        }
+
+        if val := msgs[0].FieldByNum(1); !val.Valid || val.Scaled != 0xabcd {
+            t.Errorf("big %v: field 1 is %v", big, val)
+        }
+    }
+}
+
+func TestExpandTimestamp(t *testing.T) {
+    tests := []struct {
+        last uint32
+        offset uint32
+        want uint32
+    }{
+        {0x40, 0x05, 0x45},
+        {0x45, 0x05, 0x45},
+        {0x45, 0x1f, 0x5f},
+        // the 5-bit offset rolls over
+        {0x5e, 0x01, 0x61},
+        {0x5f, 0x00, 0x60},
+        {0xffffffe0, 0x03, 0xffffffe3},
+    }
+
+    for _, tst := range tests {
+        if got := expandTimestamp(tst.last, tst.offset); got != tst.want {
+            t.Errorf("expandTimestamp(%#x, %#x) = %#x, not %#x", tst.last,
+                tst.offset, got, tst.want)
+        }
+    }
+}
+
+func TestCompressedTimestamps(t *testing.T) {
+    // 800000000 is 0x2faf0800, so its 5-bit offset is zero
+    const base = 800000000
+
+    w := new(testWriter)
+    w.define(0, 20, false, testField{3, 1, 0x02})
+    w.define(1, 20, false, testField{253, 4, 0x86}, testField{3, 1, 0x02})
+
+    // no full timestamp has been seen yet
+    w.compressed(0, 3, 100)
+    w.data(1, base + 29, 101)
+    w.compressed(0, 30, 102)
+    // rolls over from an offset of 30 to one of 2
+    w.compressed(0, 2, 103)
+    w.compressed(0, 2, 104)
+    // a new full timestamp is the new reference
+    w.data(1, base + 100, 105)
+    w.compressed(0, 7, 106)
+
+    want := []uint32{invalid_uint32, base + 29, base + 30, base + 34,
+        base + 34, base + 100, base + 103}
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != len(want) {
+        t.Fatalf("%d records, not %d", len(recs), len(want))
+    }
+
+    for i, msg := range recs {
+        rec := msg.(*MsgRecord)
+        if rec.Timestamp != want[i] {
+            t.Errorf("record %d: timestamp %d, not %d", i, rec.Timestamp,
+                want[i])
+        }
+        if rec.HeartRate != uint8(100 + i) {
+            t.Errorf("record %d: heart rate %d", i, rec.HeartRate)
+        }
+    }
+}
+
+func TestHeaderCRC(t *testing.T) {
+    // a header without a CRC, or with a zero CRC, is not checked
+    mustDecode(t, testActivity(false).buildFile(false, false))
+
+    data := testActivity(false).build()
+    data[12], data[13] = 0, 0
+    binary.LittleEndian.PutUint16(data[len(data) - 2:],
+        computeCRC(0, data[:len(data) - 2]))
+    mustDecode(t, data)
+
+    data = testActivity(false).build()
+    data[12] ^= 0xff
+
+    _, err := NewDecoder(bytes.NewReader(data))
+    var cerr *CRCError
+    if !errors.As(err, &cerr) || !cerr.Header || cerr.Offset != 0 {
+        t.Fatalf("bad header CRC gave %v", err)
+    }
+}
+
+func TestFileCRC(t *testing.T) {
+    data := testActivity(false).buildFile(true, true)
+
+    ffile, err := decodeTest(data)
+    var cerr *CRCError
+    if !errors.As(err, &cerr) || cerr.Header {
+        t.Fatalf("bad file CRC gave %v", err)
+    }
+    if cerr.Offset != int64(len(data) - 2) || cerr.Record != -1 {
+        t.Errorf("bad file CRC context %+v", cerr.ErrorContext)
+    }
+
+    // the records are all decoded before the CRC is checked
+    if n := len(ffile.Messages()); n != 6 {
+        t.Errorf("%d messages, not 6", n)
+    }
+}
+
+func TestMissingFileCRC(t *testing.T) {
+    for _, cut := range []int{1, 2} {
+        data := testActivity(false).build()
+        data = data[:len(data) - cut]
+
+        ffile, err := decodeTest(data)
+        var terr *TruncatedError
+        if !errors.As(err, &terr) || terr.Wanted != 2 ||
+            terr.Read != 2 - cut || !errors.Is(err, io.ErrUnexpectedEOF) {
+            t.Fatalf("missing %d CRC bytes gave %v", cut, err)
+        }
+        if n := len(ffile.Messages()); n != 6 {
+            t.Errorf("%d messages, not 6", n)
+        }
+    }
+}
+
+// records are read up to the header's data size, and whatever follows
+// the file CRC is taken to be another file's header
+func TestDataSize(t *testing.T) {
+    w := testActivity(false)
+    data := w.build()
+    size := len(data)
+
+    // a record which would decode if the data size were ignored
+    end := len(w.recs)
+    w.data(1, 800000010, 0, 0, 0, 0, 0, 0)
+    data = append(data, w.recs[end:]...)
+
+    ffile, err := decodeTest(data)
+    var herr *HeaderError
+    if !errors.As(err, &herr) || !errors.Is(err, ErrHeaderSize) ||
+        herr.Offset != int64(size) || herr.File != 1 {
+        t.Fatalf("trailing bytes gave %v", err)
+    }
+    if n := len(ffile.Messages()); n != 6 {
+        t.Errorf("%d messages, not 6", n)
+    }
+
+    // recovery mode skips them
+    ffile, err = NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        t.Fatal(err)
+    }
+    ffile.RecoverFromErrors(true)
+    for more := true; more && err == nil; {
+        more, err = ffile.ReadMessage(false)
+    }
+    if err != nil || len(ffile.Messages()) != 6 ||
+        len(ffile.Skipped()) != 1 || ffile.Skipped()[0].Offset != int64(size) {
+        t.Errorf("recovering from trailing bytes gave %v, skipped %v", err,
+            ffile.Skipped())
+    }
+}
+
+// a definition replaces the earlier one for its local type, so the
+// records which follow it are decoded with the new layout
+func TestRedefinition(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{3, 1, 0x02})
+    w.data(0, 800000000, 120)
+    w.define(0, 20, true, testField{253, 4, 0x86}, testField{4, 1, 0x02},
+        testField{6, 2, 0x84}, testField{3, 1, 0x02})
+    w.data(0, 800000001, 85, 3000, 121)
+    w.define(0, 21, false, testField{253, 4, 0x86}, testField{0, 1, 0x00},
+        testField{1, 1, 0x00})
+    w.data(0, 800000002, 0, 4)
+    data := w.build()
+
+    ffile := mustDecode(t, data)
+
+    msgs := ffile.Messages()
+    if len(msgs) != 3 {
+        t.Fatalf("%d messages, not 3", len(msgs))
+    }
+
+    first, ok1 := msgs[0].(*MsgRecord)
+    second, ok2 := msgs[1].(*MsgRecord)
+    event, ok3 := msgs[2].(*MsgEvent)
+    if !ok1 || !ok2 || !ok3 {
+        t.Fatalf("messages %T %T %T", msgs[0], msgs[1], msgs[2])
+    }
+    if first.HeartRate != 120 || first.IsSet("cadence") {
+        t.Errorf("first record %s", first.Text())
+    }
+    if second.Timestamp != 800000001 || second.HeartRate != 121 ||
+        second.Cadence != 85 || second.Speed != 3000 {
+        t.Errorf("second record %s", second.Text())
+    }
+    if event.Timestamp != 800000002 || event.EventType != 4 {
+        t.Errorf("event %s", event.Text())
+    }
+
+    // definitions are only kept on request
+    if defs := ffile.SubFiles()[0].Definitions(); defs != nil {
+        t.Errorf("%d definitions kept by default", len(defs))
+    }
+
+    ffile, err := NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        t.Fatal(err)
+    }
+    ffile.KeepDefinitions(true)
+    for more := true; more && err == nil; {
+        more, err = ffile.ReadMessage(false)
+    }
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    defs := ffile.SubFiles()[0].Definitions()
+    if len(defs) != 3 {
+        t.Fatalf("%d definitions kept, not 3", len(defs))
+    }
+    if defs[0].GlobalNum != 20 || len(defs[0].Fields) != 2 ||
+        defs[1].GlobalNum != 20 || len(defs[1].Fields) != 4 ||
+        defs[1].LittleEndian || defs[1].TotalBytes != 8 ||
+        defs[2].GlobalNum != 21 || defs[2].LocalType != 0 {
+        t.Errorf("definitions %+v %+v %+v", defs[0], defs[1], defs[2])
+    }
+}
@@ -0,0 +1,320 @@
+package ant_fit
+
+import (
+    "bytes"
+    "encoding/binary"
+    "math"
+    "testing"
+)
+
+// builder for FIT files used by the tests
+
+// a field in a test definition: number, size in bytes and base type byte
+type testField struct {
+    num byte
+    size byte
+    base_type byte
+}
+
+type testDef struct {
+    fields []testField
+    big bool
+    dev []testField
+}
+
+type testWriter struct {
+    recs []byte
+    defs [16]*testDef
+}
+
+// add a definition message
+func (w *testWriter) define(local byte, global uint16, big bool,
+    fields ...testField) {
+    w.defineDev(local, global, big, fields, nil)
+}
+
+// add a definition message with developer fields, whose 'base_type' is
+// the developer data index
+func (w *testWriter) defineDev(local byte, global uint16, big bool,
+    fields []testField, dev []testField) {
+    hdr := 0x40 | local
+    if dev != nil {
+        hdr |= 0x20
+    }
+
+    arch := byte(0)
+    order := binary.ByteOrder(binary.LittleEndian)
+    if big {
+        arch = 1
+        order = binary.BigEndian
+    }
+
+    rec := []byte{hdr, 0, arch, 0, 0, byte(len(fields))}
+    order.PutUint16(rec[3:], global)
+    for _, fld := range fields {
+        rec = append(rec, fld.num, fld.size, fld.base_type)
+    }
+    if dev != nil {
+        rec = append(rec, byte(len(dev)))
+        for _, fld := range dev {
+            rec = append(rec, fld.num, fld.size, fld.base_type)
+        }
+    }
+
+    w.defs[local] = &testDef{fields: fields, big: big, dev: dev}
+    w.recs = append(w.recs, rec...)
+}
+
+// add a data message with a normal header.  Each value is an integer, a
+// float64, a string, or a []byte holding the raw field; developer field
+// values are passed as []byte after the profile fields, and like other
+// []byte values are padded with zeros to the field's size.
+func (w *testWriter) data(local byte, values ...interface{}) {
+    w.record(local, local, values)
+}
+
+// add a data message with a compressed timestamp header
+func (w *testWriter) compressed(local byte, time_offset byte,
+    values ...interface{}) {
+    w.record(local, 0x80 | local << 5 | time_offset & 0x1f, values)
+}
+
+func (w *testWriter) record(local byte, hdr byte, values []interface{}) {
+    def := w.defs[local]
+
+    order := binary.ByteOrder(binary.LittleEndian)
+    if def.big {
+        order = binary.BigEndian
+    }
+
+    rec := []byte{hdr}
+    for i, fld := range def.fields {
+        rec = append(rec, encodeTestValue(fld, order, values[i])...)
+    }
+    for i, fld := range def.dev {
+        rec = append(rec, encodeTestValue(fld, order,
+            values[len(def.fields) + i].([]byte))...)
+    }
+
+    w.recs = append(w.recs, rec...)
+}
+
+func encodeTestValue(fld testField, order binary.ByteOrder,
+    val interface{}) []byte {
+    buf := make([]byte, fld.size)
+
+    switch v := val.(type) {
+    case []byte:
+        copy(buf, v)
+    case string:
+        copy(buf, v)
+    case float64:
+        if fld.base_type & 0x1f == 8 {
+            order.PutUint32(buf, math.Float32bits(float32(v)))
+        } else {
+            order.PutUint64(buf, math.Float64bits(v))
+        }
+    case int:
+        size := get_base_size(fld.base_type & 0x1f)
+        for pos := 0; pos < len(buf); pos += size {
+            put_raw_uint(buf[pos:pos + size], order, uint64(v))
+        }
+    case []int:
+        size := get_base_size(fld.base_type & 0x1f)
+        for i, elem := range v {
+            put_raw_uint(buf[i * size:(i + 1) * size], order, uint64(elem))
+        }
+    default:
+        panic("unsupported test value")
+    }
+
+    return buf
+}
+
+// add raw bytes to the records
+func (w *testWriter) raw(data ...byte) {
+    w.recs = append(w.recs, data...)
+}
+
+// the records with a 14-byte header and a good file CRC
+func (w *testWriter) build() []byte {
+    return w.buildFile(true, false)
+}
+
+// the records with a 14-byte header if 'hdr_crc' is true, or a 12-byte
+// header if not, and a file CRC which is wrong if 'bad_crc' is true
+func (w *testWriter) buildFile(hdr_crc bool, bad_crc bool) []byte {
+    size := byte(12)
+    if hdr_crc {
+        size = 14
+    }
+
+    file := []byte{size, 0x10, 0, 0, 0, 0, 0, 0, '.', 'F', 'I', 'T'}
+    binary.LittleEndian.PutUint16(file[2:], 710)
+    binary.LittleEndian.PutUint32(file[4:], uint32(len(w.recs)))
+    if hdr_crc {
+        file = binary.LittleEndian.AppendUint16(file, computeCRC(0, file))
+    }
+
+    file = append(file, w.recs...)
+
+    crc := computeCRC(0, file)
+    if bad_crc {
+        crc ^= 0xffff
+    }
+
+    return binary.LittleEndian.AppendUint16(file, crc)
+}
+
+// a short activity: a file_id message and five record messages
+func testActivity(big bool) *testWriter {
+    w := new(testWriter)
+
+    w.define(0, 0, big, testField{0, 1, 0x00}, testField{1, 2, 0x84},
+        testField{2, 2, 0x84}, testField{3, 4, 0x8c}, testField{4, 4, 0x86})
+    w.data(0, 4, 1, 1036, 3812345678, 800000000)
+
+    w.define(1, 20, big, testField{253, 4, 0x86}, testField{0, 4, 0x85},
+        testField{1, 4, 0x85}, testField{2, 2, 0x84}, testField{3, 1, 0x02},
+        testField{5, 4, 0x86}, testField{6, 2, 0x84})
+    for i := 0; i < 5; i++ {
+        w.data(1, 800000000 + i, 500000000 + i * 100, -900000000 - i * 100,
+            3000 + i, 120 + i, i * 500, 3000 + i)
+    }
+
+    return w
+}
+
+// decode every record in 'data', returning the decoder and the first error
+func decodeTest(data []byte) (*FitFile, error) {
+    ffile, err := NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        return nil, err
+    }
+
+    for {
+        more, err := ffile.ReadMessage(false)
+        if err != nil {
+            return ffile, err
+        } else if !more {
+            return ffile, nil
+        }
+    }
+}
+
+// decode 'data', failing the test on any error
+func mustDecode(t *testing.T, data []byte) *FitFile {
+    t.Helper()
+
+    ffile, err := decodeTest(data)
+    if err != nil {
+        t.Fatalf("decoding failed: %v", err)
+    }
+
+    return ffile
+}
+
+// the data messages in 'ffile' with global message number 'num'
+func testMessages(ffile *FitFile, num uint16) []FitMsg {
+    var list []FitMsg
+    for _, msg := range ffile.Messages() {
+        if msg.GlobalNum() == num {
+            list = append(list, msg)
+        }
+    }
+
+    return list
+}
+
+// a cycling activity with a record every second for 'count' seconds, a
+// lap every 'lap' records, and the session and activity messages at the
+// end, laid out as a typical bike computer writes them
+func testLargeActivity(count int, lap int) []byte {
+    const start = 800000000
+
+    w := new(testWriter)
+
+    w.define(0, 0, false, testField{0, 1, 0x00}, testField{1, 2, 0x84},
+        testField{2, 2, 0x84}, testField{3, 4, 0x8c}, testField{4, 4, 0x86})
+    w.data(0, 4, 1, 1036, 3812345678, start)
+
+    w.define(1, 23, false, testField{253, 4, 0x86}, testField{0, 1, 0x02},
+        testField{2, 2, 0x84}, testField{3, 4, 0x8c}, testField{4, 2, 0x84},
+        testField{5, 2, 0x84})
+    w.data(1, start, 0, 1, 3812345678, 1036, 420)
+
+    w.define(2, 21, false, testField{253, 4, 0x86}, testField{0, 1, 0x00},
+        testField{1, 1, 0x00}, testField{3, 4, 0x86})
+    w.data(2, start, 0, 0, 0)
+
+    w.define(3, 20, false, testField{253, 4, 0x86}, testField{0, 4, 0x85},
+        testField{1, 4, 0x85}, testField{2, 2, 0x84}, testField{3, 1, 0x02},
+        testField{4, 1, 0x02}, testField{5, 4, 0x86}, testField{6, 2, 0x84},
+        testField{7, 2, 0x84}, testField{13, 1, 0x01})
+
+    w.define(4, 19, false, testField{254, 2, 0x84},
+        testField{253, 4, 0x86}, testField{0, 1, 0x00}, testField{1, 1, 0x00},
+        testField{2, 4, 0x86}, testField{7, 4, 0x86}, testField{9, 4, 0x86})
+
+    dist := 0
+    lap_start, lap_dist, laps := start, 0, 0
+    for i := 0; i < count; i++ {
+        ts := start + i
+        speed := 7000 + i % 97 * 20
+        dist += speed / 10
+
+        w.data(3, ts, 500000000 + i * 37, -900000000 - i * 41,
+            2500 + i % 300, 120 + i % 50, 85 + i % 10, dist, speed,
+            150 + i % 200, 20 + i % 5)
+
+        if (i + 1) % lap == 0 {
+            w.data(4, laps, ts, 9, 1, lap_start, (ts - lap_start) * 1000,
+                dist - lap_dist)
+            lap_start, lap_dist = ts, dist
+            laps++
+        }
+    }
+
+    end := start + count
+    w.data(2, end, 0, 4, 0)
+
+    w.define(5, 18, false, testField{254, 2, 0x84}, testField{253, 4, 0x86},
+        testField{0, 1, 0x00}, testField{1, 1, 0x00}, testField{2, 4, 0x86},
+        testField{5, 1, 0x00}, testField{7, 4, 0x86}, testField{9, 4, 0x86})
+    w.data(5, 0, end, 8, 1, start, 2, count * 1000, dist)
+
+    w.define(6, 34, false, testField{253, 4, 0x86}, testField{0, 4, 0x86},
+        testField{1, 2, 0x84}, testField{5, 4, 0x86})
+    w.data(6, end, count * 1000, 1, end + 3600)
+
+    return w.build()
+}
+
+// an indoor ride recorded from a speed sensor, whose records hold packed
+// speed and distance which must be unpacked into their components
+func testSensorActivity(count int) []byte {
+    const start = 800000000
+
+    w := new(testWriter)
+
+    w.define(0, 0, false, testField{0, 1, 0x00}, testField{1, 2, 0x84},
+        testField{2, 2, 0x84}, testField{3, 4, 0x8c}, testField{4, 4, 0x86})
+    w.data(0, 4, 1, 1036, 3812345678, start)
+
+    w.define(1, 20, false, testField{253, 4, 0x86}, testField{3, 1, 0x02},
+        testField{8, 3, 0x0d}, testField{52, 2, 0x84}, testField{7, 2, 0x84})
+
+    dist := 0
+    for i := 0; i < count; i++ {
+        // speed in 1/100 m/s and distance in 1/16 m, which rolls over
+        speed := 700 + i % 97 * 2
+        dist += speed * 16 / 100
+        packed := speed | dist % 4096 << 12
+
+        w.data(1, start + i, 120 + i % 50,
+            []byte{byte(packed), byte(packed >> 8), byte(packed >> 16)},
+            (85 + i % 10) * 256 + i % 256, 150 + i % 200)
+    }
+
+    return w.build()
+}
@@ -1,23 +1,42 @@
 package ant_fit
 
 import (
-    "errors"
     "fmt"
+    "math"
+    "time"
 )
 
+// FitFieldDefinition describes one field of a definition message
 type FitFieldDefinition struct {
-    num byte
-    size byte
-    is_endian bool
-    base_type byte
+    Num byte
+    Size byte
+    IsEndian bool
+    BaseType byte
 }
 
+// FitDevFieldDefinition describes a FIT 2.0 developer field
+type FitDevFieldDefinition struct {
+    Num byte
+    Size byte
+    DevIndex byte
+}
+
+// FitDefinition describes the layout of the data messages which use
+// its local message type
 type FitDefinition struct {
-    local_type byte
-    little_endian bool
-    global_num uint16
-    fields []*FitFieldDefinition
-    total_bytes uint16
+    LocalType byte
+    LittleEndian bool
+    GlobalNum uint16
+    Fields []*FitFieldDefinition
+    DevFields []*FitDevFieldDefinition
+    TotalBytes int
+
+    // copy of this definition used for compressed-timestamp records
+    timestamp_def *FitDefinition
+    // copy of this definition with fields unpacked from components
+    component_def *FitDefinition
+    // fields which hold or restart running totals
+    accum_plan *accumPlan
 }
 
 // message interface
@@ -25,64 +44,243 @@ type FitDefinition struct {
 type FitMsg interface {
     Name() string
     Text() string
+    IsSet(name string) bool
+    Scaled(name string) float64
+    Units(name string) string
+    Accumulated(name string) float64
+    SubField(name string) string
+    UnknownFields() []*FitUnknownField
+    DeveloperFields() []*FitDeveloperField
+    GlobalNum() uint16
+    Field(name string) Value
+    FieldByNum(num byte) Value
+    Fields() []Value
+
+    base() *msgBase
 }
 
 // file_id message
 
+// MsgFileId holds the fields of a file_id message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
 type MsgFileId struct {
-    msgtype byte
-    manufacturer uint16
-    product uint16
-    serial_number uint32
-    time_created uint32
-    number uint16
-}
-
-func (msg *MsgFileId) msgtype_name() string {
-    switch msg.msgtype {
-    case 1: return "device"
-    case 2: return "settings"
-    case 3: return "sport"
-    case 4: return "activity"
-    case 5: return "workout"
-    case 6: return "course"
-    case 7: return "schedules"
-    case 9: return "weight"
-    case 10: return "totals"
-    case 11: return "goals"
-    case 14: return "blood_pressure"
-    case 15: return "monitoring"
-    case 20: return "activity_summary"
-    case 28: return "monitoring_daily"
-    default: return fmt.Sprintf("unknown#%d", msg.msgtype)
-    }
+    msgBase
+
+    Type File
+    Manufacturer Manufacturer
+    Product uint16 // see SubField("product")
+    SerialNumber uint32
+    TimeCreated uint32
+    Number uint16
 }
 
 func (msg *MsgFileId) Name() string {
     return "file_id"
 }
 
+// time_created as a time.Time; see DateTime()
+func (msg *MsgFileId) TimeCreatedTime() time.Time {
+    return DateTime(msg.TimeCreated)
+}
+
+// name of the subfield which holds the value of product, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgFileId) product_subfield() string {
+    switch {
+    case msg.Manufacturer == 1 || msg.Manufacturer == 15 || msg.Manufacturer == 13: return "garmin_product"
+    default: return ""
+    }
+}
+
+// product when it holds garmin_product
+func (msg *MsgFileId) GarminProduct() uint16 {
+    if msg.product_subfield() != "garmin_product" ||
+        !is_valid_uint16(msg.Product) {
+        return invalid_uint16
+    }
+    return uint16(msg.Product)
+}
+
 func (msg *MsgFileId) Text() string {
-    return fmt.Sprintf("file_id msgtyp %s mfct %d prod %d ser# %d timecre %d" +
-        " # %d", msg.msgtype_name(), msg.manufacturer, msg.product,
-        msg.serial_number, msg.time_created, msg.number)
+    txt := "file_id"
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_uint16(uint16(msg.Manufacturer)) {
+        txt += fmt.Sprintf(" mfct %s", msg.Manufacturer)
+    }
+    if is_valid_uint16(msg.Product) {
+        switch msg.product_subfield() {
+        case "garmin_product":
+            txt += fmt.Sprintf(" garminprod %d", msg.GarminProduct())
+        default:
+            txt += fmt.Sprintf(" prod %d", msg.Product)
+        }
+    }
+    if is_valid_uint32z(msg.SerialNumber) {
+        txt += fmt.Sprintf(" ser# %d", msg.SerialNumber)
+    }
+    if is_valid_uint32(msg.TimeCreated) {
+        txt += fmt.Sprintf(" timecre %d", msg.TimeCreated)
+    }
+    if is_valid_uint16(msg.Number) {
+        txt += fmt.Sprintf(" # %d", msg.Number)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgFileId) IsSet(name string) bool {
+    switch name {
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "manufacturer": return is_valid_uint16(uint16(msg.Manufacturer))
+    case "product": return is_valid_uint16(msg.Product)
+    case "serial_number": return is_valid_uint32z(msg.SerialNumber)
+    case "time_created": return is_valid_uint32(msg.TimeCreated)
+    case "number": return is_valid_uint16(msg.Number)
+    case "garmin_product":
+        return msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgFileId) Scaled(name string) float64 {
+    switch name {
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    case "manufacturer":
+        if is_valid_uint16(uint16(msg.Manufacturer)) {
+            return float64(msg.Manufacturer)
+        }
+    case "product":
+        if is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
+        }
+    case "serial_number":
+        if is_valid_uint32z(msg.SerialNumber) {
+            return float64(msg.SerialNumber)
+        }
+    case "time_created":
+        if is_valid_uint32(msg.TimeCreated) {
+            return float64(msg.TimeCreated)
+        }
+    case "number":
+        if is_valid_uint16(msg.Number) {
+            return float64(msg.Number)
+        }
+    case "garmin_product":
+        if msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgFileId) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// SubField returns the name of the subfield selected for the named
+// field by the message's other fields, or an empty string if none is
+func (msg *MsgFileId) SubField(name string) string {
+    switch name {
+    case "product": return msg.product_subfield()
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgFileId) GlobalNum() uint16 {
+    return 0
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgFileId) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "type", Num: 0, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 1:
+        return Value{Name: "manufacturer", Num: 1, BaseType: 4,
+            Raw: msg.Manufacturer, Scaled: msg.Scaled("manufacturer"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.Manufacturer))}
+    case 2:
+        return Value{Name: "product", Num: 2, BaseType: 4,
+            Raw: msg.Product, Scaled: msg.Scaled("product"),
+            Units: "", Valid: is_valid_uint16(msg.Product)}
+    case 3:
+        return Value{Name: "serial_number", Num: 3, BaseType: 12,
+            Raw: msg.SerialNumber, Scaled: msg.Scaled("serial_number"),
+            Units: "", Valid: is_valid_uint32z(msg.SerialNumber)}
+    case 4:
+        return Value{Name: "time_created", Num: 4, BaseType: 6,
+            Raw: msg.TimeCreated, Scaled: msg.Scaled("time_created"),
+            Units: "", Valid: is_valid_uint32(msg.TimeCreated)}
+    case 5:
+        return Value{Name: "number", Num: 5, BaseType: 4,
+            Raw: msg.Number, Scaled: msg.Scaled("number"),
+            Units: "", Valid: is_valid_uint16(msg.Number)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgFileId) Field(name string) Value {
+    switch name {
+    case "type": return msg.FieldByNum(0)
+    case "manufacturer": return msg.FieldByNum(1)
+    case "product": return msg.FieldByNum(2)
+    case "serial_number": return msg.FieldByNum(3)
+    case "time_created": return msg.FieldByNum(4)
+    case "number": return msg.FieldByNum(5)
+    case "garmin_product":
+        return Value{Name: "garmin_product", Num: 2, BaseType: 4,
+            Raw: msg.GarminProduct(), Scaled: msg.Scaled("garmin_product"),
+            Units: "", Valid: msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product)}
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgFileId) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 2, 3, 4, 5})
 }
 
 func NewMsgFileId(def *FitDefinition, data []byte) (*MsgFileId, error) {
     msg := new(MsgFileId)
 
+    msg.Type = File(invalid_enum)
+    msg.Manufacturer = Manufacturer(invalid_uint16)
+    msg.Product = invalid_uint16
+    msg.TimeCreated = invalid_uint32
+    msg.Number = invalid_uint16
+
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 1: msg.manufacturer, pos = get_uint16_pos(data, pos)
-        case 2: msg.product, pos = get_uint16_pos(data, pos)
-        case 3: msg.serial_number, pos = get_uint32_pos(data, pos)
-        case 4: msg.time_created, pos = get_uint32_pos(data, pos)
-        case 5: msg.number, pos = get_uint16_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Type = File(get_enum(buf, fld, order))
+        case 1: msg.Manufacturer = Manufacturer(get_uint16(buf, fld, order))
+        case 2: msg.Product = get_uint16(buf, fld, order)
+        case 3: msg.SerialNumber = get_uint32z(buf, fld, order)
+        case 4: msg.TimeCreated = get_uint32(buf, fld, order)
+        case 5: msg.Number = get_uint16(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad file_id field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
@@ -91,10 +289,14 @@ func NewMsgFileId(def *FitDefinition, data []byte) (*MsgFileId, error) {
 
 // capabilities message
 
+// MsgCapabilities holds the fields of a capabilities message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
 type MsgCapabilities struct {
-    languages uint8
-    sports uint8
-    workouts_supported uint32
+    msgBase
+
+    Languages []uint8
+    Sports []uint8
+    WorkoutsSupported uint32
 }
 
 func (msg *MsgCapabilities) Name() string {
@@ -102,23 +304,105 @@ func (msg *MsgCapabilities) Name() string {
 }
 
 func (msg *MsgCapabilities) Text() string {
-    return fmt.Sprintf("capabilities languages %d sports %d" +
-        " workoutssupported %d", msg.languages, msg.sports,
-        msg.workouts_supported)
+    txt := "capabilities"
+    if len(msg.Languages) > 0 {
+        txt += fmt.Sprintf(" languages %d", msg.Languages)
+    }
+    if len(msg.Sports) > 0 {
+        txt += fmt.Sprintf(" sports %d", msg.Sports)
+    }
+    if is_valid_uint32z(msg.WorkoutsSupported) {
+        txt += fmt.Sprintf(" workoutssupported %d", msg.WorkoutsSupported)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgCapabilities) IsSet(name string) bool {
+    switch name {
+    case "languages": return len(msg.Languages) > 0
+    case "sports": return len(msg.Sports) > 0
+    case "workouts_supported": return is_valid_uint32z(msg.WorkoutsSupported)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgCapabilities) Scaled(name string) float64 {
+    switch name {
+    case "workouts_supported":
+        if is_valid_uint32z(msg.WorkoutsSupported) {
+            return float64(msg.WorkoutsSupported)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgCapabilities) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgCapabilities) GlobalNum() uint16 {
+    return 1
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgCapabilities) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "languages", Num: 0, BaseType: 10,
+            Raw: msg.Languages, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.Languages) > 0}
+    case 1:
+        return Value{Name: "sports", Num: 1, BaseType: 10,
+            Raw: msg.Sports, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.Sports) > 0}
+    case 21:
+        return Value{Name: "workouts_supported", Num: 21, BaseType: 12,
+            Raw: msg.WorkoutsSupported, Scaled: msg.Scaled("workouts_supported"),
+            Units: "", Valid: is_valid_uint32z(msg.WorkoutsSupported)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgCapabilities) Field(name string) Value {
+    switch name {
+    case "languages": return msg.FieldByNum(0)
+    case "sports": return msg.FieldByNum(1)
+    case "workouts_supported": return msg.FieldByNum(21)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgCapabilities) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 21})
 }
 
 func NewMsgCapabilities(def *FitDefinition, data []byte) (*MsgCapabilities, error) {
     msg := new(MsgCapabilities)
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.languages, pos = get_uint8_pos(data, pos)
-        case 1: msg.sports, pos = get_uint8_pos(data, pos)
-        case 21: msg.workouts_supported, pos = get_uint32_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Languages = get_uint8z_array(buf, fld, order)
+        case 1: msg.Sports = get_uint8z_array(buf, fld, order)
+        case 21: msg.WorkoutsSupported = get_uint32z(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad capabilities field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
@@ -127,8 +411,12 @@ func NewMsgCapabilities(def *FitDefinition, data []byte) (*MsgCapabilities, erro
 
 // device_settings message
 
+// MsgDeviceSettings holds the fields of a device_settings message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
 type MsgDeviceSettings struct {
-    utc_offset uint32
+    msgBase
+
+    UTCOffset uint32
 }
 
 func (msg *MsgDeviceSettings) Name() string {
@@ -136,19 +424,87 @@ func (msg *MsgDeviceSettings) Name() string {
 }
 
 func (msg *MsgDeviceSettings) Text() string {
-    return fmt.Sprintf("device_settings utcoffset %d", msg.utc_offset)
+    txt := "device_settings"
+    if is_valid_uint32(msg.UTCOffset) {
+        txt += fmt.Sprintf(" utcoffset %d", msg.UTCOffset)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgDeviceSettings) IsSet(name string) bool {
+    switch name {
+    case "utc_offset": return is_valid_uint32(msg.UTCOffset)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgDeviceSettings) Scaled(name string) float64 {
+    switch name {
+    case "utc_offset":
+        if is_valid_uint32(msg.UTCOffset) {
+            return float64(msg.UTCOffset)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgDeviceSettings) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgDeviceSettings) GlobalNum() uint16 {
+    return 2
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgDeviceSettings) FieldByNum(num byte) Value {
+    switch num {
+    case 1:
+        return Value{Name: "utc_offset", Num: 1, BaseType: 6,
+            Raw: msg.UTCOffset, Scaled: msg.Scaled("utc_offset"),
+            Units: "", Valid: is_valid_uint32(msg.UTCOffset)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgDeviceSettings) Field(name string) Value {
+    switch name {
+    case "utc_offset": return msg.FieldByNum(1)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgDeviceSettings) Fields() []Value {
+    return fieldValues(msg, []byte{1})
 }
 
 func NewMsgDeviceSettings(def *FitDefinition, data []byte) (*MsgDeviceSettings, error) {
     msg := new(MsgDeviceSettings)
 
+    msg.UTCOffset = invalid_uint32
+
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 1: msg.utc_offset, pos = get_uint32_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 1: msg.UTCOffset = get_uint32(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad device_settings field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
@@ -157,82 +513,445 @@ func NewMsgDeviceSettings(def *FitDefinition, data []byte) (*MsgDeviceSettings,
 
 // user_profile message
 
+// MsgUserProfile holds the fields of a user_profile message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
 type MsgUserProfile struct {
-    message_index uint16
-    friendly_name string
-    gender byte
-    age uint8
-    height uint8
-    weight uint16
-    language byte
-    elev_setting byte
-    weight_setting byte
-    resting_heart_rate uint8
-    default_max_running_heart_rate uint8
-    default_max_biking_heart_rate uint8
-    default_max_heart_rate uint8
-    hr_setting byte
-    speed_setting byte
-    dist_setting byte
-    power_setting byte
-    activity_class byte
-    position_setting byte
-    temperature_setting byte
-    local_id uint16
-    global_id byte
+    msgBase
+
+    MessageIndex uint16
+    FriendlyName string
+    Gender Gender
+    Age uint8 // years
+    Height uint8 // m, scale 100
+    Weight uint16 // kg, scale 10
+    Language Language
+    ElevSetting DisplayMeasure
+    WeightSetting DisplayMeasure
+    RestingHeartRate uint8 // bpm
+    DefaultMaxRunningHeartRate uint8 // bpm
+    DefaultMaxBikingHeartRate uint8 // bpm
+    DefaultMaxHeartRate uint8 // bpm
+    HRSetting DisplayHeart
+    SpeedSetting DisplayMeasure
+    DistSetting DisplayMeasure
+    PowerSetting DisplayPower
+    ActivityClass byte
+    PositionSetting DisplayPosition
+    TemperatureSetting DisplayMeasure
+    LocalID uint16
+    GlobalID []byte
 }
 
 func (msg *MsgUserProfile) Name() string {
     return "user_profile"
 }
 
+// height in m
+func (msg *MsgUserProfile) HeightScaled() float64 {
+    if !is_valid_uint8(msg.Height) {
+        return math.NaN()
+    }
+    return float64(msg.Height) / 100
+}
+
+// weight in kg
+func (msg *MsgUserProfile) WeightScaled() float64 {
+    if !is_valid_uint16(msg.Weight) {
+        return math.NaN()
+    }
+    return float64(msg.Weight) / 10
+}
+
 func (msg *MsgUserProfile) Text() string {
-    return fmt.Sprintf("user_profile msgidx %d friendlyname %s gender %d" +
-        " age %d height %d weight %d language %d elevsetting %d" +
-        " weightsetting %d restingheartrate %d defaultmaxrunningheartrate %d" +
-        " defaultmaxbikingheartrate %d defaultmaxheartrate %d hrsetting %d" +
-        " speedsetting %d distsetting %d powersetting %d activityclass %d" +
-        " possetting %d tempsetting %d localid %d globalid %d",
-        msg.message_index, msg.friendly_name, msg.gender, msg.age, msg.height,
-        msg.weight, msg.language, msg.elev_setting, msg.weight_setting,
-        msg.resting_heart_rate, msg.default_max_running_heart_rate,
-        msg.default_max_biking_heart_rate, msg.default_max_heart_rate,
-        msg.hr_setting, msg.speed_setting, msg.dist_setting, msg.power_setting,
-        msg.activity_class, msg.position_setting, msg.temperature_setting,
-        msg.local_id, msg.global_id)
+    txt := "user_profile"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_string(msg.FriendlyName) {
+        txt += fmt.Sprintf(" friendlyname %s", msg.FriendlyName)
+    }
+    if is_valid_enum(byte(msg.Gender)) {
+        txt += fmt.Sprintf(" gender %s", msg.Gender)
+    }
+    if is_valid_uint8(msg.Age) {
+        txt += fmt.Sprintf(" age %d", msg.Age)
+    }
+    if is_valid_uint8(msg.Height) {
+        txt += fmt.Sprintf(" height %g", msg.HeightScaled())
+    }
+    if is_valid_uint16(msg.Weight) {
+        txt += fmt.Sprintf(" weight %g", msg.WeightScaled())
+    }
+    if is_valid_enum(byte(msg.Language)) {
+        txt += fmt.Sprintf(" language %s", msg.Language)
+    }
+    if is_valid_enum(byte(msg.ElevSetting)) {
+        txt += fmt.Sprintf(" elevsetting %s", msg.ElevSetting)
+    }
+    if is_valid_enum(byte(msg.WeightSetting)) {
+        txt += fmt.Sprintf(" weightsetting %s", msg.WeightSetting)
+    }
+    if is_valid_uint8(msg.RestingHeartRate) {
+        txt += fmt.Sprintf(" restingheartrate %d", msg.RestingHeartRate)
+    }
+    if is_valid_uint8(msg.DefaultMaxRunningHeartRate) {
+        txt += fmt.Sprintf(" defaultmaxrunningheartrate %d", msg.DefaultMaxRunningHeartRate)
+    }
+    if is_valid_uint8(msg.DefaultMaxBikingHeartRate) {
+        txt += fmt.Sprintf(" defaultmaxbikingheartrate %d", msg.DefaultMaxBikingHeartRate)
+    }
+    if is_valid_uint8(msg.DefaultMaxHeartRate) {
+        txt += fmt.Sprintf(" defaultmaxheartrate %d", msg.DefaultMaxHeartRate)
+    }
+    if is_valid_enum(byte(msg.HRSetting)) {
+        txt += fmt.Sprintf(" hrsetting %s", msg.HRSetting)
+    }
+    if is_valid_enum(byte(msg.SpeedSetting)) {
+        txt += fmt.Sprintf(" speedsetting %s", msg.SpeedSetting)
+    }
+    if is_valid_enum(byte(msg.DistSetting)) {
+        txt += fmt.Sprintf(" distsetting %s", msg.DistSetting)
+    }
+    if is_valid_enum(byte(msg.PowerSetting)) {
+        txt += fmt.Sprintf(" powersetting %s", msg.PowerSetting)
+    }
+    if is_valid_enum(msg.ActivityClass) {
+        txt += fmt.Sprintf(" activityclass %d", msg.ActivityClass)
+    }
+    if is_valid_enum(byte(msg.PositionSetting)) {
+        txt += fmt.Sprintf(" possetting %s", msg.PositionSetting)
+    }
+    if is_valid_enum(byte(msg.TemperatureSetting)) {
+        txt += fmt.Sprintf(" tempsetting %s", msg.TemperatureSetting)
+    }
+    if is_valid_uint16(msg.LocalID) {
+        txt += fmt.Sprintf(" localid %d", msg.LocalID)
+    }
+    if len(msg.GlobalID) > 0 {
+        txt += fmt.Sprintf(" globalid %d", msg.GlobalID)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgUserProfile) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "friendly_name": return is_valid_string(msg.FriendlyName)
+    case "gender": return is_valid_enum(byte(msg.Gender))
+    case "age": return is_valid_uint8(msg.Age)
+    case "height": return is_valid_uint8(msg.Height)
+    case "weight": return is_valid_uint16(msg.Weight)
+    case "language": return is_valid_enum(byte(msg.Language))
+    case "elev_setting": return is_valid_enum(byte(msg.ElevSetting))
+    case "weight_setting": return is_valid_enum(byte(msg.WeightSetting))
+    case "resting_heart_rate": return is_valid_uint8(msg.RestingHeartRate)
+    case "default_max_running_heart_rate": return is_valid_uint8(msg.DefaultMaxRunningHeartRate)
+    case "default_max_biking_heart_rate": return is_valid_uint8(msg.DefaultMaxBikingHeartRate)
+    case "default_max_heart_rate": return is_valid_uint8(msg.DefaultMaxHeartRate)
+    case "hr_setting": return is_valid_enum(byte(msg.HRSetting))
+    case "speed_setting": return is_valid_enum(byte(msg.SpeedSetting))
+    case "dist_setting": return is_valid_enum(byte(msg.DistSetting))
+    case "power_setting": return is_valid_enum(byte(msg.PowerSetting))
+    case "activity_class": return is_valid_enum(msg.ActivityClass)
+    case "position_setting": return is_valid_enum(byte(msg.PositionSetting))
+    case "temperature_setting": return is_valid_enum(byte(msg.TemperatureSetting))
+    case "local_id": return is_valid_uint16(msg.LocalID)
+    case "global_id": return len(msg.GlobalID) > 0
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgUserProfile) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "gender":
+        if is_valid_enum(byte(msg.Gender)) {
+            return float64(msg.Gender)
+        }
+    case "age":
+        if is_valid_uint8(msg.Age) {
+            return float64(msg.Age)
+        }
+    case "height":
+        return msg.HeightScaled()
+    case "weight":
+        return msg.WeightScaled()
+    case "language":
+        if is_valid_enum(byte(msg.Language)) {
+            return float64(msg.Language)
+        }
+    case "elev_setting":
+        if is_valid_enum(byte(msg.ElevSetting)) {
+            return float64(msg.ElevSetting)
+        }
+    case "weight_setting":
+        if is_valid_enum(byte(msg.WeightSetting)) {
+            return float64(msg.WeightSetting)
+        }
+    case "resting_heart_rate":
+        if is_valid_uint8(msg.RestingHeartRate) {
+            return float64(msg.RestingHeartRate)
+        }
+    case "default_max_running_heart_rate":
+        if is_valid_uint8(msg.DefaultMaxRunningHeartRate) {
+            return float64(msg.DefaultMaxRunningHeartRate)
+        }
+    case "default_max_biking_heart_rate":
+        if is_valid_uint8(msg.DefaultMaxBikingHeartRate) {
+            return float64(msg.DefaultMaxBikingHeartRate)
+        }
+    case "default_max_heart_rate":
+        if is_valid_uint8(msg.DefaultMaxHeartRate) {
+            return float64(msg.DefaultMaxHeartRate)
+        }
+    case "hr_setting":
+        if is_valid_enum(byte(msg.HRSetting)) {
+            return float64(msg.HRSetting)
+        }
+    case "speed_setting":
+        if is_valid_enum(byte(msg.SpeedSetting)) {
+            return float64(msg.SpeedSetting)
+        }
+    case "dist_setting":
+        if is_valid_enum(byte(msg.DistSetting)) {
+            return float64(msg.DistSetting)
+        }
+    case "power_setting":
+        if is_valid_enum(byte(msg.PowerSetting)) {
+            return float64(msg.PowerSetting)
+        }
+    case "activity_class":
+        if is_valid_enum(msg.ActivityClass) {
+            return float64(msg.ActivityClass)
+        }
+    case "position_setting":
+        if is_valid_enum(byte(msg.PositionSetting)) {
+            return float64(msg.PositionSetting)
+        }
+    case "temperature_setting":
+        if is_valid_enum(byte(msg.TemperatureSetting)) {
+            return float64(msg.TemperatureSetting)
+        }
+    case "local_id":
+        if is_valid_uint16(msg.LocalID) {
+            return float64(msg.LocalID)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgUserProfile) Units(name string) string {
+    switch name {
+    case "age": return "years"
+    case "height": return "m"
+    case "weight": return "kg"
+    case "resting_heart_rate": return "bpm"
+    case "default_max_running_heart_rate": return "bpm"
+    case "default_max_biking_heart_rate": return "bpm"
+    case "default_max_heart_rate": return "bpm"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgUserProfile) GlobalNum() uint16 {
+    return 3
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgUserProfile) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "friendly_name", Num: 0, BaseType: 7,
+            Raw: msg.FriendlyName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.FriendlyName)}
+    case 1:
+        return Value{Name: "gender", Num: 1, BaseType: 0,
+            Raw: msg.Gender, Scaled: msg.Scaled("gender"),
+            Units: "", Valid: is_valid_enum(byte(msg.Gender))}
+    case 2:
+        return Value{Name: "age", Num: 2, BaseType: 2,
+            Raw: msg.Age, Scaled: msg.Scaled("age"),
+            Units: "years", Valid: is_valid_uint8(msg.Age)}
+    case 3:
+        return Value{Name: "height", Num: 3, BaseType: 2,
+            Raw: msg.Height, Scaled: msg.Scaled("height"),
+            Units: "m", Valid: is_valid_uint8(msg.Height)}
+    case 4:
+        return Value{Name: "weight", Num: 4, BaseType: 4,
+            Raw: msg.Weight, Scaled: msg.Scaled("weight"),
+            Units: "kg", Valid: is_valid_uint16(msg.Weight)}
+    case 5:
+        return Value{Name: "language", Num: 5, BaseType: 0,
+            Raw: msg.Language, Scaled: msg.Scaled("language"),
+            Units: "", Valid: is_valid_enum(byte(msg.Language))}
+    case 6:
+        return Value{Name: "elev_setting", Num: 6, BaseType: 0,
+            Raw: msg.ElevSetting, Scaled: msg.Scaled("elev_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.ElevSetting))}
+    case 7:
+        return Value{Name: "weight_setting", Num: 7, BaseType: 0,
+            Raw: msg.WeightSetting, Scaled: msg.Scaled("weight_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.WeightSetting))}
+    case 8:
+        return Value{Name: "resting_heart_rate", Num: 8, BaseType: 2,
+            Raw: msg.RestingHeartRate, Scaled: msg.Scaled("resting_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.RestingHeartRate)}
+    case 9:
+        return Value{Name: "default_max_running_heart_rate", Num: 9, BaseType: 2,
+            Raw: msg.DefaultMaxRunningHeartRate, Scaled: msg.Scaled("default_max_running_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.DefaultMaxRunningHeartRate)}
+    case 10:
+        return Value{Name: "default_max_biking_heart_rate", Num: 10, BaseType: 2,
+            Raw: msg.DefaultMaxBikingHeartRate, Scaled: msg.Scaled("default_max_biking_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.DefaultMaxBikingHeartRate)}
+    case 11:
+        return Value{Name: "default_max_heart_rate", Num: 11, BaseType: 2,
+            Raw: msg.DefaultMaxHeartRate, Scaled: msg.Scaled("default_max_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.DefaultMaxHeartRate)}
+    case 12:
+        return Value{Name: "hr_setting", Num: 12, BaseType: 0,
+            Raw: msg.HRSetting, Scaled: msg.Scaled("hr_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.HRSetting))}
+    case 13:
+        return Value{Name: "speed_setting", Num: 13, BaseType: 0,
+            Raw: msg.SpeedSetting, Scaled: msg.Scaled("speed_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.SpeedSetting))}
+    case 14:
+        return Value{Name: "dist_setting", Num: 14, BaseType: 0,
+            Raw: msg.DistSetting, Scaled: msg.Scaled("dist_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.DistSetting))}
+    case 16:
+        return Value{Name: "power_setting", Num: 16, BaseType: 0,
+            Raw: msg.PowerSetting, Scaled: msg.Scaled("power_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.PowerSetting))}
+    case 17:
+        return Value{Name: "activity_class", Num: 17, BaseType: 0,
+            Raw: msg.ActivityClass, Scaled: msg.Scaled("activity_class"),
+            Units: "", Valid: is_valid_enum(msg.ActivityClass)}
+    case 18:
+        return Value{Name: "position_setting", Num: 18, BaseType: 0,
+            Raw: msg.PositionSetting, Scaled: msg.Scaled("position_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.PositionSetting))}
+    case 21:
+        return Value{Name: "temperature_setting", Num: 21, BaseType: 0,
+            Raw: msg.TemperatureSetting, Scaled: msg.Scaled("temperature_setting"),
+            Units: "", Valid: is_valid_enum(byte(msg.TemperatureSetting))}
+    case 22:
+        return Value{Name: "local_id", Num: 22, BaseType: 4,
+            Raw: msg.LocalID, Scaled: msg.Scaled("local_id"),
+            Units: "", Valid: is_valid_uint16(msg.LocalID)}
+    case 23:
+        return Value{Name: "global_id", Num: 23, BaseType: 13,
+            Raw: msg.GlobalID, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.GlobalID) > 0}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgUserProfile) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "friendly_name": return msg.FieldByNum(0)
+    case "gender": return msg.FieldByNum(1)
+    case "age": return msg.FieldByNum(2)
+    case "height": return msg.FieldByNum(3)
+    case "weight": return msg.FieldByNum(4)
+    case "language": return msg.FieldByNum(5)
+    case "elev_setting": return msg.FieldByNum(6)
+    case "weight_setting": return msg.FieldByNum(7)
+    case "resting_heart_rate": return msg.FieldByNum(8)
+    case "default_max_running_heart_rate": return msg.FieldByNum(9)
+    case "default_max_biking_heart_rate": return msg.FieldByNum(10)
+    case "default_max_heart_rate": return msg.FieldByNum(11)
+    case "hr_setting": return msg.FieldByNum(12)
+    case "speed_setting": return msg.FieldByNum(13)
+    case "dist_setting": return msg.FieldByNum(14)
+    case "power_setting": return msg.FieldByNum(16)
+    case "activity_class": return msg.FieldByNum(17)
+    case "position_setting": return msg.FieldByNum(18)
+    case "temperature_setting": return msg.FieldByNum(21)
+    case "local_id": return msg.FieldByNum(22)
+    case "global_id": return msg.FieldByNum(23)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgUserProfile) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 16, 17, 18, 21, 22, 23})
 }
 
 func NewMsgUserProfile(def *FitDefinition, data []byte) (*MsgUserProfile, error) {
     msg := new(MsgUserProfile)
 
+    msg.MessageIndex = invalid_uint16
+    msg.Gender = Gender(invalid_enum)
+    msg.Age = invalid_uint8
+    msg.Height = invalid_uint8
+    msg.Weight = invalid_uint16
+    msg.Language = Language(invalid_enum)
+    msg.ElevSetting = DisplayMeasure(invalid_enum)
+    msg.WeightSetting = DisplayMeasure(invalid_enum)
+    msg.RestingHeartRate = invalid_uint8
+    msg.DefaultMaxRunningHeartRate = invalid_uint8
+    msg.DefaultMaxBikingHeartRate = invalid_uint8
+    msg.DefaultMaxHeartRate = invalid_uint8
+    msg.HRSetting = DisplayHeart(invalid_enum)
+    msg.SpeedSetting = DisplayMeasure(invalid_enum)
+    msg.DistSetting = DisplayMeasure(invalid_enum)
+    msg.PowerSetting = DisplayPower(invalid_enum)
+    msg.ActivityClass = invalid_enum
+    msg.PositionSetting = DisplayPosition(invalid_enum)
+    msg.TemperatureSetting = DisplayMeasure(invalid_enum)
+    msg.LocalID = invalid_uint16
+
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.friendly_name, pos = get_string_pos(data, pos)
-        case 1: msg.gender, pos = get_byte_pos(data, pos)
-        case 2: msg.age, pos = get_uint8_pos(data, pos)
-        case 3: msg.height, pos = get_uint8_pos(data, pos)
-        case 4: msg.weight, pos = get_uint16_pos(data, pos)
-        case 5: msg.language, pos = get_byte_pos(data, pos)
-        case 6: msg.elev_setting, pos = get_byte_pos(data, pos)
-        case 7: msg.weight_setting, pos = get_byte_pos(data, pos)
-        case 8: msg.resting_heart_rate, pos = get_uint8_pos(data, pos)
-        case 9: msg.default_max_running_heart_rate, pos = get_uint8_pos(data, pos)
-        case 10: msg.default_max_biking_heart_rate, pos = get_uint8_pos(data, pos)
-        case 11: msg.default_max_heart_rate, pos = get_uint8_pos(data, pos)
-        case 12: msg.hr_setting, pos = get_byte_pos(data, pos)
-        case 13: msg.speed_setting, pos = get_byte_pos(data, pos)
-        case 14: msg.dist_setting, pos = get_byte_pos(data, pos)
-        case 16: msg.power_setting, pos = get_byte_pos(data, pos)
-        case 17: msg.activity_class, pos = get_byte_pos(data, pos)
-        case 18: msg.position_setting, pos = get_byte_pos(data, pos)
-        case 21: msg.temperature_setting, pos = get_byte_pos(data, pos)
-        case 22: msg.local_id, pos = get_uint16_pos(data, pos)
-        case 23: msg.global_id, pos = get_byte_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.FriendlyName = get_string(buf, fld, order)
+        case 1: msg.Gender = Gender(get_enum(buf, fld, order))
+        case 2: msg.Age = get_uint8(buf, fld, order)
+        case 3: msg.Height = get_uint8(buf, fld, order)
+        case 4: msg.Weight = get_uint16(buf, fld, order)
+        case 5: msg.Language = Language(get_enum(buf, fld, order))
+        case 6: msg.ElevSetting = DisplayMeasure(get_enum(buf, fld, order))
+        case 7: msg.WeightSetting = DisplayMeasure(get_enum(buf, fld, order))
+        case 8: msg.RestingHeartRate = get_uint8(buf, fld, order)
+        case 9: msg.DefaultMaxRunningHeartRate = get_uint8(buf, fld, order)
+        case 10: msg.DefaultMaxBikingHeartRate = get_uint8(buf, fld, order)
+        case 11: msg.DefaultMaxHeartRate = get_uint8(buf, fld, order)
+        case 12: msg.HRSetting = DisplayHeart(get_enum(buf, fld, order))
+        case 13: msg.SpeedSetting = DisplayMeasure(get_enum(buf, fld, order))
+        case 14: msg.DistSetting = DisplayMeasure(get_enum(buf, fld, order))
+        case 16: msg.PowerSetting = DisplayPower(get_enum(buf, fld, order))
+        case 17: msg.ActivityClass = get_enum(buf, fld, order)
+        case 18: msg.PositionSetting = DisplayPosition(get_enum(buf, fld, order))
+        case 21: msg.TemperatureSetting = DisplayMeasure(get_enum(buf, fld, order))
+        case 22: msg.LocalID = get_uint16(buf, fld, order)
+        case 23: msg.GlobalID = get_byte_array(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad user_profile field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
@@ -241,12 +960,16 @@ func NewMsgUserProfile(def *FitDefinition, data []byte) (*MsgUserProfile, error)
 
 // hrm_profile message
 
+// MsgHrmProfile holds the fields of a hrm_profile message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
 type MsgHrmProfile struct {
-    message_index uint16
-    enabled byte
-    hrm_ant_id uint16
-    log_hrv byte
-    hrm_ant_id_trans_type uint8
+    msgBase
+
+    MessageIndex uint16
+    Enabled Bool
+    HRMANTID uint16
+    LogHRV Bool
+    HRMANTIDTransType uint8
 }
 
 func (msg *MsgHrmProfile) Name() string {
@@ -254,2009 +977,12904 @@ func (msg *MsgHrmProfile) Name() string {
 }
 
 func (msg *MsgHrmProfile) Text() string {
-    return fmt.Sprintf("hrm_profile msgidx %d enabled %d hrmantid %d" +
-        " loghrv %d hrmantidtranstyp %d", msg.message_index, msg.enabled,
-        msg.hrm_ant_id, msg.log_hrv, msg.hrm_ant_id_trans_type)
+    txt := "hrm_profile"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.Enabled)) {
+        txt += fmt.Sprintf(" enabled %s", msg.Enabled)
+    }
+    if is_valid_uint16z(msg.HRMANTID) {
+        txt += fmt.Sprintf(" hrmantid %d", msg.HRMANTID)
+    }
+    if is_valid_enum(byte(msg.LogHRV)) {
+        txt += fmt.Sprintf(" loghrv %s", msg.LogHRV)
+    }
+    if is_valid_uint8z(msg.HRMANTIDTransType) {
+        txt += fmt.Sprintf(" hrmantidtranstyp %d", msg.HRMANTIDTransType)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgHrmProfile) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "enabled": return is_valid_enum(byte(msg.Enabled))
+    case "hrm_ant_id": return is_valid_uint16z(msg.HRMANTID)
+    case "log_hrv": return is_valid_enum(byte(msg.LogHRV))
+    case "hrm_ant_id_trans_type": return is_valid_uint8z(msg.HRMANTIDTransType)
+    default: return false
+    }
 }
 
-func NewMsgHrmProfile(def *FitDefinition, data []byte) (*MsgHrmProfile, error) {
-    msg := new(MsgHrmProfile)
-
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.enabled, pos = get_byte_pos(data, pos)
-        case 1: msg.hrm_ant_id, pos = get_uint16_pos(data, pos)
-        case 2: msg.log_hrv, pos = get_byte_pos(data, pos)
-        case 3: msg.hrm_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad hrm_profile field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgHrmProfile) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "enabled":
+        if is_valid_enum(byte(msg.Enabled)) {
+            return float64(msg.Enabled)
+        }
+    case "hrm_ant_id":
+        if is_valid_uint16z(msg.HRMANTID) {
+            return float64(msg.HRMANTID)
+        }
+    case "log_hrv":
+        if is_valid_enum(byte(msg.LogHRV)) {
+            return float64(msg.LogHRV)
+        }
+    case "hrm_ant_id_trans_type":
+        if is_valid_uint8z(msg.HRMANTIDTransType) {
+            return float64(msg.HRMANTIDTransType)
         }
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// sdm_profile message
+// Units returns the units of the named field's scaled value
+func (msg *MsgHrmProfile) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
 
-type MsgSdmProfile struct {
-    message_index uint16
-    enabled byte
-    sdm_ant_id uint16
-    sdm_cal_factor uint16
-    odometer uint32
-    speed_source byte
-    sdm_ant_id_trans_type uint8
-    odometer_rollover uint8
+// GlobalNum returns the message's global message number
+func (msg *MsgHrmProfile) GlobalNum() uint16 {
+    return 4
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgHrmProfile) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "enabled", Num: 0, BaseType: 0,
+            Raw: msg.Enabled, Scaled: msg.Scaled("enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.Enabled))}
+    case 1:
+        return Value{Name: "hrm_ant_id", Num: 1, BaseType: 11,
+            Raw: msg.HRMANTID, Scaled: msg.Scaled("hrm_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.HRMANTID)}
+    case 2:
+        return Value{Name: "log_hrv", Num: 2, BaseType: 0,
+            Raw: msg.LogHRV, Scaled: msg.Scaled("log_hrv"),
+            Units: "", Valid: is_valid_enum(byte(msg.LogHRV))}
+    case 3:
+        return Value{Name: "hrm_ant_id_trans_type", Num: 3, BaseType: 10,
+            Raw: msg.HRMANTIDTransType, Scaled: msg.Scaled("hrm_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.HRMANTIDTransType)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgSdmProfile) Name() string {
-    return "sdm_profile"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgHrmProfile) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "enabled": return msg.FieldByNum(0)
+    case "hrm_ant_id": return msg.FieldByNum(1)
+    case "log_hrv": return msg.FieldByNum(2)
+    case "hrm_ant_id_trans_type": return msg.FieldByNum(3)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgSdmProfile) Text() string {
-    return fmt.Sprintf("sdm_profile msgidx %d enabled %d sdmantid %d" +
-        " sdmcalfactor %d odometer %d speedsource %d sdmantidtranstyp %d" +
-        " odometerrollover %d", msg.message_index, msg.enabled, msg.sdm_ant_id,
-        msg.sdm_cal_factor, msg.odometer, msg.speed_source,
-        msg.sdm_ant_id_trans_type, msg.odometer_rollover)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgHrmProfile) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3})
 }
 
-func NewMsgSdmProfile(def *FitDefinition, data []byte) (*MsgSdmProfile, error) {
-    msg := new(MsgSdmProfile)
+func NewMsgHrmProfile(def *FitDefinition, data []byte) (*MsgHrmProfile, error) {
+    msg := new(MsgHrmProfile)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Enabled = Bool(invalid_enum)
+    msg.LogHRV = Bool(invalid_enum)
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.enabled, pos = get_byte_pos(data, pos)
-        case 1: msg.sdm_ant_id, pos = get_uint16_pos(data, pos)
-        case 2: msg.sdm_cal_factor, pos = get_uint16_pos(data, pos)
-        case 3: msg.odometer, pos = get_uint32_pos(data, pos)
-        case 4: msg.speed_source, pos = get_byte_pos(data, pos)
-        case 5: msg.sdm_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        case 7: msg.odometer_rollover, pos = get_uint8_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.Enabled = Bool(get_enum(buf, fld, order))
+        case 1: msg.HRMANTID = get_uint16z(buf, fld, order)
+        case 2: msg.LogHRV = Bool(get_enum(buf, fld, order))
+        case 3: msg.HRMANTIDTransType = get_uint8z(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad sdm_profile field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// bike_profile message
+// sdm_profile message
 
-type MsgBikeProfile struct {
-    message_index uint16
-    name string
-    sport byte
-    sub_sport byte
-    odometer uint32
-    bike_spd_ant_id uint16
-    bike_cad_ant_id uint16
-    bike_spdcad_ant_id uint16
-    bike_power_ant_id uint16
-    custom_wheelsize uint16
-    auto_wheelsize uint16
-    bike_weight uint16
-    power_cal_factor uint16
-    auto_wheel_cal byte
-    auto_power_zero byte
-    id uint8
-    spd_enabled byte
-    cad_enabled byte
-    spdcad_enabled byte
-    power_enabled byte
-    crank_length uint8
-    enabled byte
-    bike_spd_ant_id_trans_type uint8
-    bike_cad_ant_id_trans_type uint8
-    bike_spdcad_ant_id_trans_type uint8
-    bike_power_ant_id_trans_type uint8
-    odometer_rollover uint8
+// MsgSdmProfile holds the fields of a sdm_profile message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSdmProfile struct {
+    msgBase
+
+    MessageIndex uint16
+    Enabled Bool
+    SDMANTID uint16
+    SDMCalFactor uint16 // %, scale 10
+    Odometer uint32 // m, scale 100
+    SpeedSource Bool
+    SDMANTIDTransType uint8
+    OdometerRollover uint8
 }
 
-func (msg *MsgBikeProfile) Name() string {
-    return "bike_profile"
+func (msg *MsgSdmProfile) Name() string {
+    return "sdm_profile"
 }
 
-func (msg *MsgBikeProfile) Text() string {
-    return fmt.Sprintf("bike_profile msgidx %d name %s sport %d subsport %d" +
-        " odometer %d bikespdantid %d bikecadantid %d bikespdcadantid %d" +
-        " bikepowerantid %d customwheelsize %d autowheelsize %d bikeweight %d" +
-        " powercalfactor %d autowheelcal %d autopowerzero %d id %d" +
-        " spdenabled %d cadenabled %d spdcadenabled %d powerenabled %d" +
-        " cranklen %d enabled %d bikespdantidtranstyp %d" +
-        " bikecadantidtranstyp %d bikespdcadantidtranstyp %d" +
-        " bikepowerantidtranstyp %d odometerrollover %d", msg.message_index,
-        msg.name, msg.sport, msg.sub_sport, msg.odometer, msg.bike_spd_ant_id,
-        msg.bike_cad_ant_id, msg.bike_spdcad_ant_id, msg.bike_power_ant_id,
-        msg.custom_wheelsize, msg.auto_wheelsize, msg.bike_weight,
-        msg.power_cal_factor, msg.auto_wheel_cal, msg.auto_power_zero, msg.id,
-        msg.spd_enabled, msg.cad_enabled, msg.spdcad_enabled, msg.power_enabled,
-        msg.crank_length, msg.enabled, msg.bike_spd_ant_id_trans_type,
-        msg.bike_cad_ant_id_trans_type, msg.bike_spdcad_ant_id_trans_type,
-        msg.bike_power_ant_id_trans_type, msg.odometer_rollover)
+// sdm_cal_factor in %
+func (msg *MsgSdmProfile) SDMCalFactorScaled() float64 {
+    if !is_valid_uint16(msg.SDMCalFactor) {
+        return math.NaN()
+    }
+    return float64(msg.SDMCalFactor) / 10
 }
 
-func NewMsgBikeProfile(def *FitDefinition, data []byte) (*MsgBikeProfile, error) {
-    msg := new(MsgBikeProfile)
+// odometer in m
+func (msg *MsgSdmProfile) OdometerScaled() float64 {
+    if !is_valid_uint32(msg.Odometer) {
+        return math.NaN()
+    }
+    return float64(msg.Odometer) / 100
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.name, pos = get_string_pos(data, pos)
-        case 1: msg.sport, pos = get_byte_pos(data, pos)
-        case 2: msg.sub_sport, pos = get_byte_pos(data, pos)
-        case 3: msg.odometer, pos = get_uint32_pos(data, pos)
-        case 4: msg.bike_spd_ant_id, pos = get_uint16_pos(data, pos)
-        case 5: msg.bike_cad_ant_id, pos = get_uint16_pos(data, pos)
-        case 6: msg.bike_spdcad_ant_id, pos = get_uint16_pos(data, pos)
-        case 7: msg.bike_power_ant_id, pos = get_uint16_pos(data, pos)
-        case 8: msg.custom_wheelsize, pos = get_uint16_pos(data, pos)
-        case 9: msg.auto_wheelsize, pos = get_uint16_pos(data, pos)
-        case 10: msg.bike_weight, pos = get_uint16_pos(data, pos)
-        case 11: msg.power_cal_factor, pos = get_uint16_pos(data, pos)
-        case 12: msg.auto_wheel_cal, pos = get_byte_pos(data, pos)
-        case 13: msg.auto_power_zero, pos = get_byte_pos(data, pos)
-        case 14: msg.id, pos = get_uint8_pos(data, pos)
-        case 15: msg.spd_enabled, pos = get_byte_pos(data, pos)
-        case 16: msg.cad_enabled, pos = get_byte_pos(data, pos)
-        case 17: msg.spdcad_enabled, pos = get_byte_pos(data, pos)
-        case 18: msg.power_enabled, pos = get_byte_pos(data, pos)
-        case 19: msg.crank_length, pos = get_uint8_pos(data, pos)
-        case 20: msg.enabled, pos = get_byte_pos(data, pos)
-        case 21: msg.bike_spd_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        case 22: msg.bike_cad_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        case 23: msg.bike_spdcad_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        case 24: msg.bike_power_ant_id_trans_type, pos = get_uint8_pos(data, pos)
-        case 37: msg.odometer_rollover, pos = get_uint8_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad bike_profile field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+func (msg *MsgSdmProfile) Text() string {
+    txt := "sdm_profile"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.Enabled)) {
+        txt += fmt.Sprintf(" enabled %s", msg.Enabled)
+    }
+    if is_valid_uint16z(msg.SDMANTID) {
+        txt += fmt.Sprintf(" sdmantid %d", msg.SDMANTID)
+    }
+    if is_valid_uint16(msg.SDMCalFactor) {
+        txt += fmt.Sprintf(" sdmcalfactor %g", msg.SDMCalFactorScaled())
     }
+    if is_valid_uint32(msg.Odometer) {
+        txt += fmt.Sprintf(" odometer %g", msg.OdometerScaled())
+    }
+    if is_valid_enum(byte(msg.SpeedSource)) {
+        txt += fmt.Sprintf(" speedsource %s", msg.SpeedSource)
+    }
+    if is_valid_uint8z(msg.SDMANTIDTransType) {
+        txt += fmt.Sprintf(" sdmantidtranstyp %d", msg.SDMANTIDTransType)
+    }
+    if is_valid_uint8(msg.OdometerRollover) {
+        txt += fmt.Sprintf(" odometerrollover %d", msg.OdometerRollover)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSdmProfile) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "enabled": return is_valid_enum(byte(msg.Enabled))
+    case "sdm_ant_id": return is_valid_uint16z(msg.SDMANTID)
+    case "sdm_cal_factor": return is_valid_uint16(msg.SDMCalFactor)
+    case "odometer": return is_valid_uint32(msg.Odometer)
+    case "speed_source": return is_valid_enum(byte(msg.SpeedSource))
+    case "sdm_ant_id_trans_type": return is_valid_uint8z(msg.SDMANTIDTransType)
+    case "odometer_rollover": return is_valid_uint8(msg.OdometerRollover)
+    default: return false
+    }
+}
 
-    return msg, nil
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSdmProfile) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "enabled":
+        if is_valid_enum(byte(msg.Enabled)) {
+            return float64(msg.Enabled)
+        }
+    case "sdm_ant_id":
+        if is_valid_uint16z(msg.SDMANTID) {
+            return float64(msg.SDMANTID)
+        }
+    case "sdm_cal_factor":
+        return msg.SDMCalFactorScaled()
+    case "odometer":
+        return msg.OdometerScaled()
+    case "speed_source":
+        if is_valid_enum(byte(msg.SpeedSource)) {
+            return float64(msg.SpeedSource)
+        }
+    case "sdm_ant_id_trans_type":
+        if is_valid_uint8z(msg.SDMANTIDTransType) {
+            return float64(msg.SDMANTIDTransType)
+        }
+    case "odometer_rollover":
+        if is_valid_uint8(msg.OdometerRollover) {
+            return float64(msg.OdometerRollover)
+        }
+    }
+    return math.NaN()
 }
 
-// zones_target message
+// Units returns the units of the named field's scaled value
+func (msg *MsgSdmProfile) Units(name string) string {
+    switch name {
+    case "sdm_cal_factor": return "%"
+    case "odometer": return "m"
+    default: return ""
+    }
+}
 
-type MsgZonesTarget struct {
-    max_heart_rate uint8
-    threshold_heart_rate uint8
-    functional_threshold_power uint16
-    hr_calc_type byte
-    pwr_calc_type byte
+// GlobalNum returns the message's global message number
+func (msg *MsgSdmProfile) GlobalNum() uint16 {
+    return 5
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSdmProfile) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "enabled", Num: 0, BaseType: 0,
+            Raw: msg.Enabled, Scaled: msg.Scaled("enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.Enabled))}
+    case 1:
+        return Value{Name: "sdm_ant_id", Num: 1, BaseType: 11,
+            Raw: msg.SDMANTID, Scaled: msg.Scaled("sdm_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.SDMANTID)}
+    case 2:
+        return Value{Name: "sdm_cal_factor", Num: 2, BaseType: 4,
+            Raw: msg.SDMCalFactor, Scaled: msg.Scaled("sdm_cal_factor"),
+            Units: "%", Valid: is_valid_uint16(msg.SDMCalFactor)}
+    case 3:
+        return Value{Name: "odometer", Num: 3, BaseType: 6,
+            Raw: msg.Odometer, Scaled: msg.Scaled("odometer"),
+            Units: "m", Valid: is_valid_uint32(msg.Odometer)}
+    case 4:
+        return Value{Name: "speed_source", Num: 4, BaseType: 0,
+            Raw: msg.SpeedSource, Scaled: msg.Scaled("speed_source"),
+            Units: "", Valid: is_valid_enum(byte(msg.SpeedSource))}
+    case 5:
+        return Value{Name: "sdm_ant_id_trans_type", Num: 5, BaseType: 10,
+            Raw: msg.SDMANTIDTransType, Scaled: msg.Scaled("sdm_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.SDMANTIDTransType)}
+    case 7:
+        return Value{Name: "odometer_rollover", Num: 7, BaseType: 2,
+            Raw: msg.OdometerRollover, Scaled: msg.Scaled("odometer_rollover"),
+            Units: "", Valid: is_valid_uint8(msg.OdometerRollover)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgZonesTarget) Name() string {
-    return "zones_target"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSdmProfile) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "enabled": return msg.FieldByNum(0)
+    case "sdm_ant_id": return msg.FieldByNum(1)
+    case "sdm_cal_factor": return msg.FieldByNum(2)
+    case "odometer": return msg.FieldByNum(3)
+    case "speed_source": return msg.FieldByNum(4)
+    case "sdm_ant_id_trans_type": return msg.FieldByNum(5)
+    case "odometer_rollover": return msg.FieldByNum(7)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgZonesTarget) Text() string {
-    return fmt.Sprintf("zones_target maxheartrate %d thresholdheartrate %d" +
-        " functionalthresholdpower %d hrcalctyp %d pwrcalctyp %d",
-        msg.max_heart_rate, msg.threshold_heart_rate,
-        msg.functional_threshold_power, msg.hr_calc_type, msg.pwr_calc_type)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSdmProfile) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4, 5, 7})
 }
 
-func NewMsgZonesTarget(def *FitDefinition, data []byte) (*MsgZonesTarget, error) {
-    msg := new(MsgZonesTarget)
+func NewMsgSdmProfile(def *FitDefinition, data []byte) (*MsgSdmProfile, error) {
+    msg := new(MsgSdmProfile)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Enabled = Bool(invalid_enum)
+    msg.SDMCalFactor = invalid_uint16
+    msg.Odometer = invalid_uint32
+    msg.SpeedSource = Bool(invalid_enum)
+    msg.OdometerRollover = invalid_uint8
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 1: msg.max_heart_rate, pos = get_uint8_pos(data, pos)
-        case 2: msg.threshold_heart_rate, pos = get_uint8_pos(data, pos)
-        case 3: msg.functional_threshold_power, pos = get_uint16_pos(data, pos)
-        case 5: msg.hr_calc_type, pos = get_byte_pos(data, pos)
-        case 7: msg.pwr_calc_type, pos = get_byte_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.Enabled = Bool(get_enum(buf, fld, order))
+        case 1: msg.SDMANTID = get_uint16z(buf, fld, order)
+        case 2: msg.SDMCalFactor = get_uint16(buf, fld, order)
+        case 3: msg.Odometer = get_uint32(buf, fld, order)
+        case 4: msg.SpeedSource = Bool(get_enum(buf, fld, order))
+        case 5: msg.SDMANTIDTransType = get_uint8z(buf, fld, order)
+        case 7: msg.OdometerRollover = get_uint8(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad zones_target field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// hr_zone message
+// bike_profile message
 
-type MsgHrZone struct {
-    message_index uint16
-    high_bpm uint8
-    name string
+// MsgBikeProfile holds the fields of a bike_profile message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgBikeProfile struct {
+    msgBase
+
+    MessageIndex uint16
+    BikeProfileName string
+    Sport Sport
+    SubSport SubSport
+    Odometer uint32 // m, scale 100
+    BikeSpdANTID uint16
+    BikeCadANTID uint16
+    BikeSpdcadANTID uint16
+    BikePowerANTID uint16
+    CustomWheelsize uint16 // m, scale 1000
+    AutoWheelsize uint16 // m, scale 1000
+    BikeWeight uint16 // kg, scale 10
+    PowerCalFactor uint16 // %, scale 10
+    AutoWheelCal Bool
+    AutoPowerZero Bool
+    ID uint8
+    SpdEnabled Bool
+    CadEnabled Bool
+    SpdcadEnabled Bool
+    PowerEnabled Bool
+    CrankLength uint8 // mm, scale 2, offset -110
+    Enabled Bool
+    BikeSpdANTIDTransType uint8
+    BikeCadANTIDTransType uint8
+    BikeSpdcadANTIDTransType uint8
+    BikePowerANTIDTransType uint8
+    OdometerRollover uint8
 }
 
-func (msg *MsgHrZone) Name() string {
-    return "hr_zone"
+func (msg *MsgBikeProfile) Name() string {
+    return "bike_profile"
 }
 
-func (msg *MsgHrZone) Text() string {
-    return fmt.Sprintf("hr_zone msgidx %d highbpm %d name %s",
-        msg.message_index, msg.high_bpm, msg.name)
+// odometer in m
+func (msg *MsgBikeProfile) OdometerScaled() float64 {
+    if !is_valid_uint32(msg.Odometer) {
+        return math.NaN()
+    }
+    return float64(msg.Odometer) / 100
 }
 
-func NewMsgHrZone(def *FitDefinition, data []byte) (*MsgHrZone, error) {
-    msg := new(MsgHrZone)
+// custom_wheelsize in m
+func (msg *MsgBikeProfile) CustomWheelsizeScaled() float64 {
+    if !is_valid_uint16(msg.CustomWheelsize) {
+        return math.NaN()
+    }
+    return float64(msg.CustomWheelsize) / 1000
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 1: msg.high_bpm, pos = get_uint8_pos(data, pos)
-        case 2: msg.name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad hr_zone field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+// auto_wheelsize in m
+func (msg *MsgBikeProfile) AutoWheelsizeScaled() float64 {
+    if !is_valid_uint16(msg.AutoWheelsize) {
+        return math.NaN()
     }
+    return float64(msg.AutoWheelsize) / 1000
+}
 
-    return msg, nil
+// bike_weight in kg
+func (msg *MsgBikeProfile) BikeWeightScaled() float64 {
+    if !is_valid_uint16(msg.BikeWeight) {
+        return math.NaN()
+    }
+    return float64(msg.BikeWeight) / 10
 }
 
-// power_zone message
-
-type MsgPowerZone struct {
-    message_index uint16
-    high_value uint16
-    name string
+// power_cal_factor in %
+func (msg *MsgBikeProfile) PowerCalFactorScaled() float64 {
+    if !is_valid_uint16(msg.PowerCalFactor) {
+        return math.NaN()
+    }
+    return float64(msg.PowerCalFactor) / 10
 }
 
-func (msg *MsgPowerZone) Name() string {
-    return "power_zone"
+// crank_length in mm
+func (msg *MsgBikeProfile) CrankLengthScaled() float64 {
+    if !is_valid_uint8(msg.CrankLength) {
+        return math.NaN()
+    }
+    return (float64(msg.CrankLength) - -220) / 2
 }
 
-func (msg *MsgPowerZone) Text() string {
-    return fmt.Sprintf("power_zone msgidx %d highvalue %d name %s",
-        msg.message_index, msg.high_value, msg.name)
+func (msg *MsgBikeProfile) Text() string {
+    txt := "bike_profile"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_string(msg.BikeProfileName) {
+        txt += fmt.Sprintf(" name %s", msg.BikeProfileName)
+    }
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_enum(byte(msg.SubSport)) {
+        txt += fmt.Sprintf(" subsport %s", msg.SubSport)
+    }
+    if is_valid_uint32(msg.Odometer) {
+        txt += fmt.Sprintf(" odometer %g", msg.OdometerScaled())
+    }
+    if is_valid_uint16z(msg.BikeSpdANTID) {
+        txt += fmt.Sprintf(" bikespdantid %d", msg.BikeSpdANTID)
+    }
+    if is_valid_uint16z(msg.BikeCadANTID) {
+        txt += fmt.Sprintf(" bikecadantid %d", msg.BikeCadANTID)
+    }
+    if is_valid_uint16z(msg.BikeSpdcadANTID) {
+        txt += fmt.Sprintf(" bikespdcadantid %d", msg.BikeSpdcadANTID)
+    }
+    if is_valid_uint16z(msg.BikePowerANTID) {
+        txt += fmt.Sprintf(" bikepowerantid %d", msg.BikePowerANTID)
+    }
+    if is_valid_uint16(msg.CustomWheelsize) {
+        txt += fmt.Sprintf(" customwheelsize %g", msg.CustomWheelsizeScaled())
+    }
+    if is_valid_uint16(msg.AutoWheelsize) {
+        txt += fmt.Sprintf(" autowheelsize %g", msg.AutoWheelsizeScaled())
+    }
+    if is_valid_uint16(msg.BikeWeight) {
+        txt += fmt.Sprintf(" bikeweight %g", msg.BikeWeightScaled())
+    }
+    if is_valid_uint16(msg.PowerCalFactor) {
+        txt += fmt.Sprintf(" powercalfactor %g", msg.PowerCalFactorScaled())
+    }
+    if is_valid_enum(byte(msg.AutoWheelCal)) {
+        txt += fmt.Sprintf(" autowheelcal %s", msg.AutoWheelCal)
+    }
+    if is_valid_enum(byte(msg.AutoPowerZero)) {
+        txt += fmt.Sprintf(" autopowerzero %s", msg.AutoPowerZero)
+    }
+    if is_valid_uint8(msg.ID) {
+        txt += fmt.Sprintf(" id %d", msg.ID)
+    }
+    if is_valid_enum(byte(msg.SpdEnabled)) {
+        txt += fmt.Sprintf(" spdenabled %s", msg.SpdEnabled)
+    }
+    if is_valid_enum(byte(msg.CadEnabled)) {
+        txt += fmt.Sprintf(" cadenabled %s", msg.CadEnabled)
+    }
+    if is_valid_enum(byte(msg.SpdcadEnabled)) {
+        txt += fmt.Sprintf(" spdcadenabled %s", msg.SpdcadEnabled)
+    }
+    if is_valid_enum(byte(msg.PowerEnabled)) {
+        txt += fmt.Sprintf(" powerenabled %s", msg.PowerEnabled)
+    }
+    if is_valid_uint8(msg.CrankLength) {
+        txt += fmt.Sprintf(" cranklen %g", msg.CrankLengthScaled())
+    }
+    if is_valid_enum(byte(msg.Enabled)) {
+        txt += fmt.Sprintf(" enabled %s", msg.Enabled)
+    }
+    if is_valid_uint8z(msg.BikeSpdANTIDTransType) {
+        txt += fmt.Sprintf(" bikespdantidtranstyp %d", msg.BikeSpdANTIDTransType)
+    }
+    if is_valid_uint8z(msg.BikeCadANTIDTransType) {
+        txt += fmt.Sprintf(" bikecadantidtranstyp %d", msg.BikeCadANTIDTransType)
+    }
+    if is_valid_uint8z(msg.BikeSpdcadANTIDTransType) {
+        txt += fmt.Sprintf(" bikespdcadantidtranstyp %d", msg.BikeSpdcadANTIDTransType)
+    }
+    if is_valid_uint8z(msg.BikePowerANTIDTransType) {
+        txt += fmt.Sprintf(" bikepowerantidtranstyp %d", msg.BikePowerANTIDTransType)
+    }
+    if is_valid_uint8(msg.OdometerRollover) {
+        txt += fmt.Sprintf(" odometerrollover %d", msg.OdometerRollover)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgBikeProfile) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "name": return is_valid_string(msg.BikeProfileName)
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "sub_sport": return is_valid_enum(byte(msg.SubSport))
+    case "odometer": return is_valid_uint32(msg.Odometer)
+    case "bike_spd_ant_id": return is_valid_uint16z(msg.BikeSpdANTID)
+    case "bike_cad_ant_id": return is_valid_uint16z(msg.BikeCadANTID)
+    case "bike_spdcad_ant_id": return is_valid_uint16z(msg.BikeSpdcadANTID)
+    case "bike_power_ant_id": return is_valid_uint16z(msg.BikePowerANTID)
+    case "custom_wheelsize": return is_valid_uint16(msg.CustomWheelsize)
+    case "auto_wheelsize": return is_valid_uint16(msg.AutoWheelsize)
+    case "bike_weight": return is_valid_uint16(msg.BikeWeight)
+    case "power_cal_factor": return is_valid_uint16(msg.PowerCalFactor)
+    case "auto_wheel_cal": return is_valid_enum(byte(msg.AutoWheelCal))
+    case "auto_power_zero": return is_valid_enum(byte(msg.AutoPowerZero))
+    case "id": return is_valid_uint8(msg.ID)
+    case "spd_enabled": return is_valid_enum(byte(msg.SpdEnabled))
+    case "cad_enabled": return is_valid_enum(byte(msg.CadEnabled))
+    case "spdcad_enabled": return is_valid_enum(byte(msg.SpdcadEnabled))
+    case "power_enabled": return is_valid_enum(byte(msg.PowerEnabled))
+    case "crank_length": return is_valid_uint8(msg.CrankLength)
+    case "enabled": return is_valid_enum(byte(msg.Enabled))
+    case "bike_spd_ant_id_trans_type": return is_valid_uint8z(msg.BikeSpdANTIDTransType)
+    case "bike_cad_ant_id_trans_type": return is_valid_uint8z(msg.BikeCadANTIDTransType)
+    case "bike_spdcad_ant_id_trans_type": return is_valid_uint8z(msg.BikeSpdcadANTIDTransType)
+    case "bike_power_ant_id_trans_type": return is_valid_uint8z(msg.BikePowerANTIDTransType)
+    case "odometer_rollover": return is_valid_uint8(msg.OdometerRollover)
+    default: return false
+    }
 }
 
-func NewMsgPowerZone(def *FitDefinition, data []byte) (*MsgPowerZone, error) {
-    msg := new(MsgPowerZone)
-
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 1: msg.high_value, pos = get_uint16_pos(data, pos)
-        case 2: msg.name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad power_zone field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgBikeProfile) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "sub_sport":
+        if is_valid_enum(byte(msg.SubSport)) {
+            return float64(msg.SubSport)
+        }
+    case "odometer":
+        return msg.OdometerScaled()
+    case "bike_spd_ant_id":
+        if is_valid_uint16z(msg.BikeSpdANTID) {
+            return float64(msg.BikeSpdANTID)
+        }
+    case "bike_cad_ant_id":
+        if is_valid_uint16z(msg.BikeCadANTID) {
+            return float64(msg.BikeCadANTID)
+        }
+    case "bike_spdcad_ant_id":
+        if is_valid_uint16z(msg.BikeSpdcadANTID) {
+            return float64(msg.BikeSpdcadANTID)
+        }
+    case "bike_power_ant_id":
+        if is_valid_uint16z(msg.BikePowerANTID) {
+            return float64(msg.BikePowerANTID)
+        }
+    case "custom_wheelsize":
+        return msg.CustomWheelsizeScaled()
+    case "auto_wheelsize":
+        return msg.AutoWheelsizeScaled()
+    case "bike_weight":
+        return msg.BikeWeightScaled()
+    case "power_cal_factor":
+        return msg.PowerCalFactorScaled()
+    case "auto_wheel_cal":
+        if is_valid_enum(byte(msg.AutoWheelCal)) {
+            return float64(msg.AutoWheelCal)
+        }
+    case "auto_power_zero":
+        if is_valid_enum(byte(msg.AutoPowerZero)) {
+            return float64(msg.AutoPowerZero)
+        }
+    case "id":
+        if is_valid_uint8(msg.ID) {
+            return float64(msg.ID)
+        }
+    case "spd_enabled":
+        if is_valid_enum(byte(msg.SpdEnabled)) {
+            return float64(msg.SpdEnabled)
+        }
+    case "cad_enabled":
+        if is_valid_enum(byte(msg.CadEnabled)) {
+            return float64(msg.CadEnabled)
+        }
+    case "spdcad_enabled":
+        if is_valid_enum(byte(msg.SpdcadEnabled)) {
+            return float64(msg.SpdcadEnabled)
+        }
+    case "power_enabled":
+        if is_valid_enum(byte(msg.PowerEnabled)) {
+            return float64(msg.PowerEnabled)
+        }
+    case "crank_length":
+        return msg.CrankLengthScaled()
+    case "enabled":
+        if is_valid_enum(byte(msg.Enabled)) {
+            return float64(msg.Enabled)
+        }
+    case "bike_spd_ant_id_trans_type":
+        if is_valid_uint8z(msg.BikeSpdANTIDTransType) {
+            return float64(msg.BikeSpdANTIDTransType)
+        }
+    case "bike_cad_ant_id_trans_type":
+        if is_valid_uint8z(msg.BikeCadANTIDTransType) {
+            return float64(msg.BikeCadANTIDTransType)
+        }
+    case "bike_spdcad_ant_id_trans_type":
+        if is_valid_uint8z(msg.BikeSpdcadANTIDTransType) {
+            return float64(msg.BikeSpdcadANTIDTransType)
+        }
+    case "bike_power_ant_id_trans_type":
+        if is_valid_uint8z(msg.BikePowerANTIDTransType) {
+            return float64(msg.BikePowerANTIDTransType)
+        }
+    case "odometer_rollover":
+        if is_valid_uint8(msg.OdometerRollover) {
+            return float64(msg.OdometerRollover)
         }
     }
-
-    return msg, nil
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgBikeProfile) Units(name string) string {
+    switch name {
+    case "odometer": return "m"
+    case "custom_wheelsize": return "m"
+    case "auto_wheelsize": return "m"
+    case "bike_weight": return "kg"
+    case "power_cal_factor": return "%"
+    case "crank_length": return "mm"
+    default: return ""
+    }
 }
 
-// met_zone message
-
-type MsgMetZone struct {
-    message_index uint16
-    high_bpm uint8
-    calories uint16
-    fat_calories uint8
+// GlobalNum returns the message's global message number
+func (msg *MsgBikeProfile) GlobalNum() uint16 {
+    return 6
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgBikeProfile) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "name", Num: 0, BaseType: 7,
+            Raw: msg.BikeProfileName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.BikeProfileName)}
+    case 1:
+        return Value{Name: "sport", Num: 1, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 2:
+        return Value{Name: "sub_sport", Num: 2, BaseType: 0,
+            Raw: msg.SubSport, Scaled: msg.Scaled("sub_sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.SubSport))}
+    case 3:
+        return Value{Name: "odometer", Num: 3, BaseType: 6,
+            Raw: msg.Odometer, Scaled: msg.Scaled("odometer"),
+            Units: "m", Valid: is_valid_uint32(msg.Odometer)}
+    case 4:
+        return Value{Name: "bike_spd_ant_id", Num: 4, BaseType: 11,
+            Raw: msg.BikeSpdANTID, Scaled: msg.Scaled("bike_spd_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.BikeSpdANTID)}
+    case 5:
+        return Value{Name: "bike_cad_ant_id", Num: 5, BaseType: 11,
+            Raw: msg.BikeCadANTID, Scaled: msg.Scaled("bike_cad_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.BikeCadANTID)}
+    case 6:
+        return Value{Name: "bike_spdcad_ant_id", Num: 6, BaseType: 11,
+            Raw: msg.BikeSpdcadANTID, Scaled: msg.Scaled("bike_spdcad_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.BikeSpdcadANTID)}
+    case 7:
+        return Value{Name: "bike_power_ant_id", Num: 7, BaseType: 11,
+            Raw: msg.BikePowerANTID, Scaled: msg.Scaled("bike_power_ant_id"),
+            Units: "", Valid: is_valid_uint16z(msg.BikePowerANTID)}
+    case 8:
+        return Value{Name: "custom_wheelsize", Num: 8, BaseType: 4,
+            Raw: msg.CustomWheelsize, Scaled: msg.Scaled("custom_wheelsize"),
+            Units: "m", Valid: is_valid_uint16(msg.CustomWheelsize)}
+    case 9:
+        return Value{Name: "auto_wheelsize", Num: 9, BaseType: 4,
+            Raw: msg.AutoWheelsize, Scaled: msg.Scaled("auto_wheelsize"),
+            Units: "m", Valid: is_valid_uint16(msg.AutoWheelsize)}
+    case 10:
+        return Value{Name: "bike_weight", Num: 10, BaseType: 4,
+            Raw: msg.BikeWeight, Scaled: msg.Scaled("bike_weight"),
+            Units: "kg", Valid: is_valid_uint16(msg.BikeWeight)}
+    case 11:
+        return Value{Name: "power_cal_factor", Num: 11, BaseType: 4,
+            Raw: msg.PowerCalFactor, Scaled: msg.Scaled("power_cal_factor"),
+            Units: "%", Valid: is_valid_uint16(msg.PowerCalFactor)}
+    case 12:
+        return Value{Name: "auto_wheel_cal", Num: 12, BaseType: 0,
+            Raw: msg.AutoWheelCal, Scaled: msg.Scaled("auto_wheel_cal"),
+            Units: "", Valid: is_valid_enum(byte(msg.AutoWheelCal))}
+    case 13:
+        return Value{Name: "auto_power_zero", Num: 13, BaseType: 0,
+            Raw: msg.AutoPowerZero, Scaled: msg.Scaled("auto_power_zero"),
+            Units: "", Valid: is_valid_enum(byte(msg.AutoPowerZero))}
+    case 14:
+        return Value{Name: "id", Num: 14, BaseType: 2,
+            Raw: msg.ID, Scaled: msg.Scaled("id"),
+            Units: "", Valid: is_valid_uint8(msg.ID)}
+    case 15:
+        return Value{Name: "spd_enabled", Num: 15, BaseType: 0,
+            Raw: msg.SpdEnabled, Scaled: msg.Scaled("spd_enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.SpdEnabled))}
+    case 16:
+        return Value{Name: "cad_enabled", Num: 16, BaseType: 0,
+            Raw: msg.CadEnabled, Scaled: msg.Scaled("cad_enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.CadEnabled))}
+    case 17:
+        return Value{Name: "spdcad_enabled", Num: 17, BaseType: 0,
+            Raw: msg.SpdcadEnabled, Scaled: msg.Scaled("spdcad_enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.SpdcadEnabled))}
+    case 18:
+        return Value{Name: "power_enabled", Num: 18, BaseType: 0,
+            Raw: msg.PowerEnabled, Scaled: msg.Scaled("power_enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.PowerEnabled))}
+    case 19:
+        return Value{Name: "crank_length", Num: 19, BaseType: 2,
+            Raw: msg.CrankLength, Scaled: msg.Scaled("crank_length"),
+            Units: "mm", Valid: is_valid_uint8(msg.CrankLength)}
+    case 20:
+        return Value{Name: "enabled", Num: 20, BaseType: 0,
+            Raw: msg.Enabled, Scaled: msg.Scaled("enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.Enabled))}
+    case 21:
+        return Value{Name: "bike_spd_ant_id_trans_type", Num: 21, BaseType: 10,
+            Raw: msg.BikeSpdANTIDTransType, Scaled: msg.Scaled("bike_spd_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.BikeSpdANTIDTransType)}
+    case 22:
+        return Value{Name: "bike_cad_ant_id_trans_type", Num: 22, BaseType: 10,
+            Raw: msg.BikeCadANTIDTransType, Scaled: msg.Scaled("bike_cad_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.BikeCadANTIDTransType)}
+    case 23:
+        return Value{Name: "bike_spdcad_ant_id_trans_type", Num: 23, BaseType: 10,
+            Raw: msg.BikeSpdcadANTIDTransType, Scaled: msg.Scaled("bike_spdcad_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.BikeSpdcadANTIDTransType)}
+    case 24:
+        return Value{Name: "bike_power_ant_id_trans_type", Num: 24, BaseType: 10,
+            Raw: msg.BikePowerANTIDTransType, Scaled: msg.Scaled("bike_power_ant_id_trans_type"),
+            Units: "", Valid: is_valid_uint8z(msg.BikePowerANTIDTransType)}
+    case 37:
+        return Value{Name: "odometer_rollover", Num: 37, BaseType: 2,
+            Raw: msg.OdometerRollover, Scaled: msg.Scaled("odometer_rollover"),
+            Units: "", Valid: is_valid_uint8(msg.OdometerRollover)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgMetZone) Name() string {
-    return "met_zone"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgBikeProfile) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "name": return msg.FieldByNum(0)
+    case "sport": return msg.FieldByNum(1)
+    case "sub_sport": return msg.FieldByNum(2)
+    case "odometer": return msg.FieldByNum(3)
+    case "bike_spd_ant_id": return msg.FieldByNum(4)
+    case "bike_cad_ant_id": return msg.FieldByNum(5)
+    case "bike_spdcad_ant_id": return msg.FieldByNum(6)
+    case "bike_power_ant_id": return msg.FieldByNum(7)
+    case "custom_wheelsize": return msg.FieldByNum(8)
+    case "auto_wheelsize": return msg.FieldByNum(9)
+    case "bike_weight": return msg.FieldByNum(10)
+    case "power_cal_factor": return msg.FieldByNum(11)
+    case "auto_wheel_cal": return msg.FieldByNum(12)
+    case "auto_power_zero": return msg.FieldByNum(13)
+    case "id": return msg.FieldByNum(14)
+    case "spd_enabled": return msg.FieldByNum(15)
+    case "cad_enabled": return msg.FieldByNum(16)
+    case "spdcad_enabled": return msg.FieldByNum(17)
+    case "power_enabled": return msg.FieldByNum(18)
+    case "crank_length": return msg.FieldByNum(19)
+    case "enabled": return msg.FieldByNum(20)
+    case "bike_spd_ant_id_trans_type": return msg.FieldByNum(21)
+    case "bike_cad_ant_id_trans_type": return msg.FieldByNum(22)
+    case "bike_spdcad_ant_id_trans_type": return msg.FieldByNum(23)
+    case "bike_power_ant_id_trans_type": return msg.FieldByNum(24)
+    case "odometer_rollover": return msg.FieldByNum(37)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgMetZone) Text() string {
-    return fmt.Sprintf("met_zone msgidx %d highbpm %d cals %d fatcals %d",
-        msg.message_index, msg.high_bpm, msg.calories, msg.fat_calories)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgBikeProfile) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 37})
 }
 
-func NewMsgMetZone(def *FitDefinition, data []byte) (*MsgMetZone, error) {
-    msg := new(MsgMetZone)
+func NewMsgBikeProfile(def *FitDefinition, data []byte) (*MsgBikeProfile, error) {
+    msg := new(MsgBikeProfile)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Sport = Sport(invalid_enum)
+    msg.SubSport = SubSport(invalid_enum)
+    msg.Odometer = invalid_uint32
+    msg.CustomWheelsize = invalid_uint16
+    msg.AutoWheelsize = invalid_uint16
+    msg.BikeWeight = invalid_uint16
+    msg.PowerCalFactor = invalid_uint16
+    msg.AutoWheelCal = Bool(invalid_enum)
+    msg.AutoPowerZero = Bool(invalid_enum)
+    msg.ID = invalid_uint8
+    msg.SpdEnabled = Bool(invalid_enum)
+    msg.CadEnabled = Bool(invalid_enum)
+    msg.SpdcadEnabled = Bool(invalid_enum)
+    msg.PowerEnabled = Bool(invalid_enum)
+    msg.CrankLength = invalid_uint8
+    msg.Enabled = Bool(invalid_enum)
+    msg.OdometerRollover = invalid_uint8
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 1: msg.high_bpm, pos = get_uint8_pos(data, pos)
-        case 2: msg.calories, pos = get_uint16_pos(data, pos)
-        case 3: msg.fat_calories, pos = get_uint8_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.BikeProfileName = get_string(buf, fld, order)
+        case 1: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 2: msg.SubSport = SubSport(get_enum(buf, fld, order))
+        case 3: msg.Odometer = get_uint32(buf, fld, order)
+        case 4: msg.BikeSpdANTID = get_uint16z(buf, fld, order)
+        case 5: msg.BikeCadANTID = get_uint16z(buf, fld, order)
+        case 6: msg.BikeSpdcadANTID = get_uint16z(buf, fld, order)
+        case 7: msg.BikePowerANTID = get_uint16z(buf, fld, order)
+        case 8: msg.CustomWheelsize = get_uint16(buf, fld, order)
+        case 9: msg.AutoWheelsize = get_uint16(buf, fld, order)
+        case 10: msg.BikeWeight = get_uint16(buf, fld, order)
+        case 11: msg.PowerCalFactor = get_uint16(buf, fld, order)
+        case 12: msg.AutoWheelCal = Bool(get_enum(buf, fld, order))
+        case 13: msg.AutoPowerZero = Bool(get_enum(buf, fld, order))
+        case 14: msg.ID = get_uint8(buf, fld, order)
+        case 15: msg.SpdEnabled = Bool(get_enum(buf, fld, order))
+        case 16: msg.CadEnabled = Bool(get_enum(buf, fld, order))
+        case 17: msg.SpdcadEnabled = Bool(get_enum(buf, fld, order))
+        case 18: msg.PowerEnabled = Bool(get_enum(buf, fld, order))
+        case 19: msg.CrankLength = get_uint8(buf, fld, order)
+        case 20: msg.Enabled = Bool(get_enum(buf, fld, order))
+        case 21: msg.BikeSpdANTIDTransType = get_uint8z(buf, fld, order)
+        case 22: msg.BikeCadANTIDTransType = get_uint8z(buf, fld, order)
+        case 23: msg.BikeSpdcadANTIDTransType = get_uint8z(buf, fld, order)
+        case 24: msg.BikePowerANTIDTransType = get_uint8z(buf, fld, order)
+        case 37: msg.OdometerRollover = get_uint8(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad met_zone field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// sport message
+// zones_target message
 
-type MsgSport struct {
-    sport byte
-    sub_sport byte
-    name string
-}
+// MsgZonesTarget holds the fields of a zones_target message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgZonesTarget struct {
+    msgBase
 
-func (msg *MsgSport) Name() string {
-    return "sport"
+    MaxHeartRate uint8 // bpm
+    ThresholdHeartRate uint8 // bpm
+    FunctionalThresholdPower uint16 // watts
+    HRCalcType HrZoneCalc
+    PwrCalcType PwrZoneCalc
 }
 
-func (msg *MsgSport) Text() string {
-    return fmt.Sprintf("sport sport %d subsport %d name %s", msg.sport,
-        msg.sub_sport, msg.name)
+func (msg *MsgZonesTarget) Name() string {
+    return "zones_target"
 }
 
-func NewMsgSport(def *FitDefinition, data []byte) (*MsgSport, error) {
-    msg := new(MsgSport)
+func (msg *MsgZonesTarget) Text() string {
+    txt := "zones_target"
+    if is_valid_uint8(msg.MaxHeartRate) {
+        txt += fmt.Sprintf(" maxheartrate %d", msg.MaxHeartRate)
+    }
+    if is_valid_uint8(msg.ThresholdHeartRate) {
+        txt += fmt.Sprintf(" thresholdheartrate %d", msg.ThresholdHeartRate)
+    }
+    if is_valid_uint16(msg.FunctionalThresholdPower) {
+        txt += fmt.Sprintf(" functionalthresholdpower %d", msg.FunctionalThresholdPower)
+    }
+    if is_valid_enum(byte(msg.HRCalcType)) {
+        txt += fmt.Sprintf(" hrcalctyp %s", msg.HRCalcType)
+    }
+    if is_valid_enum(byte(msg.PwrCalcType)) {
+        txt += fmt.Sprintf(" pwrcalctyp %s", msg.PwrCalcType)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgZonesTarget) IsSet(name string) bool {
+    switch name {
+    case "max_heart_rate": return is_valid_uint8(msg.MaxHeartRate)
+    case "threshold_heart_rate": return is_valid_uint8(msg.ThresholdHeartRate)
+    case "functional_threshold_power": return is_valid_uint16(msg.FunctionalThresholdPower)
+    case "hr_calc_type": return is_valid_enum(byte(msg.HRCalcType))
+    case "pwr_calc_type": return is_valid_enum(byte(msg.PwrCalcType))
+    default: return false
+    }
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.sport, pos = get_byte_pos(data, pos)
-        case 1: msg.sub_sport, pos = get_byte_pos(data, pos)
-        case 3: msg.name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad sport field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgZonesTarget) Scaled(name string) float64 {
+    switch name {
+    case "max_heart_rate":
+        if is_valid_uint8(msg.MaxHeartRate) {
+            return float64(msg.MaxHeartRate)
+        }
+    case "threshold_heart_rate":
+        if is_valid_uint8(msg.ThresholdHeartRate) {
+            return float64(msg.ThresholdHeartRate)
+        }
+    case "functional_threshold_power":
+        if is_valid_uint16(msg.FunctionalThresholdPower) {
+            return float64(msg.FunctionalThresholdPower)
+        }
+    case "hr_calc_type":
+        if is_valid_enum(byte(msg.HRCalcType)) {
+            return float64(msg.HRCalcType)
+        }
+    case "pwr_calc_type":
+        if is_valid_enum(byte(msg.PwrCalcType)) {
+            return float64(msg.PwrCalcType)
         }
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// goal message
+// Units returns the units of the named field's scaled value
+func (msg *MsgZonesTarget) Units(name string) string {
+    switch name {
+    case "max_heart_rate": return "bpm"
+    case "threshold_heart_rate": return "bpm"
+    case "functional_threshold_power": return "watts"
+    default: return ""
+    }
+}
 
-type MsgGoal struct {
-    message_index uint16
-    sport byte
-    sub_sport byte
-    start_date uint32
-    end_date uint32
-    msgtype byte
-    value uint32
-    repeat byte
-    target_value uint32
-    recurrence byte
-    recurrence_value uint16
-    enabled byte
+// GlobalNum returns the message's global message number
+func (msg *MsgZonesTarget) GlobalNum() uint16 {
+    return 7
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgZonesTarget) FieldByNum(num byte) Value {
+    switch num {
+    case 1:
+        return Value{Name: "max_heart_rate", Num: 1, BaseType: 2,
+            Raw: msg.MaxHeartRate, Scaled: msg.Scaled("max_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.MaxHeartRate)}
+    case 2:
+        return Value{Name: "threshold_heart_rate", Num: 2, BaseType: 2,
+            Raw: msg.ThresholdHeartRate, Scaled: msg.Scaled("threshold_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.ThresholdHeartRate)}
+    case 3:
+        return Value{Name: "functional_threshold_power", Num: 3, BaseType: 4,
+            Raw: msg.FunctionalThresholdPower, Scaled: msg.Scaled("functional_threshold_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.FunctionalThresholdPower)}
+    case 5:
+        return Value{Name: "hr_calc_type", Num: 5, BaseType: 0,
+            Raw: msg.HRCalcType, Scaled: msg.Scaled("hr_calc_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.HRCalcType))}
+    case 7:
+        return Value{Name: "pwr_calc_type", Num: 7, BaseType: 0,
+            Raw: msg.PwrCalcType, Scaled: msg.Scaled("pwr_calc_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.PwrCalcType))}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgGoal) Name() string {
-    return "goal"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgZonesTarget) Field(name string) Value {
+    switch name {
+    case "max_heart_rate": return msg.FieldByNum(1)
+    case "threshold_heart_rate": return msg.FieldByNum(2)
+    case "functional_threshold_power": return msg.FieldByNum(3)
+    case "hr_calc_type": return msg.FieldByNum(5)
+    case "pwr_calc_type": return msg.FieldByNum(7)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgGoal) Text() string {
-    return fmt.Sprintf("goal msgidx %d sport %d subsport %d startdate %d" +
-        " enddate %d msgtyp %d value %d repeat %d targetvalue %d" +
-        " recurrence %d recurrencevalue %d enabled %d", msg.message_index,
-        msg.sport, msg.sub_sport, msg.start_date, msg.end_date, msg.msgtype,
-        msg.value, msg.repeat, msg.target_value, msg.recurrence,
-        msg.recurrence_value, msg.enabled)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgZonesTarget) Fields() []Value {
+    return fieldValues(msg, []byte{1, 2, 3, 5, 7})
 }
 
-func NewMsgGoal(def *FitDefinition, data []byte) (*MsgGoal, error) {
-    msg := new(MsgGoal)
+func NewMsgZonesTarget(def *FitDefinition, data []byte) (*MsgZonesTarget, error) {
+    msg := new(MsgZonesTarget)
+
+    msg.MaxHeartRate = invalid_uint8
+    msg.ThresholdHeartRate = invalid_uint8
+    msg.FunctionalThresholdPower = invalid_uint16
+    msg.HRCalcType = HrZoneCalc(invalid_enum)
+    msg.PwrCalcType = PwrZoneCalc(invalid_enum)
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.sport, pos = get_byte_pos(data, pos)
-        case 1: msg.sub_sport, pos = get_byte_pos(data, pos)
-        case 2: msg.start_date, pos = get_uint32_pos(data, pos)
-        case 3: msg.end_date, pos = get_uint32_pos(data, pos)
-        case 4: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 5: msg.value, pos = get_uint32_pos(data, pos)
-        case 6: msg.repeat, pos = get_byte_pos(data, pos)
-        case 7: msg.target_value, pos = get_uint32_pos(data, pos)
-        case 8: msg.recurrence, pos = get_byte_pos(data, pos)
-        case 9: msg.recurrence_value, pos = get_uint16_pos(data, pos)
-        case 10: msg.enabled, pos = get_byte_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 1: msg.MaxHeartRate = get_uint8(buf, fld, order)
+        case 2: msg.ThresholdHeartRate = get_uint8(buf, fld, order)
+        case 3: msg.FunctionalThresholdPower = get_uint16(buf, fld, order)
+        case 5: msg.HRCalcType = HrZoneCalc(get_enum(buf, fld, order))
+        case 7: msg.PwrCalcType = PwrZoneCalc(get_enum(buf, fld, order))
         default:
-            errmsg := fmt.Sprintf("Bad goal field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// session message
+// hr_zone message
 
-type MsgSession struct {
-    message_index uint16
-    timestamp uint32
-    event byte
-    event_type byte
-    start_time uint32
-    start_position_lat int32
-    start_position_long int32
-    sport byte
-    sub_sport byte
-    total_elapsed_time uint32
-    total_timer_time uint32
-    total_distance uint32
-    total_cycles uint32
-    total_calories uint16
-    total_fat_calories uint16
-    avg_speed uint16
-    max_speed uint16
-    avg_heart_rate uint8
-    max_heart_rate uint8
-    avg_cadence uint8
-    max_cadence uint8
-    avg_power uint16
-    max_power uint16
-    total_ascent uint16
-    total_descent uint16
-    total_training_effect uint8
-    first_lap_index uint16
-    num_laps uint16
-    event_group uint8
-    trigger byte
-    nec_lat int32
-    nec_long int32
-    swc_lat int32
-    swc_long int32
-    normalized_power uint16
-    training_stress_score uint16
-    intensity_factor uint16
-    left_right_balance uint16
-    avg_stroke_count uint32
-    avg_stroke_distance uint16
-    swim_stroke byte
-    pool_length uint16
-    pool_length_unit byte
-    num_active_lengths uint16
-    total_work uint32
-    avg_altitude uint16
-    max_altitude uint16
-    gps_accuracy uint8
-    avg_grade int16
-    avg_pos_grade int16
-    avg_neg_grade int16
-    max_pos_grade int16
-    max_neg_grade int16
-    avg_temperature int8
-    max_temperature int8
-    total_moving_time uint32
-    avg_pos_vertical_speed int16
-    avg_neg_vertical_speed int16
-    max_pos_vertical_speed int16
-    max_neg_vertical_speed int16
-    min_heart_rate uint8
-    time_in_hr_zone uint32
-    time_in_speed_zone uint32
-    time_in_cadence_zone uint32
-    time_in_power_zone uint32
-    avg_lap_time uint32
-    best_lap_index uint16
-    min_altitude uint16
+// MsgHrZone holds the fields of a hr_zone message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgHrZone struct {
+    msgBase
+
+    MessageIndex uint16
+    HighBpm uint8 // bpm
+    HrZoneName string
 }
 
-func (msg *MsgSession) Name() string {
-    return "session"
+func (msg *MsgHrZone) Name() string {
+    return "hr_zone"
 }
 
-func (msg *MsgSession) Text() string {
-    return fmt.Sprintf("session msgidx %d tstmp %d evt %d evttyp %d" +
-        " starttime %d startposlat %d startposlong %d sport %d subsport %d" +
-        " totalelapsedtime %d totaltimertime %d totaldist %d totalcycles %d" +
-        " totalcals %d totalfatcals %d avgspeed %d maxspeed %d" +
-        " avgheartrate %d maxheartrate %d avgcadence %d maxcadence %d" +
-        " avgpower %d maxpower %d totalascent %d totaldescent %d" +
-        " totaltrainingeffect %d firstlapidx %d numlaps %d evtgrp %d" +
-        " trigger %d neclat %d neclong %d swclat %d swclong %d" +
-        " normalizedpower %d trainingstressscore %d intensityfactor %d" +
-        " leftrightbalance %d avgstrokecount %d avgstrokedist %d" +
-        " swimstroke %d poollen %d poollenunit %d numactivelens %d" +
-        " totalwork %d avgalt %d maxalt %d gpsaccuracy %d avggrade %d" +
-        " avgposgrade %d avgneggrade %d maxposgrade %d maxneggrade %d" +
-        " avgtemp %d maxtemp %d totalmovingtime %d avgposvertspeed %d" +
-        " avgnegvertspeed %d maxposvertspeed %d maxnegvertspeed %d" +
-        " minheartrate %d timeinhrzone %d timeinspeedzone %d" +
-        " timeincadencezone %d timeinpowerzone %d avglaptime %d bestlapidx %d" +
-        " minalt %d", msg.message_index, msg.timestamp, msg.event,
-        msg.event_type, msg.start_time, msg.start_position_lat,
-        msg.start_position_long, msg.sport, msg.sub_sport,
-        msg.total_elapsed_time, msg.total_timer_time, msg.total_distance,
-        msg.total_cycles, msg.total_calories, msg.total_fat_calories,
-        msg.avg_speed, msg.max_speed, msg.avg_heart_rate, msg.max_heart_rate,
-        msg.avg_cadence, msg.max_cadence, msg.avg_power, msg.max_power,
-        msg.total_ascent, msg.total_descent, msg.total_training_effect,
-        msg.first_lap_index, msg.num_laps, msg.event_group, msg.trigger,
-        msg.nec_lat, msg.nec_long, msg.swc_lat, msg.swc_long,
-        msg.normalized_power, msg.training_stress_score, msg.intensity_factor,
-        msg.left_right_balance, msg.avg_stroke_count, msg.avg_stroke_distance,
-        msg.swim_stroke, msg.pool_length, msg.pool_length_unit,
-        msg.num_active_lengths, msg.total_work, msg.avg_altitude,
-        msg.max_altitude, msg.gps_accuracy, msg.avg_grade, msg.avg_pos_grade,
-        msg.avg_neg_grade, msg.max_pos_grade, msg.max_neg_grade,
-        msg.avg_temperature, msg.max_temperature, msg.total_moving_time,
-        msg.avg_pos_vertical_speed, msg.avg_neg_vertical_speed,
-        msg.max_pos_vertical_speed, msg.max_neg_vertical_speed,
-        msg.min_heart_rate, msg.time_in_hr_zone, msg.time_in_speed_zone,
-        msg.time_in_cadence_zone, msg.time_in_power_zone, msg.avg_lap_time,
-        msg.best_lap_index, msg.min_altitude)
+func (msg *MsgHrZone) Text() string {
+    txt := "hr_zone"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint8(msg.HighBpm) {
+        txt += fmt.Sprintf(" highbpm %d", msg.HighBpm)
+    }
+    if is_valid_string(msg.HrZoneName) {
+        txt += fmt.Sprintf(" name %s", msg.HrZoneName)
+    }
+    return txt
 }
 
-func NewMsgSession(def *FitDefinition, data []byte) (*MsgSession, error) {
-    msg := new(MsgSession)
+// IsSet reports whether the named field held a valid value
+func (msg *MsgHrZone) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "high_bpm": return is_valid_uint8(msg.HighBpm)
+    case "name": return is_valid_string(msg.HrZoneName)
+    default: return false
+    }
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.event, pos = get_byte_pos(data, pos)
-        case 1: msg.event_type, pos = get_byte_pos(data, pos)
-        case 2: msg.start_time, pos = get_uint32_pos(data, pos)
-        case 3: msg.start_position_lat, pos = get_int32_pos(data, pos)
-        case 4: msg.start_position_long, pos = get_int32_pos(data, pos)
-        case 5: msg.sport, pos = get_byte_pos(data, pos)
-        case 6: msg.sub_sport, pos = get_byte_pos(data, pos)
-        case 7: msg.total_elapsed_time, pos = get_uint32_pos(data, pos)
-        case 8: msg.total_timer_time, pos = get_uint32_pos(data, pos)
-        case 9: msg.total_distance, pos = get_uint32_pos(data, pos)
-        case 10: msg.total_cycles, pos = get_uint32_pos(data, pos)
-        case 11: msg.total_calories, pos = get_uint16_pos(data, pos)
-        case 13: msg.total_fat_calories, pos = get_uint16_pos(data, pos)
-        case 14: msg.avg_speed, pos = get_uint16_pos(data, pos)
-        case 15: msg.max_speed, pos = get_uint16_pos(data, pos)
-        case 16: msg.avg_heart_rate, pos = get_uint8_pos(data, pos)
-        case 17: msg.max_heart_rate, pos = get_uint8_pos(data, pos)
-        case 18: msg.avg_cadence, pos = get_uint8_pos(data, pos)
-        case 19: msg.max_cadence, pos = get_uint8_pos(data, pos)
-        case 20: msg.avg_power, pos = get_uint16_pos(data, pos)
-        case 21: msg.max_power, pos = get_uint16_pos(data, pos)
-        case 22: msg.total_ascent, pos = get_uint16_pos(data, pos)
-        case 23: msg.total_descent, pos = get_uint16_pos(data, pos)
-        case 24: msg.total_training_effect, pos = get_uint8_pos(data, pos)
-        case 25: msg.first_lap_index, pos = get_uint16_pos(data, pos)
-        case 26: msg.num_laps, pos = get_uint16_pos(data, pos)
-        case 27: msg.event_group, pos = get_uint8_pos(data, pos)
-        case 28: msg.trigger, pos = get_byte_pos(data, pos)
-        case 29: msg.nec_lat, pos = get_int32_pos(data, pos)
-        case 30: msg.nec_long, pos = get_int32_pos(data, pos)
-        case 31: msg.swc_lat, pos = get_int32_pos(data, pos)
-        case 32: msg.swc_long, pos = get_int32_pos(data, pos)
-        case 34: msg.normalized_power, pos = get_uint16_pos(data, pos)
-        case 35: msg.training_stress_score, pos = get_uint16_pos(data, pos)
-        case 36: msg.intensity_factor, pos = get_uint16_pos(data, pos)
-        case 37: msg.left_right_balance, pos = get_uint16_pos(data, pos)
-        case 41: msg.avg_stroke_count, pos = get_uint32_pos(data, pos)
-        case 42: msg.avg_stroke_distance, pos = get_uint16_pos(data, pos)
-        case 43: msg.swim_stroke, pos = get_byte_pos(data, pos)
-        case 44: msg.pool_length, pos = get_uint16_pos(data, pos)
-        case 46: msg.pool_length_unit, pos = get_byte_pos(data, pos)
-        case 47: msg.num_active_lengths, pos = get_uint16_pos(data, pos)
-        case 48: msg.total_work, pos = get_uint32_pos(data, pos)
-        case 49: msg.avg_altitude, pos = get_uint16_pos(data, pos)
-        case 50: msg.max_altitude, pos = get_uint16_pos(data, pos)
-        case 51: msg.gps_accuracy, pos = get_uint8_pos(data, pos)
-        case 52: msg.avg_grade, pos = get_int16_pos(data, pos)
-        case 53: msg.avg_pos_grade, pos = get_int16_pos(data, pos)
-        case 54: msg.avg_neg_grade, pos = get_int16_pos(data, pos)
-        case 55: msg.max_pos_grade, pos = get_int16_pos(data, pos)
-        case 56: msg.max_neg_grade, pos = get_int16_pos(data, pos)
-        case 57: msg.avg_temperature, pos = get_int8_pos(data, pos)
-        case 58: msg.max_temperature, pos = get_int8_pos(data, pos)
-        case 59: msg.total_moving_time, pos = get_uint32_pos(data, pos)
-        case 60: msg.avg_pos_vertical_speed, pos = get_int16_pos(data, pos)
-        case 61: msg.avg_neg_vertical_speed, pos = get_int16_pos(data, pos)
-        case 62: msg.max_pos_vertical_speed, pos = get_int16_pos(data, pos)
-        case 63: msg.max_neg_vertical_speed, pos = get_int16_pos(data, pos)
-        case 64: msg.min_heart_rate, pos = get_uint8_pos(data, pos)
-        case 65: msg.time_in_hr_zone, pos = get_uint32_pos(data, pos)
-        case 66: msg.time_in_speed_zone, pos = get_uint32_pos(data, pos)
-        case 67: msg.time_in_cadence_zone, pos = get_uint32_pos(data, pos)
-        case 68: msg.time_in_power_zone, pos = get_uint32_pos(data, pos)
-        case 69: msg.avg_lap_time, pos = get_uint32_pos(data, pos)
-        case 70: msg.best_lap_index, pos = get_uint16_pos(data, pos)
-        case 71: msg.min_altitude, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad session field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgHrZone) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "high_bpm":
+        if is_valid_uint8(msg.HighBpm) {
+            return float64(msg.HighBpm)
         }
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// lap message
+// Units returns the units of the named field's scaled value
+func (msg *MsgHrZone) Units(name string) string {
+    switch name {
+    case "high_bpm": return "bpm"
+    default: return ""
+    }
+}
 
-type MsgLap struct {
-    message_index uint16
-    timestamp uint32
-    event byte
-    event_type byte
-    start_time uint32
-    start_position_lat int32
-    start_position_long int32
-    end_position_lat int32
-    end_position_long int32
-    total_elapsed_time uint32
-    total_timer_time uint32
-    total_distance uint32
-    total_cycles uint32
-    total_calories uint16
-    total_fat_calories uint16
-    avg_speed uint16
-    max_speed uint16
-    avg_heart_rate uint8
-    max_heart_rate uint8
-    avg_cadence uint8
-    max_cadence uint8
-    avg_power uint16
-    max_power uint16
-    total_ascent uint16
-    total_descent uint16
-    intensity byte
-    lap_trigger byte
-    sport byte
-    event_group uint8
-    num_lengths uint16
-    normalized_power uint16
-    left_right_balance uint16
-    first_length_index uint16
-    avg_stroke_distance uint16
-    swim_stroke byte
-    sub_sport byte
-    num_active_lengths uint16
-    total_work uint32
-    avg_altitude uint16
-    max_altitude uint16
-    gps_accuracy uint8
-    avg_grade int16
-    avg_pos_grade int16
-    avg_neg_grade int16
-    max_pos_grade int16
-    max_neg_grade int16
-    avg_temperature int8
-    max_temperature int8
-    total_moving_time uint32
-    avg_pos_vertical_speed int16
-    avg_neg_vertical_speed int16
-    max_pos_vertical_speed int16
-    max_neg_vertical_speed int16
-    time_in_hr_zone uint32
-    time_in_speed_zone uint32
-    time_in_cadence_zone uint32
-    time_in_power_zone uint32
-    repetition_num uint16
-    min_altitude uint16
-    min_heart_rate uint8
-    wkt_step_index uint16
+// GlobalNum returns the message's global message number
+func (msg *MsgHrZone) GlobalNum() uint16 {
+    return 8
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgHrZone) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 1:
+        return Value{Name: "high_bpm", Num: 1, BaseType: 2,
+            Raw: msg.HighBpm, Scaled: msg.Scaled("high_bpm"),
+            Units: "bpm", Valid: is_valid_uint8(msg.HighBpm)}
+    case 2:
+        return Value{Name: "name", Num: 2, BaseType: 7,
+            Raw: msg.HrZoneName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.HrZoneName)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgLap) Name() string {
-    return "lap"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgHrZone) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "high_bpm": return msg.FieldByNum(1)
+    case "name": return msg.FieldByNum(2)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgLap) Text() string {
-    return fmt.Sprintf("lap msgidx %d tstmp %d evt %d evttyp %d starttime %d" +
-        " startposlat %d startposlong %d endposlat %d endposlong %d" +
-        " totalelapsedtime %d totaltimertime %d totaldist %d totalcycles %d" +
-        " totalcals %d totalfatcals %d avgspeed %d maxspeed %d" +
-        " avgheartrate %d maxheartrate %d avgcadence %d maxcadence %d" +
-        " avgpower %d maxpower %d totalascent %d totaldescent %d intensity %d" +
-        " laptrigger %d sport %d evtgrp %d numlens %d normalizedpower %d" +
-        " leftrightbalance %d firstlenidx %d avgstrokedist %d swimstroke %d" +
-        " subsport %d numactivelens %d totalwork %d avgalt %d maxalt %d" +
-        " gpsaccuracy %d avggrade %d avgposgrade %d avgneggrade %d" +
-        " maxposgrade %d maxneggrade %d avgtemp %d maxtemp %d" +
-        " totalmovingtime %d avgposvertspeed %d avgnegvertspeed %d" +
-        " maxposvertspeed %d maxnegvertspeed %d timeinhrzone %d" +
-        " timeinspeedzone %d timeincadencezone %d timeinpowerzone %d" +
-        " repetitionnum %d minalt %d minheartrate %d wktstepidx %d",
-        msg.message_index, msg.timestamp, msg.event, msg.event_type,
-        msg.start_time, msg.start_position_lat, msg.start_position_long,
-        msg.end_position_lat, msg.end_position_long, msg.total_elapsed_time,
-        msg.total_timer_time, msg.total_distance, msg.total_cycles,
-        msg.total_calories, msg.total_fat_calories, msg.avg_speed,
-        msg.max_speed, msg.avg_heart_rate, msg.max_heart_rate, msg.avg_cadence,
-        msg.max_cadence, msg.avg_power, msg.max_power, msg.total_ascent,
-        msg.total_descent, msg.intensity, msg.lap_trigger, msg.sport,
-        msg.event_group, msg.num_lengths, msg.normalized_power,
-        msg.left_right_balance, msg.first_length_index, msg.avg_stroke_distance,
-        msg.swim_stroke, msg.sub_sport, msg.num_active_lengths, msg.total_work,
-        msg.avg_altitude, msg.max_altitude, msg.gps_accuracy, msg.avg_grade,
-        msg.avg_pos_grade, msg.avg_neg_grade, msg.max_pos_grade,
-        msg.max_neg_grade, msg.avg_temperature, msg.max_temperature,
-        msg.total_moving_time, msg.avg_pos_vertical_speed,
-        msg.avg_neg_vertical_speed, msg.max_pos_vertical_speed,
-        msg.max_neg_vertical_speed, msg.time_in_hr_zone, msg.time_in_speed_zone,
-        msg.time_in_cadence_zone, msg.time_in_power_zone, msg.repetition_num,
-        msg.min_altitude, msg.min_heart_rate, msg.wkt_step_index)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgHrZone) Fields() []Value {
+    return fieldValues(msg, []byte{254, 1, 2})
 }
 
-func NewMsgLap(def *FitDefinition, data []byte) (*MsgLap, error) {
-    msg := new(MsgLap)
+func NewMsgHrZone(def *FitDefinition, data []byte) (*MsgHrZone, error) {
+    msg := new(MsgHrZone)
+
+    msg.MessageIndex = invalid_uint16
+    msg.HighBpm = invalid_uint8
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.event, pos = get_byte_pos(data, pos)
-        case 1: msg.event_type, pos = get_byte_pos(data, pos)
-        case 2: msg.start_time, pos = get_uint32_pos(data, pos)
-        case 3: msg.start_position_lat, pos = get_int32_pos(data, pos)
-        case 4: msg.start_position_long, pos = get_int32_pos(data, pos)
-        case 5: msg.end_position_lat, pos = get_int32_pos(data, pos)
-        case 6: msg.end_position_long, pos = get_int32_pos(data, pos)
-        case 7: msg.total_elapsed_time, pos = get_uint32_pos(data, pos)
-        case 8: msg.total_timer_time, pos = get_uint32_pos(data, pos)
-        case 9: msg.total_distance, pos = get_uint32_pos(data, pos)
-        case 10: msg.total_cycles, pos = get_uint32_pos(data, pos)
-        case 11: msg.total_calories, pos = get_uint16_pos(data, pos)
-        case 12: msg.total_fat_calories, pos = get_uint16_pos(data, pos)
-        case 13: msg.avg_speed, pos = get_uint16_pos(data, pos)
-        case 14: msg.max_speed, pos = get_uint16_pos(data, pos)
-        case 15: msg.avg_heart_rate, pos = get_uint8_pos(data, pos)
-        case 16: msg.max_heart_rate, pos = get_uint8_pos(data, pos)
-        case 17: msg.avg_cadence, pos = get_uint8_pos(data, pos)
-        case 18: msg.max_cadence, pos = get_uint8_pos(data, pos)
-        case 19: msg.avg_power, pos = get_uint16_pos(data, pos)
-        case 20: msg.max_power, pos = get_uint16_pos(data, pos)
-        case 21: msg.total_ascent, pos = get_uint16_pos(data, pos)
-        case 22: msg.total_descent, pos = get_uint16_pos(data, pos)
-        case 23: msg.intensity, pos = get_byte_pos(data, pos)
-        case 24: msg.lap_trigger, pos = get_byte_pos(data, pos)
-        case 25: msg.sport, pos = get_byte_pos(data, pos)
-        case 26: msg.event_group, pos = get_uint8_pos(data, pos)
-        case 32: msg.num_lengths, pos = get_uint16_pos(data, pos)
-        case 33: msg.normalized_power, pos = get_uint16_pos(data, pos)
-        case 34: msg.left_right_balance, pos = get_uint16_pos(data, pos)
-        case 35: msg.first_length_index, pos = get_uint16_pos(data, pos)
-        case 37: msg.avg_stroke_distance, pos = get_uint16_pos(data, pos)
-        case 38: msg.swim_stroke, pos = get_byte_pos(data, pos)
-        case 39: msg.sub_sport, pos = get_byte_pos(data, pos)
-        case 40: msg.num_active_lengths, pos = get_uint16_pos(data, pos)
-        case 41: msg.total_work, pos = get_uint32_pos(data, pos)
-        case 42: msg.avg_altitude, pos = get_uint16_pos(data, pos)
-        case 43: msg.max_altitude, pos = get_uint16_pos(data, pos)
-        case 44: msg.gps_accuracy, pos = get_uint8_pos(data, pos)
-        case 45: msg.avg_grade, pos = get_int16_pos(data, pos)
-        case 46: msg.avg_pos_grade, pos = get_int16_pos(data, pos)
-        case 47: msg.avg_neg_grade, pos = get_int16_pos(data, pos)
-        case 48: msg.max_pos_grade, pos = get_int16_pos(data, pos)
-        case 49: msg.max_neg_grade, pos = get_int16_pos(data, pos)
-        case 50: msg.avg_temperature, pos = get_int8_pos(data, pos)
-        case 51: msg.max_temperature, pos = get_int8_pos(data, pos)
-        case 52: msg.total_moving_time, pos = get_uint32_pos(data, pos)
-        case 53: msg.avg_pos_vertical_speed, pos = get_int16_pos(data, pos)
-        case 54: msg.avg_neg_vertical_speed, pos = get_int16_pos(data, pos)
-        case 55: msg.max_pos_vertical_speed, pos = get_int16_pos(data, pos)
-        case 56: msg.max_neg_vertical_speed, pos = get_int16_pos(data, pos)
-        case 57: msg.time_in_hr_zone, pos = get_uint32_pos(data, pos)
-        case 58: msg.time_in_speed_zone, pos = get_uint32_pos(data, pos)
-        case 59: msg.time_in_cadence_zone, pos = get_uint32_pos(data, pos)
-        case 60: msg.time_in_power_zone, pos = get_uint32_pos(data, pos)
-        case 61: msg.repetition_num, pos = get_uint16_pos(data, pos)
-        case 62: msg.min_altitude, pos = get_uint16_pos(data, pos)
-        case 63: msg.min_heart_rate, pos = get_uint8_pos(data, pos)
-        case 71: msg.wkt_step_index, pos = get_uint16_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 1: msg.HighBpm = get_uint8(buf, fld, order)
+        case 2: msg.HrZoneName = get_string(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad lap field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// record message
+// power_zone message
 
-type MsgRecord struct {
-    timestamp uint32
-    position_lat int32
-    position_long int32
-    altitude uint16
-    heart_rate uint8
-    cadence uint8
-    distance uint32
-    speed uint16
-    power uint16
-    compressed_speed_distance byte
-    grade int16
-    resistance uint8
-    time_from_course int32
-    cycle_length uint8
-    temperature int8
-    speed_1s uint8
-    cycles uint8
-    total_cycles uint32
-    compressed_accumulated_power uint16
-    accumulated_power uint32
-    left_right_balance uint8
-    gps_accuracy uint8
-    vertical_speed int16
-    calories uint16
-    left_torque_effectiveness uint8
-    right_torque_effectiveness uint8
-    left_pedal_smoothness uint8
-    right_pedal_smoothness uint8
-    combined_pedal_smoothness uint8
-    cadence256 uint16
+// MsgPowerZone holds the fields of a power_zone message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgPowerZone struct {
+    msgBase
+
+    MessageIndex uint16
+    HighValue uint16 // watts
+    PowerZoneName string
 }
 
-func (msg *MsgRecord) Name() string {
-    return "record"
+func (msg *MsgPowerZone) Name() string {
+    return "power_zone"
 }
 
-func (msg *MsgRecord) Text() string {
-    return fmt.Sprintf("record tstmp %d poslat %d poslong %d alt %d" +
-        " heartrate %d cadence %d dist %d speed %d power %d" +
-        " compressedspeeddist %d grade %d resistance %d timefromcourse %d" +
-        " cyclelen %d temp %d speed1s %d cycles %d totalcycles %d" +
-        " compressedaccumpower %d accumpower %d leftrightbalance %d" +
-        " gpsaccuracy %d vertspeed %d cals %d lefttorqueeffectiveness %d" +
-        " righttorqueeffectiveness %d leftpedalsmooth %d rightpedalsmooth %d" +
-        " combinedpedalsmooth %d cadence256 %d", msg.timestamp,
-        msg.position_lat, msg.position_long, msg.altitude, msg.heart_rate,
-        msg.cadence, msg.distance, msg.speed, msg.power,
-        msg.compressed_speed_distance, msg.grade, msg.resistance,
-        msg.time_from_course, msg.cycle_length, msg.temperature, msg.speed_1s,
-        msg.cycles, msg.total_cycles, msg.compressed_accumulated_power,
-        msg.accumulated_power, msg.left_right_balance, msg.gps_accuracy,
-        msg.vertical_speed, msg.calories, msg.left_torque_effectiveness,
-        msg.right_torque_effectiveness, msg.left_pedal_smoothness,
-        msg.right_pedal_smoothness, msg.combined_pedal_smoothness,
-        msg.cadence256)
+func (msg *MsgPowerZone) Text() string {
+    txt := "power_zone"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint16(msg.HighValue) {
+        txt += fmt.Sprintf(" highvalue %d", msg.HighValue)
+    }
+    if is_valid_string(msg.PowerZoneName) {
+        txt += fmt.Sprintf(" name %s", msg.PowerZoneName)
+    }
+    return txt
 }
 
-func NewMsgRecord(def *FitDefinition, data []byte) (*MsgRecord, error) {
-    msg := new(MsgRecord)
+// IsSet reports whether the named field held a valid value
+func (msg *MsgPowerZone) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "high_value": return is_valid_uint16(msg.HighValue)
+    case "name": return is_valid_string(msg.PowerZoneName)
+    default: return false
+    }
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.position_lat, pos = get_int32_pos(data, pos)
-        case 1: msg.position_long, pos = get_int32_pos(data, pos)
-        case 2: msg.altitude, pos = get_uint16_pos(data, pos)
-        case 3: msg.heart_rate, pos = get_uint8_pos(data, pos)
-        case 4: msg.cadence, pos = get_uint8_pos(data, pos)
-        case 5: msg.distance, pos = get_uint32_pos(data, pos)
-        case 6: msg.speed, pos = get_uint16_pos(data, pos)
-        case 7: msg.power, pos = get_uint16_pos(data, pos)
-        case 8: msg.compressed_speed_distance, pos = get_byte_pos(data, pos)
-        case 9: msg.grade, pos = get_int16_pos(data, pos)
-        case 10: msg.resistance, pos = get_uint8_pos(data, pos)
-        case 11: msg.time_from_course, pos = get_int32_pos(data, pos)
-        case 12: msg.cycle_length, pos = get_uint8_pos(data, pos)
-        case 13: msg.temperature, pos = get_int8_pos(data, pos)
-        case 17: msg.speed_1s, pos = get_uint8_pos(data, pos)
-        case 18: msg.cycles, pos = get_uint8_pos(data, pos)
-        case 19: msg.total_cycles, pos = get_uint32_pos(data, pos)
-        case 28: msg.compressed_accumulated_power, pos = get_uint16_pos(data, pos)
-        case 29: msg.accumulated_power, pos = get_uint32_pos(data, pos)
-        case 30: msg.left_right_balance, pos = get_uint8_pos(data, pos)
-        case 31: msg.gps_accuracy, pos = get_uint8_pos(data, pos)
-        case 32: msg.vertical_speed, pos = get_int16_pos(data, pos)
-        case 33: msg.calories, pos = get_uint16_pos(data, pos)
-        case 43: msg.left_torque_effectiveness, pos = get_uint8_pos(data, pos)
-        case 44: msg.right_torque_effectiveness, pos = get_uint8_pos(data, pos)
-        case 45: msg.left_pedal_smoothness, pos = get_uint8_pos(data, pos)
-        case 46: msg.right_pedal_smoothness, pos = get_uint8_pos(data, pos)
-        case 47: msg.combined_pedal_smoothness, pos = get_uint8_pos(data, pos)
-        case 52: msg.cadence256, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad record field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgPowerZone) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "high_value":
+        if is_valid_uint16(msg.HighValue) {
+            return float64(msg.HighValue)
         }
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// event message
+// Units returns the units of the named field's scaled value
+func (msg *MsgPowerZone) Units(name string) string {
+    switch name {
+    case "high_value": return "watts"
+    default: return ""
+    }
+}
 
-type MsgEvent struct {
-    timestamp uint32
-    event byte
-    event_type byte
-    data16 uint16
-    data uint32
-    event_group uint8
-}
-
-func (msg *MsgEvent) event_name() string {
-    switch msg.event {
-    case 0: return "timer"
-    case 3: return "workout"
-    case 4: return "workout_step"
-    case 5: return "power_down"
-    case 6: return "power_up"
-    case 7: return "off_course"
-    case 8: return "session"
-    case 9: return "lap"
-    case 10: return "course_point"
-    case 11: return "battery"
-    case 12: return "virtual_partner_pace"
-    case 13: return "hr_high_alert"
-    case 14: return "hr_low_alert"
-    case 15: return "speed_high_alert"
-    case 16: return "speed_low_alert"
-    case 17: return "cad_high_alert"
-    case 18: return "cad_low_alert"
-    case 19: return "power_high_alert"
-    case 20: return "power_low_alert"
-    case 21: return "recovery_hr"
-    case 22: return "battery_low"
-    case 23: return "time_duration_alert"
-    case 24: return "distance_duration_alert"
-    case 25: return "calorie_duration_alert"
-    case 26: return "activity"
-    case 27: return "fitness_equipment"
-    case 28: return "length"
-    case 36: return "calibration"
-    default: return fmt.Sprintf("unknown#%d", msg.event)
-    }
-}
-
-func (msg *MsgEvent) event_type_name() string {
-    switch msg.event_type {
-    case 0: return "start"
-    case 1: return "stop"
-    case 2: return "consecutive_depreciated"
-    case 3: return "marker"
-    case 4: return "stop_all"
-    case 5: return "begin_depreciated"
-    case 6: return "end_depreciated"
-    case 7: return "end_all_depreciated"
-    case 8: return "stop_disable"
-    case 9: return "stop_disable_all"
-    default: return fmt.Sprintf("unknown#%d", msg.event_type)
+// GlobalNum returns the message's global message number
+func (msg *MsgPowerZone) GlobalNum() uint16 {
+    return 9
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgPowerZone) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 1:
+        return Value{Name: "high_value", Num: 1, BaseType: 4,
+            Raw: msg.HighValue, Scaled: msg.Scaled("high_value"),
+            Units: "watts", Valid: is_valid_uint16(msg.HighValue)}
+    case 2:
+        return Value{Name: "name", Num: 2, BaseType: 7,
+            Raw: msg.PowerZoneName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.PowerZoneName)}
+    default: return msg.unknownValue(num)
     }
 }
 
-func (msg *MsgEvent) Name() string {
-    return "event"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgPowerZone) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "high_value": return msg.FieldByNum(1)
+    case "name": return msg.FieldByNum(2)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgEvent) Text() string {
-    return fmt.Sprintf("event tstmp %d evt %s evttyp %s data16 %d data %d" +
-        " evtgrp %d", msg.timestamp, msg.event_name(), msg.event_type_name(),
-        msg.data16, msg.data, msg.event_group)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgPowerZone) Fields() []Value {
+    return fieldValues(msg, []byte{254, 1, 2})
 }
 
-func NewMsgEvent(def *FitDefinition, data []byte) (*MsgEvent, error) {
-    msg := new(MsgEvent)
+func NewMsgPowerZone(def *FitDefinition, data []byte) (*MsgPowerZone, error) {
+    msg := new(MsgPowerZone)
+
+    msg.MessageIndex = invalid_uint16
+    msg.HighValue = invalid_uint16
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.event, pos = get_byte_pos(data, pos)
-        case 1: msg.event_type, pos = get_byte_pos(data, pos)
-        case 2: msg.data16, pos = get_uint16_pos(data, pos)
-        case 3: msg.data, pos = get_uint32_pos(data, pos)
-        case 4: msg.event_group, pos = get_uint8_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 1: msg.HighValue = get_uint16(buf, fld, order)
+        case 2: msg.PowerZoneName = get_string(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad event field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// device_info message
+// met_zone message
 
-type MsgDeviceInfo struct {
-    timestamp uint32
-    device_index uint8
-    device_type uint8
-    manufacturer uint16
-    serial_number uint32
-    product uint16
-    software_version uint16
-    hardware_version uint8
-    cum_operating_time uint32
-    battery_voltage uint16
-    battery_status uint8
-}
+// MsgMetZone holds the fields of a met_zone message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgMetZone struct {
+    msgBase
 
-func (msg *MsgDeviceInfo) Name() string {
-    return "device_info"
+    MessageIndex uint16
+    HighBpm uint8 // bpm
+    Calories uint16 // kcal / min, scale 10
+    FatCalories uint8 // kcal / min, scale 10
 }
 
-func (msg *MsgDeviceInfo) Text() string {
-    return fmt.Sprintf("device_info tstmp %d devidx %d devtyp %d mfct %d" +
-        " ser# %d prod %d soft %d hard %d cumoptime %d battvolt %d" +
-        " battstat %d", msg.timestamp, msg.device_index, msg.device_type,
-        msg.manufacturer, msg.serial_number, msg.product, msg.software_version,
-        msg.hardware_version, msg.cum_operating_time, msg.battery_voltage,
-        msg.battery_status)
+func (msg *MsgMetZone) Name() string {
+    return "met_zone"
 }
 
-func NewMsgDeviceInfo(def *FitDefinition, data []byte) (*MsgDeviceInfo, error) {
-    msg := new(MsgDeviceInfo)
-
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.device_index, pos = get_uint8_pos(data, pos)
-        case 1: msg.device_type, pos = get_uint8_pos(data, pos)
-        case 2: msg.manufacturer, pos = get_uint16_pos(data, pos)
-        case 3: msg.serial_number, pos = get_uint32_pos(data, pos)
-        case 4: msg.product, pos = get_uint16_pos(data, pos)
-        case 5: msg.software_version, pos = get_uint16_pos(data, pos)
-        case 6: msg.hardware_version, pos = get_uint8_pos(data, pos)
-        case 7: msg.cum_operating_time, pos = get_uint32_pos(data, pos)
-        case 10: msg.battery_voltage, pos = get_uint16_pos(data, pos)
-        case 11: msg.battery_status, pos = get_uint8_pos(data, pos)
-        default:
-            fmt.Printf("Ignoring device_info field #%d\n", def.fields[i].num)
-        }
+// calories in kcal / min
+func (msg *MsgMetZone) CaloriesScaled() float64 {
+    if !is_valid_uint16(msg.Calories) {
+        return math.NaN()
     }
-
-    return msg, nil
+    return float64(msg.Calories) / 10
 }
 
-// workout message
-
-type MsgWorkout struct {
-    sport byte
-    capabilities uint32
-    num_valid_steps uint16
-    wkt_name string
+// fat_calories in kcal / min
+func (msg *MsgMetZone) FatCaloriesScaled() float64 {
+    if !is_valid_uint8(msg.FatCalories) {
+        return math.NaN()
+    }
+    return float64(msg.FatCalories) / 10
 }
 
-func (msg *MsgWorkout) Name() string {
-    return "workout"
+func (msg *MsgMetZone) Text() string {
+    txt := "met_zone"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint8(msg.HighBpm) {
+        txt += fmt.Sprintf(" highbpm %d", msg.HighBpm)
+    }
+    if is_valid_uint16(msg.Calories) {
+        txt += fmt.Sprintf(" cals %g", msg.CaloriesScaled())
+    }
+    if is_valid_uint8(msg.FatCalories) {
+        txt += fmt.Sprintf(" fatcals %g", msg.FatCaloriesScaled())
+    }
+    return txt
 }
 
-func (msg *MsgWorkout) Text() string {
-    return fmt.Sprintf("workout sport %d capabilities %d numvalidsteps %d" +
-        " wktname %s", msg.sport, msg.capabilities, msg.num_valid_steps,
-        msg.wkt_name)
+// IsSet reports whether the named field held a valid value
+func (msg *MsgMetZone) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "high_bpm": return is_valid_uint8(msg.HighBpm)
+    case "calories": return is_valid_uint16(msg.Calories)
+    case "fat_calories": return is_valid_uint8(msg.FatCalories)
+    default: return false
+    }
 }
 
-func NewMsgWorkout(def *FitDefinition, data []byte) (*MsgWorkout, error) {
-    msg := new(MsgWorkout)
-
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 4: msg.sport, pos = get_byte_pos(data, pos)
-        case 5: msg.capabilities, pos = get_uint32_pos(data, pos)
-        case 6: msg.num_valid_steps, pos = get_uint16_pos(data, pos)
-        case 8: msg.wkt_name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad workout field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgMetZone) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
         }
+    case "high_bpm":
+        if is_valid_uint8(msg.HighBpm) {
+            return float64(msg.HighBpm)
+        }
+    case "calories":
+        return msg.CaloriesScaled()
+    case "fat_calories":
+        return msg.FatCaloriesScaled()
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// workout_step message
+// Units returns the units of the named field's scaled value
+func (msg *MsgMetZone) Units(name string) string {
+    switch name {
+    case "high_bpm": return "bpm"
+    case "calories": return "kcal / min"
+    case "fat_calories": return "kcal / min"
+    default: return ""
+    }
+}
 
-type MsgWorkoutStep struct {
-    message_index uint16
-    wkt_step_name string
-    duration_type byte
-    duration_value uint32
-    target_type byte
-    target_value uint32
-    custom_target_value_low uint32
-    custom_target_value_high uint32
-    intensity byte
+// GlobalNum returns the message's global message number
+func (msg *MsgMetZone) GlobalNum() uint16 {
+    return 10
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgMetZone) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 1:
+        return Value{Name: "high_bpm", Num: 1, BaseType: 2,
+            Raw: msg.HighBpm, Scaled: msg.Scaled("high_bpm"),
+            Units: "bpm", Valid: is_valid_uint8(msg.HighBpm)}
+    case 2:
+        return Value{Name: "calories", Num: 2, BaseType: 4,
+            Raw: msg.Calories, Scaled: msg.Scaled("calories"),
+            Units: "kcal / min", Valid: is_valid_uint16(msg.Calories)}
+    case 3:
+        return Value{Name: "fat_calories", Num: 3, BaseType: 2,
+            Raw: msg.FatCalories, Scaled: msg.Scaled("fat_calories"),
+            Units: "kcal / min", Valid: is_valid_uint8(msg.FatCalories)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgWorkoutStep) Name() string {
-    return "workout_step"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgMetZone) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "high_bpm": return msg.FieldByNum(1)
+    case "calories": return msg.FieldByNum(2)
+    case "fat_calories": return msg.FieldByNum(3)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgWorkoutStep) Text() string {
-    return fmt.Sprintf("workout_step msgidx %d wktstepname %s durationtyp %d" +
-        " durationvalue %d targettyp %d targetvalue %d" +
-        " customtargetvaluelow %d customtargetvaluehigh %d intensity %d",
-        msg.message_index, msg.wkt_step_name, msg.duration_type,
-        msg.duration_value, msg.target_type, msg.target_value,
-        msg.custom_target_value_low, msg.custom_target_value_high,
-        msg.intensity)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgMetZone) Fields() []Value {
+    return fieldValues(msg, []byte{254, 1, 2, 3})
 }
 
-func NewMsgWorkoutStep(def *FitDefinition, data []byte) (*MsgWorkoutStep, error) {
-    msg := new(MsgWorkoutStep)
+func NewMsgMetZone(def *FitDefinition, data []byte) (*MsgMetZone, error) {
+    msg := new(MsgMetZone)
+
+    msg.MessageIndex = invalid_uint16
+    msg.HighBpm = invalid_uint8
+    msg.Calories = invalid_uint16
+    msg.FatCalories = invalid_uint8
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.wkt_step_name, pos = get_string_pos(data, pos)
-        case 1: msg.duration_type, pos = get_byte_pos(data, pos)
-        case 2: msg.duration_value, pos = get_uint32_pos(data, pos)
-        case 3: msg.target_type, pos = get_byte_pos(data, pos)
-        case 4: msg.target_value, pos = get_uint32_pos(data, pos)
-        case 5: msg.custom_target_value_low, pos = get_uint32_pos(data, pos)
-        case 6: msg.custom_target_value_high, pos = get_uint32_pos(data, pos)
-        case 7: msg.intensity, pos = get_byte_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 1: msg.HighBpm = get_uint8(buf, fld, order)
+        case 2: msg.Calories = get_uint16(buf, fld, order)
+        case 3: msg.FatCalories = get_uint8(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad workout_step field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// schedule message
+// sport message
 
-type MsgSchedule struct {
-    manufacturer uint16
-    product uint16
-    serial_number uint32
-    time_created uint32
-    completed byte
-    msgtype byte
-    scheduled_time uint32
-}
+// MsgSport holds the fields of a sport message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSport struct {
+    msgBase
 
-func (msg *MsgSchedule) Name() string {
-    return "schedule"
+    Sport Sport
+    SubSport SubSport
+    SportName string
 }
 
-func (msg *MsgSchedule) Text() string {
-    return fmt.Sprintf("schedule mfct %d prod %d ser# %d timecre %d" +
-        " completed %d msgtyp %d scheduledtime %d", msg.manufacturer,
-        msg.product, msg.serial_number, msg.time_created, msg.completed,
-        msg.msgtype, msg.scheduled_time)
+func (msg *MsgSport) Name() string {
+    return "sport"
 }
 
-func NewMsgSchedule(def *FitDefinition, data []byte) (*MsgSchedule, error) {
+func (msg *MsgSport) Text() string {
+    txt := "sport"
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_enum(byte(msg.SubSport)) {
+        txt += fmt.Sprintf(" subsport %s", msg.SubSport)
+    }
+    if is_valid_string(msg.SportName) {
+        txt += fmt.Sprintf(" name %s", msg.SportName)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSport) IsSet(name string) bool {
+    switch name {
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "sub_sport": return is_valid_enum(byte(msg.SubSport))
+    case "name": return is_valid_string(msg.SportName)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSport) Scaled(name string) float64 {
+    switch name {
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "sub_sport":
+        if is_valid_enum(byte(msg.SubSport)) {
+            return float64(msg.SubSport)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgSport) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgSport) GlobalNum() uint16 {
+    return 12
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSport) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "sport", Num: 0, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 1:
+        return Value{Name: "sub_sport", Num: 1, BaseType: 0,
+            Raw: msg.SubSport, Scaled: msg.Scaled("sub_sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.SubSport))}
+    case 3:
+        return Value{Name: "name", Num: 3, BaseType: 7,
+            Raw: msg.SportName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.SportName)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSport) Field(name string) Value {
+    switch name {
+    case "sport": return msg.FieldByNum(0)
+    case "sub_sport": return msg.FieldByNum(1)
+    case "name": return msg.FieldByNum(3)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSport) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 3})
+}
+
+func NewMsgSport(def *FitDefinition, data []byte) (*MsgSport, error) {
+    msg := new(MsgSport)
+
+    msg.Sport = Sport(invalid_enum)
+    msg.SubSport = SubSport(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 1: msg.SubSport = SubSport(get_enum(buf, fld, order))
+        case 3: msg.SportName = get_string(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// goal message
+
+// MsgGoal holds the fields of a goal message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgGoal struct {
+    msgBase
+
+    MessageIndex uint16
+    Sport Sport
+    SubSport SubSport
+    StartDate uint32
+    EndDate uint32
+    Type Goal
+    Value uint32
+    Repeat Bool
+    TargetValue uint32
+    Recurrence GoalRecurrence
+    RecurrenceValue uint16
+    Enabled Bool
+}
+
+func (msg *MsgGoal) Name() string {
+    return "goal"
+}
+
+func (msg *MsgGoal) Text() string {
+    txt := "goal"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_enum(byte(msg.SubSport)) {
+        txt += fmt.Sprintf(" subsport %s", msg.SubSport)
+    }
+    if is_valid_uint32(msg.StartDate) {
+        txt += fmt.Sprintf(" startdate %d", msg.StartDate)
+    }
+    if is_valid_uint32(msg.EndDate) {
+        txt += fmt.Sprintf(" enddate %d", msg.EndDate)
+    }
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_uint32(msg.Value) {
+        txt += fmt.Sprintf(" value %d", msg.Value)
+    }
+    if is_valid_enum(byte(msg.Repeat)) {
+        txt += fmt.Sprintf(" repeat %s", msg.Repeat)
+    }
+    if is_valid_uint32(msg.TargetValue) {
+        txt += fmt.Sprintf(" targetvalue %d", msg.TargetValue)
+    }
+    if is_valid_enum(byte(msg.Recurrence)) {
+        txt += fmt.Sprintf(" recurrence %s", msg.Recurrence)
+    }
+    if is_valid_uint16(msg.RecurrenceValue) {
+        txt += fmt.Sprintf(" recurrencevalue %d", msg.RecurrenceValue)
+    }
+    if is_valid_enum(byte(msg.Enabled)) {
+        txt += fmt.Sprintf(" enabled %s", msg.Enabled)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgGoal) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "sub_sport": return is_valid_enum(byte(msg.SubSport))
+    case "start_date": return is_valid_uint32(msg.StartDate)
+    case "end_date": return is_valid_uint32(msg.EndDate)
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "value": return is_valid_uint32(msg.Value)
+    case "repeat": return is_valid_enum(byte(msg.Repeat))
+    case "target_value": return is_valid_uint32(msg.TargetValue)
+    case "recurrence": return is_valid_enum(byte(msg.Recurrence))
+    case "recurrence_value": return is_valid_uint16(msg.RecurrenceValue)
+    case "enabled": return is_valid_enum(byte(msg.Enabled))
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgGoal) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "sub_sport":
+        if is_valid_enum(byte(msg.SubSport)) {
+            return float64(msg.SubSport)
+        }
+    case "start_date":
+        if is_valid_uint32(msg.StartDate) {
+            return float64(msg.StartDate)
+        }
+    case "end_date":
+        if is_valid_uint32(msg.EndDate) {
+            return float64(msg.EndDate)
+        }
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    case "value":
+        if is_valid_uint32(msg.Value) {
+            return float64(msg.Value)
+        }
+    case "repeat":
+        if is_valid_enum(byte(msg.Repeat)) {
+            return float64(msg.Repeat)
+        }
+    case "target_value":
+        if is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "recurrence":
+        if is_valid_enum(byte(msg.Recurrence)) {
+            return float64(msg.Recurrence)
+        }
+    case "recurrence_value":
+        if is_valid_uint16(msg.RecurrenceValue) {
+            return float64(msg.RecurrenceValue)
+        }
+    case "enabled":
+        if is_valid_enum(byte(msg.Enabled)) {
+            return float64(msg.Enabled)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgGoal) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgGoal) GlobalNum() uint16 {
+    return 15
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgGoal) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "sport", Num: 0, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 1:
+        return Value{Name: "sub_sport", Num: 1, BaseType: 0,
+            Raw: msg.SubSport, Scaled: msg.Scaled("sub_sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.SubSport))}
+    case 2:
+        return Value{Name: "start_date", Num: 2, BaseType: 6,
+            Raw: msg.StartDate, Scaled: msg.Scaled("start_date"),
+            Units: "", Valid: is_valid_uint32(msg.StartDate)}
+    case 3:
+        return Value{Name: "end_date", Num: 3, BaseType: 6,
+            Raw: msg.EndDate, Scaled: msg.Scaled("end_date"),
+            Units: "", Valid: is_valid_uint32(msg.EndDate)}
+    case 4:
+        return Value{Name: "type", Num: 4, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 5:
+        return Value{Name: "value", Num: 5, BaseType: 6,
+            Raw: msg.Value, Scaled: msg.Scaled("value"),
+            Units: "", Valid: is_valid_uint32(msg.Value)}
+    case 6:
+        return Value{Name: "repeat", Num: 6, BaseType: 0,
+            Raw: msg.Repeat, Scaled: msg.Scaled("repeat"),
+            Units: "", Valid: is_valid_enum(byte(msg.Repeat))}
+    case 7:
+        return Value{Name: "target_value", Num: 7, BaseType: 6,
+            Raw: msg.TargetValue, Scaled: msg.Scaled("target_value"),
+            Units: "", Valid: is_valid_uint32(msg.TargetValue)}
+    case 8:
+        return Value{Name: "recurrence", Num: 8, BaseType: 0,
+            Raw: msg.Recurrence, Scaled: msg.Scaled("recurrence"),
+            Units: "", Valid: is_valid_enum(byte(msg.Recurrence))}
+    case 9:
+        return Value{Name: "recurrence_value", Num: 9, BaseType: 4,
+            Raw: msg.RecurrenceValue, Scaled: msg.Scaled("recurrence_value"),
+            Units: "", Valid: is_valid_uint16(msg.RecurrenceValue)}
+    case 10:
+        return Value{Name: "enabled", Num: 10, BaseType: 0,
+            Raw: msg.Enabled, Scaled: msg.Scaled("enabled"),
+            Units: "", Valid: is_valid_enum(byte(msg.Enabled))}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgGoal) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "sport": return msg.FieldByNum(0)
+    case "sub_sport": return msg.FieldByNum(1)
+    case "start_date": return msg.FieldByNum(2)
+    case "end_date": return msg.FieldByNum(3)
+    case "type": return msg.FieldByNum(4)
+    case "value": return msg.FieldByNum(5)
+    case "repeat": return msg.FieldByNum(6)
+    case "target_value": return msg.FieldByNum(7)
+    case "recurrence": return msg.FieldByNum(8)
+    case "recurrence_value": return msg.FieldByNum(9)
+    case "enabled": return msg.FieldByNum(10)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgGoal) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+}
+
+func NewMsgGoal(def *FitDefinition, data []byte) (*MsgGoal, error) {
+    msg := new(MsgGoal)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Sport = Sport(invalid_enum)
+    msg.SubSport = SubSport(invalid_enum)
+    msg.StartDate = invalid_uint32
+    msg.EndDate = invalid_uint32
+    msg.Type = Goal(invalid_enum)
+    msg.Value = invalid_uint32
+    msg.Repeat = Bool(invalid_enum)
+    msg.TargetValue = invalid_uint32
+    msg.Recurrence = GoalRecurrence(invalid_enum)
+    msg.RecurrenceValue = invalid_uint16
+    msg.Enabled = Bool(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 1: msg.SubSport = SubSport(get_enum(buf, fld, order))
+        case 2: msg.StartDate = get_uint32(buf, fld, order)
+        case 3: msg.EndDate = get_uint32(buf, fld, order)
+        case 4: msg.Type = Goal(get_enum(buf, fld, order))
+        case 5: msg.Value = get_uint32(buf, fld, order)
+        case 6: msg.Repeat = Bool(get_enum(buf, fld, order))
+        case 7: msg.TargetValue = get_uint32(buf, fld, order)
+        case 8: msg.Recurrence = GoalRecurrence(get_enum(buf, fld, order))
+        case 9: msg.RecurrenceValue = get_uint16(buf, fld, order)
+        case 10: msg.Enabled = Bool(get_enum(buf, fld, order))
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// session message
+
+// MsgSession holds the fields of a session message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSession struct {
+    msgBase
+
+    MessageIndex uint16
+    Timestamp uint32 // s
+    Event Event
+    EventType EventType
+    StartTime uint32
+    StartPositionLat int32 // semicircles
+    StartPositionLong int32 // semicircles
+    Sport Sport
+    SubSport SubSport
+    TotalElapsedTime uint32 // s, scale 1000
+    TotalTimerTime uint32 // s, scale 1000
+    TotalDistance uint32 // m, scale 100
+    TotalCycles uint32 // cycles
+    TotalCalories uint16 // kcal
+    TotalFatCalories uint16 // kcal
+    AvgSpeed uint16 // m/s, scale 1000
+    MaxSpeed uint16 // m/s, scale 1000
+    AvgHeartRate uint8 // bpm
+    MaxHeartRate uint8 // bpm
+    AvgCadence uint8 // rpm
+    MaxCadence uint8 // rpm
+    AvgPower uint16 // watts
+    MaxPower uint16 // watts
+    TotalAscent uint16 // m
+    TotalDescent uint16 // m
+    TotalTrainingEffect uint8 // scale 10
+    FirstLapIndex uint16
+    NumLaps uint16
+    EventGroup uint8
+    Trigger SessionTrigger
+    NecLat int32 // semicircles
+    NecLong int32 // semicircles
+    SwcLat int32 // semicircles
+    SwcLong int32 // semicircles
+    NormalizedPower uint16 // watts
+    TrainingStressScore uint16 // tss, scale 10
+    IntensityFactor uint16 // if, scale 1000
+    LeftRightBalance uint16
+    AvgStrokeCount uint32 // strokes/lap, scale 10
+    AvgStrokeDistance uint16 // m, scale 100
+    SwimStroke SwimStroke
+    PoolLength uint16 // m, scale 100
+    PoolLengthUnit DisplayMeasure
+    NumActiveLengths uint16 // lengths
+    TotalWork uint32 // J
+    AvgAltitude uint16 // m, scale 5, offset 500
+    MaxAltitude uint16 // m, scale 5, offset 500
+    GPSAccuracy uint8 // m
+    AvgGrade int16 // %, scale 100
+    AvgPosGrade int16 // %, scale 100
+    AvgNegGrade int16 // %, scale 100
+    MaxPosGrade int16 // %, scale 100
+    MaxNegGrade int16 // %, scale 100
+    AvgTemperature int8 // C
+    MaxTemperature int8 // C
+    TotalMovingTime uint32 // s, scale 1000
+    AvgPosVerticalSpeed int16 // m/s, scale 1000
+    AvgNegVerticalSpeed int16 // m/s, scale 1000
+    MaxPosVerticalSpeed int16 // m/s, scale 1000
+    MaxNegVerticalSpeed int16 // m/s, scale 1000
+    MinHeartRate uint8 // bpm
+    TimeInHRZone []uint32 // s, scale 1000
+    TimeInSpeedZone []uint32 // s, scale 1000
+    TimeInCadenceZone []uint32 // s, scale 1000
+    TimeInPowerZone []uint32 // s, scale 1000
+    AvgLapTime uint32 // s, scale 1000
+    BestLapIndex uint16
+    MinAltitude uint16 // m, scale 5, offset 500
+}
+
+func (msg *MsgSession) Name() string {
+    return "session"
+}
+
+// total_elapsed_time in s
+func (msg *MsgSession) TotalElapsedTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalElapsedTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalElapsedTime) / 1000
+}
+
+// total_timer_time in s
+func (msg *MsgSession) TotalTimerTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalTimerTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalTimerTime) / 1000
+}
+
+// total_distance in m
+func (msg *MsgSession) TotalDistanceScaled() float64 {
+    if !is_valid_uint32(msg.TotalDistance) {
+        return math.NaN()
+    }
+    return float64(msg.TotalDistance) / 100
+}
+
+// avg_speed in m/s
+func (msg *MsgSession) AvgSpeedScaled() float64 {
+    if !is_valid_uint16(msg.AvgSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgSpeed) / 1000
+}
+
+// max_speed in m/s
+func (msg *MsgSession) MaxSpeedScaled() float64 {
+    if !is_valid_uint16(msg.MaxSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxSpeed) / 1000
+}
+
+func (msg *MsgSession) TotalTrainingEffectScaled() float64 {
+    if !is_valid_uint8(msg.TotalTrainingEffect) {
+        return math.NaN()
+    }
+    return float64(msg.TotalTrainingEffect) / 10
+}
+
+// training_stress_score in tss
+func (msg *MsgSession) TrainingStressScoreScaled() float64 {
+    if !is_valid_uint16(msg.TrainingStressScore) {
+        return math.NaN()
+    }
+    return float64(msg.TrainingStressScore) / 10
+}
+
+// intensity_factor in if
+func (msg *MsgSession) IntensityFactorScaled() float64 {
+    if !is_valid_uint16(msg.IntensityFactor) {
+        return math.NaN()
+    }
+    return float64(msg.IntensityFactor) / 1000
+}
+
+// avg_stroke_count in strokes/lap
+func (msg *MsgSession) AvgStrokeCountScaled() float64 {
+    if !is_valid_uint32(msg.AvgStrokeCount) {
+        return math.NaN()
+    }
+    return float64(msg.AvgStrokeCount) / 10
+}
+
+// avg_stroke_distance in m
+func (msg *MsgSession) AvgStrokeDistanceScaled() float64 {
+    if !is_valid_uint16(msg.AvgStrokeDistance) {
+        return math.NaN()
+    }
+    return float64(msg.AvgStrokeDistance) / 100
+}
+
+// pool_length in m
+func (msg *MsgSession) PoolLengthScaled() float64 {
+    if !is_valid_uint16(msg.PoolLength) {
+        return math.NaN()
+    }
+    return float64(msg.PoolLength) / 100
+}
+
+// avg_altitude in m
+func (msg *MsgSession) AvgAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.AvgAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.AvgAltitude) - 2500) / 5
+}
+
+// max_altitude in m
+func (msg *MsgSession) MaxAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.MaxAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.MaxAltitude) - 2500) / 5
+}
+
+// avg_grade in %
+func (msg *MsgSession) AvgGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgGrade) / 100
+}
+
+// avg_pos_grade in %
+func (msg *MsgSession) AvgPosGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgPosGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgPosGrade) / 100
+}
+
+// avg_neg_grade in %
+func (msg *MsgSession) AvgNegGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgNegGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgNegGrade) / 100
+}
+
+// max_pos_grade in %
+func (msg *MsgSession) MaxPosGradeScaled() float64 {
+    if !is_valid_int16(msg.MaxPosGrade) {
+        return math.NaN()
+    }
+    return float64(msg.MaxPosGrade) / 100
+}
+
+// max_neg_grade in %
+func (msg *MsgSession) MaxNegGradeScaled() float64 {
+    if !is_valid_int16(msg.MaxNegGrade) {
+        return math.NaN()
+    }
+    return float64(msg.MaxNegGrade) / 100
+}
+
+// total_moving_time in s
+func (msg *MsgSession) TotalMovingTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalMovingTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalMovingTime) / 1000
+}
+
+// avg_pos_vertical_speed in m/s
+func (msg *MsgSession) AvgPosVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.AvgPosVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgPosVerticalSpeed) / 1000
+}
+
+// avg_neg_vertical_speed in m/s
+func (msg *MsgSession) AvgNegVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.AvgNegVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgNegVerticalSpeed) / 1000
+}
+
+// max_pos_vertical_speed in m/s
+func (msg *MsgSession) MaxPosVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.MaxPosVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxPosVerticalSpeed) / 1000
+}
+
+// max_neg_vertical_speed in m/s
+func (msg *MsgSession) MaxNegVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.MaxNegVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxNegVerticalSpeed) / 1000
+}
+
+// time_in_hr_zone in s
+func (msg *MsgSession) TimeInHRZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInHRZone))
+    for i, v := range msg.TimeInHRZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_speed_zone in s
+func (msg *MsgSession) TimeInSpeedZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInSpeedZone))
+    for i, v := range msg.TimeInSpeedZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_cadence_zone in s
+func (msg *MsgSession) TimeInCadenceZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInCadenceZone))
+    for i, v := range msg.TimeInCadenceZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_power_zone in s
+func (msg *MsgSession) TimeInPowerZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInPowerZone))
+    for i, v := range msg.TimeInPowerZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// avg_lap_time in s
+func (msg *MsgSession) AvgLapTimeScaled() float64 {
+    if !is_valid_uint32(msg.AvgLapTime) {
+        return math.NaN()
+    }
+    return float64(msg.AvgLapTime) / 1000
+}
+
+// min_altitude in m
+func (msg *MsgSession) MinAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.MinAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.MinAltitude) - 2500) / 5
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgSession) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// start_time as a time.Time; see DateTime()
+func (msg *MsgSession) StartTimeTime() time.Time {
+    return DateTime(msg.StartTime)
+}
+
+// start_position_lat and start_position_long in degrees
+func (msg *MsgSession) StartPosition() Position {
+    return PositionFromSemicircles(msg.StartPositionLat, msg.StartPositionLong)
+}
+
+// nec_lat and nec_long in degrees
+func (msg *MsgSession) NecPosition() Position {
+    return PositionFromSemicircles(msg.NecLat, msg.NecLong)
+}
+
+// swc_lat and swc_long in degrees
+func (msg *MsgSession) SwcPosition() Position {
+    return PositionFromSemicircles(msg.SwcLat, msg.SwcLong)
+}
+
+func (msg *MsgSession) Text() string {
+    txt := "session"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_enum(byte(msg.Event)) {
+        txt += fmt.Sprintf(" evt %s", msg.Event)
+    }
+    if is_valid_enum(byte(msg.EventType)) {
+        txt += fmt.Sprintf(" evttyp %s", msg.EventType)
+    }
+    if is_valid_uint32(msg.StartTime) {
+        txt += fmt.Sprintf(" starttime %d", msg.StartTime)
+    }
+    if is_valid_int32(msg.StartPositionLat) {
+        txt += fmt.Sprintf(" startposlat %d", msg.StartPositionLat)
+    }
+    if is_valid_int32(msg.StartPositionLong) {
+        txt += fmt.Sprintf(" startposlong %d", msg.StartPositionLong)
+    }
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_enum(byte(msg.SubSport)) {
+        txt += fmt.Sprintf(" subsport %s", msg.SubSport)
+    }
+    if is_valid_uint32(msg.TotalElapsedTime) {
+        txt += fmt.Sprintf(" totalelapsedtime %g", msg.TotalElapsedTimeScaled())
+    }
+    if is_valid_uint32(msg.TotalTimerTime) {
+        txt += fmt.Sprintf(" totaltimertime %g", msg.TotalTimerTimeScaled())
+    }
+    if is_valid_uint32(msg.TotalDistance) {
+        txt += fmt.Sprintf(" totaldist %g", msg.TotalDistanceScaled())
+    }
+    if is_valid_uint32(msg.TotalCycles) {
+        txt += fmt.Sprintf(" totalcycles %d", msg.TotalCycles)
+    }
+    if is_valid_uint16(msg.TotalCalories) {
+        txt += fmt.Sprintf(" totalcals %d", msg.TotalCalories)
+    }
+    if is_valid_uint16(msg.TotalFatCalories) {
+        txt += fmt.Sprintf(" totalfatcals %d", msg.TotalFatCalories)
+    }
+    if is_valid_uint16(msg.AvgSpeed) {
+        txt += fmt.Sprintf(" avgspeed %g", msg.AvgSpeedScaled())
+    }
+    if is_valid_uint16(msg.MaxSpeed) {
+        txt += fmt.Sprintf(" maxspeed %g", msg.MaxSpeedScaled())
+    }
+    if is_valid_uint8(msg.AvgHeartRate) {
+        txt += fmt.Sprintf(" avgheartrate %d", msg.AvgHeartRate)
+    }
+    if is_valid_uint8(msg.MaxHeartRate) {
+        txt += fmt.Sprintf(" maxheartrate %d", msg.MaxHeartRate)
+    }
+    if is_valid_uint8(msg.AvgCadence) {
+        txt += fmt.Sprintf(" avgcadence %d", msg.AvgCadence)
+    }
+    if is_valid_uint8(msg.MaxCadence) {
+        txt += fmt.Sprintf(" maxcadence %d", msg.MaxCadence)
+    }
+    if is_valid_uint16(msg.AvgPower) {
+        txt += fmt.Sprintf(" avgpower %d", msg.AvgPower)
+    }
+    if is_valid_uint16(msg.MaxPower) {
+        txt += fmt.Sprintf(" maxpower %d", msg.MaxPower)
+    }
+    if is_valid_uint16(msg.TotalAscent) {
+        txt += fmt.Sprintf(" totalascent %d", msg.TotalAscent)
+    }
+    if is_valid_uint16(msg.TotalDescent) {
+        txt += fmt.Sprintf(" totaldescent %d", msg.TotalDescent)
+    }
+    if is_valid_uint8(msg.TotalTrainingEffect) {
+        txt += fmt.Sprintf(" totaltrainingeffect %g", msg.TotalTrainingEffectScaled())
+    }
+    if is_valid_uint16(msg.FirstLapIndex) {
+        txt += fmt.Sprintf(" firstlapidx %d", msg.FirstLapIndex)
+    }
+    if is_valid_uint16(msg.NumLaps) {
+        txt += fmt.Sprintf(" numlaps %d", msg.NumLaps)
+    }
+    if is_valid_uint8(msg.EventGroup) {
+        txt += fmt.Sprintf(" evtgrp %d", msg.EventGroup)
+    }
+    if is_valid_enum(byte(msg.Trigger)) {
+        txt += fmt.Sprintf(" trigger %s", msg.Trigger)
+    }
+    if is_valid_int32(msg.NecLat) {
+        txt += fmt.Sprintf(" neclat %d", msg.NecLat)
+    }
+    if is_valid_int32(msg.NecLong) {
+        txt += fmt.Sprintf(" neclong %d", msg.NecLong)
+    }
+    if is_valid_int32(msg.SwcLat) {
+        txt += fmt.Sprintf(" swclat %d", msg.SwcLat)
+    }
+    if is_valid_int32(msg.SwcLong) {
+        txt += fmt.Sprintf(" swclong %d", msg.SwcLong)
+    }
+    if is_valid_uint16(msg.NormalizedPower) {
+        txt += fmt.Sprintf(" normalizedpower %d", msg.NormalizedPower)
+    }
+    if is_valid_uint16(msg.TrainingStressScore) {
+        txt += fmt.Sprintf(" trainingstressscore %g", msg.TrainingStressScoreScaled())
+    }
+    if is_valid_uint16(msg.IntensityFactor) {
+        txt += fmt.Sprintf(" intensityfactor %g", msg.IntensityFactorScaled())
+    }
+    if is_valid_uint16(msg.LeftRightBalance) {
+        txt += fmt.Sprintf(" leftrightbalance %d", msg.LeftRightBalance)
+    }
+    if is_valid_uint32(msg.AvgStrokeCount) {
+        txt += fmt.Sprintf(" avgstrokecount %g", msg.AvgStrokeCountScaled())
+    }
+    if is_valid_uint16(msg.AvgStrokeDistance) {
+        txt += fmt.Sprintf(" avgstrokedist %g", msg.AvgStrokeDistanceScaled())
+    }
+    if is_valid_enum(byte(msg.SwimStroke)) {
+        txt += fmt.Sprintf(" swimstroke %s", msg.SwimStroke)
+    }
+    if is_valid_uint16(msg.PoolLength) {
+        txt += fmt.Sprintf(" poollen %g", msg.PoolLengthScaled())
+    }
+    if is_valid_enum(byte(msg.PoolLengthUnit)) {
+        txt += fmt.Sprintf(" poollenunit %s", msg.PoolLengthUnit)
+    }
+    if is_valid_uint16(msg.NumActiveLengths) {
+        txt += fmt.Sprintf(" numactivelens %d", msg.NumActiveLengths)
+    }
+    if is_valid_uint32(msg.TotalWork) {
+        txt += fmt.Sprintf(" totalwork %d", msg.TotalWork)
+    }
+    if is_valid_uint16(msg.AvgAltitude) {
+        txt += fmt.Sprintf(" avgalt %g", msg.AvgAltitudeScaled())
+    }
+    if is_valid_uint16(msg.MaxAltitude) {
+        txt += fmt.Sprintf(" maxalt %g", msg.MaxAltitudeScaled())
+    }
+    if is_valid_uint8(msg.GPSAccuracy) {
+        txt += fmt.Sprintf(" gpsaccuracy %d", msg.GPSAccuracy)
+    }
+    if is_valid_int16(msg.AvgGrade) {
+        txt += fmt.Sprintf(" avggrade %g", msg.AvgGradeScaled())
+    }
+    if is_valid_int16(msg.AvgPosGrade) {
+        txt += fmt.Sprintf(" avgposgrade %g", msg.AvgPosGradeScaled())
+    }
+    if is_valid_int16(msg.AvgNegGrade) {
+        txt += fmt.Sprintf(" avgneggrade %g", msg.AvgNegGradeScaled())
+    }
+    if is_valid_int16(msg.MaxPosGrade) {
+        txt += fmt.Sprintf(" maxposgrade %g", msg.MaxPosGradeScaled())
+    }
+    if is_valid_int16(msg.MaxNegGrade) {
+        txt += fmt.Sprintf(" maxneggrade %g", msg.MaxNegGradeScaled())
+    }
+    if is_valid_int8(msg.AvgTemperature) {
+        txt += fmt.Sprintf(" avgtemp %d", msg.AvgTemperature)
+    }
+    if is_valid_int8(msg.MaxTemperature) {
+        txt += fmt.Sprintf(" maxtemp %d", msg.MaxTemperature)
+    }
+    if is_valid_uint32(msg.TotalMovingTime) {
+        txt += fmt.Sprintf(" totalmovingtime %g", msg.TotalMovingTimeScaled())
+    }
+    if is_valid_int16(msg.AvgPosVerticalSpeed) {
+        txt += fmt.Sprintf(" avgposvertspeed %g", msg.AvgPosVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.AvgNegVerticalSpeed) {
+        txt += fmt.Sprintf(" avgnegvertspeed %g", msg.AvgNegVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.MaxPosVerticalSpeed) {
+        txt += fmt.Sprintf(" maxposvertspeed %g", msg.MaxPosVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.MaxNegVerticalSpeed) {
+        txt += fmt.Sprintf(" maxnegvertspeed %g", msg.MaxNegVerticalSpeedScaled())
+    }
+    if is_valid_uint8(msg.MinHeartRate) {
+        txt += fmt.Sprintf(" minheartrate %d", msg.MinHeartRate)
+    }
+    if len(msg.TimeInHRZone) > 0 {
+        txt += fmt.Sprintf(" timeinhrzone %g", msg.TimeInHRZoneScaled())
+    }
+    if len(msg.TimeInSpeedZone) > 0 {
+        txt += fmt.Sprintf(" timeinspeedzone %g", msg.TimeInSpeedZoneScaled())
+    }
+    if len(msg.TimeInCadenceZone) > 0 {
+        txt += fmt.Sprintf(" timeincadencezone %g", msg.TimeInCadenceZoneScaled())
+    }
+    if len(msg.TimeInPowerZone) > 0 {
+        txt += fmt.Sprintf(" timeinpowerzone %g", msg.TimeInPowerZoneScaled())
+    }
+    if is_valid_uint32(msg.AvgLapTime) {
+        txt += fmt.Sprintf(" avglaptime %g", msg.AvgLapTimeScaled())
+    }
+    if is_valid_uint16(msg.BestLapIndex) {
+        txt += fmt.Sprintf(" bestlapidx %d", msg.BestLapIndex)
+    }
+    if is_valid_uint16(msg.MinAltitude) {
+        txt += fmt.Sprintf(" minalt %g", msg.MinAltitudeScaled())
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSession) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "event": return is_valid_enum(byte(msg.Event))
+    case "event_type": return is_valid_enum(byte(msg.EventType))
+    case "start_time": return is_valid_uint32(msg.StartTime)
+    case "start_position_lat": return is_valid_int32(msg.StartPositionLat)
+    case "start_position_long": return is_valid_int32(msg.StartPositionLong)
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "sub_sport": return is_valid_enum(byte(msg.SubSport))
+    case "total_elapsed_time": return is_valid_uint32(msg.TotalElapsedTime)
+    case "total_timer_time": return is_valid_uint32(msg.TotalTimerTime)
+    case "total_distance": return is_valid_uint32(msg.TotalDistance)
+    case "total_cycles": return is_valid_uint32(msg.TotalCycles)
+    case "total_calories": return is_valid_uint16(msg.TotalCalories)
+    case "total_fat_calories": return is_valid_uint16(msg.TotalFatCalories)
+    case "avg_speed": return is_valid_uint16(msg.AvgSpeed)
+    case "max_speed": return is_valid_uint16(msg.MaxSpeed)
+    case "avg_heart_rate": return is_valid_uint8(msg.AvgHeartRate)
+    case "max_heart_rate": return is_valid_uint8(msg.MaxHeartRate)
+    case "avg_cadence": return is_valid_uint8(msg.AvgCadence)
+    case "max_cadence": return is_valid_uint8(msg.MaxCadence)
+    case "avg_power": return is_valid_uint16(msg.AvgPower)
+    case "max_power": return is_valid_uint16(msg.MaxPower)
+    case "total_ascent": return is_valid_uint16(msg.TotalAscent)
+    case "total_descent": return is_valid_uint16(msg.TotalDescent)
+    case "total_training_effect": return is_valid_uint8(msg.TotalTrainingEffect)
+    case "first_lap_index": return is_valid_uint16(msg.FirstLapIndex)
+    case "num_laps": return is_valid_uint16(msg.NumLaps)
+    case "event_group": return is_valid_uint8(msg.EventGroup)
+    case "trigger": return is_valid_enum(byte(msg.Trigger))
+    case "nec_lat": return is_valid_int32(msg.NecLat)
+    case "nec_long": return is_valid_int32(msg.NecLong)
+    case "swc_lat": return is_valid_int32(msg.SwcLat)
+    case "swc_long": return is_valid_int32(msg.SwcLong)
+    case "normalized_power": return is_valid_uint16(msg.NormalizedPower)
+    case "training_stress_score": return is_valid_uint16(msg.TrainingStressScore)
+    case "intensity_factor": return is_valid_uint16(msg.IntensityFactor)
+    case "left_right_balance": return is_valid_uint16(msg.LeftRightBalance)
+    case "avg_stroke_count": return is_valid_uint32(msg.AvgStrokeCount)
+    case "avg_stroke_distance": return is_valid_uint16(msg.AvgStrokeDistance)
+    case "swim_stroke": return is_valid_enum(byte(msg.SwimStroke))
+    case "pool_length": return is_valid_uint16(msg.PoolLength)
+    case "pool_length_unit": return is_valid_enum(byte(msg.PoolLengthUnit))
+    case "num_active_lengths": return is_valid_uint16(msg.NumActiveLengths)
+    case "total_work": return is_valid_uint32(msg.TotalWork)
+    case "avg_altitude": return is_valid_uint16(msg.AvgAltitude)
+    case "max_altitude": return is_valid_uint16(msg.MaxAltitude)
+    case "gps_accuracy": return is_valid_uint8(msg.GPSAccuracy)
+    case "avg_grade": return is_valid_int16(msg.AvgGrade)
+    case "avg_pos_grade": return is_valid_int16(msg.AvgPosGrade)
+    case "avg_neg_grade": return is_valid_int16(msg.AvgNegGrade)
+    case "max_pos_grade": return is_valid_int16(msg.MaxPosGrade)
+    case "max_neg_grade": return is_valid_int16(msg.MaxNegGrade)
+    case "avg_temperature": return is_valid_int8(msg.AvgTemperature)
+    case "max_temperature": return is_valid_int8(msg.MaxTemperature)
+    case "total_moving_time": return is_valid_uint32(msg.TotalMovingTime)
+    case "avg_pos_vertical_speed": return is_valid_int16(msg.AvgPosVerticalSpeed)
+    case "avg_neg_vertical_speed": return is_valid_int16(msg.AvgNegVerticalSpeed)
+    case "max_pos_vertical_speed": return is_valid_int16(msg.MaxPosVerticalSpeed)
+    case "max_neg_vertical_speed": return is_valid_int16(msg.MaxNegVerticalSpeed)
+    case "min_heart_rate": return is_valid_uint8(msg.MinHeartRate)
+    case "time_in_hr_zone": return len(msg.TimeInHRZone) > 0
+    case "time_in_speed_zone": return len(msg.TimeInSpeedZone) > 0
+    case "time_in_cadence_zone": return len(msg.TimeInCadenceZone) > 0
+    case "time_in_power_zone": return len(msg.TimeInPowerZone) > 0
+    case "avg_lap_time": return is_valid_uint32(msg.AvgLapTime)
+    case "best_lap_index": return is_valid_uint16(msg.BestLapIndex)
+    case "min_altitude": return is_valid_uint16(msg.MinAltitude)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSession) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "event":
+        if is_valid_enum(byte(msg.Event)) {
+            return float64(msg.Event)
+        }
+    case "event_type":
+        if is_valid_enum(byte(msg.EventType)) {
+            return float64(msg.EventType)
+        }
+    case "start_time":
+        if is_valid_uint32(msg.StartTime) {
+            return float64(msg.StartTime)
+        }
+    case "start_position_lat":
+        if is_valid_int32(msg.StartPositionLat) {
+            return float64(msg.StartPositionLat)
+        }
+    case "start_position_long":
+        if is_valid_int32(msg.StartPositionLong) {
+            return float64(msg.StartPositionLong)
+        }
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "sub_sport":
+        if is_valid_enum(byte(msg.SubSport)) {
+            return float64(msg.SubSport)
+        }
+    case "total_elapsed_time":
+        return msg.TotalElapsedTimeScaled()
+    case "total_timer_time":
+        return msg.TotalTimerTimeScaled()
+    case "total_distance":
+        return msg.TotalDistanceScaled()
+    case "total_cycles":
+        if is_valid_uint32(msg.TotalCycles) {
+            return float64(msg.TotalCycles)
+        }
+    case "total_calories":
+        if is_valid_uint16(msg.TotalCalories) {
+            return float64(msg.TotalCalories)
+        }
+    case "total_fat_calories":
+        if is_valid_uint16(msg.TotalFatCalories) {
+            return float64(msg.TotalFatCalories)
+        }
+    case "avg_speed":
+        return msg.AvgSpeedScaled()
+    case "max_speed":
+        return msg.MaxSpeedScaled()
+    case "avg_heart_rate":
+        if is_valid_uint8(msg.AvgHeartRate) {
+            return float64(msg.AvgHeartRate)
+        }
+    case "max_heart_rate":
+        if is_valid_uint8(msg.MaxHeartRate) {
+            return float64(msg.MaxHeartRate)
+        }
+    case "avg_cadence":
+        if is_valid_uint8(msg.AvgCadence) {
+            return float64(msg.AvgCadence)
+        }
+    case "max_cadence":
+        if is_valid_uint8(msg.MaxCadence) {
+            return float64(msg.MaxCadence)
+        }
+    case "avg_power":
+        if is_valid_uint16(msg.AvgPower) {
+            return float64(msg.AvgPower)
+        }
+    case "max_power":
+        if is_valid_uint16(msg.MaxPower) {
+            return float64(msg.MaxPower)
+        }
+    case "total_ascent":
+        if is_valid_uint16(msg.TotalAscent) {
+            return float64(msg.TotalAscent)
+        }
+    case "total_descent":
+        if is_valid_uint16(msg.TotalDescent) {
+            return float64(msg.TotalDescent)
+        }
+    case "total_training_effect":
+        return msg.TotalTrainingEffectScaled()
+    case "first_lap_index":
+        if is_valid_uint16(msg.FirstLapIndex) {
+            return float64(msg.FirstLapIndex)
+        }
+    case "num_laps":
+        if is_valid_uint16(msg.NumLaps) {
+            return float64(msg.NumLaps)
+        }
+    case "event_group":
+        if is_valid_uint8(msg.EventGroup) {
+            return float64(msg.EventGroup)
+        }
+    case "trigger":
+        if is_valid_enum(byte(msg.Trigger)) {
+            return float64(msg.Trigger)
+        }
+    case "nec_lat":
+        if is_valid_int32(msg.NecLat) {
+            return float64(msg.NecLat)
+        }
+    case "nec_long":
+        if is_valid_int32(msg.NecLong) {
+            return float64(msg.NecLong)
+        }
+    case "swc_lat":
+        if is_valid_int32(msg.SwcLat) {
+            return float64(msg.SwcLat)
+        }
+    case "swc_long":
+        if is_valid_int32(msg.SwcLong) {
+            return float64(msg.SwcLong)
+        }
+    case "normalized_power":
+        if is_valid_uint16(msg.NormalizedPower) {
+            return float64(msg.NormalizedPower)
+        }
+    case "training_stress_score":
+        return msg.TrainingStressScoreScaled()
+    case "intensity_factor":
+        return msg.IntensityFactorScaled()
+    case "left_right_balance":
+        if is_valid_uint16(msg.LeftRightBalance) {
+            return float64(msg.LeftRightBalance)
+        }
+    case "avg_stroke_count":
+        return msg.AvgStrokeCountScaled()
+    case "avg_stroke_distance":
+        return msg.AvgStrokeDistanceScaled()
+    case "swim_stroke":
+        if is_valid_enum(byte(msg.SwimStroke)) {
+            return float64(msg.SwimStroke)
+        }
+    case "pool_length":
+        return msg.PoolLengthScaled()
+    case "pool_length_unit":
+        if is_valid_enum(byte(msg.PoolLengthUnit)) {
+            return float64(msg.PoolLengthUnit)
+        }
+    case "num_active_lengths":
+        if is_valid_uint16(msg.NumActiveLengths) {
+            return float64(msg.NumActiveLengths)
+        }
+    case "total_work":
+        if is_valid_uint32(msg.TotalWork) {
+            return float64(msg.TotalWork)
+        }
+    case "avg_altitude":
+        return msg.AvgAltitudeScaled()
+    case "max_altitude":
+        return msg.MaxAltitudeScaled()
+    case "gps_accuracy":
+        if is_valid_uint8(msg.GPSAccuracy) {
+            return float64(msg.GPSAccuracy)
+        }
+    case "avg_grade":
+        return msg.AvgGradeScaled()
+    case "avg_pos_grade":
+        return msg.AvgPosGradeScaled()
+    case "avg_neg_grade":
+        return msg.AvgNegGradeScaled()
+    case "max_pos_grade":
+        return msg.MaxPosGradeScaled()
+    case "max_neg_grade":
+        return msg.MaxNegGradeScaled()
+    case "avg_temperature":
+        if is_valid_int8(msg.AvgTemperature) {
+            return float64(msg.AvgTemperature)
+        }
+    case "max_temperature":
+        if is_valid_int8(msg.MaxTemperature) {
+            return float64(msg.MaxTemperature)
+        }
+    case "total_moving_time":
+        return msg.TotalMovingTimeScaled()
+    case "avg_pos_vertical_speed":
+        return msg.AvgPosVerticalSpeedScaled()
+    case "avg_neg_vertical_speed":
+        return msg.AvgNegVerticalSpeedScaled()
+    case "max_pos_vertical_speed":
+        return msg.MaxPosVerticalSpeedScaled()
+    case "max_neg_vertical_speed":
+        return msg.MaxNegVerticalSpeedScaled()
+    case "min_heart_rate":
+        if is_valid_uint8(msg.MinHeartRate) {
+            return float64(msg.MinHeartRate)
+        }
+    case "avg_lap_time":
+        return msg.AvgLapTimeScaled()
+    case "best_lap_index":
+        if is_valid_uint16(msg.BestLapIndex) {
+            return float64(msg.BestLapIndex)
+        }
+    case "min_altitude":
+        return msg.MinAltitudeScaled()
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgSession) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "start_position_lat": return "semicircles"
+    case "start_position_long": return "semicircles"
+    case "total_elapsed_time": return "s"
+    case "total_timer_time": return "s"
+    case "total_distance": return "m"
+    case "total_cycles": return "cycles"
+    case "total_calories": return "kcal"
+    case "total_fat_calories": return "kcal"
+    case "avg_speed": return "m/s"
+    case "max_speed": return "m/s"
+    case "avg_heart_rate": return "bpm"
+    case "max_heart_rate": return "bpm"
+    case "avg_cadence": return "rpm"
+    case "max_cadence": return "rpm"
+    case "avg_power": return "watts"
+    case "max_power": return "watts"
+    case "total_ascent": return "m"
+    case "total_descent": return "m"
+    case "nec_lat": return "semicircles"
+    case "nec_long": return "semicircles"
+    case "swc_lat": return "semicircles"
+    case "swc_long": return "semicircles"
+    case "normalized_power": return "watts"
+    case "training_stress_score": return "tss"
+    case "intensity_factor": return "if"
+    case "avg_stroke_count": return "strokes/lap"
+    case "avg_stroke_distance": return "m"
+    case "pool_length": return "m"
+    case "num_active_lengths": return "lengths"
+    case "total_work": return "J"
+    case "avg_altitude": return "m"
+    case "max_altitude": return "m"
+    case "gps_accuracy": return "m"
+    case "avg_grade": return "%"
+    case "avg_pos_grade": return "%"
+    case "avg_neg_grade": return "%"
+    case "max_pos_grade": return "%"
+    case "max_neg_grade": return "%"
+    case "avg_temperature": return "C"
+    case "max_temperature": return "C"
+    case "total_moving_time": return "s"
+    case "avg_pos_vertical_speed": return "m/s"
+    case "avg_neg_vertical_speed": return "m/s"
+    case "max_pos_vertical_speed": return "m/s"
+    case "max_neg_vertical_speed": return "m/s"
+    case "min_heart_rate": return "bpm"
+    case "time_in_hr_zone": return "s"
+    case "time_in_speed_zone": return "s"
+    case "time_in_cadence_zone": return "s"
+    case "time_in_power_zone": return "s"
+    case "avg_lap_time": return "s"
+    case "min_altitude": return "m"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgSession) GlobalNum() uint16 {
+    return 18
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSession) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "event", Num: 0, BaseType: 0,
+            Raw: msg.Event, Scaled: msg.Scaled("event"),
+            Units: "", Valid: is_valid_enum(byte(msg.Event))}
+    case 1:
+        return Value{Name: "event_type", Num: 1, BaseType: 0,
+            Raw: msg.EventType, Scaled: msg.Scaled("event_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.EventType))}
+    case 2:
+        return Value{Name: "start_time", Num: 2, BaseType: 6,
+            Raw: msg.StartTime, Scaled: msg.Scaled("start_time"),
+            Units: "", Valid: is_valid_uint32(msg.StartTime)}
+    case 3:
+        return Value{Name: "start_position_lat", Num: 3, BaseType: 5,
+            Raw: msg.StartPositionLat, Scaled: msg.Scaled("start_position_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.StartPositionLat)}
+    case 4:
+        return Value{Name: "start_position_long", Num: 4, BaseType: 5,
+            Raw: msg.StartPositionLong, Scaled: msg.Scaled("start_position_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.StartPositionLong)}
+    case 5:
+        return Value{Name: "sport", Num: 5, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 6:
+        return Value{Name: "sub_sport", Num: 6, BaseType: 0,
+            Raw: msg.SubSport, Scaled: msg.Scaled("sub_sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.SubSport))}
+    case 7:
+        return Value{Name: "total_elapsed_time", Num: 7, BaseType: 6,
+            Raw: msg.TotalElapsedTime, Scaled: msg.Scaled("total_elapsed_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalElapsedTime)}
+    case 8:
+        return Value{Name: "total_timer_time", Num: 8, BaseType: 6,
+            Raw: msg.TotalTimerTime, Scaled: msg.Scaled("total_timer_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalTimerTime)}
+    case 9:
+        return Value{Name: "total_distance", Num: 9, BaseType: 6,
+            Raw: msg.TotalDistance, Scaled: msg.Scaled("total_distance"),
+            Units: "m", Valid: is_valid_uint32(msg.TotalDistance)}
+    case 10:
+        return Value{Name: "total_cycles", Num: 10, BaseType: 6,
+            Raw: msg.TotalCycles, Scaled: msg.Scaled("total_cycles"),
+            Units: "cycles", Valid: is_valid_uint32(msg.TotalCycles)}
+    case 11:
+        return Value{Name: "total_calories", Num: 11, BaseType: 4,
+            Raw: msg.TotalCalories, Scaled: msg.Scaled("total_calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.TotalCalories)}
+    case 13:
+        return Value{Name: "total_fat_calories", Num: 13, BaseType: 4,
+            Raw: msg.TotalFatCalories, Scaled: msg.Scaled("total_fat_calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.TotalFatCalories)}
+    case 14:
+        return Value{Name: "avg_speed", Num: 14, BaseType: 4,
+            Raw: msg.AvgSpeed, Scaled: msg.Scaled("avg_speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.AvgSpeed)}
+    case 15:
+        return Value{Name: "max_speed", Num: 15, BaseType: 4,
+            Raw: msg.MaxSpeed, Scaled: msg.Scaled("max_speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.MaxSpeed)}
+    case 16:
+        return Value{Name: "avg_heart_rate", Num: 16, BaseType: 2,
+            Raw: msg.AvgHeartRate, Scaled: msg.Scaled("avg_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.AvgHeartRate)}
+    case 17:
+        return Value{Name: "max_heart_rate", Num: 17, BaseType: 2,
+            Raw: msg.MaxHeartRate, Scaled: msg.Scaled("max_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.MaxHeartRate)}
+    case 18:
+        return Value{Name: "avg_cadence", Num: 18, BaseType: 2,
+            Raw: msg.AvgCadence, Scaled: msg.Scaled("avg_cadence"),
+            Units: "rpm", Valid: is_valid_uint8(msg.AvgCadence)}
+    case 19:
+        return Value{Name: "max_cadence", Num: 19, BaseType: 2,
+            Raw: msg.MaxCadence, Scaled: msg.Scaled("max_cadence"),
+            Units: "rpm", Valid: is_valid_uint8(msg.MaxCadence)}
+    case 20:
+        return Value{Name: "avg_power", Num: 20, BaseType: 4,
+            Raw: msg.AvgPower, Scaled: msg.Scaled("avg_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.AvgPower)}
+    case 21:
+        return Value{Name: "max_power", Num: 21, BaseType: 4,
+            Raw: msg.MaxPower, Scaled: msg.Scaled("max_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.MaxPower)}
+    case 22:
+        return Value{Name: "total_ascent", Num: 22, BaseType: 4,
+            Raw: msg.TotalAscent, Scaled: msg.Scaled("total_ascent"),
+            Units: "m", Valid: is_valid_uint16(msg.TotalAscent)}
+    case 23:
+        return Value{Name: "total_descent", Num: 23, BaseType: 4,
+            Raw: msg.TotalDescent, Scaled: msg.Scaled("total_descent"),
+            Units: "m", Valid: is_valid_uint16(msg.TotalDescent)}
+    case 24:
+        return Value{Name: "total_training_effect", Num: 24, BaseType: 2,
+            Raw: msg.TotalTrainingEffect, Scaled: msg.Scaled("total_training_effect"),
+            Units: "", Valid: is_valid_uint8(msg.TotalTrainingEffect)}
+    case 25:
+        return Value{Name: "first_lap_index", Num: 25, BaseType: 4,
+            Raw: msg.FirstLapIndex, Scaled: msg.Scaled("first_lap_index"),
+            Units: "", Valid: is_valid_uint16(msg.FirstLapIndex)}
+    case 26:
+        return Value{Name: "num_laps", Num: 26, BaseType: 4,
+            Raw: msg.NumLaps, Scaled: msg.Scaled("num_laps"),
+            Units: "", Valid: is_valid_uint16(msg.NumLaps)}
+    case 27:
+        return Value{Name: "event_group", Num: 27, BaseType: 2,
+            Raw: msg.EventGroup, Scaled: msg.Scaled("event_group"),
+            Units: "", Valid: is_valid_uint8(msg.EventGroup)}
+    case 28:
+        return Value{Name: "trigger", Num: 28, BaseType: 0,
+            Raw: msg.Trigger, Scaled: msg.Scaled("trigger"),
+            Units: "", Valid: is_valid_enum(byte(msg.Trigger))}
+    case 29:
+        return Value{Name: "nec_lat", Num: 29, BaseType: 5,
+            Raw: msg.NecLat, Scaled: msg.Scaled("nec_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.NecLat)}
+    case 30:
+        return Value{Name: "nec_long", Num: 30, BaseType: 5,
+            Raw: msg.NecLong, Scaled: msg.Scaled("nec_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.NecLong)}
+    case 31:
+        return Value{Name: "swc_lat", Num: 31, BaseType: 5,
+            Raw: msg.SwcLat, Scaled: msg.Scaled("swc_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.SwcLat)}
+    case 32:
+        return Value{Name: "swc_long", Num: 32, BaseType: 5,
+            Raw: msg.SwcLong, Scaled: msg.Scaled("swc_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.SwcLong)}
+    case 34:
+        return Value{Name: "normalized_power", Num: 34, BaseType: 4,
+            Raw: msg.NormalizedPower, Scaled: msg.Scaled("normalized_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.NormalizedPower)}
+    case 35:
+        return Value{Name: "training_stress_score", Num: 35, BaseType: 4,
+            Raw: msg.TrainingStressScore, Scaled: msg.Scaled("training_stress_score"),
+            Units: "tss", Valid: is_valid_uint16(msg.TrainingStressScore)}
+    case 36:
+        return Value{Name: "intensity_factor", Num: 36, BaseType: 4,
+            Raw: msg.IntensityFactor, Scaled: msg.Scaled("intensity_factor"),
+            Units: "if", Valid: is_valid_uint16(msg.IntensityFactor)}
+    case 37:
+        return Value{Name: "left_right_balance", Num: 37, BaseType: 4,
+            Raw: msg.LeftRightBalance, Scaled: msg.Scaled("left_right_balance"),
+            Units: "", Valid: is_valid_uint16(msg.LeftRightBalance)}
+    case 41:
+        return Value{Name: "avg_stroke_count", Num: 41, BaseType: 6,
+            Raw: msg.AvgStrokeCount, Scaled: msg.Scaled("avg_stroke_count"),
+            Units: "strokes/lap", Valid: is_valid_uint32(msg.AvgStrokeCount)}
+    case 42:
+        return Value{Name: "avg_stroke_distance", Num: 42, BaseType: 4,
+            Raw: msg.AvgStrokeDistance, Scaled: msg.Scaled("avg_stroke_distance"),
+            Units: "m", Valid: is_valid_uint16(msg.AvgStrokeDistance)}
+    case 43:
+        return Value{Name: "swim_stroke", Num: 43, BaseType: 0,
+            Raw: msg.SwimStroke, Scaled: msg.Scaled("swim_stroke"),
+            Units: "", Valid: is_valid_enum(byte(msg.SwimStroke))}
+    case 44:
+        return Value{Name: "pool_length", Num: 44, BaseType: 4,
+            Raw: msg.PoolLength, Scaled: msg.Scaled("pool_length"),
+            Units: "m", Valid: is_valid_uint16(msg.PoolLength)}
+    case 46:
+        return Value{Name: "pool_length_unit", Num: 46, BaseType: 0,
+            Raw: msg.PoolLengthUnit, Scaled: msg.Scaled("pool_length_unit"),
+            Units: "", Valid: is_valid_enum(byte(msg.PoolLengthUnit))}
+    case 47:
+        return Value{Name: "num_active_lengths", Num: 47, BaseType: 4,
+            Raw: msg.NumActiveLengths, Scaled: msg.Scaled("num_active_lengths"),
+            Units: "lengths", Valid: is_valid_uint16(msg.NumActiveLengths)}
+    case 48:
+        return Value{Name: "total_work", Num: 48, BaseType: 6,
+            Raw: msg.TotalWork, Scaled: msg.Scaled("total_work"),
+            Units: "J", Valid: is_valid_uint32(msg.TotalWork)}
+    case 49:
+        return Value{Name: "avg_altitude", Num: 49, BaseType: 4,
+            Raw: msg.AvgAltitude, Scaled: msg.Scaled("avg_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.AvgAltitude)}
+    case 50:
+        return Value{Name: "max_altitude", Num: 50, BaseType: 4,
+            Raw: msg.MaxAltitude, Scaled: msg.Scaled("max_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.MaxAltitude)}
+    case 51:
+        return Value{Name: "gps_accuracy", Num: 51, BaseType: 2,
+            Raw: msg.GPSAccuracy, Scaled: msg.Scaled("gps_accuracy"),
+            Units: "m", Valid: is_valid_uint8(msg.GPSAccuracy)}
+    case 52:
+        return Value{Name: "avg_grade", Num: 52, BaseType: 3,
+            Raw: msg.AvgGrade, Scaled: msg.Scaled("avg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgGrade)}
+    case 53:
+        return Value{Name: "avg_pos_grade", Num: 53, BaseType: 3,
+            Raw: msg.AvgPosGrade, Scaled: msg.Scaled("avg_pos_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgPosGrade)}
+    case 54:
+        return Value{Name: "avg_neg_grade", Num: 54, BaseType: 3,
+            Raw: msg.AvgNegGrade, Scaled: msg.Scaled("avg_neg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgNegGrade)}
+    case 55:
+        return Value{Name: "max_pos_grade", Num: 55, BaseType: 3,
+            Raw: msg.MaxPosGrade, Scaled: msg.Scaled("max_pos_grade"),
+            Units: "%", Valid: is_valid_int16(msg.MaxPosGrade)}
+    case 56:
+        return Value{Name: "max_neg_grade", Num: 56, BaseType: 3,
+            Raw: msg.MaxNegGrade, Scaled: msg.Scaled("max_neg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.MaxNegGrade)}
+    case 57:
+        return Value{Name: "avg_temperature", Num: 57, BaseType: 1,
+            Raw: msg.AvgTemperature, Scaled: msg.Scaled("avg_temperature"),
+            Units: "C", Valid: is_valid_int8(msg.AvgTemperature)}
+    case 58:
+        return Value{Name: "max_temperature", Num: 58, BaseType: 1,
+            Raw: msg.MaxTemperature, Scaled: msg.Scaled("max_temperature"),
+            Units: "C", Valid: is_valid_int8(msg.MaxTemperature)}
+    case 59:
+        return Value{Name: "total_moving_time", Num: 59, BaseType: 6,
+            Raw: msg.TotalMovingTime, Scaled: msg.Scaled("total_moving_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalMovingTime)}
+    case 60:
+        return Value{Name: "avg_pos_vertical_speed", Num: 60, BaseType: 3,
+            Raw: msg.AvgPosVerticalSpeed, Scaled: msg.Scaled("avg_pos_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.AvgPosVerticalSpeed)}
+    case 61:
+        return Value{Name: "avg_neg_vertical_speed", Num: 61, BaseType: 3,
+            Raw: msg.AvgNegVerticalSpeed, Scaled: msg.Scaled("avg_neg_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.AvgNegVerticalSpeed)}
+    case 62:
+        return Value{Name: "max_pos_vertical_speed", Num: 62, BaseType: 3,
+            Raw: msg.MaxPosVerticalSpeed, Scaled: msg.Scaled("max_pos_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.MaxPosVerticalSpeed)}
+    case 63:
+        return Value{Name: "max_neg_vertical_speed", Num: 63, BaseType: 3,
+            Raw: msg.MaxNegVerticalSpeed, Scaled: msg.Scaled("max_neg_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.MaxNegVerticalSpeed)}
+    case 64:
+        return Value{Name: "min_heart_rate", Num: 64, BaseType: 2,
+            Raw: msg.MinHeartRate, Scaled: msg.Scaled("min_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.MinHeartRate)}
+    case 65:
+        return Value{Name: "time_in_hr_zone", Num: 65, BaseType: 6,
+            Raw: msg.TimeInHRZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInHRZone) > 0}
+    case 66:
+        return Value{Name: "time_in_speed_zone", Num: 66, BaseType: 6,
+            Raw: msg.TimeInSpeedZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInSpeedZone) > 0}
+    case 67:
+        return Value{Name: "time_in_cadence_zone", Num: 67, BaseType: 6,
+            Raw: msg.TimeInCadenceZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInCadenceZone) > 0}
+    case 68:
+        return Value{Name: "time_in_power_zone", Num: 68, BaseType: 6,
+            Raw: msg.TimeInPowerZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInPowerZone) > 0}
+    case 69:
+        return Value{Name: "avg_lap_time", Num: 69, BaseType: 6,
+            Raw: msg.AvgLapTime, Scaled: msg.Scaled("avg_lap_time"),
+            Units: "s", Valid: is_valid_uint32(msg.AvgLapTime)}
+    case 70:
+        return Value{Name: "best_lap_index", Num: 70, BaseType: 4,
+            Raw: msg.BestLapIndex, Scaled: msg.Scaled("best_lap_index"),
+            Units: "", Valid: is_valid_uint16(msg.BestLapIndex)}
+    case 71:
+        return Value{Name: "min_altitude", Num: 71, BaseType: 4,
+            Raw: msg.MinAltitude, Scaled: msg.Scaled("min_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.MinAltitude)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSession) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "timestamp": return msg.FieldByNum(253)
+    case "event": return msg.FieldByNum(0)
+    case "event_type": return msg.FieldByNum(1)
+    case "start_time": return msg.FieldByNum(2)
+    case "start_position_lat": return msg.FieldByNum(3)
+    case "start_position_long": return msg.FieldByNum(4)
+    case "sport": return msg.FieldByNum(5)
+    case "sub_sport": return msg.FieldByNum(6)
+    case "total_elapsed_time": return msg.FieldByNum(7)
+    case "total_timer_time": return msg.FieldByNum(8)
+    case "total_distance": return msg.FieldByNum(9)
+    case "total_cycles": return msg.FieldByNum(10)
+    case "total_calories": return msg.FieldByNum(11)
+    case "total_fat_calories": return msg.FieldByNum(13)
+    case "avg_speed": return msg.FieldByNum(14)
+    case "max_speed": return msg.FieldByNum(15)
+    case "avg_heart_rate": return msg.FieldByNum(16)
+    case "max_heart_rate": return msg.FieldByNum(17)
+    case "avg_cadence": return msg.FieldByNum(18)
+    case "max_cadence": return msg.FieldByNum(19)
+    case "avg_power": return msg.FieldByNum(20)
+    case "max_power": return msg.FieldByNum(21)
+    case "total_ascent": return msg.FieldByNum(22)
+    case "total_descent": return msg.FieldByNum(23)
+    case "total_training_effect": return msg.FieldByNum(24)
+    case "first_lap_index": return msg.FieldByNum(25)
+    case "num_laps": return msg.FieldByNum(26)
+    case "event_group": return msg.FieldByNum(27)
+    case "trigger": return msg.FieldByNum(28)
+    case "nec_lat": return msg.FieldByNum(29)
+    case "nec_long": return msg.FieldByNum(30)
+    case "swc_lat": return msg.FieldByNum(31)
+    case "swc_long": return msg.FieldByNum(32)
+    case "normalized_power": return msg.FieldByNum(34)
+    case "training_stress_score": return msg.FieldByNum(35)
+    case "intensity_factor": return msg.FieldByNum(36)
+    case "left_right_balance": return msg.FieldByNum(37)
+    case "avg_stroke_count": return msg.FieldByNum(41)
+    case "avg_stroke_distance": return msg.FieldByNum(42)
+    case "swim_stroke": return msg.FieldByNum(43)
+    case "pool_length": return msg.FieldByNum(44)
+    case "pool_length_unit": return msg.FieldByNum(46)
+    case "num_active_lengths": return msg.FieldByNum(47)
+    case "total_work": return msg.FieldByNum(48)
+    case "avg_altitude": return msg.FieldByNum(49)
+    case "max_altitude": return msg.FieldByNum(50)
+    case "gps_accuracy": return msg.FieldByNum(51)
+    case "avg_grade": return msg.FieldByNum(52)
+    case "avg_pos_grade": return msg.FieldByNum(53)
+    case "avg_neg_grade": return msg.FieldByNum(54)
+    case "max_pos_grade": return msg.FieldByNum(55)
+    case "max_neg_grade": return msg.FieldByNum(56)
+    case "avg_temperature": return msg.FieldByNum(57)
+    case "max_temperature": return msg.FieldByNum(58)
+    case "total_moving_time": return msg.FieldByNum(59)
+    case "avg_pos_vertical_speed": return msg.FieldByNum(60)
+    case "avg_neg_vertical_speed": return msg.FieldByNum(61)
+    case "max_pos_vertical_speed": return msg.FieldByNum(62)
+    case "max_neg_vertical_speed": return msg.FieldByNum(63)
+    case "min_heart_rate": return msg.FieldByNum(64)
+    case "time_in_hr_zone": return msg.FieldByNum(65)
+    case "time_in_speed_zone": return msg.FieldByNum(66)
+    case "time_in_cadence_zone": return msg.FieldByNum(67)
+    case "time_in_power_zone": return msg.FieldByNum(68)
+    case "avg_lap_time": return msg.FieldByNum(69)
+    case "best_lap_index": return msg.FieldByNum(70)
+    case "min_altitude": return msg.FieldByNum(71)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSession) Fields() []Value {
+    return fieldValues(msg, []byte{254, 253, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 34, 35, 36, 37, 41, 42, 43, 44, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 64, 65, 66, 67, 68, 69, 70, 71})
+}
+
+func NewMsgSession(def *FitDefinition, data []byte) (*MsgSession, error) {
+    msg := new(MsgSession)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Timestamp = invalid_uint32
+    msg.Event = Event(invalid_enum)
+    msg.EventType = EventType(invalid_enum)
+    msg.StartTime = invalid_uint32
+    msg.StartPositionLat = invalid_int32
+    msg.StartPositionLong = invalid_int32
+    msg.Sport = Sport(invalid_enum)
+    msg.SubSport = SubSport(invalid_enum)
+    msg.TotalElapsedTime = invalid_uint32
+    msg.TotalTimerTime = invalid_uint32
+    msg.TotalDistance = invalid_uint32
+    msg.TotalCycles = invalid_uint32
+    msg.TotalCalories = invalid_uint16
+    msg.TotalFatCalories = invalid_uint16
+    msg.AvgSpeed = invalid_uint16
+    msg.MaxSpeed = invalid_uint16
+    msg.AvgHeartRate = invalid_uint8
+    msg.MaxHeartRate = invalid_uint8
+    msg.AvgCadence = invalid_uint8
+    msg.MaxCadence = invalid_uint8
+    msg.AvgPower = invalid_uint16
+    msg.MaxPower = invalid_uint16
+    msg.TotalAscent = invalid_uint16
+    msg.TotalDescent = invalid_uint16
+    msg.TotalTrainingEffect = invalid_uint8
+    msg.FirstLapIndex = invalid_uint16
+    msg.NumLaps = invalid_uint16
+    msg.EventGroup = invalid_uint8
+    msg.Trigger = SessionTrigger(invalid_enum)
+    msg.NecLat = invalid_int32
+    msg.NecLong = invalid_int32
+    msg.SwcLat = invalid_int32
+    msg.SwcLong = invalid_int32
+    msg.NormalizedPower = invalid_uint16
+    msg.TrainingStressScore = invalid_uint16
+    msg.IntensityFactor = invalid_uint16
+    msg.LeftRightBalance = invalid_uint16
+    msg.AvgStrokeCount = invalid_uint32
+    msg.AvgStrokeDistance = invalid_uint16
+    msg.SwimStroke = SwimStroke(invalid_enum)
+    msg.PoolLength = invalid_uint16
+    msg.PoolLengthUnit = DisplayMeasure(invalid_enum)
+    msg.NumActiveLengths = invalid_uint16
+    msg.TotalWork = invalid_uint32
+    msg.AvgAltitude = invalid_uint16
+    msg.MaxAltitude = invalid_uint16
+    msg.GPSAccuracy = invalid_uint8
+    msg.AvgGrade = invalid_int16
+    msg.AvgPosGrade = invalid_int16
+    msg.AvgNegGrade = invalid_int16
+    msg.MaxPosGrade = invalid_int16
+    msg.MaxNegGrade = invalid_int16
+    msg.AvgTemperature = invalid_int8
+    msg.MaxTemperature = invalid_int8
+    msg.TotalMovingTime = invalid_uint32
+    msg.AvgPosVerticalSpeed = invalid_int16
+    msg.AvgNegVerticalSpeed = invalid_int16
+    msg.MaxPosVerticalSpeed = invalid_int16
+    msg.MaxNegVerticalSpeed = invalid_int16
+    msg.MinHeartRate = invalid_uint8
+    msg.AvgLapTime = invalid_uint32
+    msg.BestLapIndex = invalid_uint16
+    msg.MinAltitude = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.Event = Event(get_enum(buf, fld, order))
+        case 1: msg.EventType = EventType(get_enum(buf, fld, order))
+        case 2: msg.StartTime = get_uint32(buf, fld, order)
+        case 3: msg.StartPositionLat = get_int32(buf, fld, order)
+        case 4: msg.StartPositionLong = get_int32(buf, fld, order)
+        case 5: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 6: msg.SubSport = SubSport(get_enum(buf, fld, order))
+        case 7: msg.TotalElapsedTime = get_uint32(buf, fld, order)
+        case 8: msg.TotalTimerTime = get_uint32(buf, fld, order)
+        case 9: msg.TotalDistance = get_uint32(buf, fld, order)
+        case 10: msg.TotalCycles = get_uint32(buf, fld, order)
+        case 11: msg.TotalCalories = get_uint16(buf, fld, order)
+        case 13: msg.TotalFatCalories = get_uint16(buf, fld, order)
+        case 14: msg.AvgSpeed = get_uint16(buf, fld, order)
+        case 15: msg.MaxSpeed = get_uint16(buf, fld, order)
+        case 16: msg.AvgHeartRate = get_uint8(buf, fld, order)
+        case 17: msg.MaxHeartRate = get_uint8(buf, fld, order)
+        case 18: msg.AvgCadence = get_uint8(buf, fld, order)
+        case 19: msg.MaxCadence = get_uint8(buf, fld, order)
+        case 20: msg.AvgPower = get_uint16(buf, fld, order)
+        case 21: msg.MaxPower = get_uint16(buf, fld, order)
+        case 22: msg.TotalAscent = get_uint16(buf, fld, order)
+        case 23: msg.TotalDescent = get_uint16(buf, fld, order)
+        case 24: msg.TotalTrainingEffect = get_uint8(buf, fld, order)
+        case 25: msg.FirstLapIndex = get_uint16(buf, fld, order)
+        case 26: msg.NumLaps = get_uint16(buf, fld, order)
+        case 27: msg.EventGroup = get_uint8(buf, fld, order)
+        case 28: msg.Trigger = SessionTrigger(get_enum(buf, fld, order))
+        case 29: msg.NecLat = get_int32(buf, fld, order)
+        case 30: msg.NecLong = get_int32(buf, fld, order)
+        case 31: msg.SwcLat = get_int32(buf, fld, order)
+        case 32: msg.SwcLong = get_int32(buf, fld, order)
+        case 34: msg.NormalizedPower = get_uint16(buf, fld, order)
+        case 35: msg.TrainingStressScore = get_uint16(buf, fld, order)
+        case 36: msg.IntensityFactor = get_uint16(buf, fld, order)
+        case 37: msg.LeftRightBalance = get_uint16(buf, fld, order)
+        case 41: msg.AvgStrokeCount = get_uint32(buf, fld, order)
+        case 42: msg.AvgStrokeDistance = get_uint16(buf, fld, order)
+        case 43: msg.SwimStroke = SwimStroke(get_enum(buf, fld, order))
+        case 44: msg.PoolLength = get_uint16(buf, fld, order)
+        case 46: msg.PoolLengthUnit = DisplayMeasure(get_enum(buf, fld, order))
+        case 47: msg.NumActiveLengths = get_uint16(buf, fld, order)
+        case 48: msg.TotalWork = get_uint32(buf, fld, order)
+        case 49: msg.AvgAltitude = get_uint16(buf, fld, order)
+        case 50: msg.MaxAltitude = get_uint16(buf, fld, order)
+        case 51: msg.GPSAccuracy = get_uint8(buf, fld, order)
+        case 52: msg.AvgGrade = get_int16(buf, fld, order)
+        case 53: msg.AvgPosGrade = get_int16(buf, fld, order)
+        case 54: msg.AvgNegGrade = get_int16(buf, fld, order)
+        case 55: msg.MaxPosGrade = get_int16(buf, fld, order)
+        case 56: msg.MaxNegGrade = get_int16(buf, fld, order)
+        case 57: msg.AvgTemperature = get_int8(buf, fld, order)
+        case 58: msg.MaxTemperature = get_int8(buf, fld, order)
+        case 59: msg.TotalMovingTime = get_uint32(buf, fld, order)
+        case 60: msg.AvgPosVerticalSpeed = get_int16(buf, fld, order)
+        case 61: msg.AvgNegVerticalSpeed = get_int16(buf, fld, order)
+        case 62: msg.MaxPosVerticalSpeed = get_int16(buf, fld, order)
+        case 63: msg.MaxNegVerticalSpeed = get_int16(buf, fld, order)
+        case 64: msg.MinHeartRate = get_uint8(buf, fld, order)
+        case 65: msg.TimeInHRZone = get_uint32_array(buf, fld, order)
+        case 66: msg.TimeInSpeedZone = get_uint32_array(buf, fld, order)
+        case 67: msg.TimeInCadenceZone = get_uint32_array(buf, fld, order)
+        case 68: msg.TimeInPowerZone = get_uint32_array(buf, fld, order)
+        case 69: msg.AvgLapTime = get_uint32(buf, fld, order)
+        case 70: msg.BestLapIndex = get_uint16(buf, fld, order)
+        case 71: msg.MinAltitude = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// lap message
+
+// MsgLap holds the fields of a lap message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgLap struct {
+    msgBase
+
+    MessageIndex uint16
+    Timestamp uint32 // s
+    Event Event
+    EventType EventType
+    StartTime uint32
+    StartPositionLat int32 // semicircles
+    StartPositionLong int32 // semicircles
+    EndPositionLat int32 // semicircles
+    EndPositionLong int32 // semicircles
+    TotalElapsedTime uint32 // s, scale 1000
+    TotalTimerTime uint32 // s, scale 1000
+    TotalDistance uint32 // m, scale 100
+    TotalCycles uint32 // cycles
+    TotalCalories uint16 // kcal
+    TotalFatCalories uint16 // kcal
+    AvgSpeed uint16 // m/s, scale 1000
+    MaxSpeed uint16 // m/s, scale 1000
+    AvgHeartRate uint8 // bpm
+    MaxHeartRate uint8 // bpm
+    AvgCadence uint8 // rpm
+    MaxCadence uint8 // rpm
+    AvgPower uint16 // watts
+    MaxPower uint16 // watts
+    TotalAscent uint16 // m
+    TotalDescent uint16 // m
+    Intensity Intensity
+    LapTrigger LapTrigger
+    Sport Sport
+    EventGroup uint8
+    NumLengths uint16 // lengths
+    NormalizedPower uint16 // watts
+    LeftRightBalance uint16
+    FirstLengthIndex uint16
+    AvgStrokeDistance uint16 // m, scale 100
+    SwimStroke SwimStroke
+    SubSport SubSport
+    NumActiveLengths uint16 // lengths
+    TotalWork uint32 // J
+    AvgAltitude uint16 // m, scale 5, offset 500
+    MaxAltitude uint16 // m, scale 5, offset 500
+    GPSAccuracy uint8 // m
+    AvgGrade int16 // %, scale 100
+    AvgPosGrade int16 // %, scale 100
+    AvgNegGrade int16 // %, scale 100
+    MaxPosGrade int16 // %, scale 100
+    MaxNegGrade int16 // %, scale 100
+    AvgTemperature int8 // C
+    MaxTemperature int8 // C
+    TotalMovingTime uint32 // s, scale 1000
+    AvgPosVerticalSpeed int16 // m/s, scale 1000
+    AvgNegVerticalSpeed int16 // m/s, scale 1000
+    MaxPosVerticalSpeed int16 // m/s, scale 1000
+    MaxNegVerticalSpeed int16 // m/s, scale 1000
+    TimeInHRZone []uint32 // s, scale 1000
+    TimeInSpeedZone []uint32 // s, scale 1000
+    TimeInCadenceZone []uint32 // s, scale 1000
+    TimeInPowerZone []uint32 // s, scale 1000
+    RepetitionNum uint16
+    MinAltitude uint16 // m, scale 5, offset 500
+    MinHeartRate uint8 // bpm
+    WktStepIndex uint16
+}
+
+func (msg *MsgLap) Name() string {
+    return "lap"
+}
+
+// total_elapsed_time in s
+func (msg *MsgLap) TotalElapsedTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalElapsedTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalElapsedTime) / 1000
+}
+
+// total_timer_time in s
+func (msg *MsgLap) TotalTimerTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalTimerTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalTimerTime) / 1000
+}
+
+// total_distance in m
+func (msg *MsgLap) TotalDistanceScaled() float64 {
+    if !is_valid_uint32(msg.TotalDistance) {
+        return math.NaN()
+    }
+    return float64(msg.TotalDistance) / 100
+}
+
+// avg_speed in m/s
+func (msg *MsgLap) AvgSpeedScaled() float64 {
+    if !is_valid_uint16(msg.AvgSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgSpeed) / 1000
+}
+
+// max_speed in m/s
+func (msg *MsgLap) MaxSpeedScaled() float64 {
+    if !is_valid_uint16(msg.MaxSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxSpeed) / 1000
+}
+
+// avg_stroke_distance in m
+func (msg *MsgLap) AvgStrokeDistanceScaled() float64 {
+    if !is_valid_uint16(msg.AvgStrokeDistance) {
+        return math.NaN()
+    }
+    return float64(msg.AvgStrokeDistance) / 100
+}
+
+// avg_altitude in m
+func (msg *MsgLap) AvgAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.AvgAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.AvgAltitude) - 2500) / 5
+}
+
+// max_altitude in m
+func (msg *MsgLap) MaxAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.MaxAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.MaxAltitude) - 2500) / 5
+}
+
+// avg_grade in %
+func (msg *MsgLap) AvgGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgGrade) / 100
+}
+
+// avg_pos_grade in %
+func (msg *MsgLap) AvgPosGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgPosGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgPosGrade) / 100
+}
+
+// avg_neg_grade in %
+func (msg *MsgLap) AvgNegGradeScaled() float64 {
+    if !is_valid_int16(msg.AvgNegGrade) {
+        return math.NaN()
+    }
+    return float64(msg.AvgNegGrade) / 100
+}
+
+// max_pos_grade in %
+func (msg *MsgLap) MaxPosGradeScaled() float64 {
+    if !is_valid_int16(msg.MaxPosGrade) {
+        return math.NaN()
+    }
+    return float64(msg.MaxPosGrade) / 100
+}
+
+// max_neg_grade in %
+func (msg *MsgLap) MaxNegGradeScaled() float64 {
+    if !is_valid_int16(msg.MaxNegGrade) {
+        return math.NaN()
+    }
+    return float64(msg.MaxNegGrade) / 100
+}
+
+// total_moving_time in s
+func (msg *MsgLap) TotalMovingTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalMovingTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalMovingTime) / 1000
+}
+
+// avg_pos_vertical_speed in m/s
+func (msg *MsgLap) AvgPosVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.AvgPosVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgPosVerticalSpeed) / 1000
+}
+
+// avg_neg_vertical_speed in m/s
+func (msg *MsgLap) AvgNegVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.AvgNegVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgNegVerticalSpeed) / 1000
+}
+
+// max_pos_vertical_speed in m/s
+func (msg *MsgLap) MaxPosVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.MaxPosVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxPosVerticalSpeed) / 1000
+}
+
+// max_neg_vertical_speed in m/s
+func (msg *MsgLap) MaxNegVerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.MaxNegVerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.MaxNegVerticalSpeed) / 1000
+}
+
+// time_in_hr_zone in s
+func (msg *MsgLap) TimeInHRZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInHRZone))
+    for i, v := range msg.TimeInHRZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_speed_zone in s
+func (msg *MsgLap) TimeInSpeedZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInSpeedZone))
+    for i, v := range msg.TimeInSpeedZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_cadence_zone in s
+func (msg *MsgLap) TimeInCadenceZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInCadenceZone))
+    for i, v := range msg.TimeInCadenceZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// time_in_power_zone in s
+func (msg *MsgLap) TimeInPowerZoneScaled() []float64 {
+    vals := make([]float64, len(msg.TimeInPowerZone))
+    for i, v := range msg.TimeInPowerZone {
+        if !is_valid_uint32(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+// min_altitude in m
+func (msg *MsgLap) MinAltitudeScaled() float64 {
+    if !is_valid_uint16(msg.MinAltitude) {
+        return math.NaN()
+    }
+    return (float64(msg.MinAltitude) - 2500) / 5
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgLap) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// start_time as a time.Time; see DateTime()
+func (msg *MsgLap) StartTimeTime() time.Time {
+    return DateTime(msg.StartTime)
+}
+
+// start_position_lat and start_position_long in degrees
+func (msg *MsgLap) StartPosition() Position {
+    return PositionFromSemicircles(msg.StartPositionLat, msg.StartPositionLong)
+}
+
+// end_position_lat and end_position_long in degrees
+func (msg *MsgLap) EndPosition() Position {
+    return PositionFromSemicircles(msg.EndPositionLat, msg.EndPositionLong)
+}
+
+func (msg *MsgLap) Text() string {
+    txt := "lap"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_enum(byte(msg.Event)) {
+        txt += fmt.Sprintf(" evt %s", msg.Event)
+    }
+    if is_valid_enum(byte(msg.EventType)) {
+        txt += fmt.Sprintf(" evttyp %s", msg.EventType)
+    }
+    if is_valid_uint32(msg.StartTime) {
+        txt += fmt.Sprintf(" starttime %d", msg.StartTime)
+    }
+    if is_valid_int32(msg.StartPositionLat) {
+        txt += fmt.Sprintf(" startposlat %d", msg.StartPositionLat)
+    }
+    if is_valid_int32(msg.StartPositionLong) {
+        txt += fmt.Sprintf(" startposlong %d", msg.StartPositionLong)
+    }
+    if is_valid_int32(msg.EndPositionLat) {
+        txt += fmt.Sprintf(" endposlat %d", msg.EndPositionLat)
+    }
+    if is_valid_int32(msg.EndPositionLong) {
+        txt += fmt.Sprintf(" endposlong %d", msg.EndPositionLong)
+    }
+    if is_valid_uint32(msg.TotalElapsedTime) {
+        txt += fmt.Sprintf(" totalelapsedtime %g", msg.TotalElapsedTimeScaled())
+    }
+    if is_valid_uint32(msg.TotalTimerTime) {
+        txt += fmt.Sprintf(" totaltimertime %g", msg.TotalTimerTimeScaled())
+    }
+    if is_valid_uint32(msg.TotalDistance) {
+        txt += fmt.Sprintf(" totaldist %g", msg.TotalDistanceScaled())
+    }
+    if is_valid_uint32(msg.TotalCycles) {
+        txt += fmt.Sprintf(" totalcycles %d", msg.TotalCycles)
+    }
+    if is_valid_uint16(msg.TotalCalories) {
+        txt += fmt.Sprintf(" totalcals %d", msg.TotalCalories)
+    }
+    if is_valid_uint16(msg.TotalFatCalories) {
+        txt += fmt.Sprintf(" totalfatcals %d", msg.TotalFatCalories)
+    }
+    if is_valid_uint16(msg.AvgSpeed) {
+        txt += fmt.Sprintf(" avgspeed %g", msg.AvgSpeedScaled())
+    }
+    if is_valid_uint16(msg.MaxSpeed) {
+        txt += fmt.Sprintf(" maxspeed %g", msg.MaxSpeedScaled())
+    }
+    if is_valid_uint8(msg.AvgHeartRate) {
+        txt += fmt.Sprintf(" avgheartrate %d", msg.AvgHeartRate)
+    }
+    if is_valid_uint8(msg.MaxHeartRate) {
+        txt += fmt.Sprintf(" maxheartrate %d", msg.MaxHeartRate)
+    }
+    if is_valid_uint8(msg.AvgCadence) {
+        txt += fmt.Sprintf(" avgcadence %d", msg.AvgCadence)
+    }
+    if is_valid_uint8(msg.MaxCadence) {
+        txt += fmt.Sprintf(" maxcadence %d", msg.MaxCadence)
+    }
+    if is_valid_uint16(msg.AvgPower) {
+        txt += fmt.Sprintf(" avgpower %d", msg.AvgPower)
+    }
+    if is_valid_uint16(msg.MaxPower) {
+        txt += fmt.Sprintf(" maxpower %d", msg.MaxPower)
+    }
+    if is_valid_uint16(msg.TotalAscent) {
+        txt += fmt.Sprintf(" totalascent %d", msg.TotalAscent)
+    }
+    if is_valid_uint16(msg.TotalDescent) {
+        txt += fmt.Sprintf(" totaldescent %d", msg.TotalDescent)
+    }
+    if is_valid_enum(byte(msg.Intensity)) {
+        txt += fmt.Sprintf(" intensity %s", msg.Intensity)
+    }
+    if is_valid_enum(byte(msg.LapTrigger)) {
+        txt += fmt.Sprintf(" laptrigger %s", msg.LapTrigger)
+    }
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_uint8(msg.EventGroup) {
+        txt += fmt.Sprintf(" evtgrp %d", msg.EventGroup)
+    }
+    if is_valid_uint16(msg.NumLengths) {
+        txt += fmt.Sprintf(" numlens %d", msg.NumLengths)
+    }
+    if is_valid_uint16(msg.NormalizedPower) {
+        txt += fmt.Sprintf(" normalizedpower %d", msg.NormalizedPower)
+    }
+    if is_valid_uint16(msg.LeftRightBalance) {
+        txt += fmt.Sprintf(" leftrightbalance %d", msg.LeftRightBalance)
+    }
+    if is_valid_uint16(msg.FirstLengthIndex) {
+        txt += fmt.Sprintf(" firstlenidx %d", msg.FirstLengthIndex)
+    }
+    if is_valid_uint16(msg.AvgStrokeDistance) {
+        txt += fmt.Sprintf(" avgstrokedist %g", msg.AvgStrokeDistanceScaled())
+    }
+    if is_valid_enum(byte(msg.SwimStroke)) {
+        txt += fmt.Sprintf(" swimstroke %s", msg.SwimStroke)
+    }
+    if is_valid_enum(byte(msg.SubSport)) {
+        txt += fmt.Sprintf(" subsport %s", msg.SubSport)
+    }
+    if is_valid_uint16(msg.NumActiveLengths) {
+        txt += fmt.Sprintf(" numactivelens %d", msg.NumActiveLengths)
+    }
+    if is_valid_uint32(msg.TotalWork) {
+        txt += fmt.Sprintf(" totalwork %d", msg.TotalWork)
+    }
+    if is_valid_uint16(msg.AvgAltitude) {
+        txt += fmt.Sprintf(" avgalt %g", msg.AvgAltitudeScaled())
+    }
+    if is_valid_uint16(msg.MaxAltitude) {
+        txt += fmt.Sprintf(" maxalt %g", msg.MaxAltitudeScaled())
+    }
+    if is_valid_uint8(msg.GPSAccuracy) {
+        txt += fmt.Sprintf(" gpsaccuracy %d", msg.GPSAccuracy)
+    }
+    if is_valid_int16(msg.AvgGrade) {
+        txt += fmt.Sprintf(" avggrade %g", msg.AvgGradeScaled())
+    }
+    if is_valid_int16(msg.AvgPosGrade) {
+        txt += fmt.Sprintf(" avgposgrade %g", msg.AvgPosGradeScaled())
+    }
+    if is_valid_int16(msg.AvgNegGrade) {
+        txt += fmt.Sprintf(" avgneggrade %g", msg.AvgNegGradeScaled())
+    }
+    if is_valid_int16(msg.MaxPosGrade) {
+        txt += fmt.Sprintf(" maxposgrade %g", msg.MaxPosGradeScaled())
+    }
+    if is_valid_int16(msg.MaxNegGrade) {
+        txt += fmt.Sprintf(" maxneggrade %g", msg.MaxNegGradeScaled())
+    }
+    if is_valid_int8(msg.AvgTemperature) {
+        txt += fmt.Sprintf(" avgtemp %d", msg.AvgTemperature)
+    }
+    if is_valid_int8(msg.MaxTemperature) {
+        txt += fmt.Sprintf(" maxtemp %d", msg.MaxTemperature)
+    }
+    if is_valid_uint32(msg.TotalMovingTime) {
+        txt += fmt.Sprintf(" totalmovingtime %g", msg.TotalMovingTimeScaled())
+    }
+    if is_valid_int16(msg.AvgPosVerticalSpeed) {
+        txt += fmt.Sprintf(" avgposvertspeed %g", msg.AvgPosVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.AvgNegVerticalSpeed) {
+        txt += fmt.Sprintf(" avgnegvertspeed %g", msg.AvgNegVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.MaxPosVerticalSpeed) {
+        txt += fmt.Sprintf(" maxposvertspeed %g", msg.MaxPosVerticalSpeedScaled())
+    }
+    if is_valid_int16(msg.MaxNegVerticalSpeed) {
+        txt += fmt.Sprintf(" maxnegvertspeed %g", msg.MaxNegVerticalSpeedScaled())
+    }
+    if len(msg.TimeInHRZone) > 0 {
+        txt += fmt.Sprintf(" timeinhrzone %g", msg.TimeInHRZoneScaled())
+    }
+    if len(msg.TimeInSpeedZone) > 0 {
+        txt += fmt.Sprintf(" timeinspeedzone %g", msg.TimeInSpeedZoneScaled())
+    }
+    if len(msg.TimeInCadenceZone) > 0 {
+        txt += fmt.Sprintf(" timeincadencezone %g", msg.TimeInCadenceZoneScaled())
+    }
+    if len(msg.TimeInPowerZone) > 0 {
+        txt += fmt.Sprintf(" timeinpowerzone %g", msg.TimeInPowerZoneScaled())
+    }
+    if is_valid_uint16(msg.RepetitionNum) {
+        txt += fmt.Sprintf(" repetitionnum %d", msg.RepetitionNum)
+    }
+    if is_valid_uint16(msg.MinAltitude) {
+        txt += fmt.Sprintf(" minalt %g", msg.MinAltitudeScaled())
+    }
+    if is_valid_uint8(msg.MinHeartRate) {
+        txt += fmt.Sprintf(" minheartrate %d", msg.MinHeartRate)
+    }
+    if is_valid_uint16(msg.WktStepIndex) {
+        txt += fmt.Sprintf(" wktstepidx %d", msg.WktStepIndex)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgLap) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "event": return is_valid_enum(byte(msg.Event))
+    case "event_type": return is_valid_enum(byte(msg.EventType))
+    case "start_time": return is_valid_uint32(msg.StartTime)
+    case "start_position_lat": return is_valid_int32(msg.StartPositionLat)
+    case "start_position_long": return is_valid_int32(msg.StartPositionLong)
+    case "end_position_lat": return is_valid_int32(msg.EndPositionLat)
+    case "end_position_long": return is_valid_int32(msg.EndPositionLong)
+    case "total_elapsed_time": return is_valid_uint32(msg.TotalElapsedTime)
+    case "total_timer_time": return is_valid_uint32(msg.TotalTimerTime)
+    case "total_distance": return is_valid_uint32(msg.TotalDistance)
+    case "total_cycles": return is_valid_uint32(msg.TotalCycles)
+    case "total_calories": return is_valid_uint16(msg.TotalCalories)
+    case "total_fat_calories": return is_valid_uint16(msg.TotalFatCalories)
+    case "avg_speed": return is_valid_uint16(msg.AvgSpeed)
+    case "max_speed": return is_valid_uint16(msg.MaxSpeed)
+    case "avg_heart_rate": return is_valid_uint8(msg.AvgHeartRate)
+    case "max_heart_rate": return is_valid_uint8(msg.MaxHeartRate)
+    case "avg_cadence": return is_valid_uint8(msg.AvgCadence)
+    case "max_cadence": return is_valid_uint8(msg.MaxCadence)
+    case "avg_power": return is_valid_uint16(msg.AvgPower)
+    case "max_power": return is_valid_uint16(msg.MaxPower)
+    case "total_ascent": return is_valid_uint16(msg.TotalAscent)
+    case "total_descent": return is_valid_uint16(msg.TotalDescent)
+    case "intensity": return is_valid_enum(byte(msg.Intensity))
+    case "lap_trigger": return is_valid_enum(byte(msg.LapTrigger))
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "event_group": return is_valid_uint8(msg.EventGroup)
+    case "num_lengths": return is_valid_uint16(msg.NumLengths)
+    case "normalized_power": return is_valid_uint16(msg.NormalizedPower)
+    case "left_right_balance": return is_valid_uint16(msg.LeftRightBalance)
+    case "first_length_index": return is_valid_uint16(msg.FirstLengthIndex)
+    case "avg_stroke_distance": return is_valid_uint16(msg.AvgStrokeDistance)
+    case "swim_stroke": return is_valid_enum(byte(msg.SwimStroke))
+    case "sub_sport": return is_valid_enum(byte(msg.SubSport))
+    case "num_active_lengths": return is_valid_uint16(msg.NumActiveLengths)
+    case "total_work": return is_valid_uint32(msg.TotalWork)
+    case "avg_altitude": return is_valid_uint16(msg.AvgAltitude)
+    case "max_altitude": return is_valid_uint16(msg.MaxAltitude)
+    case "gps_accuracy": return is_valid_uint8(msg.GPSAccuracy)
+    case "avg_grade": return is_valid_int16(msg.AvgGrade)
+    case "avg_pos_grade": return is_valid_int16(msg.AvgPosGrade)
+    case "avg_neg_grade": return is_valid_int16(msg.AvgNegGrade)
+    case "max_pos_grade": return is_valid_int16(msg.MaxPosGrade)
+    case "max_neg_grade": return is_valid_int16(msg.MaxNegGrade)
+    case "avg_temperature": return is_valid_int8(msg.AvgTemperature)
+    case "max_temperature": return is_valid_int8(msg.MaxTemperature)
+    case "total_moving_time": return is_valid_uint32(msg.TotalMovingTime)
+    case "avg_pos_vertical_speed": return is_valid_int16(msg.AvgPosVerticalSpeed)
+    case "avg_neg_vertical_speed": return is_valid_int16(msg.AvgNegVerticalSpeed)
+    case "max_pos_vertical_speed": return is_valid_int16(msg.MaxPosVerticalSpeed)
+    case "max_neg_vertical_speed": return is_valid_int16(msg.MaxNegVerticalSpeed)
+    case "time_in_hr_zone": return len(msg.TimeInHRZone) > 0
+    case "time_in_speed_zone": return len(msg.TimeInSpeedZone) > 0
+    case "time_in_cadence_zone": return len(msg.TimeInCadenceZone) > 0
+    case "time_in_power_zone": return len(msg.TimeInPowerZone) > 0
+    case "repetition_num": return is_valid_uint16(msg.RepetitionNum)
+    case "min_altitude": return is_valid_uint16(msg.MinAltitude)
+    case "min_heart_rate": return is_valid_uint8(msg.MinHeartRate)
+    case "wkt_step_index": return is_valid_uint16(msg.WktStepIndex)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgLap) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "event":
+        if is_valid_enum(byte(msg.Event)) {
+            return float64(msg.Event)
+        }
+    case "event_type":
+        if is_valid_enum(byte(msg.EventType)) {
+            return float64(msg.EventType)
+        }
+    case "start_time":
+        if is_valid_uint32(msg.StartTime) {
+            return float64(msg.StartTime)
+        }
+    case "start_position_lat":
+        if is_valid_int32(msg.StartPositionLat) {
+            return float64(msg.StartPositionLat)
+        }
+    case "start_position_long":
+        if is_valid_int32(msg.StartPositionLong) {
+            return float64(msg.StartPositionLong)
+        }
+    case "end_position_lat":
+        if is_valid_int32(msg.EndPositionLat) {
+            return float64(msg.EndPositionLat)
+        }
+    case "end_position_long":
+        if is_valid_int32(msg.EndPositionLong) {
+            return float64(msg.EndPositionLong)
+        }
+    case "total_elapsed_time":
+        return msg.TotalElapsedTimeScaled()
+    case "total_timer_time":
+        return msg.TotalTimerTimeScaled()
+    case "total_distance":
+        return msg.TotalDistanceScaled()
+    case "total_cycles":
+        if is_valid_uint32(msg.TotalCycles) {
+            return float64(msg.TotalCycles)
+        }
+    case "total_calories":
+        if is_valid_uint16(msg.TotalCalories) {
+            return float64(msg.TotalCalories)
+        }
+    case "total_fat_calories":
+        if is_valid_uint16(msg.TotalFatCalories) {
+            return float64(msg.TotalFatCalories)
+        }
+    case "avg_speed":
+        return msg.AvgSpeedScaled()
+    case "max_speed":
+        return msg.MaxSpeedScaled()
+    case "avg_heart_rate":
+        if is_valid_uint8(msg.AvgHeartRate) {
+            return float64(msg.AvgHeartRate)
+        }
+    case "max_heart_rate":
+        if is_valid_uint8(msg.MaxHeartRate) {
+            return float64(msg.MaxHeartRate)
+        }
+    case "avg_cadence":
+        if is_valid_uint8(msg.AvgCadence) {
+            return float64(msg.AvgCadence)
+        }
+    case "max_cadence":
+        if is_valid_uint8(msg.MaxCadence) {
+            return float64(msg.MaxCadence)
+        }
+    case "avg_power":
+        if is_valid_uint16(msg.AvgPower) {
+            return float64(msg.AvgPower)
+        }
+    case "max_power":
+        if is_valid_uint16(msg.MaxPower) {
+            return float64(msg.MaxPower)
+        }
+    case "total_ascent":
+        if is_valid_uint16(msg.TotalAscent) {
+            return float64(msg.TotalAscent)
+        }
+    case "total_descent":
+        if is_valid_uint16(msg.TotalDescent) {
+            return float64(msg.TotalDescent)
+        }
+    case "intensity":
+        if is_valid_enum(byte(msg.Intensity)) {
+            return float64(msg.Intensity)
+        }
+    case "lap_trigger":
+        if is_valid_enum(byte(msg.LapTrigger)) {
+            return float64(msg.LapTrigger)
+        }
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "event_group":
+        if is_valid_uint8(msg.EventGroup) {
+            return float64(msg.EventGroup)
+        }
+    case "num_lengths":
+        if is_valid_uint16(msg.NumLengths) {
+            return float64(msg.NumLengths)
+        }
+    case "normalized_power":
+        if is_valid_uint16(msg.NormalizedPower) {
+            return float64(msg.NormalizedPower)
+        }
+    case "left_right_balance":
+        if is_valid_uint16(msg.LeftRightBalance) {
+            return float64(msg.LeftRightBalance)
+        }
+    case "first_length_index":
+        if is_valid_uint16(msg.FirstLengthIndex) {
+            return float64(msg.FirstLengthIndex)
+        }
+    case "avg_stroke_distance":
+        return msg.AvgStrokeDistanceScaled()
+    case "swim_stroke":
+        if is_valid_enum(byte(msg.SwimStroke)) {
+            return float64(msg.SwimStroke)
+        }
+    case "sub_sport":
+        if is_valid_enum(byte(msg.SubSport)) {
+            return float64(msg.SubSport)
+        }
+    case "num_active_lengths":
+        if is_valid_uint16(msg.NumActiveLengths) {
+            return float64(msg.NumActiveLengths)
+        }
+    case "total_work":
+        if is_valid_uint32(msg.TotalWork) {
+            return float64(msg.TotalWork)
+        }
+    case "avg_altitude":
+        return msg.AvgAltitudeScaled()
+    case "max_altitude":
+        return msg.MaxAltitudeScaled()
+    case "gps_accuracy":
+        if is_valid_uint8(msg.GPSAccuracy) {
+            return float64(msg.GPSAccuracy)
+        }
+    case "avg_grade":
+        return msg.AvgGradeScaled()
+    case "avg_pos_grade":
+        return msg.AvgPosGradeScaled()
+    case "avg_neg_grade":
+        return msg.AvgNegGradeScaled()
+    case "max_pos_grade":
+        return msg.MaxPosGradeScaled()
+    case "max_neg_grade":
+        return msg.MaxNegGradeScaled()
+    case "avg_temperature":
+        if is_valid_int8(msg.AvgTemperature) {
+            return float64(msg.AvgTemperature)
+        }
+    case "max_temperature":
+        if is_valid_int8(msg.MaxTemperature) {
+            return float64(msg.MaxTemperature)
+        }
+    case "total_moving_time":
+        return msg.TotalMovingTimeScaled()
+    case "avg_pos_vertical_speed":
+        return msg.AvgPosVerticalSpeedScaled()
+    case "avg_neg_vertical_speed":
+        return msg.AvgNegVerticalSpeedScaled()
+    case "max_pos_vertical_speed":
+        return msg.MaxPosVerticalSpeedScaled()
+    case "max_neg_vertical_speed":
+        return msg.MaxNegVerticalSpeedScaled()
+    case "repetition_num":
+        if is_valid_uint16(msg.RepetitionNum) {
+            return float64(msg.RepetitionNum)
+        }
+    case "min_altitude":
+        return msg.MinAltitudeScaled()
+    case "min_heart_rate":
+        if is_valid_uint8(msg.MinHeartRate) {
+            return float64(msg.MinHeartRate)
+        }
+    case "wkt_step_index":
+        if is_valid_uint16(msg.WktStepIndex) {
+            return float64(msg.WktStepIndex)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgLap) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "start_position_lat": return "semicircles"
+    case "start_position_long": return "semicircles"
+    case "end_position_lat": return "semicircles"
+    case "end_position_long": return "semicircles"
+    case "total_elapsed_time": return "s"
+    case "total_timer_time": return "s"
+    case "total_distance": return "m"
+    case "total_cycles": return "cycles"
+    case "total_calories": return "kcal"
+    case "total_fat_calories": return "kcal"
+    case "avg_speed": return "m/s"
+    case "max_speed": return "m/s"
+    case "avg_heart_rate": return "bpm"
+    case "max_heart_rate": return "bpm"
+    case "avg_cadence": return "rpm"
+    case "max_cadence": return "rpm"
+    case "avg_power": return "watts"
+    case "max_power": return "watts"
+    case "total_ascent": return "m"
+    case "total_descent": return "m"
+    case "num_lengths": return "lengths"
+    case "normalized_power": return "watts"
+    case "avg_stroke_distance": return "m"
+    case "num_active_lengths": return "lengths"
+    case "total_work": return "J"
+    case "avg_altitude": return "m"
+    case "max_altitude": return "m"
+    case "gps_accuracy": return "m"
+    case "avg_grade": return "%"
+    case "avg_pos_grade": return "%"
+    case "avg_neg_grade": return "%"
+    case "max_pos_grade": return "%"
+    case "max_neg_grade": return "%"
+    case "avg_temperature": return "C"
+    case "max_temperature": return "C"
+    case "total_moving_time": return "s"
+    case "avg_pos_vertical_speed": return "m/s"
+    case "avg_neg_vertical_speed": return "m/s"
+    case "max_pos_vertical_speed": return "m/s"
+    case "max_neg_vertical_speed": return "m/s"
+    case "time_in_hr_zone": return "s"
+    case "time_in_speed_zone": return "s"
+    case "time_in_cadence_zone": return "s"
+    case "time_in_power_zone": return "s"
+    case "min_altitude": return "m"
+    case "min_heart_rate": return "bpm"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgLap) GlobalNum() uint16 {
+    return 19
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgLap) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "event", Num: 0, BaseType: 0,
+            Raw: msg.Event, Scaled: msg.Scaled("event"),
+            Units: "", Valid: is_valid_enum(byte(msg.Event))}
+    case 1:
+        return Value{Name: "event_type", Num: 1, BaseType: 0,
+            Raw: msg.EventType, Scaled: msg.Scaled("event_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.EventType))}
+    case 2:
+        return Value{Name: "start_time", Num: 2, BaseType: 6,
+            Raw: msg.StartTime, Scaled: msg.Scaled("start_time"),
+            Units: "", Valid: is_valid_uint32(msg.StartTime)}
+    case 3:
+        return Value{Name: "start_position_lat", Num: 3, BaseType: 5,
+            Raw: msg.StartPositionLat, Scaled: msg.Scaled("start_position_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.StartPositionLat)}
+    case 4:
+        return Value{Name: "start_position_long", Num: 4, BaseType: 5,
+            Raw: msg.StartPositionLong, Scaled: msg.Scaled("start_position_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.StartPositionLong)}
+    case 5:
+        return Value{Name: "end_position_lat", Num: 5, BaseType: 5,
+            Raw: msg.EndPositionLat, Scaled: msg.Scaled("end_position_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.EndPositionLat)}
+    case 6:
+        return Value{Name: "end_position_long", Num: 6, BaseType: 5,
+            Raw: msg.EndPositionLong, Scaled: msg.Scaled("end_position_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.EndPositionLong)}
+    case 7:
+        return Value{Name: "total_elapsed_time", Num: 7, BaseType: 6,
+            Raw: msg.TotalElapsedTime, Scaled: msg.Scaled("total_elapsed_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalElapsedTime)}
+    case 8:
+        return Value{Name: "total_timer_time", Num: 8, BaseType: 6,
+            Raw: msg.TotalTimerTime, Scaled: msg.Scaled("total_timer_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalTimerTime)}
+    case 9:
+        return Value{Name: "total_distance", Num: 9, BaseType: 6,
+            Raw: msg.TotalDistance, Scaled: msg.Scaled("total_distance"),
+            Units: "m", Valid: is_valid_uint32(msg.TotalDistance)}
+    case 10:
+        return Value{Name: "total_cycles", Num: 10, BaseType: 6,
+            Raw: msg.TotalCycles, Scaled: msg.Scaled("total_cycles"),
+            Units: "cycles", Valid: is_valid_uint32(msg.TotalCycles)}
+    case 11:
+        return Value{Name: "total_calories", Num: 11, BaseType: 4,
+            Raw: msg.TotalCalories, Scaled: msg.Scaled("total_calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.TotalCalories)}
+    case 12:
+        return Value{Name: "total_fat_calories", Num: 12, BaseType: 4,
+            Raw: msg.TotalFatCalories, Scaled: msg.Scaled("total_fat_calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.TotalFatCalories)}
+    case 13:
+        return Value{Name: "avg_speed", Num: 13, BaseType: 4,
+            Raw: msg.AvgSpeed, Scaled: msg.Scaled("avg_speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.AvgSpeed)}
+    case 14:
+        return Value{Name: "max_speed", Num: 14, BaseType: 4,
+            Raw: msg.MaxSpeed, Scaled: msg.Scaled("max_speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.MaxSpeed)}
+    case 15:
+        return Value{Name: "avg_heart_rate", Num: 15, BaseType: 2,
+            Raw: msg.AvgHeartRate, Scaled: msg.Scaled("avg_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.AvgHeartRate)}
+    case 16:
+        return Value{Name: "max_heart_rate", Num: 16, BaseType: 2,
+            Raw: msg.MaxHeartRate, Scaled: msg.Scaled("max_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.MaxHeartRate)}
+    case 17:
+        return Value{Name: "avg_cadence", Num: 17, BaseType: 2,
+            Raw: msg.AvgCadence, Scaled: msg.Scaled("avg_cadence"),
+            Units: "rpm", Valid: is_valid_uint8(msg.AvgCadence)}
+    case 18:
+        return Value{Name: "max_cadence", Num: 18, BaseType: 2,
+            Raw: msg.MaxCadence, Scaled: msg.Scaled("max_cadence"),
+            Units: "rpm", Valid: is_valid_uint8(msg.MaxCadence)}
+    case 19:
+        return Value{Name: "avg_power", Num: 19, BaseType: 4,
+            Raw: msg.AvgPower, Scaled: msg.Scaled("avg_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.AvgPower)}
+    case 20:
+        return Value{Name: "max_power", Num: 20, BaseType: 4,
+            Raw: msg.MaxPower, Scaled: msg.Scaled("max_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.MaxPower)}
+    case 21:
+        return Value{Name: "total_ascent", Num: 21, BaseType: 4,
+            Raw: msg.TotalAscent, Scaled: msg.Scaled("total_ascent"),
+            Units: "m", Valid: is_valid_uint16(msg.TotalAscent)}
+    case 22:
+        return Value{Name: "total_descent", Num: 22, BaseType: 4,
+            Raw: msg.TotalDescent, Scaled: msg.Scaled("total_descent"),
+            Units: "m", Valid: is_valid_uint16(msg.TotalDescent)}
+    case 23:
+        return Value{Name: "intensity", Num: 23, BaseType: 0,
+            Raw: msg.Intensity, Scaled: msg.Scaled("intensity"),
+            Units: "", Valid: is_valid_enum(byte(msg.Intensity))}
+    case 24:
+        return Value{Name: "lap_trigger", Num: 24, BaseType: 0,
+            Raw: msg.LapTrigger, Scaled: msg.Scaled("lap_trigger"),
+            Units: "", Valid: is_valid_enum(byte(msg.LapTrigger))}
+    case 25:
+        return Value{Name: "sport", Num: 25, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 26:
+        return Value{Name: "event_group", Num: 26, BaseType: 2,
+            Raw: msg.EventGroup, Scaled: msg.Scaled("event_group"),
+            Units: "", Valid: is_valid_uint8(msg.EventGroup)}
+    case 32:
+        return Value{Name: "num_lengths", Num: 32, BaseType: 4,
+            Raw: msg.NumLengths, Scaled: msg.Scaled("num_lengths"),
+            Units: "lengths", Valid: is_valid_uint16(msg.NumLengths)}
+    case 33:
+        return Value{Name: "normalized_power", Num: 33, BaseType: 4,
+            Raw: msg.NormalizedPower, Scaled: msg.Scaled("normalized_power"),
+            Units: "watts", Valid: is_valid_uint16(msg.NormalizedPower)}
+    case 34:
+        return Value{Name: "left_right_balance", Num: 34, BaseType: 4,
+            Raw: msg.LeftRightBalance, Scaled: msg.Scaled("left_right_balance"),
+            Units: "", Valid: is_valid_uint16(msg.LeftRightBalance)}
+    case 35:
+        return Value{Name: "first_length_index", Num: 35, BaseType: 4,
+            Raw: msg.FirstLengthIndex, Scaled: msg.Scaled("first_length_index"),
+            Units: "", Valid: is_valid_uint16(msg.FirstLengthIndex)}
+    case 37:
+        return Value{Name: "avg_stroke_distance", Num: 37, BaseType: 4,
+            Raw: msg.AvgStrokeDistance, Scaled: msg.Scaled("avg_stroke_distance"),
+            Units: "m", Valid: is_valid_uint16(msg.AvgStrokeDistance)}
+    case 38:
+        return Value{Name: "swim_stroke", Num: 38, BaseType: 0,
+            Raw: msg.SwimStroke, Scaled: msg.Scaled("swim_stroke"),
+            Units: "", Valid: is_valid_enum(byte(msg.SwimStroke))}
+    case 39:
+        return Value{Name: "sub_sport", Num: 39, BaseType: 0,
+            Raw: msg.SubSport, Scaled: msg.Scaled("sub_sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.SubSport))}
+    case 40:
+        return Value{Name: "num_active_lengths", Num: 40, BaseType: 4,
+            Raw: msg.NumActiveLengths, Scaled: msg.Scaled("num_active_lengths"),
+            Units: "lengths", Valid: is_valid_uint16(msg.NumActiveLengths)}
+    case 41:
+        return Value{Name: "total_work", Num: 41, BaseType: 6,
+            Raw: msg.TotalWork, Scaled: msg.Scaled("total_work"),
+            Units: "J", Valid: is_valid_uint32(msg.TotalWork)}
+    case 42:
+        return Value{Name: "avg_altitude", Num: 42, BaseType: 4,
+            Raw: msg.AvgAltitude, Scaled: msg.Scaled("avg_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.AvgAltitude)}
+    case 43:
+        return Value{Name: "max_altitude", Num: 43, BaseType: 4,
+            Raw: msg.MaxAltitude, Scaled: msg.Scaled("max_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.MaxAltitude)}
+    case 44:
+        return Value{Name: "gps_accuracy", Num: 44, BaseType: 2,
+            Raw: msg.GPSAccuracy, Scaled: msg.Scaled("gps_accuracy"),
+            Units: "m", Valid: is_valid_uint8(msg.GPSAccuracy)}
+    case 45:
+        return Value{Name: "avg_grade", Num: 45, BaseType: 3,
+            Raw: msg.AvgGrade, Scaled: msg.Scaled("avg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgGrade)}
+    case 46:
+        return Value{Name: "avg_pos_grade", Num: 46, BaseType: 3,
+            Raw: msg.AvgPosGrade, Scaled: msg.Scaled("avg_pos_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgPosGrade)}
+    case 47:
+        return Value{Name: "avg_neg_grade", Num: 47, BaseType: 3,
+            Raw: msg.AvgNegGrade, Scaled: msg.Scaled("avg_neg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.AvgNegGrade)}
+    case 48:
+        return Value{Name: "max_pos_grade", Num: 48, BaseType: 3,
+            Raw: msg.MaxPosGrade, Scaled: msg.Scaled("max_pos_grade"),
+            Units: "%", Valid: is_valid_int16(msg.MaxPosGrade)}
+    case 49:
+        return Value{Name: "max_neg_grade", Num: 49, BaseType: 3,
+            Raw: msg.MaxNegGrade, Scaled: msg.Scaled("max_neg_grade"),
+            Units: "%", Valid: is_valid_int16(msg.MaxNegGrade)}
+    case 50:
+        return Value{Name: "avg_temperature", Num: 50, BaseType: 1,
+            Raw: msg.AvgTemperature, Scaled: msg.Scaled("avg_temperature"),
+            Units: "C", Valid: is_valid_int8(msg.AvgTemperature)}
+    case 51:
+        return Value{Name: "max_temperature", Num: 51, BaseType: 1,
+            Raw: msg.MaxTemperature, Scaled: msg.Scaled("max_temperature"),
+            Units: "C", Valid: is_valid_int8(msg.MaxTemperature)}
+    case 52:
+        return Value{Name: "total_moving_time", Num: 52, BaseType: 6,
+            Raw: msg.TotalMovingTime, Scaled: msg.Scaled("total_moving_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalMovingTime)}
+    case 53:
+        return Value{Name: "avg_pos_vertical_speed", Num: 53, BaseType: 3,
+            Raw: msg.AvgPosVerticalSpeed, Scaled: msg.Scaled("avg_pos_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.AvgPosVerticalSpeed)}
+    case 54:
+        return Value{Name: "avg_neg_vertical_speed", Num: 54, BaseType: 3,
+            Raw: msg.AvgNegVerticalSpeed, Scaled: msg.Scaled("avg_neg_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.AvgNegVerticalSpeed)}
+    case 55:
+        return Value{Name: "max_pos_vertical_speed", Num: 55, BaseType: 3,
+            Raw: msg.MaxPosVerticalSpeed, Scaled: msg.Scaled("max_pos_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.MaxPosVerticalSpeed)}
+    case 56:
+        return Value{Name: "max_neg_vertical_speed", Num: 56, BaseType: 3,
+            Raw: msg.MaxNegVerticalSpeed, Scaled: msg.Scaled("max_neg_vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.MaxNegVerticalSpeed)}
+    case 57:
+        return Value{Name: "time_in_hr_zone", Num: 57, BaseType: 6,
+            Raw: msg.TimeInHRZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInHRZone) > 0}
+    case 58:
+        return Value{Name: "time_in_speed_zone", Num: 58, BaseType: 6,
+            Raw: msg.TimeInSpeedZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInSpeedZone) > 0}
+    case 59:
+        return Value{Name: "time_in_cadence_zone", Num: 59, BaseType: 6,
+            Raw: msg.TimeInCadenceZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInCadenceZone) > 0}
+    case 60:
+        return Value{Name: "time_in_power_zone", Num: 60, BaseType: 6,
+            Raw: msg.TimeInPowerZone, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.TimeInPowerZone) > 0}
+    case 61:
+        return Value{Name: "repetition_num", Num: 61, BaseType: 4,
+            Raw: msg.RepetitionNum, Scaled: msg.Scaled("repetition_num"),
+            Units: "", Valid: is_valid_uint16(msg.RepetitionNum)}
+    case 62:
+        return Value{Name: "min_altitude", Num: 62, BaseType: 4,
+            Raw: msg.MinAltitude, Scaled: msg.Scaled("min_altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.MinAltitude)}
+    case 63:
+        return Value{Name: "min_heart_rate", Num: 63, BaseType: 2,
+            Raw: msg.MinHeartRate, Scaled: msg.Scaled("min_heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.MinHeartRate)}
+    case 71:
+        return Value{Name: "wkt_step_index", Num: 71, BaseType: 4,
+            Raw: msg.WktStepIndex, Scaled: msg.Scaled("wkt_step_index"),
+            Units: "", Valid: is_valid_uint16(msg.WktStepIndex)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgLap) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "timestamp": return msg.FieldByNum(253)
+    case "event": return msg.FieldByNum(0)
+    case "event_type": return msg.FieldByNum(1)
+    case "start_time": return msg.FieldByNum(2)
+    case "start_position_lat": return msg.FieldByNum(3)
+    case "start_position_long": return msg.FieldByNum(4)
+    case "end_position_lat": return msg.FieldByNum(5)
+    case "end_position_long": return msg.FieldByNum(6)
+    case "total_elapsed_time": return msg.FieldByNum(7)
+    case "total_timer_time": return msg.FieldByNum(8)
+    case "total_distance": return msg.FieldByNum(9)
+    case "total_cycles": return msg.FieldByNum(10)
+    case "total_calories": return msg.FieldByNum(11)
+    case "total_fat_calories": return msg.FieldByNum(12)
+    case "avg_speed": return msg.FieldByNum(13)
+    case "max_speed": return msg.FieldByNum(14)
+    case "avg_heart_rate": return msg.FieldByNum(15)
+    case "max_heart_rate": return msg.FieldByNum(16)
+    case "avg_cadence": return msg.FieldByNum(17)
+    case "max_cadence": return msg.FieldByNum(18)
+    case "avg_power": return msg.FieldByNum(19)
+    case "max_power": return msg.FieldByNum(20)
+    case "total_ascent": return msg.FieldByNum(21)
+    case "total_descent": return msg.FieldByNum(22)
+    case "intensity": return msg.FieldByNum(23)
+    case "lap_trigger": return msg.FieldByNum(24)
+    case "sport": return msg.FieldByNum(25)
+    case "event_group": return msg.FieldByNum(26)
+    case "num_lengths": return msg.FieldByNum(32)
+    case "normalized_power": return msg.FieldByNum(33)
+    case "left_right_balance": return msg.FieldByNum(34)
+    case "first_length_index": return msg.FieldByNum(35)
+    case "avg_stroke_distance": return msg.FieldByNum(37)
+    case "swim_stroke": return msg.FieldByNum(38)
+    case "sub_sport": return msg.FieldByNum(39)
+    case "num_active_lengths": return msg.FieldByNum(40)
+    case "total_work": return msg.FieldByNum(41)
+    case "avg_altitude": return msg.FieldByNum(42)
+    case "max_altitude": return msg.FieldByNum(43)
+    case "gps_accuracy": return msg.FieldByNum(44)
+    case "avg_grade": return msg.FieldByNum(45)
+    case "avg_pos_grade": return msg.FieldByNum(46)
+    case "avg_neg_grade": return msg.FieldByNum(47)
+    case "max_pos_grade": return msg.FieldByNum(48)
+    case "max_neg_grade": return msg.FieldByNum(49)
+    case "avg_temperature": return msg.FieldByNum(50)
+    case "max_temperature": return msg.FieldByNum(51)
+    case "total_moving_time": return msg.FieldByNum(52)
+    case "avg_pos_vertical_speed": return msg.FieldByNum(53)
+    case "avg_neg_vertical_speed": return msg.FieldByNum(54)
+    case "max_pos_vertical_speed": return msg.FieldByNum(55)
+    case "max_neg_vertical_speed": return msg.FieldByNum(56)
+    case "time_in_hr_zone": return msg.FieldByNum(57)
+    case "time_in_speed_zone": return msg.FieldByNum(58)
+    case "time_in_cadence_zone": return msg.FieldByNum(59)
+    case "time_in_power_zone": return msg.FieldByNum(60)
+    case "repetition_num": return msg.FieldByNum(61)
+    case "min_altitude": return msg.FieldByNum(62)
+    case "min_heart_rate": return msg.FieldByNum(63)
+    case "wkt_step_index": return msg.FieldByNum(71)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgLap) Fields() []Value {
+    return fieldValues(msg, []byte{254, 253, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 32, 33, 34, 35, 37, 38, 39, 40, 41, 42, 43, 44, 45, 46, 47, 48, 49, 50, 51, 52, 53, 54, 55, 56, 57, 58, 59, 60, 61, 62, 63, 71})
+}
+
+func NewMsgLap(def *FitDefinition, data []byte) (*MsgLap, error) {
+    msg := new(MsgLap)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Timestamp = invalid_uint32
+    msg.Event = Event(invalid_enum)
+    msg.EventType = EventType(invalid_enum)
+    msg.StartTime = invalid_uint32
+    msg.StartPositionLat = invalid_int32
+    msg.StartPositionLong = invalid_int32
+    msg.EndPositionLat = invalid_int32
+    msg.EndPositionLong = invalid_int32
+    msg.TotalElapsedTime = invalid_uint32
+    msg.TotalTimerTime = invalid_uint32
+    msg.TotalDistance = invalid_uint32
+    msg.TotalCycles = invalid_uint32
+    msg.TotalCalories = invalid_uint16
+    msg.TotalFatCalories = invalid_uint16
+    msg.AvgSpeed = invalid_uint16
+    msg.MaxSpeed = invalid_uint16
+    msg.AvgHeartRate = invalid_uint8
+    msg.MaxHeartRate = invalid_uint8
+    msg.AvgCadence = invalid_uint8
+    msg.MaxCadence = invalid_uint8
+    msg.AvgPower = invalid_uint16
+    msg.MaxPower = invalid_uint16
+    msg.TotalAscent = invalid_uint16
+    msg.TotalDescent = invalid_uint16
+    msg.Intensity = Intensity(invalid_enum)
+    msg.LapTrigger = LapTrigger(invalid_enum)
+    msg.Sport = Sport(invalid_enum)
+    msg.EventGroup = invalid_uint8
+    msg.NumLengths = invalid_uint16
+    msg.NormalizedPower = invalid_uint16
+    msg.LeftRightBalance = invalid_uint16
+    msg.FirstLengthIndex = invalid_uint16
+    msg.AvgStrokeDistance = invalid_uint16
+    msg.SwimStroke = SwimStroke(invalid_enum)
+    msg.SubSport = SubSport(invalid_enum)
+    msg.NumActiveLengths = invalid_uint16
+    msg.TotalWork = invalid_uint32
+    msg.AvgAltitude = invalid_uint16
+    msg.MaxAltitude = invalid_uint16
+    msg.GPSAccuracy = invalid_uint8
+    msg.AvgGrade = invalid_int16
+    msg.AvgPosGrade = invalid_int16
+    msg.AvgNegGrade = invalid_int16
+    msg.MaxPosGrade = invalid_int16
+    msg.MaxNegGrade = invalid_int16
+    msg.AvgTemperature = invalid_int8
+    msg.MaxTemperature = invalid_int8
+    msg.TotalMovingTime = invalid_uint32
+    msg.AvgPosVerticalSpeed = invalid_int16
+    msg.AvgNegVerticalSpeed = invalid_int16
+    msg.MaxPosVerticalSpeed = invalid_int16
+    msg.MaxNegVerticalSpeed = invalid_int16
+    msg.RepetitionNum = invalid_uint16
+    msg.MinAltitude = invalid_uint16
+    msg.MinHeartRate = invalid_uint8
+    msg.WktStepIndex = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.Event = Event(get_enum(buf, fld, order))
+        case 1: msg.EventType = EventType(get_enum(buf, fld, order))
+        case 2: msg.StartTime = get_uint32(buf, fld, order)
+        case 3: msg.StartPositionLat = get_int32(buf, fld, order)
+        case 4: msg.StartPositionLong = get_int32(buf, fld, order)
+        case 5: msg.EndPositionLat = get_int32(buf, fld, order)
+        case 6: msg.EndPositionLong = get_int32(buf, fld, order)
+        case 7: msg.TotalElapsedTime = get_uint32(buf, fld, order)
+        case 8: msg.TotalTimerTime = get_uint32(buf, fld, order)
+        case 9: msg.TotalDistance = get_uint32(buf, fld, order)
+        case 10: msg.TotalCycles = get_uint32(buf, fld, order)
+        case 11: msg.TotalCalories = get_uint16(buf, fld, order)
+        case 12: msg.TotalFatCalories = get_uint16(buf, fld, order)
+        case 13: msg.AvgSpeed = get_uint16(buf, fld, order)
+        case 14: msg.MaxSpeed = get_uint16(buf, fld, order)
+        case 15: msg.AvgHeartRate = get_uint8(buf, fld, order)
+        case 16: msg.MaxHeartRate = get_uint8(buf, fld, order)
+        case 17: msg.AvgCadence = get_uint8(buf, fld, order)
+        case 18: msg.MaxCadence = get_uint8(buf, fld, order)
+        case 19: msg.AvgPower = get_uint16(buf, fld, order)
+        case 20: msg.MaxPower = get_uint16(buf, fld, order)
+        case 21: msg.TotalAscent = get_uint16(buf, fld, order)
+        case 22: msg.TotalDescent = get_uint16(buf, fld, order)
+        case 23: msg.Intensity = Intensity(get_enum(buf, fld, order))
+        case 24: msg.LapTrigger = LapTrigger(get_enum(buf, fld, order))
+        case 25: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 26: msg.EventGroup = get_uint8(buf, fld, order)
+        case 32: msg.NumLengths = get_uint16(buf, fld, order)
+        case 33: msg.NormalizedPower = get_uint16(buf, fld, order)
+        case 34: msg.LeftRightBalance = get_uint16(buf, fld, order)
+        case 35: msg.FirstLengthIndex = get_uint16(buf, fld, order)
+        case 37: msg.AvgStrokeDistance = get_uint16(buf, fld, order)
+        case 38: msg.SwimStroke = SwimStroke(get_enum(buf, fld, order))
+        case 39: msg.SubSport = SubSport(get_enum(buf, fld, order))
+        case 40: msg.NumActiveLengths = get_uint16(buf, fld, order)
+        case 41: msg.TotalWork = get_uint32(buf, fld, order)
+        case 42: msg.AvgAltitude = get_uint16(buf, fld, order)
+        case 43: msg.MaxAltitude = get_uint16(buf, fld, order)
+        case 44: msg.GPSAccuracy = get_uint8(buf, fld, order)
+        case 45: msg.AvgGrade = get_int16(buf, fld, order)
+        case 46: msg.AvgPosGrade = get_int16(buf, fld, order)
+        case 47: msg.AvgNegGrade = get_int16(buf, fld, order)
+        case 48: msg.MaxPosGrade = get_int16(buf, fld, order)
+        case 49: msg.MaxNegGrade = get_int16(buf, fld, order)
+        case 50: msg.AvgTemperature = get_int8(buf, fld, order)
+        case 51: msg.MaxTemperature = get_int8(buf, fld, order)
+        case 52: msg.TotalMovingTime = get_uint32(buf, fld, order)
+        case 53: msg.AvgPosVerticalSpeed = get_int16(buf, fld, order)
+        case 54: msg.AvgNegVerticalSpeed = get_int16(buf, fld, order)
+        case 55: msg.MaxPosVerticalSpeed = get_int16(buf, fld, order)
+        case 56: msg.MaxNegVerticalSpeed = get_int16(buf, fld, order)
+        case 57: msg.TimeInHRZone = get_uint32_array(buf, fld, order)
+        case 58: msg.TimeInSpeedZone = get_uint32_array(buf, fld, order)
+        case 59: msg.TimeInCadenceZone = get_uint32_array(buf, fld, order)
+        case 60: msg.TimeInPowerZone = get_uint32_array(buf, fld, order)
+        case 61: msg.RepetitionNum = get_uint16(buf, fld, order)
+        case 62: msg.MinAltitude = get_uint16(buf, fld, order)
+        case 63: msg.MinHeartRate = get_uint8(buf, fld, order)
+        case 71: msg.WktStepIndex = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// record message
+
+// MsgRecord holds the fields of a record message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgRecord struct {
+    msgBase
+
+    Timestamp uint32 // s
+    PositionLat int32 // semicircles
+    PositionLong int32 // semicircles
+    Altitude uint16 // m, scale 5, offset 500
+    HeartRate uint8 // bpm
+    Cadence uint8 // rpm
+    Distance uint32 // m, scale 100
+    Speed uint16 // m/s, scale 1000
+    Power uint16 // watts
+    CompressedSpeedDistance []byte
+    Grade int16 // %, scale 100
+    Resistance uint8
+    TimeFromCourse int32 // s, scale 1000
+    CycleLength uint8 // m, scale 100
+    Temperature int8 // C
+    Speed1s []uint8 // m/s, scale 16
+    Cycles uint8 // cycles
+    TotalCycles uint32 // cycles
+    CompressedAccumulatedPower uint16
+    AccumulatedPower uint32 // watts
+    LeftRightBalance uint8
+    GPSAccuracy uint8 // m
+    VerticalSpeed int16 // m/s, scale 1000
+    Calories uint16 // kcal
+    LeftTorqueEffectiveness uint8 // percent, scale 2
+    RightTorqueEffectiveness uint8 // percent, scale 2
+    LeftPedalSmoothness uint8 // percent, scale 2
+    RightPedalSmoothness uint8 // percent, scale 2
+    CombinedPedalSmoothness uint8 // percent, scale 2
+    Cadence256 uint16 // rpm, scale 256
+}
+
+// fields of record messages which are unpacked into other fields
+var record_components = map[byte][]*fitComponent{
+    8: []*fitComponent{
+        &fitComponent{num: 6, base_type: 4, accumulate: false, bits: 12,
+            scale: 100, offset: 0, dest_scale: 1000, dest_offset: 0}, // speed
+        &fitComponent{num: 5, base_type: 6, accumulate: true, bits: 12,
+            scale: 16, offset: 0, dest_scale: 100, dest_offset: 0}, // distance
+    },
+    18: []*fitComponent{
+        &fitComponent{num: 19, base_type: 6, accumulate: true, bits: 8,
+            scale: 1, offset: 0, dest_scale: 1, dest_offset: 0}, // total_cycles
+    },
+    28: []*fitComponent{
+        &fitComponent{num: 29, base_type: 6, accumulate: true, bits: 16,
+            scale: 1, offset: 0, dest_scale: 1, dest_offset: 0}, // accumulated_power
+    },
+    52: []*fitComponent{
+        &fitComponent{num: 4, base_type: 2, accumulate: false, bits: 16,
+            scale: 256, offset: 0, dest_scale: 1, dest_offset: 0}, // cadence
+    },
+}
+
+func (msg *MsgRecord) Name() string {
+    return "record"
+}
+
+// altitude in m
+func (msg *MsgRecord) AltitudeScaled() float64 {
+    if !is_valid_uint16(msg.Altitude) {
+        return math.NaN()
+    }
+    return (float64(msg.Altitude) - 2500) / 5
+}
+
+// distance in m
+func (msg *MsgRecord) DistanceScaled() float64 {
+    if !is_valid_uint32(msg.Distance) {
+        return math.NaN()
+    }
+    return float64(msg.Distance) / 100
+}
+
+// speed in m/s
+func (msg *MsgRecord) SpeedScaled() float64 {
+    if !is_valid_uint16(msg.Speed) {
+        return math.NaN()
+    }
+    return float64(msg.Speed) / 1000
+}
+
+// grade in %
+func (msg *MsgRecord) GradeScaled() float64 {
+    if !is_valid_int16(msg.Grade) {
+        return math.NaN()
+    }
+    return float64(msg.Grade) / 100
+}
+
+// time_from_course in s
+func (msg *MsgRecord) TimeFromCourseScaled() float64 {
+    if !is_valid_int32(msg.TimeFromCourse) {
+        return math.NaN()
+    }
+    return float64(msg.TimeFromCourse) / 1000
+}
+
+// cycle_length in m
+func (msg *MsgRecord) CycleLengthScaled() float64 {
+    if !is_valid_uint8(msg.CycleLength) {
+        return math.NaN()
+    }
+    return float64(msg.CycleLength) / 100
+}
+
+// speed_1s in m/s
+func (msg *MsgRecord) Speed1sScaled() []float64 {
+    vals := make([]float64, len(msg.Speed1s))
+    for i, v := range msg.Speed1s {
+        if !is_valid_uint8(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 16
+        }
+    }
+    return vals
+}
+
+// vertical_speed in m/s
+func (msg *MsgRecord) VerticalSpeedScaled() float64 {
+    if !is_valid_int16(msg.VerticalSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.VerticalSpeed) / 1000
+}
+
+// left_torque_effectiveness in percent
+func (msg *MsgRecord) LeftTorqueEffectivenessScaled() float64 {
+    if !is_valid_uint8(msg.LeftTorqueEffectiveness) {
+        return math.NaN()
+    }
+    return float64(msg.LeftTorqueEffectiveness) / 2
+}
+
+// right_torque_effectiveness in percent
+func (msg *MsgRecord) RightTorqueEffectivenessScaled() float64 {
+    if !is_valid_uint8(msg.RightTorqueEffectiveness) {
+        return math.NaN()
+    }
+    return float64(msg.RightTorqueEffectiveness) / 2
+}
+
+// left_pedal_smoothness in percent
+func (msg *MsgRecord) LeftPedalSmoothnessScaled() float64 {
+    if !is_valid_uint8(msg.LeftPedalSmoothness) {
+        return math.NaN()
+    }
+    return float64(msg.LeftPedalSmoothness) / 2
+}
+
+// right_pedal_smoothness in percent
+func (msg *MsgRecord) RightPedalSmoothnessScaled() float64 {
+    if !is_valid_uint8(msg.RightPedalSmoothness) {
+        return math.NaN()
+    }
+    return float64(msg.RightPedalSmoothness) / 2
+}
+
+// combined_pedal_smoothness in percent
+func (msg *MsgRecord) CombinedPedalSmoothnessScaled() float64 {
+    if !is_valid_uint8(msg.CombinedPedalSmoothness) {
+        return math.NaN()
+    }
+    return float64(msg.CombinedPedalSmoothness) / 2
+}
+
+// cadence256 in rpm
+func (msg *MsgRecord) Cadence256Scaled() float64 {
+    if !is_valid_uint16(msg.Cadence256) {
+        return math.NaN()
+    }
+    return float64(msg.Cadence256) / 256
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgRecord) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// position_lat and position_long in degrees
+func (msg *MsgRecord) Position() Position {
+    return PositionFromSemicircles(msg.PositionLat, msg.PositionLong)
+}
+
+func (msg *MsgRecord) Text() string {
+    txt := "record"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_int32(msg.PositionLat) {
+        txt += fmt.Sprintf(" poslat %d", msg.PositionLat)
+    }
+    if is_valid_int32(msg.PositionLong) {
+        txt += fmt.Sprintf(" poslong %d", msg.PositionLong)
+    }
+    if is_valid_uint16(msg.Altitude) {
+        txt += fmt.Sprintf(" alt %g", msg.AltitudeScaled())
+    }
+    if is_valid_uint8(msg.HeartRate) {
+        txt += fmt.Sprintf(" heartrate %d", msg.HeartRate)
+    }
+    if is_valid_uint8(msg.Cadence) {
+        txt += fmt.Sprintf(" cadence %d", msg.Cadence)
+    }
+    if is_valid_uint32(msg.Distance) {
+        txt += fmt.Sprintf(" dist %g", msg.DistanceScaled())
+    }
+    if is_valid_uint16(msg.Speed) {
+        txt += fmt.Sprintf(" speed %g", msg.SpeedScaled())
+    }
+    if is_valid_uint16(msg.Power) {
+        txt += fmt.Sprintf(" power %d", msg.Power)
+    }
+    if len(msg.CompressedSpeedDistance) > 0 {
+        txt += fmt.Sprintf(" compressedspeeddist %d", msg.CompressedSpeedDistance)
+    }
+    if is_valid_int16(msg.Grade) {
+        txt += fmt.Sprintf(" grade %g", msg.GradeScaled())
+    }
+    if is_valid_uint8(msg.Resistance) {
+        txt += fmt.Sprintf(" resistance %d", msg.Resistance)
+    }
+    if is_valid_int32(msg.TimeFromCourse) {
+        txt += fmt.Sprintf(" timefromcourse %g", msg.TimeFromCourseScaled())
+    }
+    if is_valid_uint8(msg.CycleLength) {
+        txt += fmt.Sprintf(" cyclelen %g", msg.CycleLengthScaled())
+    }
+    if is_valid_int8(msg.Temperature) {
+        txt += fmt.Sprintf(" temp %d", msg.Temperature)
+    }
+    if len(msg.Speed1s) > 0 {
+        txt += fmt.Sprintf(" speed1s %g", msg.Speed1sScaled())
+    }
+    if is_valid_uint8(msg.Cycles) {
+        txt += fmt.Sprintf(" cycles %d", msg.Cycles)
+    }
+    if is_valid_uint32(msg.TotalCycles) {
+        txt += fmt.Sprintf(" totalcycles %d", msg.TotalCycles)
+    }
+    if is_valid_uint16(msg.CompressedAccumulatedPower) {
+        txt += fmt.Sprintf(" compressedaccumpower %d", msg.CompressedAccumulatedPower)
+    }
+    if is_valid_uint32(msg.AccumulatedPower) {
+        txt += fmt.Sprintf(" accumpower %d", msg.AccumulatedPower)
+    }
+    if is_valid_uint8(msg.LeftRightBalance) {
+        txt += fmt.Sprintf(" leftrightbalance %d", msg.LeftRightBalance)
+    }
+    if is_valid_uint8(msg.GPSAccuracy) {
+        txt += fmt.Sprintf(" gpsaccuracy %d", msg.GPSAccuracy)
+    }
+    if is_valid_int16(msg.VerticalSpeed) {
+        txt += fmt.Sprintf(" vertspeed %g", msg.VerticalSpeedScaled())
+    }
+    if is_valid_uint16(msg.Calories) {
+        txt += fmt.Sprintf(" cals %d", msg.Calories)
+    }
+    if is_valid_uint8(msg.LeftTorqueEffectiveness) {
+        txt += fmt.Sprintf(" lefttorqueeffectiveness %g", msg.LeftTorqueEffectivenessScaled())
+    }
+    if is_valid_uint8(msg.RightTorqueEffectiveness) {
+        txt += fmt.Sprintf(" righttorqueeffectiveness %g", msg.RightTorqueEffectivenessScaled())
+    }
+    if is_valid_uint8(msg.LeftPedalSmoothness) {
+        txt += fmt.Sprintf(" leftpedalsmooth %g", msg.LeftPedalSmoothnessScaled())
+    }
+    if is_valid_uint8(msg.RightPedalSmoothness) {
+        txt += fmt.Sprintf(" rightpedalsmooth %g", msg.RightPedalSmoothnessScaled())
+    }
+    if is_valid_uint8(msg.CombinedPedalSmoothness) {
+        txt += fmt.Sprintf(" combinedpedalsmooth %g", msg.CombinedPedalSmoothnessScaled())
+    }
+    if is_valid_uint16(msg.Cadence256) {
+        txt += fmt.Sprintf(" cadence256 %g", msg.Cadence256Scaled())
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgRecord) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "position_lat": return is_valid_int32(msg.PositionLat)
+    case "position_long": return is_valid_int32(msg.PositionLong)
+    case "altitude": return is_valid_uint16(msg.Altitude)
+    case "heart_rate": return is_valid_uint8(msg.HeartRate)
+    case "cadence": return is_valid_uint8(msg.Cadence)
+    case "distance": return is_valid_uint32(msg.Distance)
+    case "speed": return is_valid_uint16(msg.Speed)
+    case "power": return is_valid_uint16(msg.Power)
+    case "compressed_speed_distance": return len(msg.CompressedSpeedDistance) > 0
+    case "grade": return is_valid_int16(msg.Grade)
+    case "resistance": return is_valid_uint8(msg.Resistance)
+    case "time_from_course": return is_valid_int32(msg.TimeFromCourse)
+    case "cycle_length": return is_valid_uint8(msg.CycleLength)
+    case "temperature": return is_valid_int8(msg.Temperature)
+    case "speed_1s": return len(msg.Speed1s) > 0
+    case "cycles": return is_valid_uint8(msg.Cycles)
+    case "total_cycles": return is_valid_uint32(msg.TotalCycles)
+    case "compressed_accumulated_power": return is_valid_uint16(msg.CompressedAccumulatedPower)
+    case "accumulated_power": return is_valid_uint32(msg.AccumulatedPower)
+    case "left_right_balance": return is_valid_uint8(msg.LeftRightBalance)
+    case "gps_accuracy": return is_valid_uint8(msg.GPSAccuracy)
+    case "vertical_speed": return is_valid_int16(msg.VerticalSpeed)
+    case "calories": return is_valid_uint16(msg.Calories)
+    case "left_torque_effectiveness": return is_valid_uint8(msg.LeftTorqueEffectiveness)
+    case "right_torque_effectiveness": return is_valid_uint8(msg.RightTorqueEffectiveness)
+    case "left_pedal_smoothness": return is_valid_uint8(msg.LeftPedalSmoothness)
+    case "right_pedal_smoothness": return is_valid_uint8(msg.RightPedalSmoothness)
+    case "combined_pedal_smoothness": return is_valid_uint8(msg.CombinedPedalSmoothness)
+    case "cadence256": return is_valid_uint16(msg.Cadence256)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgRecord) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "position_lat":
+        if is_valid_int32(msg.PositionLat) {
+            return float64(msg.PositionLat)
+        }
+    case "position_long":
+        if is_valid_int32(msg.PositionLong) {
+            return float64(msg.PositionLong)
+        }
+    case "altitude":
+        return msg.AltitudeScaled()
+    case "heart_rate":
+        if is_valid_uint8(msg.HeartRate) {
+            return float64(msg.HeartRate)
+        }
+    case "cadence":
+        if is_valid_uint8(msg.Cadence) {
+            return float64(msg.Cadence)
+        }
+    case "distance":
+        return msg.DistanceScaled()
+    case "speed":
+        return msg.SpeedScaled()
+    case "power":
+        if is_valid_uint16(msg.Power) {
+            return float64(msg.Power)
+        }
+    case "grade":
+        return msg.GradeScaled()
+    case "resistance":
+        if is_valid_uint8(msg.Resistance) {
+            return float64(msg.Resistance)
+        }
+    case "time_from_course":
+        return msg.TimeFromCourseScaled()
+    case "cycle_length":
+        return msg.CycleLengthScaled()
+    case "temperature":
+        if is_valid_int8(msg.Temperature) {
+            return float64(msg.Temperature)
+        }
+    case "cycles":
+        if is_valid_uint8(msg.Cycles) {
+            return float64(msg.Cycles)
+        }
+    case "total_cycles":
+        if is_valid_uint32(msg.TotalCycles) {
+            return float64(msg.TotalCycles)
+        }
+    case "compressed_accumulated_power":
+        if is_valid_uint16(msg.CompressedAccumulatedPower) {
+            return float64(msg.CompressedAccumulatedPower)
+        }
+    case "accumulated_power":
+        if is_valid_uint32(msg.AccumulatedPower) {
+            return float64(msg.AccumulatedPower)
+        }
+    case "left_right_balance":
+        if is_valid_uint8(msg.LeftRightBalance) {
+            return float64(msg.LeftRightBalance)
+        }
+    case "gps_accuracy":
+        if is_valid_uint8(msg.GPSAccuracy) {
+            return float64(msg.GPSAccuracy)
+        }
+    case "vertical_speed":
+        return msg.VerticalSpeedScaled()
+    case "calories":
+        if is_valid_uint16(msg.Calories) {
+            return float64(msg.Calories)
+        }
+    case "left_torque_effectiveness":
+        return msg.LeftTorqueEffectivenessScaled()
+    case "right_torque_effectiveness":
+        return msg.RightTorqueEffectivenessScaled()
+    case "left_pedal_smoothness":
+        return msg.LeftPedalSmoothnessScaled()
+    case "right_pedal_smoothness":
+        return msg.RightPedalSmoothnessScaled()
+    case "combined_pedal_smoothness":
+        return msg.CombinedPedalSmoothnessScaled()
+    case "cadence256":
+        return msg.Cadence256Scaled()
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgRecord) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "position_lat": return "semicircles"
+    case "position_long": return "semicircles"
+    case "altitude": return "m"
+    case "heart_rate": return "bpm"
+    case "cadence": return "rpm"
+    case "distance": return "m"
+    case "speed": return "m/s"
+    case "power": return "watts"
+    case "grade": return "%"
+    case "time_from_course": return "s"
+    case "cycle_length": return "m"
+    case "temperature": return "C"
+    case "speed_1s": return "m/s"
+    case "cycles": return "cycles"
+    case "total_cycles": return "cycles"
+    case "accumulated_power": return "watts"
+    case "gps_accuracy": return "m"
+    case "vertical_speed": return "m/s"
+    case "calories": return "kcal"
+    case "left_torque_effectiveness": return "percent"
+    case "right_torque_effectiveness": return "percent"
+    case "left_pedal_smoothness": return "percent"
+    case "right_pedal_smoothness": return "percent"
+    case "combined_pedal_smoothness": return "percent"
+    case "cadence256": return "rpm"
+    default: return ""
+    }
+}
+
+// Accumulated returns the named field's running total, which keeps
+// increasing when the value in the file rolls over, or NaN if the
+// field is not set or is not accumulated
+func (msg *MsgRecord) Accumulated(name string) float64 {
+    switch name {
+    case "distance": return msg.total(5, 100, 0)
+    case "total_cycles": return msg.total(19, 1, 0)
+    case "accumulated_power": return msg.total(29, 1, 0)
+    default: return math.NaN()
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgRecord) GlobalNum() uint16 {
+    return 20
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgRecord) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "position_lat", Num: 0, BaseType: 5,
+            Raw: msg.PositionLat, Scaled: msg.Scaled("position_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.PositionLat)}
+    case 1:
+        return Value{Name: "position_long", Num: 1, BaseType: 5,
+            Raw: msg.PositionLong, Scaled: msg.Scaled("position_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.PositionLong)}
+    case 2:
+        return Value{Name: "altitude", Num: 2, BaseType: 4,
+            Raw: msg.Altitude, Scaled: msg.Scaled("altitude"),
+            Units: "m", Valid: is_valid_uint16(msg.Altitude)}
+    case 3:
+        return Value{Name: "heart_rate", Num: 3, BaseType: 2,
+            Raw: msg.HeartRate, Scaled: msg.Scaled("heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.HeartRate)}
+    case 4:
+        return Value{Name: "cadence", Num: 4, BaseType: 2,
+            Raw: msg.Cadence, Scaled: msg.Scaled("cadence"),
+            Units: "rpm", Valid: is_valid_uint8(msg.Cadence)}
+    case 5:
+        return Value{Name: "distance", Num: 5, BaseType: 6,
+            Raw: msg.Distance, Scaled: msg.Scaled("distance"),
+            Units: "m", Valid: is_valid_uint32(msg.Distance)}
+    case 6:
+        return Value{Name: "speed", Num: 6, BaseType: 4,
+            Raw: msg.Speed, Scaled: msg.Scaled("speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.Speed)}
+    case 7:
+        return Value{Name: "power", Num: 7, BaseType: 4,
+            Raw: msg.Power, Scaled: msg.Scaled("power"),
+            Units: "watts", Valid: is_valid_uint16(msg.Power)}
+    case 8:
+        return Value{Name: "compressed_speed_distance", Num: 8, BaseType: 13,
+            Raw: msg.CompressedSpeedDistance, Scaled: math.NaN(),
+            Units: "", Valid: len(msg.CompressedSpeedDistance) > 0}
+    case 9:
+        return Value{Name: "grade", Num: 9, BaseType: 3,
+            Raw: msg.Grade, Scaled: msg.Scaled("grade"),
+            Units: "%", Valid: is_valid_int16(msg.Grade)}
+    case 10:
+        return Value{Name: "resistance", Num: 10, BaseType: 2,
+            Raw: msg.Resistance, Scaled: msg.Scaled("resistance"),
+            Units: "", Valid: is_valid_uint8(msg.Resistance)}
+    case 11:
+        return Value{Name: "time_from_course", Num: 11, BaseType: 5,
+            Raw: msg.TimeFromCourse, Scaled: msg.Scaled("time_from_course"),
+            Units: "s", Valid: is_valid_int32(msg.TimeFromCourse)}
+    case 12:
+        return Value{Name: "cycle_length", Num: 12, BaseType: 2,
+            Raw: msg.CycleLength, Scaled: msg.Scaled("cycle_length"),
+            Units: "m", Valid: is_valid_uint8(msg.CycleLength)}
+    case 13:
+        return Value{Name: "temperature", Num: 13, BaseType: 1,
+            Raw: msg.Temperature, Scaled: msg.Scaled("temperature"),
+            Units: "C", Valid: is_valid_int8(msg.Temperature)}
+    case 17:
+        return Value{Name: "speed_1s", Num: 17, BaseType: 2,
+            Raw: msg.Speed1s, Scaled: math.NaN(),
+            Units: "m/s", Valid: len(msg.Speed1s) > 0}
+    case 18:
+        return Value{Name: "cycles", Num: 18, BaseType: 2,
+            Raw: msg.Cycles, Scaled: msg.Scaled("cycles"),
+            Units: "cycles", Valid: is_valid_uint8(msg.Cycles)}
+    case 19:
+        return Value{Name: "total_cycles", Num: 19, BaseType: 6,
+            Raw: msg.TotalCycles, Scaled: msg.Scaled("total_cycles"),
+            Units: "cycles", Valid: is_valid_uint32(msg.TotalCycles)}
+    case 28:
+        return Value{Name: "compressed_accumulated_power", Num: 28, BaseType: 4,
+            Raw: msg.CompressedAccumulatedPower, Scaled: msg.Scaled("compressed_accumulated_power"),
+            Units: "", Valid: is_valid_uint16(msg.CompressedAccumulatedPower)}
+    case 29:
+        return Value{Name: "accumulated_power", Num: 29, BaseType: 6,
+            Raw: msg.AccumulatedPower, Scaled: msg.Scaled("accumulated_power"),
+            Units: "watts", Valid: is_valid_uint32(msg.AccumulatedPower)}
+    case 30:
+        return Value{Name: "left_right_balance", Num: 30, BaseType: 2,
+            Raw: msg.LeftRightBalance, Scaled: msg.Scaled("left_right_balance"),
+            Units: "", Valid: is_valid_uint8(msg.LeftRightBalance)}
+    case 31:
+        return Value{Name: "gps_accuracy", Num: 31, BaseType: 2,
+            Raw: msg.GPSAccuracy, Scaled: msg.Scaled("gps_accuracy"),
+            Units: "m", Valid: is_valid_uint8(msg.GPSAccuracy)}
+    case 32:
+        return Value{Name: "vertical_speed", Num: 32, BaseType: 3,
+            Raw: msg.VerticalSpeed, Scaled: msg.Scaled("vertical_speed"),
+            Units: "m/s", Valid: is_valid_int16(msg.VerticalSpeed)}
+    case 33:
+        return Value{Name: "calories", Num: 33, BaseType: 4,
+            Raw: msg.Calories, Scaled: msg.Scaled("calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.Calories)}
+    case 43:
+        return Value{Name: "left_torque_effectiveness", Num: 43, BaseType: 2,
+            Raw: msg.LeftTorqueEffectiveness, Scaled: msg.Scaled("left_torque_effectiveness"),
+            Units: "percent", Valid: is_valid_uint8(msg.LeftTorqueEffectiveness)}
+    case 44:
+        return Value{Name: "right_torque_effectiveness", Num: 44, BaseType: 2,
+            Raw: msg.RightTorqueEffectiveness, Scaled: msg.Scaled("right_torque_effectiveness"),
+            Units: "percent", Valid: is_valid_uint8(msg.RightTorqueEffectiveness)}
+    case 45:
+        return Value{Name: "left_pedal_smoothness", Num: 45, BaseType: 2,
+            Raw: msg.LeftPedalSmoothness, Scaled: msg.Scaled("left_pedal_smoothness"),
+            Units: "percent", Valid: is_valid_uint8(msg.LeftPedalSmoothness)}
+    case 46:
+        return Value{Name: "right_pedal_smoothness", Num: 46, BaseType: 2,
+            Raw: msg.RightPedalSmoothness, Scaled: msg.Scaled("right_pedal_smoothness"),
+            Units: "percent", Valid: is_valid_uint8(msg.RightPedalSmoothness)}
+    case 47:
+        return Value{Name: "combined_pedal_smoothness", Num: 47, BaseType: 2,
+            Raw: msg.CombinedPedalSmoothness, Scaled: msg.Scaled("combined_pedal_smoothness"),
+            Units: "percent", Valid: is_valid_uint8(msg.CombinedPedalSmoothness)}
+    case 52:
+        return Value{Name: "cadence256", Num: 52, BaseType: 4,
+            Raw: msg.Cadence256, Scaled: msg.Scaled("cadence256"),
+            Units: "rpm", Valid: is_valid_uint16(msg.Cadence256)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgRecord) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "position_lat": return msg.FieldByNum(0)
+    case "position_long": return msg.FieldByNum(1)
+    case "altitude": return msg.FieldByNum(2)
+    case "heart_rate": return msg.FieldByNum(3)
+    case "cadence": return msg.FieldByNum(4)
+    case "distance": return msg.FieldByNum(5)
+    case "speed": return msg.FieldByNum(6)
+    case "power": return msg.FieldByNum(7)
+    case "compressed_speed_distance": return msg.FieldByNum(8)
+    case "grade": return msg.FieldByNum(9)
+    case "resistance": return msg.FieldByNum(10)
+    case "time_from_course": return msg.FieldByNum(11)
+    case "cycle_length": return msg.FieldByNum(12)
+    case "temperature": return msg.FieldByNum(13)
+    case "speed_1s": return msg.FieldByNum(17)
+    case "cycles": return msg.FieldByNum(18)
+    case "total_cycles": return msg.FieldByNum(19)
+    case "compressed_accumulated_power": return msg.FieldByNum(28)
+    case "accumulated_power": return msg.FieldByNum(29)
+    case "left_right_balance": return msg.FieldByNum(30)
+    case "gps_accuracy": return msg.FieldByNum(31)
+    case "vertical_speed": return msg.FieldByNum(32)
+    case "calories": return msg.FieldByNum(33)
+    case "left_torque_effectiveness": return msg.FieldByNum(43)
+    case "right_torque_effectiveness": return msg.FieldByNum(44)
+    case "left_pedal_smoothness": return msg.FieldByNum(45)
+    case "right_pedal_smoothness": return msg.FieldByNum(46)
+    case "combined_pedal_smoothness": return msg.FieldByNum(47)
+    case "cadence256": return msg.FieldByNum(52)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgRecord) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 17, 18, 19, 28, 29, 30, 31, 32, 33, 43, 44, 45, 46, 47, 52})
+}
+
+func NewMsgRecord(def *FitDefinition, data []byte) (*MsgRecord, error) {
+    msg := new(MsgRecord)
+
+    msg.Timestamp = invalid_uint32
+    msg.PositionLat = invalid_int32
+    msg.PositionLong = invalid_int32
+    msg.Altitude = invalid_uint16
+    msg.HeartRate = invalid_uint8
+    msg.Cadence = invalid_uint8
+    msg.Distance = invalid_uint32
+    msg.Speed = invalid_uint16
+    msg.Power = invalid_uint16
+    msg.Grade = invalid_int16
+    msg.Resistance = invalid_uint8
+    msg.TimeFromCourse = invalid_int32
+    msg.CycleLength = invalid_uint8
+    msg.Temperature = invalid_int8
+    msg.Cycles = invalid_uint8
+    msg.TotalCycles = invalid_uint32
+    msg.CompressedAccumulatedPower = invalid_uint16
+    msg.AccumulatedPower = invalid_uint32
+    msg.LeftRightBalance = invalid_uint8
+    msg.GPSAccuracy = invalid_uint8
+    msg.VerticalSpeed = invalid_int16
+    msg.Calories = invalid_uint16
+    msg.LeftTorqueEffectiveness = invalid_uint8
+    msg.RightTorqueEffectiveness = invalid_uint8
+    msg.LeftPedalSmoothness = invalid_uint8
+    msg.RightPedalSmoothness = invalid_uint8
+    msg.CombinedPedalSmoothness = invalid_uint8
+    msg.Cadence256 = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.PositionLat = get_int32(buf, fld, order)
+        case 1: msg.PositionLong = get_int32(buf, fld, order)
+        case 2: msg.Altitude = get_uint16(buf, fld, order)
+        case 3: msg.HeartRate = get_uint8(buf, fld, order)
+        case 4: msg.Cadence = get_uint8(buf, fld, order)
+        case 5: msg.Distance = get_uint32(buf, fld, order)
+        case 6: msg.Speed = get_uint16(buf, fld, order)
+        case 7: msg.Power = get_uint16(buf, fld, order)
+        case 8: msg.CompressedSpeedDistance = get_byte_array(buf, fld, order)
+        case 9: msg.Grade = get_int16(buf, fld, order)
+        case 10: msg.Resistance = get_uint8(buf, fld, order)
+        case 11: msg.TimeFromCourse = get_int32(buf, fld, order)
+        case 12: msg.CycleLength = get_uint8(buf, fld, order)
+        case 13: msg.Temperature = get_int8(buf, fld, order)
+        case 17: msg.Speed1s = get_uint8_array(buf, fld, order)
+        case 18: msg.Cycles = get_uint8(buf, fld, order)
+        case 19: msg.TotalCycles = get_uint32(buf, fld, order)
+        case 28: msg.CompressedAccumulatedPower = get_uint16(buf, fld, order)
+        case 29: msg.AccumulatedPower = get_uint32(buf, fld, order)
+        case 30: msg.LeftRightBalance = get_uint8(buf, fld, order)
+        case 31: msg.GPSAccuracy = get_uint8(buf, fld, order)
+        case 32: msg.VerticalSpeed = get_int16(buf, fld, order)
+        case 33: msg.Calories = get_uint16(buf, fld, order)
+        case 43: msg.LeftTorqueEffectiveness = get_uint8(buf, fld, order)
+        case 44: msg.RightTorqueEffectiveness = get_uint8(buf, fld, order)
+        case 45: msg.LeftPedalSmoothness = get_uint8(buf, fld, order)
+        case 46: msg.RightPedalSmoothness = get_uint8(buf, fld, order)
+        case 47: msg.CombinedPedalSmoothness = get_uint8(buf, fld, order)
+        case 52: msg.Cadence256 = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// event message
+
+// MsgEvent holds the fields of a event message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgEvent struct {
+    msgBase
+
+    Timestamp uint32 // s
+    Event Event
+    EventType EventType
+    Data16 uint16
+    Data uint32 // see SubField("data")
+    EventGroup uint8
+}
+
+// fields of event messages which are unpacked into other fields
+var event_components = map[byte][]*fitComponent{
+    2: []*fitComponent{
+        &fitComponent{num: 3, base_type: 6, accumulate: false, bits: 16,
+            scale: 1, offset: 0, dest_scale: 1, dest_offset: 0}, // data
+    },
+}
+
+func (msg *MsgEvent) Name() string {
+    return "event"
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgEvent) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// name of the subfield which holds the value of data, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgEvent) data_subfield() string {
+    switch {
+    case msg.Event == 0: return "timer_trigger"
+    case msg.Event == 10: return "course_point_index"
+    case msg.Event == 11: return "battery_level"
+    case msg.Event == 12: return "virtual_partner_speed"
+    case msg.Event == 13: return "hr_high_alert"
+    case msg.Event == 14: return "hr_low_alert"
+    case msg.Event == 15: return "speed_high_alert"
+    case msg.Event == 16: return "speed_low_alert"
+    case msg.Event == 17: return "cad_high_alert"
+    case msg.Event == 18: return "cad_low_alert"
+    case msg.Event == 19: return "power_high_alert"
+    case msg.Event == 20: return "power_low_alert"
+    case msg.Event == 23: return "time_duration_alert"
+    case msg.Event == 24: return "distance_duration_alert"
+    case msg.Event == 25: return "calorie_duration_alert"
+    case msg.Event == 27: return "fitness_equipment_state"
+    default: return ""
+    }
+}
+
+// data when it holds timer_trigger
+func (msg *MsgEvent) TimerTrigger() TimerTrigger {
+    if msg.data_subfield() != "timer_trigger" ||
+        !is_valid_uint32(msg.Data) {
+        return TimerTrigger(invalid_enum)
+    }
+    return TimerTrigger(msg.Data)
+}
+
+// data when it holds course_point_index
+func (msg *MsgEvent) CoursePointIndex() uint16 {
+    if msg.data_subfield() != "course_point_index" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// data when it holds battery_level
+func (msg *MsgEvent) BatteryLevel() uint16 {
+    if msg.data_subfield() != "battery_level" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// battery_level in V
+func (msg *MsgEvent) BatteryLevelScaled() float64 {
+    if msg.data_subfield() != "battery_level" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 1000
+}
+
+// data when it holds virtual_partner_speed
+func (msg *MsgEvent) VirtualPartnerSpeed() uint16 {
+    if msg.data_subfield() != "virtual_partner_speed" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// virtual_partner_speed in m/s
+func (msg *MsgEvent) VirtualPartnerSpeedScaled() float64 {
+    if msg.data_subfield() != "virtual_partner_speed" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 1000
+}
+
+// data when it holds hr_high_alert
+func (msg *MsgEvent) HRHighAlert() uint8 {
+    if msg.data_subfield() != "hr_high_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint8
+    }
+    return uint8(msg.Data)
+}
+
+// data when it holds hr_low_alert
+func (msg *MsgEvent) HRLowAlert() uint8 {
+    if msg.data_subfield() != "hr_low_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint8
+    }
+    return uint8(msg.Data)
+}
+
+// data when it holds speed_high_alert
+func (msg *MsgEvent) SpeedHighAlert() uint32 {
+    if msg.data_subfield() != "speed_high_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint32
+    }
+    return uint32(msg.Data)
+}
+
+// speed_high_alert in m/s
+func (msg *MsgEvent) SpeedHighAlertScaled() float64 {
+    if msg.data_subfield() != "speed_high_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 1000
+}
+
+// data when it holds speed_low_alert
+func (msg *MsgEvent) SpeedLowAlert() uint32 {
+    if msg.data_subfield() != "speed_low_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint32
+    }
+    return uint32(msg.Data)
+}
+
+// speed_low_alert in m/s
+func (msg *MsgEvent) SpeedLowAlertScaled() float64 {
+    if msg.data_subfield() != "speed_low_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 1000
+}
+
+// data when it holds cad_high_alert
+func (msg *MsgEvent) CadHighAlert() uint16 {
+    if msg.data_subfield() != "cad_high_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// data when it holds cad_low_alert
+func (msg *MsgEvent) CadLowAlert() uint16 {
+    if msg.data_subfield() != "cad_low_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// data when it holds power_high_alert
+func (msg *MsgEvent) PowerHighAlert() uint16 {
+    if msg.data_subfield() != "power_high_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// data when it holds power_low_alert
+func (msg *MsgEvent) PowerLowAlert() uint16 {
+    if msg.data_subfield() != "power_low_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint16
+    }
+    return uint16(msg.Data)
+}
+
+// data when it holds time_duration_alert
+func (msg *MsgEvent) TimeDurationAlert() uint32 {
+    if msg.data_subfield() != "time_duration_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint32
+    }
+    return uint32(msg.Data)
+}
+
+// time_duration_alert in s
+func (msg *MsgEvent) TimeDurationAlertScaled() float64 {
+    if msg.data_subfield() != "time_duration_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 1000
+}
+
+// data when it holds distance_duration_alert
+func (msg *MsgEvent) DistanceDurationAlert() uint32 {
+    if msg.data_subfield() != "distance_duration_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint32
+    }
+    return uint32(msg.Data)
+}
+
+// distance_duration_alert in m
+func (msg *MsgEvent) DistanceDurationAlertScaled() float64 {
+    if msg.data_subfield() != "distance_duration_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return math.NaN()
+    }
+    return float64(msg.Data) / 100
+}
+
+// data when it holds calorie_duration_alert
+func (msg *MsgEvent) CalorieDurationAlert() uint32 {
+    if msg.data_subfield() != "calorie_duration_alert" ||
+        !is_valid_uint32(msg.Data) {
+        return invalid_uint32
+    }
+    return uint32(msg.Data)
+}
+
+// data when it holds fitness_equipment_state
+func (msg *MsgEvent) FitnessEquipmentState() FitnessEquipmentState {
+    if msg.data_subfield() != "fitness_equipment_state" ||
+        !is_valid_uint32(msg.Data) {
+        return FitnessEquipmentState(invalid_enum)
+    }
+    return FitnessEquipmentState(msg.Data)
+}
+
+func (msg *MsgEvent) Text() string {
+    txt := "event"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_enum(byte(msg.Event)) {
+        txt += fmt.Sprintf(" evt %s", msg.Event)
+    }
+    if is_valid_enum(byte(msg.EventType)) {
+        txt += fmt.Sprintf(" evttyp %s", msg.EventType)
+    }
+    if is_valid_uint16(msg.Data16) {
+        txt += fmt.Sprintf(" data16 %d", msg.Data16)
+    }
+    if is_valid_uint32(msg.Data) {
+        switch msg.data_subfield() {
+        case "timer_trigger":
+            txt += fmt.Sprintf(" timertrigger %s", msg.TimerTrigger())
+        case "course_point_index":
+            txt += fmt.Sprintf(" coursepointidx %d", msg.CoursePointIndex())
+        case "battery_level":
+            txt += fmt.Sprintf(" battlevel %g", msg.BatteryLevelScaled())
+        case "virtual_partner_speed":
+            txt += fmt.Sprintf(" virtualpartnerspeed %g", msg.VirtualPartnerSpeedScaled())
+        case "hr_high_alert":
+            txt += fmt.Sprintf(" hrhighalert %d", msg.HRHighAlert())
+        case "hr_low_alert":
+            txt += fmt.Sprintf(" hrlowalert %d", msg.HRLowAlert())
+        case "speed_high_alert":
+            txt += fmt.Sprintf(" speedhighalert %g", msg.SpeedHighAlertScaled())
+        case "speed_low_alert":
+            txt += fmt.Sprintf(" speedlowalert %g", msg.SpeedLowAlertScaled())
+        case "cad_high_alert":
+            txt += fmt.Sprintf(" cadhighalert %d", msg.CadHighAlert())
+        case "cad_low_alert":
+            txt += fmt.Sprintf(" cadlowalert %d", msg.CadLowAlert())
+        case "power_high_alert":
+            txt += fmt.Sprintf(" powerhighalert %d", msg.PowerHighAlert())
+        case "power_low_alert":
+            txt += fmt.Sprintf(" powerlowalert %d", msg.PowerLowAlert())
+        case "time_duration_alert":
+            txt += fmt.Sprintf(" timedurationalert %g", msg.TimeDurationAlertScaled())
+        case "distance_duration_alert":
+            txt += fmt.Sprintf(" distdurationalert %g", msg.DistanceDurationAlertScaled())
+        case "calorie_duration_alert":
+            txt += fmt.Sprintf(" caldurationalert %d", msg.CalorieDurationAlert())
+        case "fitness_equipment_state":
+            txt += fmt.Sprintf(" fitnessequipmentstate %s", msg.FitnessEquipmentState())
+        default:
+            txt += fmt.Sprintf(" data %d", msg.Data)
+        }
+    }
+    if is_valid_uint8(msg.EventGroup) {
+        txt += fmt.Sprintf(" evtgrp %d", msg.EventGroup)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgEvent) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "event": return is_valid_enum(byte(msg.Event))
+    case "event_type": return is_valid_enum(byte(msg.EventType))
+    case "data16": return is_valid_uint16(msg.Data16)
+    case "data": return is_valid_uint32(msg.Data)
+    case "event_group": return is_valid_uint8(msg.EventGroup)
+    case "timer_trigger":
+        return msg.data_subfield() == "timer_trigger" && is_valid_uint32(msg.Data)
+    case "course_point_index":
+        return msg.data_subfield() == "course_point_index" && is_valid_uint32(msg.Data)
+    case "battery_level":
+        return msg.data_subfield() == "battery_level" && is_valid_uint32(msg.Data)
+    case "virtual_partner_speed":
+        return msg.data_subfield() == "virtual_partner_speed" && is_valid_uint32(msg.Data)
+    case "hr_high_alert":
+        return msg.data_subfield() == "hr_high_alert" && is_valid_uint32(msg.Data)
+    case "hr_low_alert":
+        return msg.data_subfield() == "hr_low_alert" && is_valid_uint32(msg.Data)
+    case "speed_high_alert":
+        return msg.data_subfield() == "speed_high_alert" && is_valid_uint32(msg.Data)
+    case "speed_low_alert":
+        return msg.data_subfield() == "speed_low_alert" && is_valid_uint32(msg.Data)
+    case "cad_high_alert":
+        return msg.data_subfield() == "cad_high_alert" && is_valid_uint32(msg.Data)
+    case "cad_low_alert":
+        return msg.data_subfield() == "cad_low_alert" && is_valid_uint32(msg.Data)
+    case "power_high_alert":
+        return msg.data_subfield() == "power_high_alert" && is_valid_uint32(msg.Data)
+    case "power_low_alert":
+        return msg.data_subfield() == "power_low_alert" && is_valid_uint32(msg.Data)
+    case "time_duration_alert":
+        return msg.data_subfield() == "time_duration_alert" && is_valid_uint32(msg.Data)
+    case "distance_duration_alert":
+        return msg.data_subfield() == "distance_duration_alert" && is_valid_uint32(msg.Data)
+    case "calorie_duration_alert":
+        return msg.data_subfield() == "calorie_duration_alert" && is_valid_uint32(msg.Data)
+    case "fitness_equipment_state":
+        return msg.data_subfield() == "fitness_equipment_state" && is_valid_uint32(msg.Data)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgEvent) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "event":
+        if is_valid_enum(byte(msg.Event)) {
+            return float64(msg.Event)
+        }
+    case "event_type":
+        if is_valid_enum(byte(msg.EventType)) {
+            return float64(msg.EventType)
+        }
+    case "data16":
+        if is_valid_uint16(msg.Data16) {
+            return float64(msg.Data16)
+        }
+    case "data":
+        if is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "event_group":
+        if is_valid_uint8(msg.EventGroup) {
+            return float64(msg.EventGroup)
+        }
+    case "timer_trigger":
+        if msg.data_subfield() == "timer_trigger" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "course_point_index":
+        if msg.data_subfield() == "course_point_index" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "battery_level":
+        return msg.BatteryLevelScaled()
+    case "virtual_partner_speed":
+        return msg.VirtualPartnerSpeedScaled()
+    case "hr_high_alert":
+        if msg.data_subfield() == "hr_high_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "hr_low_alert":
+        if msg.data_subfield() == "hr_low_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "speed_high_alert":
+        return msg.SpeedHighAlertScaled()
+    case "speed_low_alert":
+        return msg.SpeedLowAlertScaled()
+    case "cad_high_alert":
+        if msg.data_subfield() == "cad_high_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "cad_low_alert":
+        if msg.data_subfield() == "cad_low_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "power_high_alert":
+        if msg.data_subfield() == "power_high_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "power_low_alert":
+        if msg.data_subfield() == "power_low_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "time_duration_alert":
+        return msg.TimeDurationAlertScaled()
+    case "distance_duration_alert":
+        return msg.DistanceDurationAlertScaled()
+    case "calorie_duration_alert":
+        if msg.data_subfield() == "calorie_duration_alert" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    case "fitness_equipment_state":
+        if msg.data_subfield() == "fitness_equipment_state" && is_valid_uint32(msg.Data) {
+            return float64(msg.Data)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgEvent) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "battery_level": return "V"
+    case "virtual_partner_speed": return "m/s"
+    case "hr_high_alert": return "bpm"
+    case "hr_low_alert": return "bpm"
+    case "speed_high_alert": return "m/s"
+    case "speed_low_alert": return "m/s"
+    case "cad_high_alert": return "rpm"
+    case "cad_low_alert": return "rpm"
+    case "power_high_alert": return "watts"
+    case "power_low_alert": return "watts"
+    case "time_duration_alert": return "s"
+    case "distance_duration_alert": return "m"
+    case "calorie_duration_alert": return "calories"
+    default: return ""
+    }
+}
+
+// SubField returns the name of the subfield selected for the named
+// field by the message's other fields, or an empty string if none is
+func (msg *MsgEvent) SubField(name string) string {
+    switch name {
+    case "data": return msg.data_subfield()
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgEvent) GlobalNum() uint16 {
+    return 21
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgEvent) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "event", Num: 0, BaseType: 0,
+            Raw: msg.Event, Scaled: msg.Scaled("event"),
+            Units: "", Valid: is_valid_enum(byte(msg.Event))}
+    case 1:
+        return Value{Name: "event_type", Num: 1, BaseType: 0,
+            Raw: msg.EventType, Scaled: msg.Scaled("event_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.EventType))}
+    case 2:
+        return Value{Name: "data16", Num: 2, BaseType: 4,
+            Raw: msg.Data16, Scaled: msg.Scaled("data16"),
+            Units: "", Valid: is_valid_uint16(msg.Data16)}
+    case 3:
+        return Value{Name: "data", Num: 3, BaseType: 6,
+            Raw: msg.Data, Scaled: msg.Scaled("data"),
+            Units: "", Valid: is_valid_uint32(msg.Data)}
+    case 4:
+        return Value{Name: "event_group", Num: 4, BaseType: 2,
+            Raw: msg.EventGroup, Scaled: msg.Scaled("event_group"),
+            Units: "", Valid: is_valid_uint8(msg.EventGroup)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgEvent) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "event": return msg.FieldByNum(0)
+    case "event_type": return msg.FieldByNum(1)
+    case "data16": return msg.FieldByNum(2)
+    case "data": return msg.FieldByNum(3)
+    case "event_group": return msg.FieldByNum(4)
+    case "timer_trigger":
+        return Value{Name: "timer_trigger", Num: 3, BaseType: 0,
+            Raw: msg.TimerTrigger(), Scaled: msg.Scaled("timer_trigger"),
+            Units: "", Valid: msg.data_subfield() == "timer_trigger" && is_valid_uint32(msg.Data)}
+    case "course_point_index":
+        return Value{Name: "course_point_index", Num: 3, BaseType: 4,
+            Raw: msg.CoursePointIndex(), Scaled: msg.Scaled("course_point_index"),
+            Units: "", Valid: msg.data_subfield() == "course_point_index" && is_valid_uint32(msg.Data)}
+    case "battery_level":
+        return Value{Name: "battery_level", Num: 3, BaseType: 4,
+            Raw: msg.BatteryLevel(), Scaled: msg.Scaled("battery_level"),
+            Units: "V", Valid: msg.data_subfield() == "battery_level" && is_valid_uint32(msg.Data)}
+    case "virtual_partner_speed":
+        return Value{Name: "virtual_partner_speed", Num: 3, BaseType: 4,
+            Raw: msg.VirtualPartnerSpeed(), Scaled: msg.Scaled("virtual_partner_speed"),
+            Units: "m/s", Valid: msg.data_subfield() == "virtual_partner_speed" && is_valid_uint32(msg.Data)}
+    case "hr_high_alert":
+        return Value{Name: "hr_high_alert", Num: 3, BaseType: 2,
+            Raw: msg.HRHighAlert(), Scaled: msg.Scaled("hr_high_alert"),
+            Units: "bpm", Valid: msg.data_subfield() == "hr_high_alert" && is_valid_uint32(msg.Data)}
+    case "hr_low_alert":
+        return Value{Name: "hr_low_alert", Num: 3, BaseType: 2,
+            Raw: msg.HRLowAlert(), Scaled: msg.Scaled("hr_low_alert"),
+            Units: "bpm", Valid: msg.data_subfield() == "hr_low_alert" && is_valid_uint32(msg.Data)}
+    case "speed_high_alert":
+        return Value{Name: "speed_high_alert", Num: 3, BaseType: 6,
+            Raw: msg.SpeedHighAlert(), Scaled: msg.Scaled("speed_high_alert"),
+            Units: "m/s", Valid: msg.data_subfield() == "speed_high_alert" && is_valid_uint32(msg.Data)}
+    case "speed_low_alert":
+        return Value{Name: "speed_low_alert", Num: 3, BaseType: 6,
+            Raw: msg.SpeedLowAlert(), Scaled: msg.Scaled("speed_low_alert"),
+            Units: "m/s", Valid: msg.data_subfield() == "speed_low_alert" && is_valid_uint32(msg.Data)}
+    case "cad_high_alert":
+        return Value{Name: "cad_high_alert", Num: 3, BaseType: 4,
+            Raw: msg.CadHighAlert(), Scaled: msg.Scaled("cad_high_alert"),
+            Units: "rpm", Valid: msg.data_subfield() == "cad_high_alert" && is_valid_uint32(msg.Data)}
+    case "cad_low_alert":
+        return Value{Name: "cad_low_alert", Num: 3, BaseType: 4,
+            Raw: msg.CadLowAlert(), Scaled: msg.Scaled("cad_low_alert"),
+            Units: "rpm", Valid: msg.data_subfield() == "cad_low_alert" && is_valid_uint32(msg.Data)}
+    case "power_high_alert":
+        return Value{Name: "power_high_alert", Num: 3, BaseType: 4,
+            Raw: msg.PowerHighAlert(), Scaled: msg.Scaled("power_high_alert"),
+            Units: "watts", Valid: msg.data_subfield() == "power_high_alert" && is_valid_uint32(msg.Data)}
+    case "power_low_alert":
+        return Value{Name: "power_low_alert", Num: 3, BaseType: 4,
+            Raw: msg.PowerLowAlert(), Scaled: msg.Scaled("power_low_alert"),
+            Units: "watts", Valid: msg.data_subfield() == "power_low_alert" && is_valid_uint32(msg.Data)}
+    case "time_duration_alert":
+        return Value{Name: "time_duration_alert", Num: 3, BaseType: 6,
+            Raw: msg.TimeDurationAlert(), Scaled: msg.Scaled("time_duration_alert"),
+            Units: "s", Valid: msg.data_subfield() == "time_duration_alert" && is_valid_uint32(msg.Data)}
+    case "distance_duration_alert":
+        return Value{Name: "distance_duration_alert", Num: 3, BaseType: 6,
+            Raw: msg.DistanceDurationAlert(), Scaled: msg.Scaled("distance_duration_alert"),
+            Units: "m", Valid: msg.data_subfield() == "distance_duration_alert" && is_valid_uint32(msg.Data)}
+    case "calorie_duration_alert":
+        return Value{Name: "calorie_duration_alert", Num: 3, BaseType: 6,
+            Raw: msg.CalorieDurationAlert(), Scaled: msg.Scaled("calorie_duration_alert"),
+            Units: "calories", Valid: msg.data_subfield() == "calorie_duration_alert" && is_valid_uint32(msg.Data)}
+    case "fitness_equipment_state":
+        return Value{Name: "fitness_equipment_state", Num: 3, BaseType: 0,
+            Raw: msg.FitnessEquipmentState(), Scaled: msg.Scaled("fitness_equipment_state"),
+            Units: "", Valid: msg.data_subfield() == "fitness_equipment_state" && is_valid_uint32(msg.Data)}
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgEvent) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4})
+}
+
+func NewMsgEvent(def *FitDefinition, data []byte) (*MsgEvent, error) {
+    msg := new(MsgEvent)
+
+    msg.Timestamp = invalid_uint32
+    msg.Event = Event(invalid_enum)
+    msg.EventType = EventType(invalid_enum)
+    msg.Data16 = invalid_uint16
+    msg.Data = invalid_uint32
+    msg.EventGroup = invalid_uint8
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.Event = Event(get_enum(buf, fld, order))
+        case 1: msg.EventType = EventType(get_enum(buf, fld, order))
+        case 2: msg.Data16 = get_uint16(buf, fld, order)
+        case 3: msg.Data = get_uint32(buf, fld, order)
+        case 4: msg.EventGroup = get_uint8(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// device_info message
+
+// MsgDeviceInfo holds the fields of a device_info message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgDeviceInfo struct {
+    msgBase
+
+    Timestamp uint32 // s
+    DeviceIndex uint8
+    DeviceType uint8
+    Manufacturer Manufacturer
+    SerialNumber uint32
+    Product uint16 // see SubField("product")
+    SoftwareVersion uint16 // scale 100
+    HardwareVersion uint8
+    CumOperatingTime uint32 // s
+    BatteryVoltage uint16 // V, scale 256
+    BatteryStatus BatteryStatus
+}
+
+func (msg *MsgDeviceInfo) Name() string {
+    return "device_info"
+}
+
+func (msg *MsgDeviceInfo) SoftwareVersionScaled() float64 {
+    if !is_valid_uint16(msg.SoftwareVersion) {
+        return math.NaN()
+    }
+    return float64(msg.SoftwareVersion) / 100
+}
+
+// battery_voltage in V
+func (msg *MsgDeviceInfo) BatteryVoltageScaled() float64 {
+    if !is_valid_uint16(msg.BatteryVoltage) {
+        return math.NaN()
+    }
+    return float64(msg.BatteryVoltage) / 256
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgDeviceInfo) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// name of the subfield which holds the value of product, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgDeviceInfo) product_subfield() string {
+    switch {
+    case msg.Manufacturer == 1 || msg.Manufacturer == 15 || msg.Manufacturer == 13: return "garmin_product"
+    default: return ""
+    }
+}
+
+// product when it holds garmin_product
+func (msg *MsgDeviceInfo) GarminProduct() uint16 {
+    if msg.product_subfield() != "garmin_product" ||
+        !is_valid_uint16(msg.Product) {
+        return invalid_uint16
+    }
+    return uint16(msg.Product)
+}
+
+func (msg *MsgDeviceInfo) Text() string {
+    txt := "device_info"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint8(msg.DeviceIndex) {
+        txt += fmt.Sprintf(" devidx %d", msg.DeviceIndex)
+    }
+    if is_valid_uint8(msg.DeviceType) {
+        txt += fmt.Sprintf(" devtyp %d", msg.DeviceType)
+    }
+    if is_valid_uint16(uint16(msg.Manufacturer)) {
+        txt += fmt.Sprintf(" mfct %s", msg.Manufacturer)
+    }
+    if is_valid_uint32z(msg.SerialNumber) {
+        txt += fmt.Sprintf(" ser# %d", msg.SerialNumber)
+    }
+    if is_valid_uint16(msg.Product) {
+        switch msg.product_subfield() {
+        case "garmin_product":
+            txt += fmt.Sprintf(" garminprod %d", msg.GarminProduct())
+        default:
+            txt += fmt.Sprintf(" prod %d", msg.Product)
+        }
+    }
+    if is_valid_uint16(msg.SoftwareVersion) {
+        txt += fmt.Sprintf(" soft %g", msg.SoftwareVersionScaled())
+    }
+    if is_valid_uint8(msg.HardwareVersion) {
+        txt += fmt.Sprintf(" hard %d", msg.HardwareVersion)
+    }
+    if is_valid_uint32(msg.CumOperatingTime) {
+        txt += fmt.Sprintf(" cumoptime %d", msg.CumOperatingTime)
+    }
+    if is_valid_uint16(msg.BatteryVoltage) {
+        txt += fmt.Sprintf(" battvolt %g", msg.BatteryVoltageScaled())
+    }
+    if is_valid_uint8(uint8(msg.BatteryStatus)) {
+        txt += fmt.Sprintf(" battstat %s", msg.BatteryStatus)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgDeviceInfo) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "device_index": return is_valid_uint8(msg.DeviceIndex)
+    case "device_type": return is_valid_uint8(msg.DeviceType)
+    case "manufacturer": return is_valid_uint16(uint16(msg.Manufacturer))
+    case "serial_number": return is_valid_uint32z(msg.SerialNumber)
+    case "product": return is_valid_uint16(msg.Product)
+    case "software_version": return is_valid_uint16(msg.SoftwareVersion)
+    case "hardware_version": return is_valid_uint8(msg.HardwareVersion)
+    case "cum_operating_time": return is_valid_uint32(msg.CumOperatingTime)
+    case "battery_voltage": return is_valid_uint16(msg.BatteryVoltage)
+    case "battery_status": return is_valid_uint8(uint8(msg.BatteryStatus))
+    case "garmin_product":
+        return msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgDeviceInfo) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "device_index":
+        if is_valid_uint8(msg.DeviceIndex) {
+            return float64(msg.DeviceIndex)
+        }
+    case "device_type":
+        if is_valid_uint8(msg.DeviceType) {
+            return float64(msg.DeviceType)
+        }
+    case "manufacturer":
+        if is_valid_uint16(uint16(msg.Manufacturer)) {
+            return float64(msg.Manufacturer)
+        }
+    case "serial_number":
+        if is_valid_uint32z(msg.SerialNumber) {
+            return float64(msg.SerialNumber)
+        }
+    case "product":
+        if is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
+        }
+    case "software_version":
+        return msg.SoftwareVersionScaled()
+    case "hardware_version":
+        if is_valid_uint8(msg.HardwareVersion) {
+            return float64(msg.HardwareVersion)
+        }
+    case "cum_operating_time":
+        if is_valid_uint32(msg.CumOperatingTime) {
+            return float64(msg.CumOperatingTime)
+        }
+    case "battery_voltage":
+        return msg.BatteryVoltageScaled()
+    case "battery_status":
+        if is_valid_uint8(uint8(msg.BatteryStatus)) {
+            return float64(msg.BatteryStatus)
+        }
+    case "garmin_product":
+        if msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgDeviceInfo) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "cum_operating_time": return "s"
+    case "battery_voltage": return "V"
+    default: return ""
+    }
+}
+
+// SubField returns the name of the subfield selected for the named
+// field by the message's other fields, or an empty string if none is
+func (msg *MsgDeviceInfo) SubField(name string) string {
+    switch name {
+    case "product": return msg.product_subfield()
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgDeviceInfo) GlobalNum() uint16 {
+    return 23
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgDeviceInfo) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "device_index", Num: 0, BaseType: 2,
+            Raw: msg.DeviceIndex, Scaled: msg.Scaled("device_index"),
+            Units: "", Valid: is_valid_uint8(msg.DeviceIndex)}
+    case 1:
+        return Value{Name: "device_type", Num: 1, BaseType: 2,
+            Raw: msg.DeviceType, Scaled: msg.Scaled("device_type"),
+            Units: "", Valid: is_valid_uint8(msg.DeviceType)}
+    case 2:
+        return Value{Name: "manufacturer", Num: 2, BaseType: 4,
+            Raw: msg.Manufacturer, Scaled: msg.Scaled("manufacturer"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.Manufacturer))}
+    case 3:
+        return Value{Name: "serial_number", Num: 3, BaseType: 12,
+            Raw: msg.SerialNumber, Scaled: msg.Scaled("serial_number"),
+            Units: "", Valid: is_valid_uint32z(msg.SerialNumber)}
+    case 4:
+        return Value{Name: "product", Num: 4, BaseType: 4,
+            Raw: msg.Product, Scaled: msg.Scaled("product"),
+            Units: "", Valid: is_valid_uint16(msg.Product)}
+    case 5:
+        return Value{Name: "software_version", Num: 5, BaseType: 4,
+            Raw: msg.SoftwareVersion, Scaled: msg.Scaled("software_version"),
+            Units: "", Valid: is_valid_uint16(msg.SoftwareVersion)}
+    case 6:
+        return Value{Name: "hardware_version", Num: 6, BaseType: 2,
+            Raw: msg.HardwareVersion, Scaled: msg.Scaled("hardware_version"),
+            Units: "", Valid: is_valid_uint8(msg.HardwareVersion)}
+    case 7:
+        return Value{Name: "cum_operating_time", Num: 7, BaseType: 6,
+            Raw: msg.CumOperatingTime, Scaled: msg.Scaled("cum_operating_time"),
+            Units: "s", Valid: is_valid_uint32(msg.CumOperatingTime)}
+    case 10:
+        return Value{Name: "battery_voltage", Num: 10, BaseType: 4,
+            Raw: msg.BatteryVoltage, Scaled: msg.Scaled("battery_voltage"),
+            Units: "V", Valid: is_valid_uint16(msg.BatteryVoltage)}
+    case 11:
+        return Value{Name: "battery_status", Num: 11, BaseType: 2,
+            Raw: msg.BatteryStatus, Scaled: msg.Scaled("battery_status"),
+            Units: "", Valid: is_valid_uint8(uint8(msg.BatteryStatus))}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgDeviceInfo) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "device_index": return msg.FieldByNum(0)
+    case "device_type": return msg.FieldByNum(1)
+    case "manufacturer": return msg.FieldByNum(2)
+    case "serial_number": return msg.FieldByNum(3)
+    case "product": return msg.FieldByNum(4)
+    case "software_version": return msg.FieldByNum(5)
+    case "hardware_version": return msg.FieldByNum(6)
+    case "cum_operating_time": return msg.FieldByNum(7)
+    case "battery_voltage": return msg.FieldByNum(10)
+    case "battery_status": return msg.FieldByNum(11)
+    case "garmin_product":
+        return Value{Name: "garmin_product", Num: 4, BaseType: 4,
+            Raw: msg.GarminProduct(), Scaled: msg.Scaled("garmin_product"),
+            Units: "", Valid: msg.product_subfield() == "garmin_product" && is_valid_uint16(msg.Product)}
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgDeviceInfo) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 6, 7, 10, 11})
+}
+
+func NewMsgDeviceInfo(def *FitDefinition, data []byte) (*MsgDeviceInfo, error) {
+    msg := new(MsgDeviceInfo)
+
+    msg.Timestamp = invalid_uint32
+    msg.DeviceIndex = invalid_uint8
+    msg.DeviceType = invalid_uint8
+    msg.Manufacturer = Manufacturer(invalid_uint16)
+    msg.Product = invalid_uint16
+    msg.SoftwareVersion = invalid_uint16
+    msg.HardwareVersion = invalid_uint8
+    msg.CumOperatingTime = invalid_uint32
+    msg.BatteryVoltage = invalid_uint16
+    msg.BatteryStatus = BatteryStatus(invalid_uint8)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.DeviceIndex = get_uint8(buf, fld, order)
+        case 1: msg.DeviceType = get_uint8(buf, fld, order)
+        case 2: msg.Manufacturer = Manufacturer(get_uint16(buf, fld, order))
+        case 3: msg.SerialNumber = get_uint32z(buf, fld, order)
+        case 4: msg.Product = get_uint16(buf, fld, order)
+        case 5: msg.SoftwareVersion = get_uint16(buf, fld, order)
+        case 6: msg.HardwareVersion = get_uint8(buf, fld, order)
+        case 7: msg.CumOperatingTime = get_uint32(buf, fld, order)
+        case 10: msg.BatteryVoltage = get_uint16(buf, fld, order)
+        case 11: msg.BatteryStatus = BatteryStatus(get_uint8(buf, fld, order))
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// workout message
+
+// MsgWorkout holds the fields of a workout message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgWorkout struct {
+    msgBase
+
+    Sport Sport
+    Capabilities uint32
+    NumValidSteps uint16
+    WktName string
+}
+
+func (msg *MsgWorkout) Name() string {
+    return "workout"
+}
+
+func (msg *MsgWorkout) Text() string {
+    txt := "workout"
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_uint32z(msg.Capabilities) {
+        txt += fmt.Sprintf(" capabilities %d", msg.Capabilities)
+    }
+    if is_valid_uint16(msg.NumValidSteps) {
+        txt += fmt.Sprintf(" numvalidsteps %d", msg.NumValidSteps)
+    }
+    if is_valid_string(msg.WktName) {
+        txt += fmt.Sprintf(" wktname %s", msg.WktName)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgWorkout) IsSet(name string) bool {
+    switch name {
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "capabilities": return is_valid_uint32z(msg.Capabilities)
+    case "num_valid_steps": return is_valid_uint16(msg.NumValidSteps)
+    case "wkt_name": return is_valid_string(msg.WktName)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgWorkout) Scaled(name string) float64 {
+    switch name {
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "capabilities":
+        if is_valid_uint32z(msg.Capabilities) {
+            return float64(msg.Capabilities)
+        }
+    case "num_valid_steps":
+        if is_valid_uint16(msg.NumValidSteps) {
+            return float64(msg.NumValidSteps)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgWorkout) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgWorkout) GlobalNum() uint16 {
+    return 26
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgWorkout) FieldByNum(num byte) Value {
+    switch num {
+    case 4:
+        return Value{Name: "sport", Num: 4, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 5:
+        return Value{Name: "capabilities", Num: 5, BaseType: 12,
+            Raw: msg.Capabilities, Scaled: msg.Scaled("capabilities"),
+            Units: "", Valid: is_valid_uint32z(msg.Capabilities)}
+    case 6:
+        return Value{Name: "num_valid_steps", Num: 6, BaseType: 4,
+            Raw: msg.NumValidSteps, Scaled: msg.Scaled("num_valid_steps"),
+            Units: "", Valid: is_valid_uint16(msg.NumValidSteps)}
+    case 8:
+        return Value{Name: "wkt_name", Num: 8, BaseType: 7,
+            Raw: msg.WktName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.WktName)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgWorkout) Field(name string) Value {
+    switch name {
+    case "sport": return msg.FieldByNum(4)
+    case "capabilities": return msg.FieldByNum(5)
+    case "num_valid_steps": return msg.FieldByNum(6)
+    case "wkt_name": return msg.FieldByNum(8)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgWorkout) Fields() []Value {
+    return fieldValues(msg, []byte{4, 5, 6, 8})
+}
+
+func NewMsgWorkout(def *FitDefinition, data []byte) (*MsgWorkout, error) {
+    msg := new(MsgWorkout)
+
+    msg.Sport = Sport(invalid_enum)
+    msg.NumValidSteps = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 4: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 5: msg.Capabilities = get_uint32z(buf, fld, order)
+        case 6: msg.NumValidSteps = get_uint16(buf, fld, order)
+        case 8: msg.WktName = get_string(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// workout_step message
+
+// MsgWorkoutStep holds the fields of a workout_step message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgWorkoutStep struct {
+    msgBase
+
+    MessageIndex uint16
+    WktStepName string
+    DurationType WktStepDuration
+    DurationValue uint32 // see SubField("duration_value")
+    TargetType WktStepTarget
+    TargetValue uint32 // see SubField("target_value")
+    CustomTargetValueLow uint32 // see SubField("custom_target_value_low")
+    CustomTargetValueHigh uint32 // see SubField("custom_target_value_high")
+    Intensity Intensity
+}
+
+func (msg *MsgWorkoutStep) Name() string {
+    return "workout_step"
+}
+
+// name of the subfield which holds the value of duration_value, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgWorkoutStep) duration_value_subfield() string {
+    switch {
+    case msg.DurationType == 0 || msg.DurationType == 28: return "duration_time"
+    case msg.DurationType == 1: return "duration_distance"
+    case msg.DurationType == 2 || msg.DurationType == 3: return "duration_hr"
+    case msg.DurationType == 4: return "duration_calories"
+    case msg.DurationType == 6 || msg.DurationType == 7 || msg.DurationType == 8 || msg.DurationType == 9 || msg.DurationType == 10 || msg.DurationType == 11 || msg.DurationType == 12 || msg.DurationType == 13: return "duration_step"
+    case msg.DurationType == 14 || msg.DurationType == 15: return "duration_power"
+    default: return ""
+    }
+}
+
+// duration_value when it holds duration_time
+func (msg *MsgWorkoutStep) DurationTime() uint32 {
+    if msg.duration_value_subfield() != "duration_time" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// duration_time in s
+func (msg *MsgWorkoutStep) DurationTimeScaled() float64 {
+    if msg.duration_value_subfield() != "duration_time" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return math.NaN()
+    }
+    return float64(msg.DurationValue) / 1000
+}
+
+// duration_value when it holds duration_distance
+func (msg *MsgWorkoutStep) DurationDistance() uint32 {
+    if msg.duration_value_subfield() != "duration_distance" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// duration_distance in m
+func (msg *MsgWorkoutStep) DurationDistanceScaled() float64 {
+    if msg.duration_value_subfield() != "duration_distance" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return math.NaN()
+    }
+    return float64(msg.DurationValue) / 100
+}
+
+// duration_value when it holds duration_hr
+func (msg *MsgWorkoutStep) DurationHR() uint32 {
+    if msg.duration_value_subfield() != "duration_hr" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// duration_value when it holds duration_calories
+func (msg *MsgWorkoutStep) DurationCalories() uint32 {
+    if msg.duration_value_subfield() != "duration_calories" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// duration_value when it holds duration_step
+func (msg *MsgWorkoutStep) DurationStep() uint32 {
+    if msg.duration_value_subfield() != "duration_step" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// duration_value when it holds duration_power
+func (msg *MsgWorkoutStep) DurationPower() uint32 {
+    if msg.duration_value_subfield() != "duration_power" ||
+        !is_valid_uint32(msg.DurationValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.DurationValue)
+}
+
+// name of the subfield which holds the value of target_value, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgWorkoutStep) target_value_subfield() string {
+    switch {
+    case msg.TargetType == 1: return "target_hr_zone"
+    case msg.TargetType == 4: return "target_power_zone"
+    case msg.DurationType == 6: return "repeat_steps"
+    case msg.DurationType == 7: return "repeat_time"
+    case msg.DurationType == 8: return "repeat_distance"
+    case msg.DurationType == 9: return "repeat_calories"
+    case msg.DurationType == 10 || msg.DurationType == 11: return "repeat_hr"
+    case msg.DurationType == 12 || msg.DurationType == 13: return "repeat_power"
+    default: return ""
+    }
+}
+
+// target_value when it holds target_hr_zone
+func (msg *MsgWorkoutStep) TargetHRZone() uint32 {
+    if msg.target_value_subfield() != "target_hr_zone" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// target_value when it holds target_power_zone
+func (msg *MsgWorkoutStep) TargetPowerZone() uint32 {
+    if msg.target_value_subfield() != "target_power_zone" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// target_value when it holds repeat_steps
+func (msg *MsgWorkoutStep) RepeatSteps() uint32 {
+    if msg.target_value_subfield() != "repeat_steps" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// target_value when it holds repeat_time
+func (msg *MsgWorkoutStep) RepeatTime() uint32 {
+    if msg.target_value_subfield() != "repeat_time" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// repeat_time in s
+func (msg *MsgWorkoutStep) RepeatTimeScaled() float64 {
+    if msg.target_value_subfield() != "repeat_time" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return math.NaN()
+    }
+    return float64(msg.TargetValue) / 1000
+}
+
+// target_value when it holds repeat_distance
+func (msg *MsgWorkoutStep) RepeatDistance() uint32 {
+    if msg.target_value_subfield() != "repeat_distance" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// repeat_distance in m
+func (msg *MsgWorkoutStep) RepeatDistanceScaled() float64 {
+    if msg.target_value_subfield() != "repeat_distance" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return math.NaN()
+    }
+    return float64(msg.TargetValue) / 100
+}
+
+// target_value when it holds repeat_calories
+func (msg *MsgWorkoutStep) RepeatCalories() uint32 {
+    if msg.target_value_subfield() != "repeat_calories" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// target_value when it holds repeat_hr
+func (msg *MsgWorkoutStep) RepeatHR() uint32 {
+    if msg.target_value_subfield() != "repeat_hr" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// target_value when it holds repeat_power
+func (msg *MsgWorkoutStep) RepeatPower() uint32 {
+    if msg.target_value_subfield() != "repeat_power" ||
+        !is_valid_uint32(msg.TargetValue) {
+        return invalid_uint32
+    }
+    return uint32(msg.TargetValue)
+}
+
+// name of the subfield which holds the value of custom_target_value_low, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgWorkoutStep) custom_target_value_low_subfield() string {
+    switch {
+    case msg.TargetType == 0: return "custom_target_speed_low"
+    case msg.TargetType == 1: return "custom_target_heart_rate_low"
+    case msg.TargetType == 3: return "custom_target_cadence_low"
+    case msg.TargetType == 4: return "custom_target_power_low"
+    default: return ""
+    }
+}
+
+// custom_target_value_low when it holds custom_target_speed_low
+func (msg *MsgWorkoutStep) CustomTargetSpeedLow() uint32 {
+    if msg.custom_target_value_low_subfield() != "custom_target_speed_low" ||
+        !is_valid_uint32(msg.CustomTargetValueLow) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueLow)
+}
+
+// custom_target_speed_low in m/s
+func (msg *MsgWorkoutStep) CustomTargetSpeedLowScaled() float64 {
+    if msg.custom_target_value_low_subfield() != "custom_target_speed_low" ||
+        !is_valid_uint32(msg.CustomTargetValueLow) {
+        return math.NaN()
+    }
+    return float64(msg.CustomTargetValueLow) / 1000
+}
+
+// custom_target_value_low when it holds custom_target_heart_rate_low
+func (msg *MsgWorkoutStep) CustomTargetHeartRateLow() uint32 {
+    if msg.custom_target_value_low_subfield() != "custom_target_heart_rate_low" ||
+        !is_valid_uint32(msg.CustomTargetValueLow) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueLow)
+}
+
+// custom_target_value_low when it holds custom_target_cadence_low
+func (msg *MsgWorkoutStep) CustomTargetCadenceLow() uint32 {
+    if msg.custom_target_value_low_subfield() != "custom_target_cadence_low" ||
+        !is_valid_uint32(msg.CustomTargetValueLow) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueLow)
+}
+
+// custom_target_value_low when it holds custom_target_power_low
+func (msg *MsgWorkoutStep) CustomTargetPowerLow() uint32 {
+    if msg.custom_target_value_low_subfield() != "custom_target_power_low" ||
+        !is_valid_uint32(msg.CustomTargetValueLow) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueLow)
+}
+
+// name of the subfield which holds the value of custom_target_value_high, or an empty
+// string if the message's other fields don't select one
+func (msg *MsgWorkoutStep) custom_target_value_high_subfield() string {
+    switch {
+    case msg.TargetType == 0: return "custom_target_speed_high"
+    case msg.TargetType == 1: return "custom_target_heart_rate_high"
+    case msg.TargetType == 3: return "custom_target_cadence_high"
+    case msg.TargetType == 4: return "custom_target_power_high"
+    default: return ""
+    }
+}
+
+// custom_target_value_high when it holds custom_target_speed_high
+func (msg *MsgWorkoutStep) CustomTargetSpeedHigh() uint32 {
+    if msg.custom_target_value_high_subfield() != "custom_target_speed_high" ||
+        !is_valid_uint32(msg.CustomTargetValueHigh) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueHigh)
+}
+
+// custom_target_speed_high in m/s
+func (msg *MsgWorkoutStep) CustomTargetSpeedHighScaled() float64 {
+    if msg.custom_target_value_high_subfield() != "custom_target_speed_high" ||
+        !is_valid_uint32(msg.CustomTargetValueHigh) {
+        return math.NaN()
+    }
+    return float64(msg.CustomTargetValueHigh) / 1000
+}
+
+// custom_target_value_high when it holds custom_target_heart_rate_high
+func (msg *MsgWorkoutStep) CustomTargetHeartRateHigh() uint32 {
+    if msg.custom_target_value_high_subfield() != "custom_target_heart_rate_high" ||
+        !is_valid_uint32(msg.CustomTargetValueHigh) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueHigh)
+}
+
+// custom_target_value_high when it holds custom_target_cadence_high
+func (msg *MsgWorkoutStep) CustomTargetCadenceHigh() uint32 {
+    if msg.custom_target_value_high_subfield() != "custom_target_cadence_high" ||
+        !is_valid_uint32(msg.CustomTargetValueHigh) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueHigh)
+}
+
+// custom_target_value_high when it holds custom_target_power_high
+func (msg *MsgWorkoutStep) CustomTargetPowerHigh() uint32 {
+    if msg.custom_target_value_high_subfield() != "custom_target_power_high" ||
+        !is_valid_uint32(msg.CustomTargetValueHigh) {
+        return invalid_uint32
+    }
+    return uint32(msg.CustomTargetValueHigh)
+}
+
+func (msg *MsgWorkoutStep) Text() string {
+    txt := "workout_step"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_string(msg.WktStepName) {
+        txt += fmt.Sprintf(" wktstepname %s", msg.WktStepName)
+    }
+    if is_valid_enum(byte(msg.DurationType)) {
+        txt += fmt.Sprintf(" durationtyp %s", msg.DurationType)
+    }
+    if is_valid_uint32(msg.DurationValue) {
+        switch msg.duration_value_subfield() {
+        case "duration_time":
+            txt += fmt.Sprintf(" durationtime %g", msg.DurationTimeScaled())
+        case "duration_distance":
+            txt += fmt.Sprintf(" durationdist %g", msg.DurationDistanceScaled())
+        case "duration_hr":
+            txt += fmt.Sprintf(" durationhr %d", msg.DurationHR())
+        case "duration_calories":
+            txt += fmt.Sprintf(" durationcals %d", msg.DurationCalories())
+        case "duration_step":
+            txt += fmt.Sprintf(" durationstep %d", msg.DurationStep())
+        case "duration_power":
+            txt += fmt.Sprintf(" durationpower %d", msg.DurationPower())
+        default:
+            txt += fmt.Sprintf(" durationvalue %d", msg.DurationValue)
+        }
+    }
+    if is_valid_enum(byte(msg.TargetType)) {
+        txt += fmt.Sprintf(" targettyp %s", msg.TargetType)
+    }
+    if is_valid_uint32(msg.TargetValue) {
+        switch msg.target_value_subfield() {
+        case "target_hr_zone":
+            txt += fmt.Sprintf(" targethrzone %d", msg.TargetHRZone())
+        case "target_power_zone":
+            txt += fmt.Sprintf(" targetpowerzone %d", msg.TargetPowerZone())
+        case "repeat_steps":
+            txt += fmt.Sprintf(" repeatsteps %d", msg.RepeatSteps())
+        case "repeat_time":
+            txt += fmt.Sprintf(" repeattime %g", msg.RepeatTimeScaled())
+        case "repeat_distance":
+            txt += fmt.Sprintf(" repeatdist %g", msg.RepeatDistanceScaled())
+        case "repeat_calories":
+            txt += fmt.Sprintf(" repeatcals %d", msg.RepeatCalories())
+        case "repeat_hr":
+            txt += fmt.Sprintf(" repeathr %d", msg.RepeatHR())
+        case "repeat_power":
+            txt += fmt.Sprintf(" repeatpower %d", msg.RepeatPower())
+        default:
+            txt += fmt.Sprintf(" targetvalue %d", msg.TargetValue)
+        }
+    }
+    if is_valid_uint32(msg.CustomTargetValueLow) {
+        switch msg.custom_target_value_low_subfield() {
+        case "custom_target_speed_low":
+            txt += fmt.Sprintf(" customtargetspeedlow %g", msg.CustomTargetSpeedLowScaled())
+        case "custom_target_heart_rate_low":
+            txt += fmt.Sprintf(" customtargetheartratelow %d", msg.CustomTargetHeartRateLow())
+        case "custom_target_cadence_low":
+            txt += fmt.Sprintf(" customtargetcadencelow %d", msg.CustomTargetCadenceLow())
+        case "custom_target_power_low":
+            txt += fmt.Sprintf(" customtargetpowerlow %d", msg.CustomTargetPowerLow())
+        default:
+            txt += fmt.Sprintf(" customtargetvaluelow %d", msg.CustomTargetValueLow)
+        }
+    }
+    if is_valid_uint32(msg.CustomTargetValueHigh) {
+        switch msg.custom_target_value_high_subfield() {
+        case "custom_target_speed_high":
+            txt += fmt.Sprintf(" customtargetspeedhigh %g", msg.CustomTargetSpeedHighScaled())
+        case "custom_target_heart_rate_high":
+            txt += fmt.Sprintf(" customtargetheartratehigh %d", msg.CustomTargetHeartRateHigh())
+        case "custom_target_cadence_high":
+            txt += fmt.Sprintf(" customtargetcadencehigh %d", msg.CustomTargetCadenceHigh())
+        case "custom_target_power_high":
+            txt += fmt.Sprintf(" customtargetpowerhigh %d", msg.CustomTargetPowerHigh())
+        default:
+            txt += fmt.Sprintf(" customtargetvaluehigh %d", msg.CustomTargetValueHigh)
+        }
+    }
+    if is_valid_enum(byte(msg.Intensity)) {
+        txt += fmt.Sprintf(" intensity %s", msg.Intensity)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgWorkoutStep) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "wkt_step_name": return is_valid_string(msg.WktStepName)
+    case "duration_type": return is_valid_enum(byte(msg.DurationType))
+    case "duration_value": return is_valid_uint32(msg.DurationValue)
+    case "target_type": return is_valid_enum(byte(msg.TargetType))
+    case "target_value": return is_valid_uint32(msg.TargetValue)
+    case "custom_target_value_low": return is_valid_uint32(msg.CustomTargetValueLow)
+    case "custom_target_value_high": return is_valid_uint32(msg.CustomTargetValueHigh)
+    case "intensity": return is_valid_enum(byte(msg.Intensity))
+    case "duration_time":
+        return msg.duration_value_subfield() == "duration_time" && is_valid_uint32(msg.DurationValue)
+    case "duration_distance":
+        return msg.duration_value_subfield() == "duration_distance" && is_valid_uint32(msg.DurationValue)
+    case "duration_hr":
+        return msg.duration_value_subfield() == "duration_hr" && is_valid_uint32(msg.DurationValue)
+    case "duration_calories":
+        return msg.duration_value_subfield() == "duration_calories" && is_valid_uint32(msg.DurationValue)
+    case "duration_step":
+        return msg.duration_value_subfield() == "duration_step" && is_valid_uint32(msg.DurationValue)
+    case "duration_power":
+        return msg.duration_value_subfield() == "duration_power" && is_valid_uint32(msg.DurationValue)
+    case "target_hr_zone":
+        return msg.target_value_subfield() == "target_hr_zone" && is_valid_uint32(msg.TargetValue)
+    case "target_power_zone":
+        return msg.target_value_subfield() == "target_power_zone" && is_valid_uint32(msg.TargetValue)
+    case "repeat_steps":
+        return msg.target_value_subfield() == "repeat_steps" && is_valid_uint32(msg.TargetValue)
+    case "repeat_time":
+        return msg.target_value_subfield() == "repeat_time" && is_valid_uint32(msg.TargetValue)
+    case "repeat_distance":
+        return msg.target_value_subfield() == "repeat_distance" && is_valid_uint32(msg.TargetValue)
+    case "repeat_calories":
+        return msg.target_value_subfield() == "repeat_calories" && is_valid_uint32(msg.TargetValue)
+    case "repeat_hr":
+        return msg.target_value_subfield() == "repeat_hr" && is_valid_uint32(msg.TargetValue)
+    case "repeat_power":
+        return msg.target_value_subfield() == "repeat_power" && is_valid_uint32(msg.TargetValue)
+    case "custom_target_speed_low":
+        return msg.custom_target_value_low_subfield() == "custom_target_speed_low" && is_valid_uint32(msg.CustomTargetValueLow)
+    case "custom_target_heart_rate_low":
+        return msg.custom_target_value_low_subfield() == "custom_target_heart_rate_low" && is_valid_uint32(msg.CustomTargetValueLow)
+    case "custom_target_cadence_low":
+        return msg.custom_target_value_low_subfield() == "custom_target_cadence_low" && is_valid_uint32(msg.CustomTargetValueLow)
+    case "custom_target_power_low":
+        return msg.custom_target_value_low_subfield() == "custom_target_power_low" && is_valid_uint32(msg.CustomTargetValueLow)
+    case "custom_target_speed_high":
+        return msg.custom_target_value_high_subfield() == "custom_target_speed_high" && is_valid_uint32(msg.CustomTargetValueHigh)
+    case "custom_target_heart_rate_high":
+        return msg.custom_target_value_high_subfield() == "custom_target_heart_rate_high" && is_valid_uint32(msg.CustomTargetValueHigh)
+    case "custom_target_cadence_high":
+        return msg.custom_target_value_high_subfield() == "custom_target_cadence_high" && is_valid_uint32(msg.CustomTargetValueHigh)
+    case "custom_target_power_high":
+        return msg.custom_target_value_high_subfield() == "custom_target_power_high" && is_valid_uint32(msg.CustomTargetValueHigh)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgWorkoutStep) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "duration_type":
+        if is_valid_enum(byte(msg.DurationType)) {
+            return float64(msg.DurationType)
+        }
+    case "duration_value":
+        if is_valid_uint32(msg.DurationValue) {
+            return float64(msg.DurationValue)
+        }
+    case "target_type":
+        if is_valid_enum(byte(msg.TargetType)) {
+            return float64(msg.TargetType)
+        }
+    case "target_value":
+        if is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "custom_target_value_low":
+        if is_valid_uint32(msg.CustomTargetValueLow) {
+            return float64(msg.CustomTargetValueLow)
+        }
+    case "custom_target_value_high":
+        if is_valid_uint32(msg.CustomTargetValueHigh) {
+            return float64(msg.CustomTargetValueHigh)
+        }
+    case "intensity":
+        if is_valid_enum(byte(msg.Intensity)) {
+            return float64(msg.Intensity)
+        }
+    case "duration_time":
+        return msg.DurationTimeScaled()
+    case "duration_distance":
+        return msg.DurationDistanceScaled()
+    case "duration_hr":
+        if msg.duration_value_subfield() == "duration_hr" && is_valid_uint32(msg.DurationValue) {
+            return float64(msg.DurationValue)
+        }
+    case "duration_calories":
+        if msg.duration_value_subfield() == "duration_calories" && is_valid_uint32(msg.DurationValue) {
+            return float64(msg.DurationValue)
+        }
+    case "duration_step":
+        if msg.duration_value_subfield() == "duration_step" && is_valid_uint32(msg.DurationValue) {
+            return float64(msg.DurationValue)
+        }
+    case "duration_power":
+        if msg.duration_value_subfield() == "duration_power" && is_valid_uint32(msg.DurationValue) {
+            return float64(msg.DurationValue)
+        }
+    case "target_hr_zone":
+        if msg.target_value_subfield() == "target_hr_zone" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "target_power_zone":
+        if msg.target_value_subfield() == "target_power_zone" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "repeat_steps":
+        if msg.target_value_subfield() == "repeat_steps" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "repeat_time":
+        return msg.RepeatTimeScaled()
+    case "repeat_distance":
+        return msg.RepeatDistanceScaled()
+    case "repeat_calories":
+        if msg.target_value_subfield() == "repeat_calories" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "repeat_hr":
+        if msg.target_value_subfield() == "repeat_hr" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "repeat_power":
+        if msg.target_value_subfield() == "repeat_power" && is_valid_uint32(msg.TargetValue) {
+            return float64(msg.TargetValue)
+        }
+    case "custom_target_speed_low":
+        return msg.CustomTargetSpeedLowScaled()
+    case "custom_target_heart_rate_low":
+        if msg.custom_target_value_low_subfield() == "custom_target_heart_rate_low" && is_valid_uint32(msg.CustomTargetValueLow) {
+            return float64(msg.CustomTargetValueLow)
+        }
+    case "custom_target_cadence_low":
+        if msg.custom_target_value_low_subfield() == "custom_target_cadence_low" && is_valid_uint32(msg.CustomTargetValueLow) {
+            return float64(msg.CustomTargetValueLow)
+        }
+    case "custom_target_power_low":
+        if msg.custom_target_value_low_subfield() == "custom_target_power_low" && is_valid_uint32(msg.CustomTargetValueLow) {
+            return float64(msg.CustomTargetValueLow)
+        }
+    case "custom_target_speed_high":
+        return msg.CustomTargetSpeedHighScaled()
+    case "custom_target_heart_rate_high":
+        if msg.custom_target_value_high_subfield() == "custom_target_heart_rate_high" && is_valid_uint32(msg.CustomTargetValueHigh) {
+            return float64(msg.CustomTargetValueHigh)
+        }
+    case "custom_target_cadence_high":
+        if msg.custom_target_value_high_subfield() == "custom_target_cadence_high" && is_valid_uint32(msg.CustomTargetValueHigh) {
+            return float64(msg.CustomTargetValueHigh)
+        }
+    case "custom_target_power_high":
+        if msg.custom_target_value_high_subfield() == "custom_target_power_high" && is_valid_uint32(msg.CustomTargetValueHigh) {
+            return float64(msg.CustomTargetValueHigh)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgWorkoutStep) Units(name string) string {
+    switch name {
+    case "duration_time": return "s"
+    case "duration_distance": return "m"
+    case "duration_hr": return "% or bpm"
+    case "duration_calories": return "calories"
+    case "duration_power": return "% or watts"
+    case "repeat_time": return "s"
+    case "repeat_distance": return "m"
+    case "repeat_calories": return "calories"
+    case "repeat_hr": return "% or bpm"
+    case "repeat_power": return "% or watts"
+    case "custom_target_speed_low": return "m/s"
+    case "custom_target_heart_rate_low": return "% or bpm"
+    case "custom_target_cadence_low": return "rpm"
+    case "custom_target_power_low": return "% or watts"
+    case "custom_target_speed_high": return "m/s"
+    case "custom_target_heart_rate_high": return "% or bpm"
+    case "custom_target_cadence_high": return "rpm"
+    case "custom_target_power_high": return "% or watts"
+    default: return ""
+    }
+}
+
+// SubField returns the name of the subfield selected for the named
+// field by the message's other fields, or an empty string if none is
+func (msg *MsgWorkoutStep) SubField(name string) string {
+    switch name {
+    case "duration_value": return msg.duration_value_subfield()
+    case "target_value": return msg.target_value_subfield()
+    case "custom_target_value_low": return msg.custom_target_value_low_subfield()
+    case "custom_target_value_high": return msg.custom_target_value_high_subfield()
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgWorkoutStep) GlobalNum() uint16 {
+    return 27
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgWorkoutStep) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "wkt_step_name", Num: 0, BaseType: 7,
+            Raw: msg.WktStepName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.WktStepName)}
+    case 1:
+        return Value{Name: "duration_type", Num: 1, BaseType: 0,
+            Raw: msg.DurationType, Scaled: msg.Scaled("duration_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.DurationType))}
+    case 2:
+        return Value{Name: "duration_value", Num: 2, BaseType: 6,
+            Raw: msg.DurationValue, Scaled: msg.Scaled("duration_value"),
+            Units: "", Valid: is_valid_uint32(msg.DurationValue)}
+    case 3:
+        return Value{Name: "target_type", Num: 3, BaseType: 0,
+            Raw: msg.TargetType, Scaled: msg.Scaled("target_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.TargetType))}
+    case 4:
+        return Value{Name: "target_value", Num: 4, BaseType: 6,
+            Raw: msg.TargetValue, Scaled: msg.Scaled("target_value"),
+            Units: "", Valid: is_valid_uint32(msg.TargetValue)}
+    case 5:
+        return Value{Name: "custom_target_value_low", Num: 5, BaseType: 6,
+            Raw: msg.CustomTargetValueLow, Scaled: msg.Scaled("custom_target_value_low"),
+            Units: "", Valid: is_valid_uint32(msg.CustomTargetValueLow)}
+    case 6:
+        return Value{Name: "custom_target_value_high", Num: 6, BaseType: 6,
+            Raw: msg.CustomTargetValueHigh, Scaled: msg.Scaled("custom_target_value_high"),
+            Units: "", Valid: is_valid_uint32(msg.CustomTargetValueHigh)}
+    case 7:
+        return Value{Name: "intensity", Num: 7, BaseType: 0,
+            Raw: msg.Intensity, Scaled: msg.Scaled("intensity"),
+            Units: "", Valid: is_valid_enum(byte(msg.Intensity))}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgWorkoutStep) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "wkt_step_name": return msg.FieldByNum(0)
+    case "duration_type": return msg.FieldByNum(1)
+    case "duration_value": return msg.FieldByNum(2)
+    case "target_type": return msg.FieldByNum(3)
+    case "target_value": return msg.FieldByNum(4)
+    case "custom_target_value_low": return msg.FieldByNum(5)
+    case "custom_target_value_high": return msg.FieldByNum(6)
+    case "intensity": return msg.FieldByNum(7)
+    case "duration_time":
+        return Value{Name: "duration_time", Num: 2, BaseType: 6,
+            Raw: msg.DurationTime(), Scaled: msg.Scaled("duration_time"),
+            Units: "s", Valid: msg.duration_value_subfield() == "duration_time" && is_valid_uint32(msg.DurationValue)}
+    case "duration_distance":
+        return Value{Name: "duration_distance", Num: 2, BaseType: 6,
+            Raw: msg.DurationDistance(), Scaled: msg.Scaled("duration_distance"),
+            Units: "m", Valid: msg.duration_value_subfield() == "duration_distance" && is_valid_uint32(msg.DurationValue)}
+    case "duration_hr":
+        return Value{Name: "duration_hr", Num: 2, BaseType: 6,
+            Raw: msg.DurationHR(), Scaled: msg.Scaled("duration_hr"),
+            Units: "% or bpm", Valid: msg.duration_value_subfield() == "duration_hr" && is_valid_uint32(msg.DurationValue)}
+    case "duration_calories":
+        return Value{Name: "duration_calories", Num: 2, BaseType: 6,
+            Raw: msg.DurationCalories(), Scaled: msg.Scaled("duration_calories"),
+            Units: "calories", Valid: msg.duration_value_subfield() == "duration_calories" && is_valid_uint32(msg.DurationValue)}
+    case "duration_step":
+        return Value{Name: "duration_step", Num: 2, BaseType: 6,
+            Raw: msg.DurationStep(), Scaled: msg.Scaled("duration_step"),
+            Units: "", Valid: msg.duration_value_subfield() == "duration_step" && is_valid_uint32(msg.DurationValue)}
+    case "duration_power":
+        return Value{Name: "duration_power", Num: 2, BaseType: 6,
+            Raw: msg.DurationPower(), Scaled: msg.Scaled("duration_power"),
+            Units: "% or watts", Valid: msg.duration_value_subfield() == "duration_power" && is_valid_uint32(msg.DurationValue)}
+    case "target_hr_zone":
+        return Value{Name: "target_hr_zone", Num: 4, BaseType: 6,
+            Raw: msg.TargetHRZone(), Scaled: msg.Scaled("target_hr_zone"),
+            Units: "", Valid: msg.target_value_subfield() == "target_hr_zone" && is_valid_uint32(msg.TargetValue)}
+    case "target_power_zone":
+        return Value{Name: "target_power_zone", Num: 4, BaseType: 6,
+            Raw: msg.TargetPowerZone(), Scaled: msg.Scaled("target_power_zone"),
+            Units: "", Valid: msg.target_value_subfield() == "target_power_zone" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_steps":
+        return Value{Name: "repeat_steps", Num: 4, BaseType: 6,
+            Raw: msg.RepeatSteps(), Scaled: msg.Scaled("repeat_steps"),
+            Units: "", Valid: msg.target_value_subfield() == "repeat_steps" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_time":
+        return Value{Name: "repeat_time", Num: 4, BaseType: 6,
+            Raw: msg.RepeatTime(), Scaled: msg.Scaled("repeat_time"),
+            Units: "s", Valid: msg.target_value_subfield() == "repeat_time" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_distance":
+        return Value{Name: "repeat_distance", Num: 4, BaseType: 6,
+            Raw: msg.RepeatDistance(), Scaled: msg.Scaled("repeat_distance"),
+            Units: "m", Valid: msg.target_value_subfield() == "repeat_distance" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_calories":
+        return Value{Name: "repeat_calories", Num: 4, BaseType: 6,
+            Raw: msg.RepeatCalories(), Scaled: msg.Scaled("repeat_calories"),
+            Units: "calories", Valid: msg.target_value_subfield() == "repeat_calories" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_hr":
+        return Value{Name: "repeat_hr", Num: 4, BaseType: 6,
+            Raw: msg.RepeatHR(), Scaled: msg.Scaled("repeat_hr"),
+            Units: "% or bpm", Valid: msg.target_value_subfield() == "repeat_hr" && is_valid_uint32(msg.TargetValue)}
+    case "repeat_power":
+        return Value{Name: "repeat_power", Num: 4, BaseType: 6,
+            Raw: msg.RepeatPower(), Scaled: msg.Scaled("repeat_power"),
+            Units: "% or watts", Valid: msg.target_value_subfield() == "repeat_power" && is_valid_uint32(msg.TargetValue)}
+    case "custom_target_speed_low":
+        return Value{Name: "custom_target_speed_low", Num: 5, BaseType: 6,
+            Raw: msg.CustomTargetSpeedLow(), Scaled: msg.Scaled("custom_target_speed_low"),
+            Units: "m/s", Valid: msg.custom_target_value_low_subfield() == "custom_target_speed_low" && is_valid_uint32(msg.CustomTargetValueLow)}
+    case "custom_target_heart_rate_low":
+        return Value{Name: "custom_target_heart_rate_low", Num: 5, BaseType: 6,
+            Raw: msg.CustomTargetHeartRateLow(), Scaled: msg.Scaled("custom_target_heart_rate_low"),
+            Units: "% or bpm", Valid: msg.custom_target_value_low_subfield() == "custom_target_heart_rate_low" && is_valid_uint32(msg.CustomTargetValueLow)}
+    case "custom_target_cadence_low":
+        return Value{Name: "custom_target_cadence_low", Num: 5, BaseType: 6,
+            Raw: msg.CustomTargetCadenceLow(), Scaled: msg.Scaled("custom_target_cadence_low"),
+            Units: "rpm", Valid: msg.custom_target_value_low_subfield() == "custom_target_cadence_low" && is_valid_uint32(msg.CustomTargetValueLow)}
+    case "custom_target_power_low":
+        return Value{Name: "custom_target_power_low", Num: 5, BaseType: 6,
+            Raw: msg.CustomTargetPowerLow(), Scaled: msg.Scaled("custom_target_power_low"),
+            Units: "% or watts", Valid: msg.custom_target_value_low_subfield() == "custom_target_power_low" && is_valid_uint32(msg.CustomTargetValueLow)}
+    case "custom_target_speed_high":
+        return Value{Name: "custom_target_speed_high", Num: 6, BaseType: 6,
+            Raw: msg.CustomTargetSpeedHigh(), Scaled: msg.Scaled("custom_target_speed_high"),
+            Units: "m/s", Valid: msg.custom_target_value_high_subfield() == "custom_target_speed_high" && is_valid_uint32(msg.CustomTargetValueHigh)}
+    case "custom_target_heart_rate_high":
+        return Value{Name: "custom_target_heart_rate_high", Num: 6, BaseType: 6,
+            Raw: msg.CustomTargetHeartRateHigh(), Scaled: msg.Scaled("custom_target_heart_rate_high"),
+            Units: "% or bpm", Valid: msg.custom_target_value_high_subfield() == "custom_target_heart_rate_high" && is_valid_uint32(msg.CustomTargetValueHigh)}
+    case "custom_target_cadence_high":
+        return Value{Name: "custom_target_cadence_high", Num: 6, BaseType: 6,
+            Raw: msg.CustomTargetCadenceHigh(), Scaled: msg.Scaled("custom_target_cadence_high"),
+            Units: "rpm", Valid: msg.custom_target_value_high_subfield() == "custom_target_cadence_high" && is_valid_uint32(msg.CustomTargetValueHigh)}
+    case "custom_target_power_high":
+        return Value{Name: "custom_target_power_high", Num: 6, BaseType: 6,
+            Raw: msg.CustomTargetPowerHigh(), Scaled: msg.Scaled("custom_target_power_high"),
+            Units: "% or watts", Valid: msg.custom_target_value_high_subfield() == "custom_target_power_high" && is_valid_uint32(msg.CustomTargetValueHigh)}
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgWorkoutStep) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4, 5, 6, 7})
+}
+
+func NewMsgWorkoutStep(def *FitDefinition, data []byte) (*MsgWorkoutStep, error) {
+    msg := new(MsgWorkoutStep)
+
+    msg.MessageIndex = invalid_uint16
+    msg.DurationType = WktStepDuration(invalid_enum)
+    msg.DurationValue = invalid_uint32
+    msg.TargetType = WktStepTarget(invalid_enum)
+    msg.TargetValue = invalid_uint32
+    msg.CustomTargetValueLow = invalid_uint32
+    msg.CustomTargetValueHigh = invalid_uint32
+    msg.Intensity = Intensity(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.WktStepName = get_string(buf, fld, order)
+        case 1: msg.DurationType = WktStepDuration(get_enum(buf, fld, order))
+        case 2: msg.DurationValue = get_uint32(buf, fld, order)
+        case 3: msg.TargetType = WktStepTarget(get_enum(buf, fld, order))
+        case 4: msg.TargetValue = get_uint32(buf, fld, order)
+        case 5: msg.CustomTargetValueLow = get_uint32(buf, fld, order)
+        case 6: msg.CustomTargetValueHigh = get_uint32(buf, fld, order)
+        case 7: msg.Intensity = Intensity(get_enum(buf, fld, order))
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// schedule message
+
+// MsgSchedule holds the fields of a schedule message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSchedule struct {
+    msgBase
+
+    Manufacturer Manufacturer
+    Product uint16
+    SerialNumber uint32
+    TimeCreated uint32
+    Completed Bool
+    Type Schedule
+    ScheduledTime uint32
+}
+
+func (msg *MsgSchedule) Name() string {
+    return "schedule"
+}
+
+// time_created as a time.Time; see DateTime()
+func (msg *MsgSchedule) TimeCreatedTime() time.Time {
+    return DateTime(msg.TimeCreated)
+}
+
+// scheduled_time as a time.Time; see DateTime()
+func (msg *MsgSchedule) ScheduledTimeTime() time.Time {
+    return DateTime(msg.ScheduledTime)
+}
+
+func (msg *MsgSchedule) Text() string {
+    txt := "schedule"
+    if is_valid_uint16(uint16(msg.Manufacturer)) {
+        txt += fmt.Sprintf(" mfct %s", msg.Manufacturer)
+    }
+    if is_valid_uint16(msg.Product) {
+        txt += fmt.Sprintf(" prod %d", msg.Product)
+    }
+    if is_valid_uint32z(msg.SerialNumber) {
+        txt += fmt.Sprintf(" ser# %d", msg.SerialNumber)
+    }
+    if is_valid_uint32(msg.TimeCreated) {
+        txt += fmt.Sprintf(" timecre %d", msg.TimeCreated)
+    }
+    if is_valid_enum(byte(msg.Completed)) {
+        txt += fmt.Sprintf(" completed %s", msg.Completed)
+    }
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_uint32(msg.ScheduledTime) {
+        txt += fmt.Sprintf(" scheduledtime %d", msg.ScheduledTime)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSchedule) IsSet(name string) bool {
+    switch name {
+    case "manufacturer": return is_valid_uint16(uint16(msg.Manufacturer))
+    case "product": return is_valid_uint16(msg.Product)
+    case "serial_number": return is_valid_uint32z(msg.SerialNumber)
+    case "time_created": return is_valid_uint32(msg.TimeCreated)
+    case "completed": return is_valid_enum(byte(msg.Completed))
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "scheduled_time": return is_valid_uint32(msg.ScheduledTime)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSchedule) Scaled(name string) float64 {
+    switch name {
+    case "manufacturer":
+        if is_valid_uint16(uint16(msg.Manufacturer)) {
+            return float64(msg.Manufacturer)
+        }
+    case "product":
+        if is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
+        }
+    case "serial_number":
+        if is_valid_uint32z(msg.SerialNumber) {
+            return float64(msg.SerialNumber)
+        }
+    case "time_created":
+        if is_valid_uint32(msg.TimeCreated) {
+            return float64(msg.TimeCreated)
+        }
+    case "completed":
+        if is_valid_enum(byte(msg.Completed)) {
+            return float64(msg.Completed)
+        }
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    case "scheduled_time":
+        if is_valid_uint32(msg.ScheduledTime) {
+            return float64(msg.ScheduledTime)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgSchedule) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgSchedule) GlobalNum() uint16 {
+    return 28
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSchedule) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "manufacturer", Num: 0, BaseType: 4,
+            Raw: msg.Manufacturer, Scaled: msg.Scaled("manufacturer"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.Manufacturer))}
+    case 1:
+        return Value{Name: "product", Num: 1, BaseType: 4,
+            Raw: msg.Product, Scaled: msg.Scaled("product"),
+            Units: "", Valid: is_valid_uint16(msg.Product)}
+    case 2:
+        return Value{Name: "serial_number", Num: 2, BaseType: 12,
+            Raw: msg.SerialNumber, Scaled: msg.Scaled("serial_number"),
+            Units: "", Valid: is_valid_uint32z(msg.SerialNumber)}
+    case 3:
+        return Value{Name: "time_created", Num: 3, BaseType: 6,
+            Raw: msg.TimeCreated, Scaled: msg.Scaled("time_created"),
+            Units: "", Valid: is_valid_uint32(msg.TimeCreated)}
+    case 4:
+        return Value{Name: "completed", Num: 4, BaseType: 0,
+            Raw: msg.Completed, Scaled: msg.Scaled("completed"),
+            Units: "", Valid: is_valid_enum(byte(msg.Completed))}
+    case 5:
+        return Value{Name: "type", Num: 5, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 6:
+        return Value{Name: "scheduled_time", Num: 6, BaseType: 6,
+            Raw: msg.ScheduledTime, Scaled: msg.Scaled("scheduled_time"),
+            Units: "", Valid: is_valid_uint32(msg.ScheduledTime)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSchedule) Field(name string) Value {
+    switch name {
+    case "manufacturer": return msg.FieldByNum(0)
+    case "product": return msg.FieldByNum(1)
+    case "serial_number": return msg.FieldByNum(2)
+    case "time_created": return msg.FieldByNum(3)
+    case "completed": return msg.FieldByNum(4)
+    case "type": return msg.FieldByNum(5)
+    case "scheduled_time": return msg.FieldByNum(6)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSchedule) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1, 2, 3, 4, 5, 6})
+}
+
+func NewMsgSchedule(def *FitDefinition, data []byte) (*MsgSchedule, error) {
     msg := new(MsgSchedule)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.manufacturer, pos = get_uint16_pos(data, pos)
-        case 1: msg.product, pos = get_uint16_pos(data, pos)
-        case 2: msg.serial_number, pos = get_uint32_pos(data, pos)
-        case 3: msg.time_created, pos = get_uint32_pos(data, pos)
-        case 4: msg.completed, pos = get_byte_pos(data, pos)
-        case 5: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 6: msg.scheduled_time, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad schedule field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+    msg.Manufacturer = Manufacturer(invalid_uint16)
+    msg.Product = invalid_uint16
+    msg.TimeCreated = invalid_uint32
+    msg.Completed = Bool(invalid_enum)
+    msg.Type = Schedule(invalid_enum)
+    msg.ScheduledTime = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Manufacturer = Manufacturer(get_uint16(buf, fld, order))
+        case 1: msg.Product = get_uint16(buf, fld, order)
+        case 2: msg.SerialNumber = get_uint32z(buf, fld, order)
+        case 3: msg.TimeCreated = get_uint32(buf, fld, order)
+        case 4: msg.Completed = Bool(get_enum(buf, fld, order))
+        case 5: msg.Type = Schedule(get_enum(buf, fld, order))
+        case 6: msg.ScheduledTime = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// weight_scale message
+
+// MsgWeightScale holds the fields of a weight_scale message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgWeightScale struct {
+    msgBase
+
+    Timestamp uint32 // s
+    Weight uint16 // kg, scale 100
+    PercentFat uint16 // %, scale 100
+    PercentHydration uint16 // %, scale 100
+    VisceralFatMass uint16 // kg, scale 100
+    BoneMass uint16 // kg, scale 100
+    MuscleMass uint16 // kg, scale 100
+    BasalMET uint16 // kcal/day, scale 4
+    PhysiqueRating uint8
+    ActiveMET uint16 // kcal/day, scale 4
+    MetabolicAge uint8 // years
+    VisceralFatRating uint8
+    UserProfileIndex uint16
+}
+
+func (msg *MsgWeightScale) Name() string {
+    return "weight_scale"
+}
+
+// weight in kg
+func (msg *MsgWeightScale) WeightScaled() float64 {
+    if !is_valid_uint16(msg.Weight) {
+        return math.NaN()
+    }
+    return float64(msg.Weight) / 100
+}
+
+// percent_fat in %
+func (msg *MsgWeightScale) PercentFatScaled() float64 {
+    if !is_valid_uint16(msg.PercentFat) {
+        return math.NaN()
+    }
+    return float64(msg.PercentFat) / 100
+}
+
+// percent_hydration in %
+func (msg *MsgWeightScale) PercentHydrationScaled() float64 {
+    if !is_valid_uint16(msg.PercentHydration) {
+        return math.NaN()
+    }
+    return float64(msg.PercentHydration) / 100
+}
+
+// visceral_fat_mass in kg
+func (msg *MsgWeightScale) VisceralFatMassScaled() float64 {
+    if !is_valid_uint16(msg.VisceralFatMass) {
+        return math.NaN()
+    }
+    return float64(msg.VisceralFatMass) / 100
+}
+
+// bone_mass in kg
+func (msg *MsgWeightScale) BoneMassScaled() float64 {
+    if !is_valid_uint16(msg.BoneMass) {
+        return math.NaN()
+    }
+    return float64(msg.BoneMass) / 100
+}
+
+// muscle_mass in kg
+func (msg *MsgWeightScale) MuscleMassScaled() float64 {
+    if !is_valid_uint16(msg.MuscleMass) {
+        return math.NaN()
+    }
+    return float64(msg.MuscleMass) / 100
+}
+
+// basal_met in kcal/day
+func (msg *MsgWeightScale) BasalMETScaled() float64 {
+    if !is_valid_uint16(msg.BasalMET) {
+        return math.NaN()
+    }
+    return float64(msg.BasalMET) / 4
+}
+
+// active_met in kcal/day
+func (msg *MsgWeightScale) ActiveMETScaled() float64 {
+    if !is_valid_uint16(msg.ActiveMET) {
+        return math.NaN()
+    }
+    return float64(msg.ActiveMET) / 4
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgWeightScale) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+func (msg *MsgWeightScale) Text() string {
+    txt := "weight_scale"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint16(msg.Weight) {
+        txt += fmt.Sprintf(" weight %g", msg.WeightScaled())
+    }
+    if is_valid_uint16(msg.PercentFat) {
+        txt += fmt.Sprintf(" percentfat %g", msg.PercentFatScaled())
+    }
+    if is_valid_uint16(msg.PercentHydration) {
+        txt += fmt.Sprintf(" percenthydration %g", msg.PercentHydrationScaled())
+    }
+    if is_valid_uint16(msg.VisceralFatMass) {
+        txt += fmt.Sprintf(" visceralfatmass %g", msg.VisceralFatMassScaled())
+    }
+    if is_valid_uint16(msg.BoneMass) {
+        txt += fmt.Sprintf(" bonemass %g", msg.BoneMassScaled())
+    }
+    if is_valid_uint16(msg.MuscleMass) {
+        txt += fmt.Sprintf(" musclemass %g", msg.MuscleMassScaled())
+    }
+    if is_valid_uint16(msg.BasalMET) {
+        txt += fmt.Sprintf(" basalmet %g", msg.BasalMETScaled())
+    }
+    if is_valid_uint8(msg.PhysiqueRating) {
+        txt += fmt.Sprintf(" physiquerating %d", msg.PhysiqueRating)
+    }
+    if is_valid_uint16(msg.ActiveMET) {
+        txt += fmt.Sprintf(" activemet %g", msg.ActiveMETScaled())
+    }
+    if is_valid_uint8(msg.MetabolicAge) {
+        txt += fmt.Sprintf(" metabolicage %d", msg.MetabolicAge)
+    }
+    if is_valid_uint8(msg.VisceralFatRating) {
+        txt += fmt.Sprintf(" visceralfatrating %d", msg.VisceralFatRating)
+    }
+    if is_valid_uint16(msg.UserProfileIndex) {
+        txt += fmt.Sprintf(" userprofileidx %d", msg.UserProfileIndex)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgWeightScale) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "weight": return is_valid_uint16(msg.Weight)
+    case "percent_fat": return is_valid_uint16(msg.PercentFat)
+    case "percent_hydration": return is_valid_uint16(msg.PercentHydration)
+    case "visceral_fat_mass": return is_valid_uint16(msg.VisceralFatMass)
+    case "bone_mass": return is_valid_uint16(msg.BoneMass)
+    case "muscle_mass": return is_valid_uint16(msg.MuscleMass)
+    case "basal_met": return is_valid_uint16(msg.BasalMET)
+    case "physique_rating": return is_valid_uint8(msg.PhysiqueRating)
+    case "active_met": return is_valid_uint16(msg.ActiveMET)
+    case "metabolic_age": return is_valid_uint8(msg.MetabolicAge)
+    case "visceral_fat_rating": return is_valid_uint8(msg.VisceralFatRating)
+    case "user_profile_index": return is_valid_uint16(msg.UserProfileIndex)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgWeightScale) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "weight":
+        return msg.WeightScaled()
+    case "percent_fat":
+        return msg.PercentFatScaled()
+    case "percent_hydration":
+        return msg.PercentHydrationScaled()
+    case "visceral_fat_mass":
+        return msg.VisceralFatMassScaled()
+    case "bone_mass":
+        return msg.BoneMassScaled()
+    case "muscle_mass":
+        return msg.MuscleMassScaled()
+    case "basal_met":
+        return msg.BasalMETScaled()
+    case "physique_rating":
+        if is_valid_uint8(msg.PhysiqueRating) {
+            return float64(msg.PhysiqueRating)
+        }
+    case "active_met":
+        return msg.ActiveMETScaled()
+    case "metabolic_age":
+        if is_valid_uint8(msg.MetabolicAge) {
+            return float64(msg.MetabolicAge)
+        }
+    case "visceral_fat_rating":
+        if is_valid_uint8(msg.VisceralFatRating) {
+            return float64(msg.VisceralFatRating)
+        }
+    case "user_profile_index":
+        if is_valid_uint16(msg.UserProfileIndex) {
+            return float64(msg.UserProfileIndex)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgWeightScale) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "weight": return "kg"
+    case "percent_fat": return "%"
+    case "percent_hydration": return "%"
+    case "visceral_fat_mass": return "kg"
+    case "bone_mass": return "kg"
+    case "muscle_mass": return "kg"
+    case "basal_met": return "kcal/day"
+    case "active_met": return "kcal/day"
+    case "metabolic_age": return "years"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgWeightScale) GlobalNum() uint16 {
+    return 30
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgWeightScale) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "weight", Num: 0, BaseType: 4,
+            Raw: msg.Weight, Scaled: msg.Scaled("weight"),
+            Units: "kg", Valid: is_valid_uint16(msg.Weight)}
+    case 1:
+        return Value{Name: "percent_fat", Num: 1, BaseType: 4,
+            Raw: msg.PercentFat, Scaled: msg.Scaled("percent_fat"),
+            Units: "%", Valid: is_valid_uint16(msg.PercentFat)}
+    case 2:
+        return Value{Name: "percent_hydration", Num: 2, BaseType: 4,
+            Raw: msg.PercentHydration, Scaled: msg.Scaled("percent_hydration"),
+            Units: "%", Valid: is_valid_uint16(msg.PercentHydration)}
+    case 3:
+        return Value{Name: "visceral_fat_mass", Num: 3, BaseType: 4,
+            Raw: msg.VisceralFatMass, Scaled: msg.Scaled("visceral_fat_mass"),
+            Units: "kg", Valid: is_valid_uint16(msg.VisceralFatMass)}
+    case 4:
+        return Value{Name: "bone_mass", Num: 4, BaseType: 4,
+            Raw: msg.BoneMass, Scaled: msg.Scaled("bone_mass"),
+            Units: "kg", Valid: is_valid_uint16(msg.BoneMass)}
+    case 5:
+        return Value{Name: "muscle_mass", Num: 5, BaseType: 4,
+            Raw: msg.MuscleMass, Scaled: msg.Scaled("muscle_mass"),
+            Units: "kg", Valid: is_valid_uint16(msg.MuscleMass)}
+    case 7:
+        return Value{Name: "basal_met", Num: 7, BaseType: 4,
+            Raw: msg.BasalMET, Scaled: msg.Scaled("basal_met"),
+            Units: "kcal/day", Valid: is_valid_uint16(msg.BasalMET)}
+    case 8:
+        return Value{Name: "physique_rating", Num: 8, BaseType: 2,
+            Raw: msg.PhysiqueRating, Scaled: msg.Scaled("physique_rating"),
+            Units: "", Valid: is_valid_uint8(msg.PhysiqueRating)}
+    case 9:
+        return Value{Name: "active_met", Num: 9, BaseType: 4,
+            Raw: msg.ActiveMET, Scaled: msg.Scaled("active_met"),
+            Units: "kcal/day", Valid: is_valid_uint16(msg.ActiveMET)}
+    case 10:
+        return Value{Name: "metabolic_age", Num: 10, BaseType: 2,
+            Raw: msg.MetabolicAge, Scaled: msg.Scaled("metabolic_age"),
+            Units: "years", Valid: is_valid_uint8(msg.MetabolicAge)}
+    case 11:
+        return Value{Name: "visceral_fat_rating", Num: 11, BaseType: 2,
+            Raw: msg.VisceralFatRating, Scaled: msg.Scaled("visceral_fat_rating"),
+            Units: "", Valid: is_valid_uint8(msg.VisceralFatRating)}
+    case 12:
+        return Value{Name: "user_profile_index", Num: 12, BaseType: 4,
+            Raw: msg.UserProfileIndex, Scaled: msg.Scaled("user_profile_index"),
+            Units: "", Valid: is_valid_uint16(msg.UserProfileIndex)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgWeightScale) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "weight": return msg.FieldByNum(0)
+    case "percent_fat": return msg.FieldByNum(1)
+    case "percent_hydration": return msg.FieldByNum(2)
+    case "visceral_fat_mass": return msg.FieldByNum(3)
+    case "bone_mass": return msg.FieldByNum(4)
+    case "muscle_mass": return msg.FieldByNum(5)
+    case "basal_met": return msg.FieldByNum(7)
+    case "physique_rating": return msg.FieldByNum(8)
+    case "active_met": return msg.FieldByNum(9)
+    case "metabolic_age": return msg.FieldByNum(10)
+    case "visceral_fat_rating": return msg.FieldByNum(11)
+    case "user_profile_index": return msg.FieldByNum(12)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgWeightScale) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 7, 8, 9, 10, 11, 12})
+}
+
+func NewMsgWeightScale(def *FitDefinition, data []byte) (*MsgWeightScale, error) {
+    msg := new(MsgWeightScale)
+
+    msg.Timestamp = invalid_uint32
+    msg.Weight = invalid_uint16
+    msg.PercentFat = invalid_uint16
+    msg.PercentHydration = invalid_uint16
+    msg.VisceralFatMass = invalid_uint16
+    msg.BoneMass = invalid_uint16
+    msg.MuscleMass = invalid_uint16
+    msg.BasalMET = invalid_uint16
+    msg.PhysiqueRating = invalid_uint8
+    msg.ActiveMET = invalid_uint16
+    msg.MetabolicAge = invalid_uint8
+    msg.VisceralFatRating = invalid_uint8
+    msg.UserProfileIndex = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.Weight = get_uint16(buf, fld, order)
+        case 1: msg.PercentFat = get_uint16(buf, fld, order)
+        case 2: msg.PercentHydration = get_uint16(buf, fld, order)
+        case 3: msg.VisceralFatMass = get_uint16(buf, fld, order)
+        case 4: msg.BoneMass = get_uint16(buf, fld, order)
+        case 5: msg.MuscleMass = get_uint16(buf, fld, order)
+        case 7: msg.BasalMET = get_uint16(buf, fld, order)
+        case 8: msg.PhysiqueRating = get_uint8(buf, fld, order)
+        case 9: msg.ActiveMET = get_uint16(buf, fld, order)
+        case 10: msg.MetabolicAge = get_uint8(buf, fld, order)
+        case 11: msg.VisceralFatRating = get_uint8(buf, fld, order)
+        case 12: msg.UserProfileIndex = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// course message
+
+// MsgCourse holds the fields of a course message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgCourse struct {
+    msgBase
+
+    Sport Sport
+    CourseName string
+    Capabilities uint32
+}
+
+func (msg *MsgCourse) Name() string {
+    return "course"
+}
+
+func (msg *MsgCourse) Text() string {
+    txt := "course"
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_string(msg.CourseName) {
+        txt += fmt.Sprintf(" name %s", msg.CourseName)
+    }
+    if is_valid_uint32z(msg.Capabilities) {
+        txt += fmt.Sprintf(" capabilities %d", msg.Capabilities)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgCourse) IsSet(name string) bool {
+    switch name {
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "name": return is_valid_string(msg.CourseName)
+    case "capabilities": return is_valid_uint32z(msg.Capabilities)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgCourse) Scaled(name string) float64 {
+    switch name {
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "capabilities":
+        if is_valid_uint32z(msg.Capabilities) {
+            return float64(msg.Capabilities)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgCourse) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgCourse) GlobalNum() uint16 {
+    return 31
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgCourse) FieldByNum(num byte) Value {
+    switch num {
+    case 4:
+        return Value{Name: "sport", Num: 4, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 5:
+        return Value{Name: "name", Num: 5, BaseType: 7,
+            Raw: msg.CourseName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.CourseName)}
+    case 6:
+        return Value{Name: "capabilities", Num: 6, BaseType: 12,
+            Raw: msg.Capabilities, Scaled: msg.Scaled("capabilities"),
+            Units: "", Valid: is_valid_uint32z(msg.Capabilities)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgCourse) Field(name string) Value {
+    switch name {
+    case "sport": return msg.FieldByNum(4)
+    case "name": return msg.FieldByNum(5)
+    case "capabilities": return msg.FieldByNum(6)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgCourse) Fields() []Value {
+    return fieldValues(msg, []byte{4, 5, 6})
+}
+
+func NewMsgCourse(def *FitDefinition, data []byte) (*MsgCourse, error) {
+    msg := new(MsgCourse)
+
+    msg.Sport = Sport(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 4: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 5: msg.CourseName = get_string(buf, fld, order)
+        case 6: msg.Capabilities = get_uint32z(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// course_point message
+
+// MsgCoursePoint holds the fields of a course_point message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgCoursePoint struct {
+    msgBase
+
+    MessageIndex uint16
+    Timestamp uint32
+    PositionLat int32 // semicircles
+    PositionLong int32 // semicircles
+    Distance uint32 // m, scale 100
+    Type CoursePoint
+    CoursePointName string
+}
+
+func (msg *MsgCoursePoint) Name() string {
+    return "course_point"
+}
+
+// distance in m
+func (msg *MsgCoursePoint) DistanceScaled() float64 {
+    if !is_valid_uint32(msg.Distance) {
+        return math.NaN()
+    }
+    return float64(msg.Distance) / 100
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgCoursePoint) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// position_lat and position_long in degrees
+func (msg *MsgCoursePoint) Position() Position {
+    return PositionFromSemicircles(msg.PositionLat, msg.PositionLong)
+}
+
+func (msg *MsgCoursePoint) Text() string {
+    txt := "course_point"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_int32(msg.PositionLat) {
+        txt += fmt.Sprintf(" poslat %d", msg.PositionLat)
+    }
+    if is_valid_int32(msg.PositionLong) {
+        txt += fmt.Sprintf(" poslong %d", msg.PositionLong)
+    }
+    if is_valid_uint32(msg.Distance) {
+        txt += fmt.Sprintf(" dist %g", msg.DistanceScaled())
+    }
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_string(msg.CoursePointName) {
+        txt += fmt.Sprintf(" name %s", msg.CoursePointName)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgCoursePoint) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "position_lat": return is_valid_int32(msg.PositionLat)
+    case "position_long": return is_valid_int32(msg.PositionLong)
+    case "distance": return is_valid_uint32(msg.Distance)
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "name": return is_valid_string(msg.CoursePointName)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgCoursePoint) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "position_lat":
+        if is_valid_int32(msg.PositionLat) {
+            return float64(msg.PositionLat)
+        }
+    case "position_long":
+        if is_valid_int32(msg.PositionLong) {
+            return float64(msg.PositionLong)
+        }
+    case "distance":
+        return msg.DistanceScaled()
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgCoursePoint) Units(name string) string {
+    switch name {
+    case "position_lat": return "semicircles"
+    case "position_long": return "semicircles"
+    case "distance": return "m"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgCoursePoint) GlobalNum() uint16 {
+    return 32
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgCoursePoint) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 1:
+        return Value{Name: "timestamp", Num: 1, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.Timestamp)}
+    case 2:
+        return Value{Name: "position_lat", Num: 2, BaseType: 5,
+            Raw: msg.PositionLat, Scaled: msg.Scaled("position_lat"),
+            Units: "semicircles", Valid: is_valid_int32(msg.PositionLat)}
+    case 3:
+        return Value{Name: "position_long", Num: 3, BaseType: 5,
+            Raw: msg.PositionLong, Scaled: msg.Scaled("position_long"),
+            Units: "semicircles", Valid: is_valid_int32(msg.PositionLong)}
+    case 4:
+        return Value{Name: "distance", Num: 4, BaseType: 6,
+            Raw: msg.Distance, Scaled: msg.Scaled("distance"),
+            Units: "m", Valid: is_valid_uint32(msg.Distance)}
+    case 5:
+        return Value{Name: "type", Num: 5, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 6:
+        return Value{Name: "name", Num: 6, BaseType: 7,
+            Raw: msg.CoursePointName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.CoursePointName)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgCoursePoint) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "timestamp": return msg.FieldByNum(1)
+    case "position_lat": return msg.FieldByNum(2)
+    case "position_long": return msg.FieldByNum(3)
+    case "distance": return msg.FieldByNum(4)
+    case "type": return msg.FieldByNum(5)
+    case "name": return msg.FieldByNum(6)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgCoursePoint) Fields() []Value {
+    return fieldValues(msg, []byte{254, 1, 2, 3, 4, 5, 6})
+}
+
+func NewMsgCoursePoint(def *FitDefinition, data []byte) (*MsgCoursePoint, error) {
+    msg := new(MsgCoursePoint)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Timestamp = invalid_uint32
+    msg.PositionLat = invalid_int32
+    msg.PositionLong = invalid_int32
+    msg.Distance = invalid_uint32
+    msg.Type = CoursePoint(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 1: msg.Timestamp = get_uint32(buf, fld, order)
+        case 2: msg.PositionLat = get_int32(buf, fld, order)
+        case 3: msg.PositionLong = get_int32(buf, fld, order)
+        case 4: msg.Distance = get_uint32(buf, fld, order)
+        case 5: msg.Type = CoursePoint(get_enum(buf, fld, order))
+        case 6: msg.CoursePointName = get_string(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// totals message
+
+// MsgTotals holds the fields of a totals message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgTotals struct {
+    msgBase
+
+    MessageIndex uint16
+    Timestamp uint32 // s
+    TimerTime uint32 // s
+    Distance uint32 // m
+    Calories uint32 // kcal
+    Sport Sport
+    ElapsedTime uint32 // s
+    Sessions uint16
+    ActiveTime uint32 // s
+}
+
+func (msg *MsgTotals) Name() string {
+    return "totals"
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgTotals) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+func (msg *MsgTotals) Text() string {
+    txt := "totals"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint32(msg.TimerTime) {
+        txt += fmt.Sprintf(" timertime %d", msg.TimerTime)
+    }
+    if is_valid_uint32(msg.Distance) {
+        txt += fmt.Sprintf(" dist %d", msg.Distance)
+    }
+    if is_valid_uint32(msg.Calories) {
+        txt += fmt.Sprintf(" cals %d", msg.Calories)
+    }
+    if is_valid_enum(byte(msg.Sport)) {
+        txt += fmt.Sprintf(" sport %s", msg.Sport)
+    }
+    if is_valid_uint32(msg.ElapsedTime) {
+        txt += fmt.Sprintf(" elapsedtime %d", msg.ElapsedTime)
+    }
+    if is_valid_uint16(msg.Sessions) {
+        txt += fmt.Sprintf(" sessions %d", msg.Sessions)
+    }
+    if is_valid_uint32(msg.ActiveTime) {
+        txt += fmt.Sprintf(" activetime %d", msg.ActiveTime)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgTotals) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "timer_time": return is_valid_uint32(msg.TimerTime)
+    case "distance": return is_valid_uint32(msg.Distance)
+    case "calories": return is_valid_uint32(msg.Calories)
+    case "sport": return is_valid_enum(byte(msg.Sport))
+    case "elapsed_time": return is_valid_uint32(msg.ElapsedTime)
+    case "sessions": return is_valid_uint16(msg.Sessions)
+    case "active_time": return is_valid_uint32(msg.ActiveTime)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgTotals) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "timer_time":
+        if is_valid_uint32(msg.TimerTime) {
+            return float64(msg.TimerTime)
+        }
+    case "distance":
+        if is_valid_uint32(msg.Distance) {
+            return float64(msg.Distance)
+        }
+    case "calories":
+        if is_valid_uint32(msg.Calories) {
+            return float64(msg.Calories)
+        }
+    case "sport":
+        if is_valid_enum(byte(msg.Sport)) {
+            return float64(msg.Sport)
+        }
+    case "elapsed_time":
+        if is_valid_uint32(msg.ElapsedTime) {
+            return float64(msg.ElapsedTime)
+        }
+    case "sessions":
+        if is_valid_uint16(msg.Sessions) {
+            return float64(msg.Sessions)
+        }
+    case "active_time":
+        if is_valid_uint32(msg.ActiveTime) {
+            return float64(msg.ActiveTime)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgTotals) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "timer_time": return "s"
+    case "distance": return "m"
+    case "calories": return "kcal"
+    case "elapsed_time": return "s"
+    case "active_time": return "s"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgTotals) GlobalNum() uint16 {
+    return 33
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgTotals) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "timer_time", Num: 0, BaseType: 6,
+            Raw: msg.TimerTime, Scaled: msg.Scaled("timer_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TimerTime)}
+    case 1:
+        return Value{Name: "distance", Num: 1, BaseType: 6,
+            Raw: msg.Distance, Scaled: msg.Scaled("distance"),
+            Units: "m", Valid: is_valid_uint32(msg.Distance)}
+    case 2:
+        return Value{Name: "calories", Num: 2, BaseType: 6,
+            Raw: msg.Calories, Scaled: msg.Scaled("calories"),
+            Units: "kcal", Valid: is_valid_uint32(msg.Calories)}
+    case 3:
+        return Value{Name: "sport", Num: 3, BaseType: 0,
+            Raw: msg.Sport, Scaled: msg.Scaled("sport"),
+            Units: "", Valid: is_valid_enum(byte(msg.Sport))}
+    case 4:
+        return Value{Name: "elapsed_time", Num: 4, BaseType: 6,
+            Raw: msg.ElapsedTime, Scaled: msg.Scaled("elapsed_time"),
+            Units: "s", Valid: is_valid_uint32(msg.ElapsedTime)}
+    case 5:
+        return Value{Name: "sessions", Num: 5, BaseType: 4,
+            Raw: msg.Sessions, Scaled: msg.Scaled("sessions"),
+            Units: "", Valid: is_valid_uint16(msg.Sessions)}
+    case 6:
+        return Value{Name: "active_time", Num: 6, BaseType: 6,
+            Raw: msg.ActiveTime, Scaled: msg.Scaled("active_time"),
+            Units: "s", Valid: is_valid_uint32(msg.ActiveTime)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgTotals) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "timestamp": return msg.FieldByNum(253)
+    case "timer_time": return msg.FieldByNum(0)
+    case "distance": return msg.FieldByNum(1)
+    case "calories": return msg.FieldByNum(2)
+    case "sport": return msg.FieldByNum(3)
+    case "elapsed_time": return msg.FieldByNum(4)
+    case "sessions": return msg.FieldByNum(5)
+    case "active_time": return msg.FieldByNum(6)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgTotals) Fields() []Value {
+    return fieldValues(msg, []byte{254, 253, 0, 1, 2, 3, 4, 5, 6})
+}
+
+func NewMsgTotals(def *FitDefinition, data []byte) (*MsgTotals, error) {
+    msg := new(MsgTotals)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Timestamp = invalid_uint32
+    msg.TimerTime = invalid_uint32
+    msg.Distance = invalid_uint32
+    msg.Calories = invalid_uint32
+    msg.Sport = Sport(invalid_enum)
+    msg.ElapsedTime = invalid_uint32
+    msg.Sessions = invalid_uint16
+    msg.ActiveTime = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.TimerTime = get_uint32(buf, fld, order)
+        case 1: msg.Distance = get_uint32(buf, fld, order)
+        case 2: msg.Calories = get_uint32(buf, fld, order)
+        case 3: msg.Sport = Sport(get_enum(buf, fld, order))
+        case 4: msg.ElapsedTime = get_uint32(buf, fld, order)
+        case 5: msg.Sessions = get_uint16(buf, fld, order)
+        case 6: msg.ActiveTime = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// activity message
+
+// MsgActivity holds the fields of a activity message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgActivity struct {
+    msgBase
+
+    Timestamp uint32
+    TotalTimerTime uint32 // s, scale 1000
+    NumSessions uint16
+    Type Activity
+    Event Event
+    EventType EventType
+    LocalTimestamp uint32
+    EventGroup uint8
+}
+
+func (msg *MsgActivity) Name() string {
+    return "activity"
+}
+
+// total_timer_time in s
+func (msg *MsgActivity) TotalTimerTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalTimerTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalTimerTime) / 1000
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgActivity) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// local_timestamp as a time.Time; see LocalDateTime()
+func (msg *MsgActivity) LocalTimestampTime() time.Time {
+    return LocalDateTime(msg.LocalTimestamp)
+}
+
+func (msg *MsgActivity) Text() string {
+    txt := "activity"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint32(msg.TotalTimerTime) {
+        txt += fmt.Sprintf(" totaltimertime %g", msg.TotalTimerTimeScaled())
+    }
+    if is_valid_uint16(msg.NumSessions) {
+        txt += fmt.Sprintf(" numsessions %d", msg.NumSessions)
+    }
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_enum(byte(msg.Event)) {
+        txt += fmt.Sprintf(" evt %s", msg.Event)
+    }
+    if is_valid_enum(byte(msg.EventType)) {
+        txt += fmt.Sprintf(" evttyp %s", msg.EventType)
+    }
+    if is_valid_uint32(msg.LocalTimestamp) {
+        txt += fmt.Sprintf(" localtstmp %d", msg.LocalTimestamp)
+    }
+    if is_valid_uint8(msg.EventGroup) {
+        txt += fmt.Sprintf(" evtgrp %d", msg.EventGroup)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgActivity) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "total_timer_time": return is_valid_uint32(msg.TotalTimerTime)
+    case "num_sessions": return is_valid_uint16(msg.NumSessions)
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "event": return is_valid_enum(byte(msg.Event))
+    case "event_type": return is_valid_enum(byte(msg.EventType))
+    case "local_timestamp": return is_valid_uint32(msg.LocalTimestamp)
+    case "event_group": return is_valid_uint8(msg.EventGroup)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgActivity) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "total_timer_time":
+        return msg.TotalTimerTimeScaled()
+    case "num_sessions":
+        if is_valid_uint16(msg.NumSessions) {
+            return float64(msg.NumSessions)
+        }
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    case "event":
+        if is_valid_enum(byte(msg.Event)) {
+            return float64(msg.Event)
+        }
+    case "event_type":
+        if is_valid_enum(byte(msg.EventType)) {
+            return float64(msg.EventType)
+        }
+    case "local_timestamp":
+        if is_valid_uint32(msg.LocalTimestamp) {
+            return float64(msg.LocalTimestamp)
+        }
+    case "event_group":
+        if is_valid_uint8(msg.EventGroup) {
+            return float64(msg.EventGroup)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgActivity) Units(name string) string {
+    switch name {
+    case "total_timer_time": return "s"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgActivity) GlobalNum() uint16 {
+    return 34
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgActivity) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "total_timer_time", Num: 0, BaseType: 6,
+            Raw: msg.TotalTimerTime, Scaled: msg.Scaled("total_timer_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalTimerTime)}
+    case 1:
+        return Value{Name: "num_sessions", Num: 1, BaseType: 4,
+            Raw: msg.NumSessions, Scaled: msg.Scaled("num_sessions"),
+            Units: "", Valid: is_valid_uint16(msg.NumSessions)}
+    case 2:
+        return Value{Name: "type", Num: 2, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 3:
+        return Value{Name: "event", Num: 3, BaseType: 0,
+            Raw: msg.Event, Scaled: msg.Scaled("event"),
+            Units: "", Valid: is_valid_enum(byte(msg.Event))}
+    case 4:
+        return Value{Name: "event_type", Num: 4, BaseType: 0,
+            Raw: msg.EventType, Scaled: msg.Scaled("event_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.EventType))}
+    case 5:
+        return Value{Name: "local_timestamp", Num: 5, BaseType: 6,
+            Raw: msg.LocalTimestamp, Scaled: msg.Scaled("local_timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.LocalTimestamp)}
+    case 6:
+        return Value{Name: "event_group", Num: 6, BaseType: 2,
+            Raw: msg.EventGroup, Scaled: msg.Scaled("event_group"),
+            Units: "", Valid: is_valid_uint8(msg.EventGroup)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgActivity) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "total_timer_time": return msg.FieldByNum(0)
+    case "num_sessions": return msg.FieldByNum(1)
+    case "type": return msg.FieldByNum(2)
+    case "event": return msg.FieldByNum(3)
+    case "event_type": return msg.FieldByNum(4)
+    case "local_timestamp": return msg.FieldByNum(5)
+    case "event_group": return msg.FieldByNum(6)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgActivity) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 6})
+}
+
+func NewMsgActivity(def *FitDefinition, data []byte) (*MsgActivity, error) {
+    msg := new(MsgActivity)
+
+    msg.Timestamp = invalid_uint32
+    msg.TotalTimerTime = invalid_uint32
+    msg.NumSessions = invalid_uint16
+    msg.Type = Activity(invalid_enum)
+    msg.Event = Event(invalid_enum)
+    msg.EventType = EventType(invalid_enum)
+    msg.LocalTimestamp = invalid_uint32
+    msg.EventGroup = invalid_uint8
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.TotalTimerTime = get_uint32(buf, fld, order)
+        case 1: msg.NumSessions = get_uint16(buf, fld, order)
+        case 2: msg.Type = Activity(get_enum(buf, fld, order))
+        case 3: msg.Event = Event(get_enum(buf, fld, order))
+        case 4: msg.EventType = EventType(get_enum(buf, fld, order))
+        case 5: msg.LocalTimestamp = get_uint32(buf, fld, order)
+        case 6: msg.EventGroup = get_uint8(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// software message
+
+// MsgSoftware holds the fields of a software message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSoftware struct {
+    msgBase
+
+    MessageIndex uint16
+    Version uint16 // scale 100
+    PartNumber string
+}
+
+func (msg *MsgSoftware) Name() string {
+    return "software"
+}
+
+func (msg *MsgSoftware) VersionScaled() float64 {
+    if !is_valid_uint16(msg.Version) {
+        return math.NaN()
+    }
+    return float64(msg.Version) / 100
+}
+
+func (msg *MsgSoftware) Text() string {
+    txt := "software"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint16(msg.Version) {
+        txt += fmt.Sprintf(" vers %g", msg.VersionScaled())
+    }
+    if is_valid_string(msg.PartNumber) {
+        txt += fmt.Sprintf(" part# %s", msg.PartNumber)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSoftware) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "version": return is_valid_uint16(msg.Version)
+    case "part_number": return is_valid_string(msg.PartNumber)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSoftware) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "version":
+        return msg.VersionScaled()
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgSoftware) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgSoftware) GlobalNum() uint16 {
+    return 35
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSoftware) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 3:
+        return Value{Name: "version", Num: 3, BaseType: 4,
+            Raw: msg.Version, Scaled: msg.Scaled("version"),
+            Units: "", Valid: is_valid_uint16(msg.Version)}
+    case 5:
+        return Value{Name: "part_number", Num: 5, BaseType: 7,
+            Raw: msg.PartNumber, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.PartNumber)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSoftware) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "version": return msg.FieldByNum(3)
+    case "part_number": return msg.FieldByNum(5)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSoftware) Fields() []Value {
+    return fieldValues(msg, []byte{254, 3, 5})
+}
+
+func NewMsgSoftware(def *FitDefinition, data []byte) (*MsgSoftware, error) {
+    msg := new(MsgSoftware)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Version = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 3: msg.Version = get_uint16(buf, fld, order)
+        case 5: msg.PartNumber = get_string(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// file_capabilities message
+
+// MsgFileCapabilities holds the fields of a file_capabilities message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgFileCapabilities struct {
+    msgBase
+
+    MessageIndex uint16
+    Type File
+    Flags uint8
+    Directory string
+    MaxCount uint16
+    MaxSize uint32 // bytes
+}
+
+func (msg *MsgFileCapabilities) Name() string {
+    return "file_capabilities"
+}
+
+func (msg *MsgFileCapabilities) Text() string {
+    txt := "file_capabilities"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.Type)) {
+        txt += fmt.Sprintf(" msgtyp %s", msg.Type)
+    }
+    if is_valid_uint8z(msg.Flags) {
+        txt += fmt.Sprintf(" flags %d", msg.Flags)
+    }
+    if is_valid_string(msg.Directory) {
+        txt += fmt.Sprintf(" directory %s", msg.Directory)
+    }
+    if is_valid_uint16(msg.MaxCount) {
+        txt += fmt.Sprintf(" maxcount %d", msg.MaxCount)
+    }
+    if is_valid_uint32(msg.MaxSize) {
+        txt += fmt.Sprintf(" maxsize %d", msg.MaxSize)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgFileCapabilities) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "type": return is_valid_enum(byte(msg.Type))
+    case "flags": return is_valid_uint8z(msg.Flags)
+    case "directory": return is_valid_string(msg.Directory)
+    case "max_count": return is_valid_uint16(msg.MaxCount)
+    case "max_size": return is_valid_uint32(msg.MaxSize)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgFileCapabilities) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "type":
+        if is_valid_enum(byte(msg.Type)) {
+            return float64(msg.Type)
+        }
+    case "flags":
+        if is_valid_uint8z(msg.Flags) {
+            return float64(msg.Flags)
+        }
+    case "max_count":
+        if is_valid_uint16(msg.MaxCount) {
+            return float64(msg.MaxCount)
+        }
+    case "max_size":
+        if is_valid_uint32(msg.MaxSize) {
+            return float64(msg.MaxSize)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgFileCapabilities) Units(name string) string {
+    switch name {
+    case "max_size": return "bytes"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgFileCapabilities) GlobalNum() uint16 {
+    return 37
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgFileCapabilities) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "type", Num: 0, BaseType: 0,
+            Raw: msg.Type, Scaled: msg.Scaled("type"),
+            Units: "", Valid: is_valid_enum(byte(msg.Type))}
+    case 1:
+        return Value{Name: "flags", Num: 1, BaseType: 10,
+            Raw: msg.Flags, Scaled: msg.Scaled("flags"),
+            Units: "", Valid: is_valid_uint8z(msg.Flags)}
+    case 2:
+        return Value{Name: "directory", Num: 2, BaseType: 7,
+            Raw: msg.Directory, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.Directory)}
+    case 3:
+        return Value{Name: "max_count", Num: 3, BaseType: 4,
+            Raw: msg.MaxCount, Scaled: msg.Scaled("max_count"),
+            Units: "", Valid: is_valid_uint16(msg.MaxCount)}
+    case 4:
+        return Value{Name: "max_size", Num: 4, BaseType: 6,
+            Raw: msg.MaxSize, Scaled: msg.Scaled("max_size"),
+            Units: "bytes", Valid: is_valid_uint32(msg.MaxSize)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgFileCapabilities) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "type": return msg.FieldByNum(0)
+    case "flags": return msg.FieldByNum(1)
+    case "directory": return msg.FieldByNum(2)
+    case "max_count": return msg.FieldByNum(3)
+    case "max_size": return msg.FieldByNum(4)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgFileCapabilities) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3, 4})
+}
+
+func NewMsgFileCapabilities(def *FitDefinition, data []byte) (*MsgFileCapabilities, error) {
+    msg := new(MsgFileCapabilities)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Type = File(invalid_enum)
+    msg.MaxCount = invalid_uint16
+    msg.MaxSize = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.Type = File(get_enum(buf, fld, order))
+        case 1: msg.Flags = get_uint8z(buf, fld, order)
+        case 2: msg.Directory = get_string(buf, fld, order)
+        case 3: msg.MaxCount = get_uint16(buf, fld, order)
+        case 4: msg.MaxSize = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// mesg_capabilities message
+
+// MsgMesgCapabilities holds the fields of a mesg_capabilities message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgMesgCapabilities struct {
+    msgBase
+
+    MessageIndex uint16
+    File File
+    MesgNum MesgNum
+    CountType MesgCount
+    Count uint16
+}
+
+func (msg *MsgMesgCapabilities) Name() string {
+    return "mesg_capabilities"
+}
+
+func (msg *MsgMesgCapabilities) Text() string {
+    txt := "mesg_capabilities"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.File)) {
+        txt += fmt.Sprintf(" file %s", msg.File)
+    }
+    if is_valid_uint16(uint16(msg.MesgNum)) {
+        txt += fmt.Sprintf(" mesgnum %s", msg.MesgNum)
+    }
+    if is_valid_enum(byte(msg.CountType)) {
+        txt += fmt.Sprintf(" counttyp %s", msg.CountType)
+    }
+    if is_valid_uint16(msg.Count) {
+        txt += fmt.Sprintf(" count %d", msg.Count)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgMesgCapabilities) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "file": return is_valid_enum(byte(msg.File))
+    case "mesg_num": return is_valid_uint16(uint16(msg.MesgNum))
+    case "count_type": return is_valid_enum(byte(msg.CountType))
+    case "count": return is_valid_uint16(msg.Count)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgMesgCapabilities) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "file":
+        if is_valid_enum(byte(msg.File)) {
+            return float64(msg.File)
+        }
+    case "mesg_num":
+        if is_valid_uint16(uint16(msg.MesgNum)) {
+            return float64(msg.MesgNum)
+        }
+    case "count_type":
+        if is_valid_enum(byte(msg.CountType)) {
+            return float64(msg.CountType)
+        }
+    case "count":
+        if is_valid_uint16(msg.Count) {
+            return float64(msg.Count)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgMesgCapabilities) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgMesgCapabilities) GlobalNum() uint16 {
+    return 38
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgMesgCapabilities) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "file", Num: 0, BaseType: 0,
+            Raw: msg.File, Scaled: msg.Scaled("file"),
+            Units: "", Valid: is_valid_enum(byte(msg.File))}
+    case 1:
+        return Value{Name: "mesg_num", Num: 1, BaseType: 4,
+            Raw: msg.MesgNum, Scaled: msg.Scaled("mesg_num"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.MesgNum))}
+    case 2:
+        return Value{Name: "count_type", Num: 2, BaseType: 0,
+            Raw: msg.CountType, Scaled: msg.Scaled("count_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.CountType))}
+    case 3:
+        return Value{Name: "count", Num: 3, BaseType: 4,
+            Raw: msg.Count, Scaled: msg.Scaled("count"),
+            Units: "", Valid: is_valid_uint16(msg.Count)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgMesgCapabilities) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "file": return msg.FieldByNum(0)
+    case "mesg_num": return msg.FieldByNum(1)
+    case "count_type": return msg.FieldByNum(2)
+    case "count": return msg.FieldByNum(3)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgMesgCapabilities) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3})
+}
+
+func NewMsgMesgCapabilities(def *FitDefinition, data []byte) (*MsgMesgCapabilities, error) {
+    msg := new(MsgMesgCapabilities)
+
+    msg.MessageIndex = invalid_uint16
+    msg.File = File(invalid_enum)
+    msg.MesgNum = MesgNum(invalid_uint16)
+    msg.CountType = MesgCount(invalid_enum)
+    msg.Count = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.File = File(get_enum(buf, fld, order))
+        case 1: msg.MesgNum = MesgNum(get_uint16(buf, fld, order))
+        case 2: msg.CountType = MesgCount(get_enum(buf, fld, order))
+        case 3: msg.Count = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// field_capabilities message
+
+// MsgFieldCapabilities holds the fields of a field_capabilities message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgFieldCapabilities struct {
+    msgBase
+
+    MessageIndex uint16
+    File File
+    MesgNum MesgNum
+    FieldNum uint8
+    Count uint16
+}
+
+func (msg *MsgFieldCapabilities) Name() string {
+    return "field_capabilities"
+}
+
+func (msg *MsgFieldCapabilities) Text() string {
+    txt := "field_capabilities"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_enum(byte(msg.File)) {
+        txt += fmt.Sprintf(" file %s", msg.File)
+    }
+    if is_valid_uint16(uint16(msg.MesgNum)) {
+        txt += fmt.Sprintf(" mesgnum %s", msg.MesgNum)
+    }
+    if is_valid_uint8(msg.FieldNum) {
+        txt += fmt.Sprintf(" fieldnum %d", msg.FieldNum)
+    }
+    if is_valid_uint16(msg.Count) {
+        txt += fmt.Sprintf(" count %d", msg.Count)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgFieldCapabilities) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "file": return is_valid_enum(byte(msg.File))
+    case "mesg_num": return is_valid_uint16(uint16(msg.MesgNum))
+    case "field_num": return is_valid_uint8(msg.FieldNum)
+    case "count": return is_valid_uint16(msg.Count)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgFieldCapabilities) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "file":
+        if is_valid_enum(byte(msg.File)) {
+            return float64(msg.File)
+        }
+    case "mesg_num":
+        if is_valid_uint16(uint16(msg.MesgNum)) {
+            return float64(msg.MesgNum)
+        }
+    case "field_num":
+        if is_valid_uint8(msg.FieldNum) {
+            return float64(msg.FieldNum)
+        }
+    case "count":
+        if is_valid_uint16(msg.Count) {
+            return float64(msg.Count)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgFieldCapabilities) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgFieldCapabilities) GlobalNum() uint16 {
+    return 39
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgFieldCapabilities) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "file", Num: 0, BaseType: 0,
+            Raw: msg.File, Scaled: msg.Scaled("file"),
+            Units: "", Valid: is_valid_enum(byte(msg.File))}
+    case 1:
+        return Value{Name: "mesg_num", Num: 1, BaseType: 4,
+            Raw: msg.MesgNum, Scaled: msg.Scaled("mesg_num"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.MesgNum))}
+    case 2:
+        return Value{Name: "field_num", Num: 2, BaseType: 2,
+            Raw: msg.FieldNum, Scaled: msg.Scaled("field_num"),
+            Units: "", Valid: is_valid_uint8(msg.FieldNum)}
+    case 3:
+        return Value{Name: "count", Num: 3, BaseType: 4,
+            Raw: msg.Count, Scaled: msg.Scaled("count"),
+            Units: "", Valid: is_valid_uint16(msg.Count)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgFieldCapabilities) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "file": return msg.FieldByNum(0)
+    case "mesg_num": return msg.FieldByNum(1)
+    case "field_num": return msg.FieldByNum(2)
+    case "count": return msg.FieldByNum(3)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgFieldCapabilities) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1, 2, 3})
+}
+
+func NewMsgFieldCapabilities(def *FitDefinition, data []byte) (*MsgFieldCapabilities, error) {
+    msg := new(MsgFieldCapabilities)
+
+    msg.MessageIndex = invalid_uint16
+    msg.File = File(invalid_enum)
+    msg.MesgNum = MesgNum(invalid_uint16)
+    msg.FieldNum = invalid_uint8
+    msg.Count = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.File = File(get_enum(buf, fld, order))
+        case 1: msg.MesgNum = MesgNum(get_uint16(buf, fld, order))
+        case 2: msg.FieldNum = get_uint8(buf, fld, order)
+        case 3: msg.Count = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// file_creator message
+
+// MsgFileCreator holds the fields of a file_creator message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgFileCreator struct {
+    msgBase
+
+    SoftwareVersion uint16
+    HardwareVersion uint8
+}
+
+func (msg *MsgFileCreator) Name() string {
+    return "file_creator"
+}
+
+func (msg *MsgFileCreator) Text() string {
+    txt := "file_creator"
+    if is_valid_uint16(msg.SoftwareVersion) {
+        txt += fmt.Sprintf(" soft %d", msg.SoftwareVersion)
+    }
+    if is_valid_uint8(msg.HardwareVersion) {
+        txt += fmt.Sprintf(" hard %d", msg.HardwareVersion)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgFileCreator) IsSet(name string) bool {
+    switch name {
+    case "software_version": return is_valid_uint16(msg.SoftwareVersion)
+    case "hardware_version": return is_valid_uint8(msg.HardwareVersion)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgFileCreator) Scaled(name string) float64 {
+    switch name {
+    case "software_version":
+        if is_valid_uint16(msg.SoftwareVersion) {
+            return float64(msg.SoftwareVersion)
+        }
+    case "hardware_version":
+        if is_valid_uint8(msg.HardwareVersion) {
+            return float64(msg.HardwareVersion)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgFileCreator) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgFileCreator) GlobalNum() uint16 {
+    return 49
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgFileCreator) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "software_version", Num: 0, BaseType: 4,
+            Raw: msg.SoftwareVersion, Scaled: msg.Scaled("software_version"),
+            Units: "", Valid: is_valid_uint16(msg.SoftwareVersion)}
+    case 1:
+        return Value{Name: "hardware_version", Num: 1, BaseType: 2,
+            Raw: msg.HardwareVersion, Scaled: msg.Scaled("hardware_version"),
+            Units: "", Valid: is_valid_uint8(msg.HardwareVersion)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgFileCreator) Field(name string) Value {
+    switch name {
+    case "software_version": return msg.FieldByNum(0)
+    case "hardware_version": return msg.FieldByNum(1)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgFileCreator) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1})
+}
+
+func NewMsgFileCreator(def *FitDefinition, data []byte) (*MsgFileCreator, error) {
+    msg := new(MsgFileCreator)
+
+    msg.SoftwareVersion = invalid_uint16
+    msg.HardwareVersion = invalid_uint8
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.SoftwareVersion = get_uint16(buf, fld, order)
+        case 1: msg.HardwareVersion = get_uint8(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// blood_pressure message
+
+// MsgBloodPressure holds the fields of a blood_pressure message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgBloodPressure struct {
+    msgBase
+
+    Timestamp uint32
+    SystolicPressure uint16 // mmHg
+    DiastolicPressure uint16 // mmHg
+    MeanArterialPressure uint16 // mmHg
+    Map3SampleMean uint16 // mmHg
+    MapMorningValues uint16 // mmHg
+    MapEveningValues uint16 // mmHg
+    HeartRate uint8 // bpm
+    HeartRateType HrType
+    Status BpStatus
+    UserProfileIndex uint16
+}
+
+func (msg *MsgBloodPressure) Name() string {
+    return "blood_pressure"
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgBloodPressure) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+func (msg *MsgBloodPressure) Text() string {
+    txt := "blood_pressure"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint16(msg.SystolicPressure) {
+        txt += fmt.Sprintf(" systolicpressure %d", msg.SystolicPressure)
+    }
+    if is_valid_uint16(msg.DiastolicPressure) {
+        txt += fmt.Sprintf(" diastolicpressure %d", msg.DiastolicPressure)
+    }
+    if is_valid_uint16(msg.MeanArterialPressure) {
+        txt += fmt.Sprintf(" meanarterialpressure %d", msg.MeanArterialPressure)
+    }
+    if is_valid_uint16(msg.Map3SampleMean) {
+        txt += fmt.Sprintf(" map3samplemean %d", msg.Map3SampleMean)
+    }
+    if is_valid_uint16(msg.MapMorningValues) {
+        txt += fmt.Sprintf(" mapmorningvalues %d", msg.MapMorningValues)
+    }
+    if is_valid_uint16(msg.MapEveningValues) {
+        txt += fmt.Sprintf(" mapeveningvalues %d", msg.MapEveningValues)
+    }
+    if is_valid_uint8(msg.HeartRate) {
+        txt += fmt.Sprintf(" heartrate %d", msg.HeartRate)
+    }
+    if is_valid_enum(byte(msg.HeartRateType)) {
+        txt += fmt.Sprintf(" heartratetyp %s", msg.HeartRateType)
+    }
+    if is_valid_enum(byte(msg.Status)) {
+        txt += fmt.Sprintf(" stat %s", msg.Status)
+    }
+    if is_valid_uint16(msg.UserProfileIndex) {
+        txt += fmt.Sprintf(" userprofileidx %d", msg.UserProfileIndex)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgBloodPressure) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "systolic_pressure": return is_valid_uint16(msg.SystolicPressure)
+    case "diastolic_pressure": return is_valid_uint16(msg.DiastolicPressure)
+    case "mean_arterial_pressure": return is_valid_uint16(msg.MeanArterialPressure)
+    case "map_3_sample_mean": return is_valid_uint16(msg.Map3SampleMean)
+    case "map_morning_values": return is_valid_uint16(msg.MapMorningValues)
+    case "map_evening_values": return is_valid_uint16(msg.MapEveningValues)
+    case "heart_rate": return is_valid_uint8(msg.HeartRate)
+    case "heart_rate_type": return is_valid_enum(byte(msg.HeartRateType))
+    case "status": return is_valid_enum(byte(msg.Status))
+    case "user_profile_index": return is_valid_uint16(msg.UserProfileIndex)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgBloodPressure) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "systolic_pressure":
+        if is_valid_uint16(msg.SystolicPressure) {
+            return float64(msg.SystolicPressure)
+        }
+    case "diastolic_pressure":
+        if is_valid_uint16(msg.DiastolicPressure) {
+            return float64(msg.DiastolicPressure)
+        }
+    case "mean_arterial_pressure":
+        if is_valid_uint16(msg.MeanArterialPressure) {
+            return float64(msg.MeanArterialPressure)
+        }
+    case "map_3_sample_mean":
+        if is_valid_uint16(msg.Map3SampleMean) {
+            return float64(msg.Map3SampleMean)
+        }
+    case "map_morning_values":
+        if is_valid_uint16(msg.MapMorningValues) {
+            return float64(msg.MapMorningValues)
+        }
+    case "map_evening_values":
+        if is_valid_uint16(msg.MapEveningValues) {
+            return float64(msg.MapEveningValues)
+        }
+    case "heart_rate":
+        if is_valid_uint8(msg.HeartRate) {
+            return float64(msg.HeartRate)
+        }
+    case "heart_rate_type":
+        if is_valid_enum(byte(msg.HeartRateType)) {
+            return float64(msg.HeartRateType)
+        }
+    case "status":
+        if is_valid_enum(byte(msg.Status)) {
+            return float64(msg.Status)
+        }
+    case "user_profile_index":
+        if is_valid_uint16(msg.UserProfileIndex) {
+            return float64(msg.UserProfileIndex)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgBloodPressure) Units(name string) string {
+    switch name {
+    case "systolic_pressure": return "mmHg"
+    case "diastolic_pressure": return "mmHg"
+    case "mean_arterial_pressure": return "mmHg"
+    case "map_3_sample_mean": return "mmHg"
+    case "map_morning_values": return "mmHg"
+    case "map_evening_values": return "mmHg"
+    case "heart_rate": return "bpm"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgBloodPressure) GlobalNum() uint16 {
+    return 51
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgBloodPressure) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "systolic_pressure", Num: 0, BaseType: 4,
+            Raw: msg.SystolicPressure, Scaled: msg.Scaled("systolic_pressure"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.SystolicPressure)}
+    case 1:
+        return Value{Name: "diastolic_pressure", Num: 1, BaseType: 4,
+            Raw: msg.DiastolicPressure, Scaled: msg.Scaled("diastolic_pressure"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.DiastolicPressure)}
+    case 2:
+        return Value{Name: "mean_arterial_pressure", Num: 2, BaseType: 4,
+            Raw: msg.MeanArterialPressure, Scaled: msg.Scaled("mean_arterial_pressure"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.MeanArterialPressure)}
+    case 3:
+        return Value{Name: "map_3_sample_mean", Num: 3, BaseType: 4,
+            Raw: msg.Map3SampleMean, Scaled: msg.Scaled("map_3_sample_mean"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.Map3SampleMean)}
+    case 4:
+        return Value{Name: "map_morning_values", Num: 4, BaseType: 4,
+            Raw: msg.MapMorningValues, Scaled: msg.Scaled("map_morning_values"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.MapMorningValues)}
+    case 5:
+        return Value{Name: "map_evening_values", Num: 5, BaseType: 4,
+            Raw: msg.MapEveningValues, Scaled: msg.Scaled("map_evening_values"),
+            Units: "mmHg", Valid: is_valid_uint16(msg.MapEveningValues)}
+    case 6:
+        return Value{Name: "heart_rate", Num: 6, BaseType: 2,
+            Raw: msg.HeartRate, Scaled: msg.Scaled("heart_rate"),
+            Units: "bpm", Valid: is_valid_uint8(msg.HeartRate)}
+    case 7:
+        return Value{Name: "heart_rate_type", Num: 7, BaseType: 0,
+            Raw: msg.HeartRateType, Scaled: msg.Scaled("heart_rate_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.HeartRateType))}
+    case 8:
+        return Value{Name: "status", Num: 8, BaseType: 0,
+            Raw: msg.Status, Scaled: msg.Scaled("status"),
+            Units: "", Valid: is_valid_enum(byte(msg.Status))}
+    case 9:
+        return Value{Name: "user_profile_index", Num: 9, BaseType: 4,
+            Raw: msg.UserProfileIndex, Scaled: msg.Scaled("user_profile_index"),
+            Units: "", Valid: is_valid_uint16(msg.UserProfileIndex)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgBloodPressure) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "systolic_pressure": return msg.FieldByNum(0)
+    case "diastolic_pressure": return msg.FieldByNum(1)
+    case "mean_arterial_pressure": return msg.FieldByNum(2)
+    case "map_3_sample_mean": return msg.FieldByNum(3)
+    case "map_morning_values": return msg.FieldByNum(4)
+    case "map_evening_values": return msg.FieldByNum(5)
+    case "heart_rate": return msg.FieldByNum(6)
+    case "heart_rate_type": return msg.FieldByNum(7)
+    case "status": return msg.FieldByNum(8)
+    case "user_profile_index": return msg.FieldByNum(9)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgBloodPressure) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 6, 7, 8, 9})
+}
+
+func NewMsgBloodPressure(def *FitDefinition, data []byte) (*MsgBloodPressure, error) {
+    msg := new(MsgBloodPressure)
+
+    msg.Timestamp = invalid_uint32
+    msg.SystolicPressure = invalid_uint16
+    msg.DiastolicPressure = invalid_uint16
+    msg.MeanArterialPressure = invalid_uint16
+    msg.Map3SampleMean = invalid_uint16
+    msg.MapMorningValues = invalid_uint16
+    msg.MapEveningValues = invalid_uint16
+    msg.HeartRate = invalid_uint8
+    msg.HeartRateType = HrType(invalid_enum)
+    msg.Status = BpStatus(invalid_enum)
+    msg.UserProfileIndex = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.SystolicPressure = get_uint16(buf, fld, order)
+        case 1: msg.DiastolicPressure = get_uint16(buf, fld, order)
+        case 2: msg.MeanArterialPressure = get_uint16(buf, fld, order)
+        case 3: msg.Map3SampleMean = get_uint16(buf, fld, order)
+        case 4: msg.MapMorningValues = get_uint16(buf, fld, order)
+        case 5: msg.MapEveningValues = get_uint16(buf, fld, order)
+        case 6: msg.HeartRate = get_uint8(buf, fld, order)
+        case 7: msg.HeartRateType = HrType(get_enum(buf, fld, order))
+        case 8: msg.Status = BpStatus(get_enum(buf, fld, order))
+        case 9: msg.UserProfileIndex = get_uint16(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// speed_zone message
+
+// MsgSpeedZone holds the fields of a speed_zone message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSpeedZone struct {
+    msgBase
+
+    MessageIndex uint16
+    HighValue uint16 // m/s, scale 1000
+    SpeedZoneName string
+}
+
+func (msg *MsgSpeedZone) Name() string {
+    return "speed_zone"
+}
+
+// high_value in m/s
+func (msg *MsgSpeedZone) HighValueScaled() float64 {
+    if !is_valid_uint16(msg.HighValue) {
+        return math.NaN()
+    }
+    return float64(msg.HighValue) / 1000
+}
+
+func (msg *MsgSpeedZone) Text() string {
+    txt := "speed_zone"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint16(msg.HighValue) {
+        txt += fmt.Sprintf(" highvalue %g", msg.HighValueScaled())
+    }
+    if is_valid_string(msg.SpeedZoneName) {
+        txt += fmt.Sprintf(" name %s", msg.SpeedZoneName)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSpeedZone) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "high_value": return is_valid_uint16(msg.HighValue)
+    case "name": return is_valid_string(msg.SpeedZoneName)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSpeedZone) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "high_value":
+        return msg.HighValueScaled()
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgSpeedZone) Units(name string) string {
+    switch name {
+    case "high_value": return "m/s"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgSpeedZone) GlobalNum() uint16 {
+    return 53
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSpeedZone) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "high_value", Num: 0, BaseType: 4,
+            Raw: msg.HighValue, Scaled: msg.Scaled("high_value"),
+            Units: "m/s", Valid: is_valid_uint16(msg.HighValue)}
+    case 1:
+        return Value{Name: "name", Num: 1, BaseType: 7,
+            Raw: msg.SpeedZoneName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.SpeedZoneName)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSpeedZone) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "high_value": return msg.FieldByNum(0)
+    case "name": return msg.FieldByNum(1)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSpeedZone) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1})
+}
+
+func NewMsgSpeedZone(def *FitDefinition, data []byte) (*MsgSpeedZone, error) {
+    msg := new(MsgSpeedZone)
+
+    msg.MessageIndex = invalid_uint16
+    msg.HighValue = invalid_uint16
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.HighValue = get_uint16(buf, fld, order)
+        case 1: msg.SpeedZoneName = get_string(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// monitoring message
+
+// MsgMonitoring holds the fields of a monitoring message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgMonitoring struct {
+    msgBase
+
+    Timestamp uint32 // s
+    DeviceIndex uint8
+    Calories uint16 // kcal
+    Distance uint32 // m, scale 100
+    Cycles uint32 // cycles, scale 2
+    ActiveTime uint32 // s, scale 1000
+    ActivityType ActivityType
+    ActivitySubtype ActivitySubtype
+    CompressedDistance uint16
+    CompressedCycles uint16
+    CompressedActiveTime uint16
+    LocalTimestamp uint32
+}
+
+// fields of monitoring messages which are unpacked into other fields
+var monitoring_components = map[byte][]*fitComponent{
+    8: []*fitComponent{
+        &fitComponent{num: 2, base_type: 6, accumulate: true, bits: 16,
+            scale: 100, offset: 0, dest_scale: 100, dest_offset: 0}, // distance
+    },
+    9: []*fitComponent{
+        &fitComponent{num: 3, base_type: 6, accumulate: true, bits: 16,
+            scale: 2, offset: 0, dest_scale: 2, dest_offset: 0}, // cycles
+    },
+    10: []*fitComponent{
+        &fitComponent{num: 4, base_type: 6, accumulate: true, bits: 16,
+            scale: 1000, offset: 0, dest_scale: 1000, dest_offset: 0}, // active_time
+    },
+}
+
+func (msg *MsgMonitoring) Name() string {
+    return "monitoring"
+}
+
+// distance in m
+func (msg *MsgMonitoring) DistanceScaled() float64 {
+    if !is_valid_uint32(msg.Distance) {
+        return math.NaN()
+    }
+    return float64(msg.Distance) / 100
+}
+
+// cycles in cycles
+func (msg *MsgMonitoring) CyclesScaled() float64 {
+    if !is_valid_uint32(msg.Cycles) {
+        return math.NaN()
+    }
+    return float64(msg.Cycles) / 2
+}
+
+// active_time in s
+func (msg *MsgMonitoring) ActiveTimeScaled() float64 {
+    if !is_valid_uint32(msg.ActiveTime) {
+        return math.NaN()
+    }
+    return float64(msg.ActiveTime) / 1000
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgMonitoring) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// local_timestamp as a time.Time; see LocalDateTime()
+func (msg *MsgMonitoring) LocalTimestampTime() time.Time {
+    return LocalDateTime(msg.LocalTimestamp)
+}
+
+func (msg *MsgMonitoring) Text() string {
+    txt := "monitoring"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint8(msg.DeviceIndex) {
+        txt += fmt.Sprintf(" devidx %d", msg.DeviceIndex)
+    }
+    if is_valid_uint16(msg.Calories) {
+        txt += fmt.Sprintf(" cals %d", msg.Calories)
+    }
+    if is_valid_uint32(msg.Distance) {
+        txt += fmt.Sprintf(" dist %g", msg.DistanceScaled())
+    }
+    if is_valid_uint32(msg.Cycles) {
+        txt += fmt.Sprintf(" cycles %g", msg.CyclesScaled())
+    }
+    if is_valid_uint32(msg.ActiveTime) {
+        txt += fmt.Sprintf(" activetime %g", msg.ActiveTimeScaled())
+    }
+    if is_valid_enum(byte(msg.ActivityType)) {
+        txt += fmt.Sprintf(" activitytyp %s", msg.ActivityType)
+    }
+    if is_valid_enum(byte(msg.ActivitySubtype)) {
+        txt += fmt.Sprintf(" activitysubtyp %s", msg.ActivitySubtype)
+    }
+    if is_valid_uint16(msg.CompressedDistance) {
+        txt += fmt.Sprintf(" compresseddist %d", msg.CompressedDistance)
+    }
+    if is_valid_uint16(msg.CompressedCycles) {
+        txt += fmt.Sprintf(" compressedcycles %d", msg.CompressedCycles)
+    }
+    if is_valid_uint16(msg.CompressedActiveTime) {
+        txt += fmt.Sprintf(" compressedactivetime %d", msg.CompressedActiveTime)
+    }
+    if is_valid_uint32(msg.LocalTimestamp) {
+        txt += fmt.Sprintf(" localtstmp %d", msg.LocalTimestamp)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgMonitoring) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "device_index": return is_valid_uint8(msg.DeviceIndex)
+    case "calories": return is_valid_uint16(msg.Calories)
+    case "distance": return is_valid_uint32(msg.Distance)
+    case "cycles": return is_valid_uint32(msg.Cycles)
+    case "active_time": return is_valid_uint32(msg.ActiveTime)
+    case "activity_type": return is_valid_enum(byte(msg.ActivityType))
+    case "activity_subtype": return is_valid_enum(byte(msg.ActivitySubtype))
+    case "compressed_distance": return is_valid_uint16(msg.CompressedDistance)
+    case "compressed_cycles": return is_valid_uint16(msg.CompressedCycles)
+    case "compressed_active_time": return is_valid_uint16(msg.CompressedActiveTime)
+    case "local_timestamp": return is_valid_uint32(msg.LocalTimestamp)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgMonitoring) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "device_index":
+        if is_valid_uint8(msg.DeviceIndex) {
+            return float64(msg.DeviceIndex)
+        }
+    case "calories":
+        if is_valid_uint16(msg.Calories) {
+            return float64(msg.Calories)
+        }
+    case "distance":
+        return msg.DistanceScaled()
+    case "cycles":
+        return msg.CyclesScaled()
+    case "active_time":
+        return msg.ActiveTimeScaled()
+    case "activity_type":
+        if is_valid_enum(byte(msg.ActivityType)) {
+            return float64(msg.ActivityType)
+        }
+    case "activity_subtype":
+        if is_valid_enum(byte(msg.ActivitySubtype)) {
+            return float64(msg.ActivitySubtype)
+        }
+    case "compressed_distance":
+        if is_valid_uint16(msg.CompressedDistance) {
+            return float64(msg.CompressedDistance)
+        }
+    case "compressed_cycles":
+        if is_valid_uint16(msg.CompressedCycles) {
+            return float64(msg.CompressedCycles)
+        }
+    case "compressed_active_time":
+        if is_valid_uint16(msg.CompressedActiveTime) {
+            return float64(msg.CompressedActiveTime)
+        }
+    case "local_timestamp":
+        if is_valid_uint32(msg.LocalTimestamp) {
+            return float64(msg.LocalTimestamp)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgMonitoring) Units(name string) string {
+    switch name {
+    case "timestamp": return "s"
+    case "calories": return "kcal"
+    case "distance": return "m"
+    case "cycles": return "cycles"
+    case "active_time": return "s"
+    default: return ""
+    }
+}
+
+// Accumulated returns the named field's running total, which keeps
+// increasing when the value in the file rolls over, or NaN if the
+// field is not set or is not accumulated
+func (msg *MsgMonitoring) Accumulated(name string) float64 {
+    switch name {
+    case "distance": return msg.total(2, 100, 0)
+    case "cycles": return msg.total(3, 2, 0)
+    case "active_time": return msg.total(4, 1000, 0)
+    default: return math.NaN()
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgMonitoring) GlobalNum() uint16 {
+    return 55
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgMonitoring) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "s", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "device_index", Num: 0, BaseType: 2,
+            Raw: msg.DeviceIndex, Scaled: msg.Scaled("device_index"),
+            Units: "", Valid: is_valid_uint8(msg.DeviceIndex)}
+    case 1:
+        return Value{Name: "calories", Num: 1, BaseType: 4,
+            Raw: msg.Calories, Scaled: msg.Scaled("calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.Calories)}
+    case 2:
+        return Value{Name: "distance", Num: 2, BaseType: 6,
+            Raw: msg.Distance, Scaled: msg.Scaled("distance"),
+            Units: "m", Valid: is_valid_uint32(msg.Distance)}
+    case 3:
+        return Value{Name: "cycles", Num: 3, BaseType: 6,
+            Raw: msg.Cycles, Scaled: msg.Scaled("cycles"),
+            Units: "cycles", Valid: is_valid_uint32(msg.Cycles)}
+    case 4:
+        return Value{Name: "active_time", Num: 4, BaseType: 6,
+            Raw: msg.ActiveTime, Scaled: msg.Scaled("active_time"),
+            Units: "s", Valid: is_valid_uint32(msg.ActiveTime)}
+    case 5:
+        return Value{Name: "activity_type", Num: 5, BaseType: 0,
+            Raw: msg.ActivityType, Scaled: msg.Scaled("activity_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.ActivityType))}
+    case 6:
+        return Value{Name: "activity_subtype", Num: 6, BaseType: 0,
+            Raw: msg.ActivitySubtype, Scaled: msg.Scaled("activity_subtype"),
+            Units: "", Valid: is_valid_enum(byte(msg.ActivitySubtype))}
+    case 8:
+        return Value{Name: "compressed_distance", Num: 8, BaseType: 4,
+            Raw: msg.CompressedDistance, Scaled: msg.Scaled("compressed_distance"),
+            Units: "", Valid: is_valid_uint16(msg.CompressedDistance)}
+    case 9:
+        return Value{Name: "compressed_cycles", Num: 9, BaseType: 4,
+            Raw: msg.CompressedCycles, Scaled: msg.Scaled("compressed_cycles"),
+            Units: "", Valid: is_valid_uint16(msg.CompressedCycles)}
+    case 10:
+        return Value{Name: "compressed_active_time", Num: 10, BaseType: 4,
+            Raw: msg.CompressedActiveTime, Scaled: msg.Scaled("compressed_active_time"),
+            Units: "", Valid: is_valid_uint16(msg.CompressedActiveTime)}
+    case 11:
+        return Value{Name: "local_timestamp", Num: 11, BaseType: 6,
+            Raw: msg.LocalTimestamp, Scaled: msg.Scaled("local_timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.LocalTimestamp)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgMonitoring) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "device_index": return msg.FieldByNum(0)
+    case "calories": return msg.FieldByNum(1)
+    case "distance": return msg.FieldByNum(2)
+    case "cycles": return msg.FieldByNum(3)
+    case "active_time": return msg.FieldByNum(4)
+    case "activity_type": return msg.FieldByNum(5)
+    case "activity_subtype": return msg.FieldByNum(6)
+    case "compressed_distance": return msg.FieldByNum(8)
+    case "compressed_cycles": return msg.FieldByNum(9)
+    case "compressed_active_time": return msg.FieldByNum(10)
+    case "local_timestamp": return msg.FieldByNum(11)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgMonitoring) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0, 1, 2, 3, 4, 5, 6, 8, 9, 10, 11})
+}
+
+func NewMsgMonitoring(def *FitDefinition, data []byte) (*MsgMonitoring, error) {
+    msg := new(MsgMonitoring)
+
+    msg.Timestamp = invalid_uint32
+    msg.DeviceIndex = invalid_uint8
+    msg.Calories = invalid_uint16
+    msg.Distance = invalid_uint32
+    msg.Cycles = invalid_uint32
+    msg.ActiveTime = invalid_uint32
+    msg.ActivityType = ActivityType(invalid_enum)
+    msg.ActivitySubtype = ActivitySubtype(invalid_enum)
+    msg.CompressedDistance = invalid_uint16
+    msg.CompressedCycles = invalid_uint16
+    msg.CompressedActiveTime = invalid_uint16
+    msg.LocalTimestamp = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.DeviceIndex = get_uint8(buf, fld, order)
+        case 1: msg.Calories = get_uint16(buf, fld, order)
+        case 2: msg.Distance = get_uint32(buf, fld, order)
+        case 3: msg.Cycles = get_uint32(buf, fld, order)
+        case 4: msg.ActiveTime = get_uint32(buf, fld, order)
+        case 5: msg.ActivityType = ActivityType(get_enum(buf, fld, order))
+        case 6: msg.ActivitySubtype = ActivitySubtype(get_enum(buf, fld, order))
+        case 8: msg.CompressedDistance = get_uint16(buf, fld, order)
+        case 9: msg.CompressedCycles = get_uint16(buf, fld, order)
+        case 10: msg.CompressedActiveTime = get_uint16(buf, fld, order)
+        case 11: msg.LocalTimestamp = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// hrv message
+
+// MsgHrv holds the fields of a hrv message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgHrv struct {
+    msgBase
+
+    Time []uint16 // s, scale 1000
+}
+
+func (msg *MsgHrv) Name() string {
+    return "hrv"
+}
+
+// time in s
+func (msg *MsgHrv) TimeScaled() []float64 {
+    vals := make([]float64, len(msg.Time))
+    for i, v := range msg.Time {
+        if !is_valid_uint16(v) {
+            vals[i] = math.NaN()
+        } else {
+            vals[i] = float64(v) / 1000
+        }
+    }
+    return vals
+}
+
+func (msg *MsgHrv) Text() string {
+    txt := "hrv"
+    if len(msg.Time) > 0 {
+        txt += fmt.Sprintf(" time %g", msg.TimeScaled())
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgHrv) IsSet(name string) bool {
+    switch name {
+    case "time": return len(msg.Time) > 0
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgHrv) Scaled(name string) float64 {
+    switch name {
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgHrv) Units(name string) string {
+    switch name {
+    case "time": return "s"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgHrv) GlobalNum() uint16 {
+    return 78
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgHrv) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "time", Num: 0, BaseType: 4,
+            Raw: msg.Time, Scaled: math.NaN(),
+            Units: "s", Valid: len(msg.Time) > 0}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgHrv) Field(name string) Value {
+    switch name {
+    case "time": return msg.FieldByNum(0)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgHrv) Fields() []Value {
+    return fieldValues(msg, []byte{0})
+}
+
+func NewMsgHrv(def *FitDefinition, data []byte) (*MsgHrv, error) {
+    msg := new(MsgHrv)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Time = get_uint16_array(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// length message
+
+// MsgLength holds the fields of a length message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgLength struct {
+    msgBase
+
+    MessageIndex uint16
+    Timestamp uint32
+    Event Event
+    EventType EventType
+    StartTime uint32
+    TotalElapsedTime uint32 // s, scale 1000
+    TotalTimerTime uint32 // s, scale 1000
+    TotalStrokes uint16 // strokes
+    AvgSpeed uint16 // m/s, scale 1000
+    SwimStroke SwimStroke
+    AvgSwimmingCadence uint8 // strokes/min
+    EventGroup uint8
+    TotalCalories uint16 // kcal
+    LengthType LengthType
+}
+
+func (msg *MsgLength) Name() string {
+    return "length"
+}
+
+// total_elapsed_time in s
+func (msg *MsgLength) TotalElapsedTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalElapsedTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalElapsedTime) / 1000
+}
+
+// total_timer_time in s
+func (msg *MsgLength) TotalTimerTimeScaled() float64 {
+    if !is_valid_uint32(msg.TotalTimerTime) {
+        return math.NaN()
+    }
+    return float64(msg.TotalTimerTime) / 1000
+}
+
+// avg_speed in m/s
+func (msg *MsgLength) AvgSpeedScaled() float64 {
+    if !is_valid_uint16(msg.AvgSpeed) {
+        return math.NaN()
+    }
+    return float64(msg.AvgSpeed) / 1000
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgLength) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// start_time as a time.Time; see DateTime()
+func (msg *MsgLength) StartTimeTime() time.Time {
+    return DateTime(msg.StartTime)
+}
+
+func (msg *MsgLength) Text() string {
+    txt := "length"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_enum(byte(msg.Event)) {
+        txt += fmt.Sprintf(" evt %s", msg.Event)
+    }
+    if is_valid_enum(byte(msg.EventType)) {
+        txt += fmt.Sprintf(" evttyp %s", msg.EventType)
+    }
+    if is_valid_uint32(msg.StartTime) {
+        txt += fmt.Sprintf(" starttime %d", msg.StartTime)
+    }
+    if is_valid_uint32(msg.TotalElapsedTime) {
+        txt += fmt.Sprintf(" totalelapsedtime %g", msg.TotalElapsedTimeScaled())
+    }
+    if is_valid_uint32(msg.TotalTimerTime) {
+        txt += fmt.Sprintf(" totaltimertime %g", msg.TotalTimerTimeScaled())
+    }
+    if is_valid_uint16(msg.TotalStrokes) {
+        txt += fmt.Sprintf(" totalstrokes %d", msg.TotalStrokes)
+    }
+    if is_valid_uint16(msg.AvgSpeed) {
+        txt += fmt.Sprintf(" avgspeed %g", msg.AvgSpeedScaled())
+    }
+    if is_valid_enum(byte(msg.SwimStroke)) {
+        txt += fmt.Sprintf(" swimstroke %s", msg.SwimStroke)
+    }
+    if is_valid_uint8(msg.AvgSwimmingCadence) {
+        txt += fmt.Sprintf(" avgswimmingcadence %d", msg.AvgSwimmingCadence)
+    }
+    if is_valid_uint8(msg.EventGroup) {
+        txt += fmt.Sprintf(" evtgrp %d", msg.EventGroup)
+    }
+    if is_valid_uint16(msg.TotalCalories) {
+        txt += fmt.Sprintf(" totalcals %d", msg.TotalCalories)
+    }
+    if is_valid_enum(byte(msg.LengthType)) {
+        txt += fmt.Sprintf(" lentyp %s", msg.LengthType)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgLength) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "event": return is_valid_enum(byte(msg.Event))
+    case "event_type": return is_valid_enum(byte(msg.EventType))
+    case "start_time": return is_valid_uint32(msg.StartTime)
+    case "total_elapsed_time": return is_valid_uint32(msg.TotalElapsedTime)
+    case "total_timer_time": return is_valid_uint32(msg.TotalTimerTime)
+    case "total_strokes": return is_valid_uint16(msg.TotalStrokes)
+    case "avg_speed": return is_valid_uint16(msg.AvgSpeed)
+    case "swim_stroke": return is_valid_enum(byte(msg.SwimStroke))
+    case "avg_swimming_cadence": return is_valid_uint8(msg.AvgSwimmingCadence)
+    case "event_group": return is_valid_uint8(msg.EventGroup)
+    case "total_calories": return is_valid_uint16(msg.TotalCalories)
+    case "length_type": return is_valid_enum(byte(msg.LengthType))
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgLength) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "event":
+        if is_valid_enum(byte(msg.Event)) {
+            return float64(msg.Event)
+        }
+    case "event_type":
+        if is_valid_enum(byte(msg.EventType)) {
+            return float64(msg.EventType)
+        }
+    case "start_time":
+        if is_valid_uint32(msg.StartTime) {
+            return float64(msg.StartTime)
+        }
+    case "total_elapsed_time":
+        return msg.TotalElapsedTimeScaled()
+    case "total_timer_time":
+        return msg.TotalTimerTimeScaled()
+    case "total_strokes":
+        if is_valid_uint16(msg.TotalStrokes) {
+            return float64(msg.TotalStrokes)
+        }
+    case "avg_speed":
+        return msg.AvgSpeedScaled()
+    case "swim_stroke":
+        if is_valid_enum(byte(msg.SwimStroke)) {
+            return float64(msg.SwimStroke)
+        }
+    case "avg_swimming_cadence":
+        if is_valid_uint8(msg.AvgSwimmingCadence) {
+            return float64(msg.AvgSwimmingCadence)
+        }
+    case "event_group":
+        if is_valid_uint8(msg.EventGroup) {
+            return float64(msg.EventGroup)
+        }
+    case "total_calories":
+        if is_valid_uint16(msg.TotalCalories) {
+            return float64(msg.TotalCalories)
+        }
+    case "length_type":
+        if is_valid_enum(byte(msg.LengthType)) {
+            return float64(msg.LengthType)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgLength) Units(name string) string {
+    switch name {
+    case "total_elapsed_time": return "s"
+    case "total_timer_time": return "s"
+    case "total_strokes": return "strokes"
+    case "avg_speed": return "m/s"
+    case "avg_swimming_cadence": return "strokes/min"
+    case "total_calories": return "kcal"
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgLength) GlobalNum() uint16 {
+    return 101
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgLength) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "event", Num: 0, BaseType: 0,
+            Raw: msg.Event, Scaled: msg.Scaled("event"),
+            Units: "", Valid: is_valid_enum(byte(msg.Event))}
+    case 1:
+        return Value{Name: "event_type", Num: 1, BaseType: 0,
+            Raw: msg.EventType, Scaled: msg.Scaled("event_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.EventType))}
+    case 2:
+        return Value{Name: "start_time", Num: 2, BaseType: 6,
+            Raw: msg.StartTime, Scaled: msg.Scaled("start_time"),
+            Units: "", Valid: is_valid_uint32(msg.StartTime)}
+    case 3:
+        return Value{Name: "total_elapsed_time", Num: 3, BaseType: 6,
+            Raw: msg.TotalElapsedTime, Scaled: msg.Scaled("total_elapsed_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalElapsedTime)}
+    case 4:
+        return Value{Name: "total_timer_time", Num: 4, BaseType: 6,
+            Raw: msg.TotalTimerTime, Scaled: msg.Scaled("total_timer_time"),
+            Units: "s", Valid: is_valid_uint32(msg.TotalTimerTime)}
+    case 5:
+        return Value{Name: "total_strokes", Num: 5, BaseType: 4,
+            Raw: msg.TotalStrokes, Scaled: msg.Scaled("total_strokes"),
+            Units: "strokes", Valid: is_valid_uint16(msg.TotalStrokes)}
+    case 6:
+        return Value{Name: "avg_speed", Num: 6, BaseType: 4,
+            Raw: msg.AvgSpeed, Scaled: msg.Scaled("avg_speed"),
+            Units: "m/s", Valid: is_valid_uint16(msg.AvgSpeed)}
+    case 7:
+        return Value{Name: "swim_stroke", Num: 7, BaseType: 0,
+            Raw: msg.SwimStroke, Scaled: msg.Scaled("swim_stroke"),
+            Units: "", Valid: is_valid_enum(byte(msg.SwimStroke))}
+    case 9:
+        return Value{Name: "avg_swimming_cadence", Num: 9, BaseType: 2,
+            Raw: msg.AvgSwimmingCadence, Scaled: msg.Scaled("avg_swimming_cadence"),
+            Units: "strokes/min", Valid: is_valid_uint8(msg.AvgSwimmingCadence)}
+    case 10:
+        return Value{Name: "event_group", Num: 10, BaseType: 2,
+            Raw: msg.EventGroup, Scaled: msg.Scaled("event_group"),
+            Units: "", Valid: is_valid_uint8(msg.EventGroup)}
+    case 11:
+        return Value{Name: "total_calories", Num: 11, BaseType: 4,
+            Raw: msg.TotalCalories, Scaled: msg.Scaled("total_calories"),
+            Units: "kcal", Valid: is_valid_uint16(msg.TotalCalories)}
+    case 12:
+        return Value{Name: "length_type", Num: 12, BaseType: 0,
+            Raw: msg.LengthType, Scaled: msg.Scaled("length_type"),
+            Units: "", Valid: is_valid_enum(byte(msg.LengthType))}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgLength) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "timestamp": return msg.FieldByNum(253)
+    case "event": return msg.FieldByNum(0)
+    case "event_type": return msg.FieldByNum(1)
+    case "start_time": return msg.FieldByNum(2)
+    case "total_elapsed_time": return msg.FieldByNum(3)
+    case "total_timer_time": return msg.FieldByNum(4)
+    case "total_strokes": return msg.FieldByNum(5)
+    case "avg_speed": return msg.FieldByNum(6)
+    case "swim_stroke": return msg.FieldByNum(7)
+    case "avg_swimming_cadence": return msg.FieldByNum(9)
+    case "event_group": return msg.FieldByNum(10)
+    case "total_calories": return msg.FieldByNum(11)
+    case "length_type": return msg.FieldByNum(12)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgLength) Fields() []Value {
+    return fieldValues(msg, []byte{254, 253, 0, 1, 2, 3, 4, 5, 6, 7, 9, 10, 11, 12})
+}
+
+func NewMsgLength(def *FitDefinition, data []byte) (*MsgLength, error) {
+    msg := new(MsgLength)
+
+    msg.MessageIndex = invalid_uint16
+    msg.Timestamp = invalid_uint32
+    msg.Event = Event(invalid_enum)
+    msg.EventType = EventType(invalid_enum)
+    msg.StartTime = invalid_uint32
+    msg.TotalElapsedTime = invalid_uint32
+    msg.TotalTimerTime = invalid_uint32
+    msg.TotalStrokes = invalid_uint16
+    msg.AvgSpeed = invalid_uint16
+    msg.SwimStroke = SwimStroke(invalid_enum)
+    msg.AvgSwimmingCadence = invalid_uint8
+    msg.EventGroup = invalid_uint8
+    msg.TotalCalories = invalid_uint16
+    msg.LengthType = LengthType(invalid_enum)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.Event = Event(get_enum(buf, fld, order))
+        case 1: msg.EventType = EventType(get_enum(buf, fld, order))
+        case 2: msg.StartTime = get_uint32(buf, fld, order)
+        case 3: msg.TotalElapsedTime = get_uint32(buf, fld, order)
+        case 4: msg.TotalTimerTime = get_uint32(buf, fld, order)
+        case 5: msg.TotalStrokes = get_uint16(buf, fld, order)
+        case 6: msg.AvgSpeed = get_uint16(buf, fld, order)
+        case 7: msg.SwimStroke = SwimStroke(get_enum(buf, fld, order))
+        case 9: msg.AvgSwimmingCadence = get_uint8(buf, fld, order)
+        case 10: msg.EventGroup = get_uint8(buf, fld, order)
+        case 11: msg.TotalCalories = get_uint16(buf, fld, order)
+        case 12: msg.LengthType = LengthType(get_enum(buf, fld, order))
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// monitoring_info message
+
+// MsgMonitoringInfo holds the fields of a monitoring_info message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgMonitoringInfo struct {
+    msgBase
+
+    Timestamp uint32
+    LocalTimestamp uint32
+}
+
+func (msg *MsgMonitoringInfo) Name() string {
+    return "monitoring_info"
+}
+
+// timestamp as a time.Time; see DateTime()
+func (msg *MsgMonitoringInfo) TimestampTime() time.Time {
+    return DateTime(msg.Timestamp)
+}
+
+// local_timestamp as a time.Time; see LocalDateTime()
+func (msg *MsgMonitoringInfo) LocalTimestampTime() time.Time {
+    return LocalDateTime(msg.LocalTimestamp)
+}
+
+func (msg *MsgMonitoringInfo) Text() string {
+    txt := "monitoring_info"
+    if is_valid_uint32(msg.Timestamp) {
+        txt += fmt.Sprintf(" tstmp %d", msg.Timestamp)
+    }
+    if is_valid_uint32(msg.LocalTimestamp) {
+        txt += fmt.Sprintf(" localtstmp %d", msg.LocalTimestamp)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgMonitoringInfo) IsSet(name string) bool {
+    switch name {
+    case "timestamp": return is_valid_uint32(msg.Timestamp)
+    case "local_timestamp": return is_valid_uint32(msg.LocalTimestamp)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgMonitoringInfo) Scaled(name string) float64 {
+    switch name {
+    case "timestamp":
+        if is_valid_uint32(msg.Timestamp) {
+            return float64(msg.Timestamp)
+        }
+    case "local_timestamp":
+        if is_valid_uint32(msg.LocalTimestamp) {
+            return float64(msg.LocalTimestamp)
+        }
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgMonitoringInfo) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgMonitoringInfo) GlobalNum() uint16 {
+    return 103
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgMonitoringInfo) FieldByNum(num byte) Value {
+    switch num {
+    case 253:
+        return Value{Name: "timestamp", Num: 253, BaseType: 6,
+            Raw: msg.Timestamp, Scaled: msg.Scaled("timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.Timestamp)}
+    case 0:
+        return Value{Name: "local_timestamp", Num: 0, BaseType: 6,
+            Raw: msg.LocalTimestamp, Scaled: msg.Scaled("local_timestamp"),
+            Units: "", Valid: is_valid_uint32(msg.LocalTimestamp)}
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgMonitoringInfo) Field(name string) Value {
+    switch name {
+    case "timestamp": return msg.FieldByNum(253)
+    case "local_timestamp": return msg.FieldByNum(0)
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgMonitoringInfo) Fields() []Value {
+    return fieldValues(msg, []byte{253, 0})
+}
+
+func NewMsgMonitoringInfo(def *FitDefinition, data []byte) (*MsgMonitoringInfo, error) {
+    msg := new(MsgMonitoringInfo)
+
+    msg.Timestamp = invalid_uint32
+    msg.LocalTimestamp = invalid_uint32
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 253: msg.Timestamp = get_uint32(buf, fld, order)
+        case 0: msg.LocalTimestamp = get_uint32(buf, fld, order)
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// pad message
+
+// MsgPad holds the fields of a pad message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgPad struct {
+    msgBase
+}
+
+func (msg *MsgPad) Name() string {
+    return "pad"
+}
+
+func (msg *MsgPad) Text() string {
+    txt := "pad"
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgPad) IsSet(name string) bool {
+    switch name {
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgPad) Scaled(name string) float64 {
+    switch name {
+    }
+    return math.NaN()
+}
+
+// Units returns the units of the named field's scaled value
+func (msg *MsgPad) Units(name string) string {
+    switch name {
+    default: return ""
+    }
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgPad) GlobalNum() uint16 {
+    return 105
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgPad) FieldByNum(num byte) Value {
+    switch num {
+    default: return msg.unknownValue(num)
+    }
+}
+
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgPad) Field(name string) Value {
+    switch name {
+    default: return msg.developerValue(name)
+    }
+}
+
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgPad) Fields() []Value {
+    return fieldValues(msg, []byte{})
+}
+
+func NewMsgPad(def *FitDefinition, data []byte) (*MsgPad, error) {
+    msg := new(MsgPad)
+
+    pos := 0
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        switch fld.Num {
+        default:
+            msg.addUnknown(def, fld, buf)
+        }
+    }
+
+    return msg, nil
+}
+
+// slave_device message
+
+// MsgSlaveDevice holds the fields of a slave_device message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgSlaveDevice struct {
+    msgBase
+
+    Manufacturer Manufacturer
+    Product uint16
+}
+
+func (msg *MsgSlaveDevice) Name() string {
+    return "slave_device"
+}
+
+func (msg *MsgSlaveDevice) Text() string {
+    txt := "slave_device"
+    if is_valid_uint16(uint16(msg.Manufacturer)) {
+        txt += fmt.Sprintf(" mfct %s", msg.Manufacturer)
+    }
+    if is_valid_uint16(msg.Product) {
+        txt += fmt.Sprintf(" prod %d", msg.Product)
+    }
+    return txt
+}
+
+// IsSet reports whether the named field held a valid value
+func (msg *MsgSlaveDevice) IsSet(name string) bool {
+    switch name {
+    case "manufacturer": return is_valid_uint16(uint16(msg.Manufacturer))
+    case "product": return is_valid_uint16(msg.Product)
+    default: return false
+    }
+}
+
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgSlaveDevice) Scaled(name string) float64 {
+    switch name {
+    case "manufacturer":
+        if is_valid_uint16(uint16(msg.Manufacturer)) {
+            return float64(msg.Manufacturer)
+        }
+    case "product":
+        if is_valid_uint16(msg.Product) {
+            return float64(msg.Product)
         }
     }
+    return math.NaN()
+}
 
-    return msg, nil
+// Units returns the units of the named field's scaled value
+func (msg *MsgSlaveDevice) Units(name string) string {
+    switch name {
+    default: return ""
+    }
 }
 
-// weight_scale message
+// GlobalNum returns the message's global message number
+func (msg *MsgSlaveDevice) GlobalNum() uint16 {
+    return 106
+}
 
-type MsgWeightScale struct {
-    timestamp uint32
-    weight uint16
-    percent_fat uint16
-    percent_hydration uint16
-    visceral_fat_mass uint16
-    bone_mass uint16
-    muscle_mass uint16
-    basal_met uint16
-    physique_rating uint8
-    active_met uint16
-    metabolic_age uint8
-    visceral_fat_rating uint8
-    user_profile_index uint16
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgSlaveDevice) FieldByNum(num byte) Value {
+    switch num {
+    case 0:
+        return Value{Name: "manufacturer", Num: 0, BaseType: 4,
+            Raw: msg.Manufacturer, Scaled: msg.Scaled("manufacturer"),
+            Units: "", Valid: is_valid_uint16(uint16(msg.Manufacturer))}
+    case 1:
+        return Value{Name: "product", Num: 1, BaseType: 4,
+            Raw: msg.Product, Scaled: msg.Scaled("product"),
+            Units: "", Valid: is_valid_uint16(msg.Product)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgWeightScale) Name() string {
-    return "weight_scale"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgSlaveDevice) Field(name string) Value {
+    switch name {
+    case "manufacturer": return msg.FieldByNum(0)
+    case "product": return msg.FieldByNum(1)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgWeightScale) Text() string {
-    return fmt.Sprintf("weight_scale tstmp %d weight %d percentfat %d" +
-        " percenthydration %d visceralfatmass %d bonemass %d musclemass %d" +
-        " basalmet %d physiquerating %d activemet %d metabolicage %d" +
-        " visceralfatrating %d userprofileidx %d", msg.timestamp, msg.weight,
-        msg.percent_fat, msg.percent_hydration, msg.visceral_fat_mass,
-        msg.bone_mass, msg.muscle_mass, msg.basal_met, msg.physique_rating,
-        msg.active_met, msg.metabolic_age, msg.visceral_fat_rating,
-        msg.user_profile_index)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgSlaveDevice) Fields() []Value {
+    return fieldValues(msg, []byte{0, 1})
 }
 
-func NewMsgWeightScale(def *FitDefinition, data []byte) (*MsgWeightScale, error) {
-    msg := new(MsgWeightScale)
+func NewMsgSlaveDevice(def *FitDefinition, data []byte) (*MsgSlaveDevice, error) {
+    msg := new(MsgSlaveDevice)
+
+    msg.Manufacturer = Manufacturer(invalid_uint16)
+    msg.Product = invalid_uint16
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.weight, pos = get_uint16_pos(data, pos)
-        case 1: msg.percent_fat, pos = get_uint16_pos(data, pos)
-        case 2: msg.percent_hydration, pos = get_uint16_pos(data, pos)
-        case 3: msg.visceral_fat_mass, pos = get_uint16_pos(data, pos)
-        case 4: msg.bone_mass, pos = get_uint16_pos(data, pos)
-        case 5: msg.muscle_mass, pos = get_uint16_pos(data, pos)
-        case 7: msg.basal_met, pos = get_uint16_pos(data, pos)
-        case 8: msg.physique_rating, pos = get_uint8_pos(data, pos)
-        case 9: msg.active_met, pos = get_uint16_pos(data, pos)
-        case 10: msg.metabolic_age, pos = get_uint8_pos(data, pos)
-        case 11: msg.visceral_fat_rating, pos = get_uint8_pos(data, pos)
-        case 12: msg.user_profile_index, pos = get_uint16_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 0: msg.Manufacturer = Manufacturer(get_uint16(buf, fld, order))
+        case 1: msg.Product = get_uint16(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad weight_scale field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// course message
+// cadence_zone message
 
-type MsgCourse struct {
-    sport byte
-    name string
-    capabilities uint32
+// MsgCadenceZone holds the fields of a cadence_zone message.  Fields which
+// were not in the message hold FIT's invalid value; see IsSet().
+type MsgCadenceZone struct {
+    msgBase
+
+    MessageIndex uint16
+    HighValue uint8 // rpm
+    CadenceZoneName string
 }
 
-func (msg *MsgCourse) Name() string {
-    return "course"
+func (msg *MsgCadenceZone) Name() string {
+    return "cadence_zone"
 }
 
-func (msg *MsgCourse) Text() string {
-    return fmt.Sprintf("course sport %d name %s capabilities %d", msg.sport,
-        msg.name, msg.capabilities)
+func (msg *MsgCadenceZone) Text() string {
+    txt := "cadence_zone"
+    if is_valid_uint16(msg.MessageIndex) {
+        txt += fmt.Sprintf(" msgidx %d", msg.MessageIndex)
+    }
+    if is_valid_uint8(msg.HighValue) {
+        txt += fmt.Sprintf(" highvalue %d", msg.HighValue)
+    }
+    if is_valid_string(msg.CadenceZoneName) {
+        txt += fmt.Sprintf(" name %s", msg.CadenceZoneName)
+    }
+    return txt
 }
 
-func NewMsgCourse(def *FitDefinition, data []byte) (*MsgCourse, error) {
-    msg := new(MsgCourse)
+// IsSet reports whether the named field held a valid value
+func (msg *MsgCadenceZone) IsSet(name string) bool {
+    switch name {
+    case "message_index": return is_valid_uint16(msg.MessageIndex)
+    case "high_value": return is_valid_uint8(msg.HighValue)
+    case "name": return is_valid_string(msg.CadenceZoneName)
+    default: return false
+    }
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 4: msg.sport, pos = get_byte_pos(data, pos)
-        case 5: msg.name, pos = get_string_pos(data, pos)
-        case 6: msg.capabilities, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad course field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+// Scaled returns the named field's value after applying the profile's
+// scale and offset, or NaN if the field is not set or not numeric
+func (msg *MsgCadenceZone) Scaled(name string) float64 {
+    switch name {
+    case "message_index":
+        if is_valid_uint16(msg.MessageIndex) {
+            return float64(msg.MessageIndex)
+        }
+    case "high_value":
+        if is_valid_uint8(msg.HighValue) {
+            return float64(msg.HighValue)
         }
     }
-
-    return msg, nil
+    return math.NaN()
 }
 
-// course_point message
+// Units returns the units of the named field's scaled value
+func (msg *MsgCadenceZone) Units(name string) string {
+    switch name {
+    case "high_value": return "rpm"
+    default: return ""
+    }
+}
 
-type MsgCoursePoint struct {
-    message_index uint16
-    timestamp uint32
-    position_lat int32
-    position_long int32
-    distance uint32
-    msgtype byte
-    name string
+// GlobalNum returns the message's global message number
+func (msg *MsgCadenceZone) GlobalNum() uint16 {
+    return 131
+}
+
+// FieldByNum returns the field with the profile's field number 'num'
+func (msg *MsgCadenceZone) FieldByNum(num byte) Value {
+    switch num {
+    case 254:
+        return Value{Name: "message_index", Num: 254, BaseType: 4,
+            Raw: msg.MessageIndex, Scaled: msg.Scaled("message_index"),
+            Units: "", Valid: is_valid_uint16(msg.MessageIndex)}
+    case 0:
+        return Value{Name: "high_value", Num: 0, BaseType: 2,
+            Raw: msg.HighValue, Scaled: msg.Scaled("high_value"),
+            Units: "rpm", Valid: is_valid_uint8(msg.HighValue)}
+    case 1:
+        return Value{Name: "name", Num: 1, BaseType: 7,
+            Raw: msg.CadenceZoneName, Scaled: math.NaN(),
+            Units: "", Valid: is_valid_string(msg.CadenceZoneName)}
+    default: return msg.unknownValue(num)
+    }
 }
 
-func (msg *MsgCoursePoint) Name() string {
-    return "course_point"
+// Field returns the named field or subfield, or the developer field
+// with that name
+func (msg *MsgCadenceZone) Field(name string) Value {
+    switch name {
+    case "message_index": return msg.FieldByNum(254)
+    case "high_value": return msg.FieldByNum(0)
+    case "name": return msg.FieldByNum(1)
+    default: return msg.developerValue(name)
+    }
 }
 
-func (msg *MsgCoursePoint) Text() string {
-    return fmt.Sprintf("course_point msgidx %d tstmp %d poslat %d poslong %d" +
-        " dist %d msgtyp %d name %s", msg.message_index, msg.timestamp,
-        msg.position_lat, msg.position_long, msg.distance, msg.msgtype,
-        msg.name)
+// Fields returns the fields which are set, in profile order, followed
+// by any fields which are not in the profile
+func (msg *MsgCadenceZone) Fields() []Value {
+    return fieldValues(msg, []byte{254, 0, 1})
 }
 
-func NewMsgCoursePoint(def *FitDefinition, data []byte) (*MsgCoursePoint, error) {
-    msg := new(MsgCoursePoint)
+func NewMsgCadenceZone(def *FitDefinition, data []byte) (*MsgCadenceZone, error) {
+    msg := new(MsgCadenceZone)
+
+    msg.MessageIndex = invalid_uint16
+    msg.HighValue = invalid_uint8
 
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 1: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 2: msg.position_lat, pos = get_int32_pos(data, pos)
-        case 3: msg.position_long, pos = get_int32_pos(data, pos)
-        case 4: msg.distance, pos = get_uint32_pos(data, pos)
-        case 5: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 6: msg.name, pos = get_string_pos(data, pos)
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        buf := data[pos:pos + int(fld.Size)]
+        pos += int(fld.Size)
+
+        order := def.fieldOrder(fld)
+        switch fld.Num {
+        case 254: msg.MessageIndex = get_uint16(buf, fld, order)
+        case 0: msg.HighValue = get_uint8(buf, fld, order)
+        case 1: msg.CadenceZoneName = get_string(buf, fld, order)
         default:
-            errmsg := fmt.Sprintf("Bad course_point field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
+            msg.addUnknown(def, fld, buf)
         }
     }
 
     return msg, nil
 }
 
-// totals message
 
-type MsgTotals struct {
-    message_index uint16
-    timestamp uint32
-    timer_time uint32
-    distance uint32
-    calories uint32
-    sport byte
-    elapsed_time uint32
-    sessions uint16
-    active_time uint32
+// unknown message
+
+// MsgUnknown holds a message whose global message number is not
+// in the profile.  Data is the raw record; each field is also
+// available from UnknownFields().
+type MsgUnknown struct {
+    msgBase
+
+    global_num uint16
+    Data []byte
 }
 
-func (msg *MsgTotals) Name() string {
-    return "totals"
+func (msg *MsgUnknown) Name() string {
+    return fmt.Sprintf("unknown#%d", msg.global_num)
 }
 
-func (msg *MsgTotals) Text() string {
-    return fmt.Sprintf("totals msgidx %d tstmp %d timertime %d dist %d" +
-        " cals %d sport %d elapsedtime %d sessions %d activetime %d",
-        msg.message_index, msg.timestamp, msg.timer_time, msg.distance,
-        msg.calories, msg.sport, msg.elapsed_time, msg.sessions,
-        msg.active_time)
+func (msg *MsgUnknown) Text() string {
+    return fmt.Sprintf("unknown#%d", msg.global_num)
 }
 
-func NewMsgTotals(def *FitDefinition, data []byte) (*MsgTotals, error) {
-    msg := new(MsgTotals)
+func (msg *MsgUnknown) IsSet(name string) bool {
+    return false
+}
+
+func (msg *MsgUnknown) Scaled(name string) float64 {
+    return math.NaN()
+}
+
+func (msg *MsgUnknown) Units(name string) string {
+    return ""
+}
+
+// GlobalNum returns the message's global message number
+func (msg *MsgUnknown) GlobalNum() uint16 {
+    return msg.global_num
+}
+
+// FieldByNum returns the field numbered 'num'
+func (msg *MsgUnknown) FieldByNum(num byte) Value {
+    return msg.unknownValue(num)
+}
+
+// Field returns the developer field with the given name, as no other
+// fields of an unknown message have names
+func (msg *MsgUnknown) Field(name string) Value {
+    return msg.developerValue(name)
+}
+
+// Fields returns every field of the message
+func (msg *MsgUnknown) Fields() []Value {
+    return fieldValues(msg, nil)
+}
+
+func NewMsgUnknown(def *FitDefinition, data []byte,
+    global_num uint16) (*MsgUnknown, error) {
+    msg := new(MsgUnknown)
+
+    msg.global_num = global_num
+    msg.Data = make([]byte, len(data))
+    copy(msg.Data, data)
 
+    // every field in an unknown message is an unknown field
     pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.timer_time, pos = get_uint32_pos(data, pos)
-        case 1: msg.distance, pos = get_uint32_pos(data, pos)
-        case 2: msg.calories, pos = get_uint32_pos(data, pos)
-        case 3: msg.sport, pos = get_byte_pos(data, pos)
-        case 4: msg.elapsed_time, pos = get_uint32_pos(data, pos)
-        case 5: msg.sessions, pos = get_uint16_pos(data, pos)
-        case 6: msg.active_time, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad totals field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+    for i := 0; i < len(def.Fields); i++ {
+        fld := def.Fields[i]
+        msg.addUnknown(def, fld, data[pos:pos + int(fld.Size)])
+        pos += int(fld.Size)
     }
 
     return msg, nil
 }
 
-// activity message
+// Handlers holds the callbacks used by FitFile.Decode().  All are
+// optional.  OnMessage receives every data message which doesn't
+// have a callback of its own, including unknown messages.
+type Handlers struct {
+    OnDefinition func(def *FitDefinition) error
+    OnMessage func(msg FitMsg) error
+
+    OnFileId func(msg *MsgFileId) error
+    OnCapabilities func(msg *MsgCapabilities) error
+    OnDeviceSettings func(msg *MsgDeviceSettings) error
+    OnUserProfile func(msg *MsgUserProfile) error
+    OnHrmProfile func(msg *MsgHrmProfile) error
+    OnSdmProfile func(msg *MsgSdmProfile) error
+    OnBikeProfile func(msg *MsgBikeProfile) error
+    OnZonesTarget func(msg *MsgZonesTarget) error
+    OnHrZone func(msg *MsgHrZone) error
+    OnPowerZone func(msg *MsgPowerZone) error
+    OnMetZone func(msg *MsgMetZone) error
+    OnSport func(msg *MsgSport) error
+    OnGoal func(msg *MsgGoal) error
+    OnSession func(msg *MsgSession) error
+    OnLap func(msg *MsgLap) error
+    OnRecord func(msg *MsgRecord) error
+    OnEvent func(msg *MsgEvent) error
+    OnDeviceInfo func(msg *MsgDeviceInfo) error
+    OnWorkout func(msg *MsgWorkout) error
+    OnWorkoutStep func(msg *MsgWorkoutStep) error
+    OnSchedule func(msg *MsgSchedule) error
+    OnWeightScale func(msg *MsgWeightScale) error
+    OnCourse func(msg *MsgCourse) error
+    OnCoursePoint func(msg *MsgCoursePoint) error
+    OnTotals func(msg *MsgTotals) error
+    OnActivity func(msg *MsgActivity) error
+    OnSoftware func(msg *MsgSoftware) error
+    OnFileCapabilities func(msg *MsgFileCapabilities) error
+    OnMesgCapabilities func(msg *MsgMesgCapabilities) error
+    OnFieldCapabilities func(msg *MsgFieldCapabilities) error
+    OnFileCreator func(msg *MsgFileCreator) error
+    OnBloodPressure func(msg *MsgBloodPressure) error
+    OnSpeedZone func(msg *MsgSpeedZone) error
+    OnMonitoring func(msg *MsgMonitoring) error
+    OnHrv func(msg *MsgHrv) error
+    OnLength func(msg *MsgLength) error
+    OnMonitoringInfo func(msg *MsgMonitoringInfo) error
+    OnPad func(msg *MsgPad) error
+    OnSlaveDevice func(msg *MsgSlaveDevice) error
+    OnCadenceZone func(msg *MsgCadenceZone) error
+    OnFieldDescription func(msg *MsgFieldDescription) error
+    OnDeveloperDataId func(msg *MsgDeveloperDataId) error
+}
+
+// pass 'msg' to its callback
+func (handlers *Handlers) dispatch(msg FitMsg) error {
+    switch m := msg.(type) {
+    case *MsgFileId:
+        if handlers.OnFileId != nil {
+            return handlers.OnFileId(m)
+        }
+    case *MsgCapabilities:
+        if handlers.OnCapabilities != nil {
+            return handlers.OnCapabilities(m)
+        }
+    case *MsgDeviceSettings:
+        if handlers.OnDeviceSettings != nil {
+            return handlers.OnDeviceSettings(m)
+        }
+    case *MsgUserProfile:
+        if handlers.OnUserProfile != nil {
+            return handlers.OnUserProfile(m)
+        }
+    case *MsgHrmProfile:
+        if handlers.OnHrmProfile != nil {
+            return handlers.OnHrmProfile(m)
+        }
+    case *MsgSdmProfile:
+        if handlers.OnSdmProfile != nil {
+            return handlers.OnSdmProfile(m)
+        }
+    case *MsgBikeProfile:
+        if handlers.OnBikeProfile != nil {
+            return handlers.OnBikeProfile(m)
+        }
+    case *MsgZonesTarget:
+        if handlers.OnZonesTarget != nil {
+            return handlers.OnZonesTarget(m)
+        }
+    case *MsgHrZone:
+        if handlers.OnHrZone != nil {
+            return handlers.OnHrZone(m)
+        }
+    case *MsgPowerZone:
+        if handlers.OnPowerZone != nil {
+            return handlers.OnPowerZone(m)
+        }
+    case *MsgMetZone:
+        if handlers.OnMetZone != nil {
+            return handlers.OnMetZone(m)
+        }
+    case *MsgSport:
+        if handlers.OnSport != nil {
+            return handlers.OnSport(m)
+        }
+    case *MsgGoal:
+        if handlers.OnGoal != nil {
+            return handlers.OnGoal(m)
+        }
+    case *MsgSession:
+        if handlers.OnSession != nil {
+            return handlers.OnSession(m)
+        }
+    case *MsgLap:
+        if handlers.OnLap != nil {
+            return handlers.OnLap(m)
+        }
+    case *MsgRecord:
+        if handlers.OnRecord != nil {
+            return handlers.OnRecord(m)
+        }
+    case *MsgEvent:
+        if handlers.OnEvent != nil {
+            return handlers.OnEvent(m)
+        }
+    case *MsgDeviceInfo:
+        if handlers.OnDeviceInfo != nil {
+            return handlers.OnDeviceInfo(m)
+        }
+    case *MsgWorkout:
+        if handlers.OnWorkout != nil {
+            return handlers.OnWorkout(m)
+        }
+    case *MsgWorkoutStep:
+        if handlers.OnWorkoutStep != nil {
+            return handlers.OnWorkoutStep(m)
+        }
+    case *MsgSchedule:
+        if handlers.OnSchedule != nil {
+            return handlers.OnSchedule(m)
+        }
+    case *MsgWeightScale:
+        if handlers.OnWeightScale != nil {
+            return handlers.OnWeightScale(m)
+        }
+    case *MsgCourse:
+        if handlers.OnCourse != nil {
+            return handlers.OnCourse(m)
+        }
+    case *MsgCoursePoint:
+        if handlers.OnCoursePoint != nil {
+            return handlers.OnCoursePoint(m)
+        }
+    case *MsgTotals:
+        if handlers.OnTotals != nil {
+            return handlers.OnTotals(m)
+        }
+    case *MsgActivity:
+        if handlers.OnActivity != nil {
+            return handlers.OnActivity(m)
+        }
+    case *MsgSoftware:
+        if handlers.OnSoftware != nil {
+            return handlers.OnSoftware(m)
+        }
+    case *MsgFileCapabilities:
+        if handlers.OnFileCapabilities != nil {
+            return handlers.OnFileCapabilities(m)
+        }
+    case *MsgMesgCapabilities:
+        if handlers.OnMesgCapabilities != nil {
+            return handlers.OnMesgCapabilities(m)
+        }
+    case *MsgFieldCapabilities:
+        if handlers.OnFieldCapabilities != nil {
+            return handlers.OnFieldCapabilities(m)
+        }
+    case *MsgFileCreator:
+        if handlers.OnFileCreator != nil {
+            return handlers.OnFileCreator(m)
+        }
+    case *MsgBloodPressure:
+        if handlers.OnBloodPressure != nil {
+            return handlers.OnBloodPressure(m)
+        }
+    case *MsgSpeedZone:
+        if handlers.OnSpeedZone != nil {
+            return handlers.OnSpeedZone(m)
+        }
+    case *MsgMonitoring:
+        if handlers.OnMonitoring != nil {
+            return handlers.OnMonitoring(m)
+        }
+    case *MsgHrv:
+        if handlers.OnHrv != nil {
+            return handlers.OnHrv(m)
+        }
+    case *MsgLength:
+        if handlers.OnLength != nil {
+            return handlers.OnLength(m)
+        }
+    case *MsgMonitoringInfo:
+        if handlers.OnMonitoringInfo != nil {
+            return handlers.OnMonitoringInfo(m)
+        }
+    case *MsgPad:
+        if handlers.OnPad != nil {
+            return handlers.OnPad(m)
+        }
+    case *MsgSlaveDevice:
+        if handlers.OnSlaveDevice != nil {
+            return handlers.OnSlaveDevice(m)
+        }
+    case *MsgCadenceZone:
+        if handlers.OnCadenceZone != nil {
+            return handlers.OnCadenceZone(m)
+        }
+    case *MsgFieldDescription:
+        if handlers.OnFieldDescription != nil {
+            return handlers.OnFieldDescription(m)
+        }
+    case *MsgDeveloperDataId:
+        if handlers.OnDeveloperDataId != nil {
+            return handlers.OnDeveloperDataId(m)
+        }
+    }
 
-type MsgActivity struct {
-    timestamp uint32
-    total_timer_time uint32
-    num_sessions uint16
-    msgtype byte
-    event byte
-    event_type byte
-    local_timestamp uint32
-    event_group uint8
+    if handlers.OnMessage != nil {
+        return handlers.OnMessage(msg)
+    }
+
+    return nil
 }
 
-func (msg *MsgActivity) Name() string {
-    return "activity"
+// activity type
+
+type Activity byte
+
+const (
+    ActivityManual Activity = 0
+    ActivityAutoMultiSport Activity = 1
+)
+
+func (val Activity) String() string {
+    switch val {
+    case ActivityManual: return "manual"
+    case ActivityAutoMultiSport: return "auto_multi_sport"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgActivity) Text() string {
-    return fmt.Sprintf("activity tstmp %d totaltimertime %d numsessions %d" +
-        " msgtyp %d evt %d evttyp %d localtstmp %d evtgrp %d", msg.timestamp,
-        msg.total_timer_time, msg.num_sessions, msg.msgtype, msg.event,
-        msg.event_type, msg.local_timestamp, msg.event_group)
+func (val Activity) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// activity_subtype type
+
+type ActivitySubtype byte
+
+const (
+    ActivitySubtypeGeneric ActivitySubtype = 0
+    ActivitySubtypeTreadmill ActivitySubtype = 1
+    ActivitySubtypeStreet ActivitySubtype = 2
+    ActivitySubtypeTrail ActivitySubtype = 3
+    ActivitySubtypeTrack ActivitySubtype = 4
+    ActivitySubtypeSpin ActivitySubtype = 5
+    ActivitySubtypeIndoorCycling ActivitySubtype = 6
+    ActivitySubtypeRoad ActivitySubtype = 7
+    ActivitySubtypeMountain ActivitySubtype = 8
+    ActivitySubtypeDownhill ActivitySubtype = 9
+    ActivitySubtypeRecumbent ActivitySubtype = 10
+    ActivitySubtypeCyclocross ActivitySubtype = 11
+    ActivitySubtypeHandCycling ActivitySubtype = 12
+    ActivitySubtypeTrackCycling ActivitySubtype = 13
+    ActivitySubtypeIndoorRowing ActivitySubtype = 14
+    ActivitySubtypeElliptical ActivitySubtype = 15
+    ActivitySubtypeStairClimbing ActivitySubtype = 16
+    ActivitySubtypeLapSwimming ActivitySubtype = 17
+    ActivitySubtypeOpenWater ActivitySubtype = 18
+    ActivitySubtypeAll ActivitySubtype = 254
+)
+
+func (val ActivitySubtype) String() string {
+    switch val {
+    case ActivitySubtypeGeneric: return "generic"
+    case ActivitySubtypeTreadmill: return "treadmill"
+    case ActivitySubtypeStreet: return "street"
+    case ActivitySubtypeTrail: return "trail"
+    case ActivitySubtypeTrack: return "track"
+    case ActivitySubtypeSpin: return "spin"
+    case ActivitySubtypeIndoorCycling: return "indoor_cycling"
+    case ActivitySubtypeRoad: return "road"
+    case ActivitySubtypeMountain: return "mountain"
+    case ActivitySubtypeDownhill: return "downhill"
+    case ActivitySubtypeRecumbent: return "recumbent"
+    case ActivitySubtypeCyclocross: return "cyclocross"
+    case ActivitySubtypeHandCycling: return "hand_cycling"
+    case ActivitySubtypeTrackCycling: return "track_cycling"
+    case ActivitySubtypeIndoorRowing: return "indoor_rowing"
+    case ActivitySubtypeElliptical: return "elliptical"
+    case ActivitySubtypeStairClimbing: return "stair_climbing"
+    case ActivitySubtypeLapSwimming: return "lap_swimming"
+    case ActivitySubtypeOpenWater: return "open_water"
+    case ActivitySubtypeAll: return "all"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func NewMsgActivity(def *FitDefinition, data []byte) (*MsgActivity, error) {
-    msg := new(MsgActivity)
+func (val ActivitySubtype) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.total_timer_time, pos = get_uint32_pos(data, pos)
-        case 1: msg.num_sessions, pos = get_uint16_pos(data, pos)
-        case 2: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 3: msg.event, pos = get_byte_pos(data, pos)
-        case 4: msg.event_type, pos = get_byte_pos(data, pos)
-        case 5: msg.local_timestamp, pos = get_uint32_pos(data, pos)
-        case 6: msg.event_group, pos = get_uint8_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad activity field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+
+// activity_type type
+
+type ActivityType byte
+
+const (
+    ActivityTypeGeneric ActivityType = 0
+    ActivityTypeRunning ActivityType = 1
+    ActivityTypeCycling ActivityType = 2
+    ActivityTypeTransition ActivityType = 3
+    ActivityTypeFitnessEquipment ActivityType = 4
+    ActivityTypeSwimming ActivityType = 5
+    ActivityTypeWalking ActivityType = 6
+    ActivityTypeAll ActivityType = 254
+)
+
+func (val ActivityType) String() string {
+    switch val {
+    case ActivityTypeGeneric: return "generic"
+    case ActivityTypeRunning: return "running"
+    case ActivityTypeCycling: return "cycling"
+    case ActivityTypeTransition: return "transition"
+    case ActivityTypeFitnessEquipment: return "fitness_equipment"
+    case ActivityTypeSwimming: return "swimming"
+    case ActivityTypeWalking: return "walking"
+    case ActivityTypeAll: return "all"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val ActivityType) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// software message
 
-type MsgSoftware struct {
-    message_index uint16
-    version uint16
-    part_number string
+// battery_status type
+
+type BatteryStatus uint8
+
+const (
+    BatteryStatusNew BatteryStatus = 1
+    BatteryStatusGood BatteryStatus = 2
+    BatteryStatusOk BatteryStatus = 3
+    BatteryStatusLow BatteryStatus = 4
+    BatteryStatusCritical BatteryStatus = 5
+)
+
+func (val BatteryStatus) String() string {
+    switch val {
+    case BatteryStatusNew: return "new"
+    case BatteryStatusGood: return "good"
+    case BatteryStatusOk: return "ok"
+    case BatteryStatusLow: return "low"
+    case BatteryStatusCritical: return "critical"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgSoftware) Name() string {
-    return "software"
+func (val BatteryStatus) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgSoftware) Text() string {
-    return fmt.Sprintf("software msgidx %d vers %d part# %s", msg.message_index,
-        msg.version, msg.part_number)
+
+// bool type
+
+type Bool byte
+
+const (
+    BoolFalse Bool = 0
+    BoolTrue Bool = 1
+)
+
+func (val Bool) String() string {
+    switch val {
+    case BoolFalse: return "false"
+    case BoolTrue: return "true"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func NewMsgSoftware(def *FitDefinition, data []byte) (*MsgSoftware, error) {
-    msg := new(MsgSoftware)
+func (val Bool) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// bp_status type
+
+type BpStatus byte
+
+const (
+    BpStatusNoError BpStatus = 0
+    BpStatusErrorIncompleteData BpStatus = 1
+    BpStatusErrorNoMeasurement BpStatus = 2
+    BpStatusErrorDataOutOfRange BpStatus = 3
+    BpStatusErrorIrregularHeartRate BpStatus = 4
+)
+
+func (val BpStatus) String() string {
+    switch val {
+    case BpStatusNoError: return "no_error"
+    case BpStatusErrorIncompleteData: return "error_incomplete_data"
+    case BpStatusErrorNoMeasurement: return "error_no_measurement"
+    case BpStatusErrorDataOutOfRange: return "error_data_out_of_range"
+    case BpStatusErrorIrregularHeartRate: return "error_irregular_heart_rate"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val BpStatus) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// course_point type
+
+type CoursePoint byte
+
+const (
+    CoursePointGeneric CoursePoint = 0
+    CoursePointSummit CoursePoint = 1
+    CoursePointValley CoursePoint = 2
+    CoursePointWater CoursePoint = 3
+    CoursePointFood CoursePoint = 4
+    CoursePointDanger CoursePoint = 5
+    CoursePointLeft CoursePoint = 6
+    CoursePointRight CoursePoint = 7
+    CoursePointStraight CoursePoint = 8
+    CoursePointFirstAid CoursePoint = 9
+    CoursePointFourthCategory CoursePoint = 10
+    CoursePointThirdCategory CoursePoint = 11
+    CoursePointSecondCategory CoursePoint = 12
+    CoursePointFirstCategory CoursePoint = 13
+    CoursePointHorsCategory CoursePoint = 14
+    CoursePointSprint CoursePoint = 15
+    CoursePointLeftFork CoursePoint = 16
+    CoursePointRightFork CoursePoint = 17
+    CoursePointMiddleFork CoursePoint = 18
+    CoursePointSlightLeft CoursePoint = 19
+    CoursePointSharpLeft CoursePoint = 20
+    CoursePointSlightRight CoursePoint = 21
+    CoursePointSharpRight CoursePoint = 22
+    CoursePointUTurn CoursePoint = 23
+)
+
+func (val CoursePoint) String() string {
+    switch val {
+    case CoursePointGeneric: return "generic"
+    case CoursePointSummit: return "summit"
+    case CoursePointValley: return "valley"
+    case CoursePointWater: return "water"
+    case CoursePointFood: return "food"
+    case CoursePointDanger: return "danger"
+    case CoursePointLeft: return "left"
+    case CoursePointRight: return "right"
+    case CoursePointStraight: return "straight"
+    case CoursePointFirstAid: return "first_aid"
+    case CoursePointFourthCategory: return "fourth_category"
+    case CoursePointThirdCategory: return "third_category"
+    case CoursePointSecondCategory: return "second_category"
+    case CoursePointFirstCategory: return "first_category"
+    case CoursePointHorsCategory: return "hors_category"
+    case CoursePointSprint: return "sprint"
+    case CoursePointLeftFork: return "left_fork"
+    case CoursePointRightFork: return "right_fork"
+    case CoursePointMiddleFork: return "middle_fork"
+    case CoursePointSlightLeft: return "slight_left"
+    case CoursePointSharpLeft: return "sharp_left"
+    case CoursePointSlightRight: return "slight_right"
+    case CoursePointSharpRight: return "sharp_right"
+    case CoursePointUTurn: return "u_turn"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val CoursePoint) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// display_heart type
+
+type DisplayHeart byte
+
+const (
+    DisplayHeartBpm DisplayHeart = 0
+    DisplayHeartMax DisplayHeart = 1
+    DisplayHeartReserve DisplayHeart = 2
+)
+
+func (val DisplayHeart) String() string {
+    switch val {
+    case DisplayHeartBpm: return "bpm"
+    case DisplayHeartMax: return "max"
+    case DisplayHeartReserve: return "reserve"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val DisplayHeart) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// display_measure type
+
+type DisplayMeasure byte
+
+const (
+    DisplayMeasureMetric DisplayMeasure = 0
+    DisplayMeasureStatute DisplayMeasure = 1
+)
+
+func (val DisplayMeasure) String() string {
+    switch val {
+    case DisplayMeasureMetric: return "metric"
+    case DisplayMeasureStatute: return "statute"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val DisplayMeasure) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// display_position type
+
+type DisplayPosition byte
+
+const (
+    DisplayPositionDegree DisplayPosition = 0
+    DisplayPositionDegreeMinute DisplayPosition = 1
+    DisplayPositionDegreeMinuteSecond DisplayPosition = 2
+    DisplayPositionAustrianGrid DisplayPosition = 3
+    DisplayPositionBritishGrid DisplayPosition = 4
+    DisplayPositionDutchGrid DisplayPosition = 5
+    DisplayPositionHungarianGrid DisplayPosition = 6
+    DisplayPositionFinnishGrid DisplayPosition = 7
+    DisplayPositionGermanGrid DisplayPosition = 8
+    DisplayPositionIcelandicGrid DisplayPosition = 9
+    DisplayPositionIndonesianEquatorial DisplayPosition = 10
+    DisplayPositionIndonesianIrian DisplayPosition = 11
+    DisplayPositionIndonesianSouthern DisplayPosition = 12
+    DisplayPositionIndiaZone0 DisplayPosition = 13
+    DisplayPositionIndiaZoneIa DisplayPosition = 14
+    DisplayPositionIndiaZoneIb DisplayPosition = 15
+    DisplayPositionIndiaZoneIia DisplayPosition = 16
+    DisplayPositionIndiaZoneIib DisplayPosition = 17
+    DisplayPositionIndiaZoneIiia DisplayPosition = 18
+    DisplayPositionIndiaZoneIiib DisplayPosition = 19
+    DisplayPositionIndiaZoneIva DisplayPosition = 20
+    DisplayPositionIndiaZoneIvb DisplayPosition = 21
+    DisplayPositionIrishTransverse DisplayPosition = 22
+    DisplayPositionIrishGrid DisplayPosition = 23
+    DisplayPositionLoran DisplayPosition = 24
+    DisplayPositionMaidenheadGrid DisplayPosition = 25
+    DisplayPositionMgrsGrid DisplayPosition = 26
+    DisplayPositionNewZealandGrid DisplayPosition = 27
+    DisplayPositionNewZealandTransverse DisplayPosition = 28
+    DisplayPositionQatarGrid DisplayPosition = 29
+    DisplayPositionModifiedSwedishGrid DisplayPosition = 30
+    DisplayPositionSwedishGrid DisplayPosition = 31
+    DisplayPositionSouthAfricanGrid DisplayPosition = 32
+    DisplayPositionSwissGrid DisplayPosition = 33
+    DisplayPositionTaiwanGrid DisplayPosition = 34
+    DisplayPositionUnitedStatesGrid DisplayPosition = 35
+    DisplayPositionUtmUpsGrid DisplayPosition = 36
+    DisplayPositionWestMalayan DisplayPosition = 37
+    DisplayPositionBorneoRso DisplayPosition = 38
+    DisplayPositionEstonianGrid DisplayPosition = 39
+    DisplayPositionLatvianGrid DisplayPosition = 40
+    DisplayPositionSwedishRef99Grid DisplayPosition = 41
+)
+
+func (val DisplayPosition) String() string {
+    switch val {
+    case DisplayPositionDegree: return "degree"
+    case DisplayPositionDegreeMinute: return "degree_minute"
+    case DisplayPositionDegreeMinuteSecond: return "degree_minute_second"
+    case DisplayPositionAustrianGrid: return "austrian_grid"
+    case DisplayPositionBritishGrid: return "british_grid"
+    case DisplayPositionDutchGrid: return "dutch_grid"
+    case DisplayPositionHungarianGrid: return "hungarian_grid"
+    case DisplayPositionFinnishGrid: return "finnish_grid"
+    case DisplayPositionGermanGrid: return "german_grid"
+    case DisplayPositionIcelandicGrid: return "icelandic_grid"
+    case DisplayPositionIndonesianEquatorial: return "indonesian_equatorial"
+    case DisplayPositionIndonesianIrian: return "indonesian_irian"
+    case DisplayPositionIndonesianSouthern: return "indonesian_southern"
+    case DisplayPositionIndiaZone0: return "india_zone_0"
+    case DisplayPositionIndiaZoneIa: return "india_zone_ia"
+    case DisplayPositionIndiaZoneIb: return "india_zone_ib"
+    case DisplayPositionIndiaZoneIia: return "india_zone_iia"
+    case DisplayPositionIndiaZoneIib: return "india_zone_iib"
+    case DisplayPositionIndiaZoneIiia: return "india_zone_iiia"
+    case DisplayPositionIndiaZoneIiib: return "india_zone_iiib"
+    case DisplayPositionIndiaZoneIva: return "india_zone_iva"
+    case DisplayPositionIndiaZoneIvb: return "india_zone_ivb"
+    case DisplayPositionIrishTransverse: return "irish_transverse"
+    case DisplayPositionIrishGrid: return "irish_grid"
+    case DisplayPositionLoran: return "loran"
+    case DisplayPositionMaidenheadGrid: return "maidenhead_grid"
+    case DisplayPositionMgrsGrid: return "mgrs_grid"
+    case DisplayPositionNewZealandGrid: return "new_zealand_grid"
+    case DisplayPositionNewZealandTransverse: return "new_zealand_transverse"
+    case DisplayPositionQatarGrid: return "qatar_grid"
+    case DisplayPositionModifiedSwedishGrid: return "modified_swedish_grid"
+    case DisplayPositionSwedishGrid: return "swedish_grid"
+    case DisplayPositionSouthAfricanGrid: return "south_african_grid"
+    case DisplayPositionSwissGrid: return "swiss_grid"
+    case DisplayPositionTaiwanGrid: return "taiwan_grid"
+    case DisplayPositionUnitedStatesGrid: return "united_states_grid"
+    case DisplayPositionUtmUpsGrid: return "utm_ups_grid"
+    case DisplayPositionWestMalayan: return "west_malayan"
+    case DisplayPositionBorneoRso: return "borneo_rso"
+    case DisplayPositionEstonianGrid: return "estonian_grid"
+    case DisplayPositionLatvianGrid: return "latvian_grid"
+    case DisplayPositionSwedishRef99Grid: return "swedish_ref_99_grid"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val DisplayPosition) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// display_power type
+
+type DisplayPower byte
+
+const (
+    DisplayPowerWatts DisplayPower = 0
+    DisplayPowerPercentFtp DisplayPower = 1
+)
+
+func (val DisplayPower) String() string {
+    switch val {
+    case DisplayPowerWatts: return "watts"
+    case DisplayPowerPercentFtp: return "percent_ftp"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val DisplayPower) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// event type
+
+type Event byte
+
+const (
+    EventTimer Event = 0
+    EventWorkout Event = 3
+    EventWorkoutStep Event = 4
+    EventPowerDown Event = 5
+    EventPowerUp Event = 6
+    EventOffCourse Event = 7
+    EventSession Event = 8
+    EventLap Event = 9
+    EventCoursePoint Event = 10
+    EventBattery Event = 11
+    EventVirtualPartnerPace Event = 12
+    EventHrHighAlert Event = 13
+    EventHrLowAlert Event = 14
+    EventSpeedHighAlert Event = 15
+    EventSpeedLowAlert Event = 16
+    EventCadHighAlert Event = 17
+    EventCadLowAlert Event = 18
+    EventPowerHighAlert Event = 19
+    EventPowerLowAlert Event = 20
+    EventRecoveryHr Event = 21
+    EventBatteryLow Event = 22
+    EventTimeDurationAlert Event = 23
+    EventDistanceDurationAlert Event = 24
+    EventCalorieDurationAlert Event = 25
+    EventActivity Event = 26
+    EventFitnessEquipment Event = 27
+    EventLength Event = 28
+    EventCalibration Event = 36
+)
+
+func (val Event) String() string {
+    switch val {
+    case EventTimer: return "timer"
+    case EventWorkout: return "workout"
+    case EventWorkoutStep: return "workout_step"
+    case EventPowerDown: return "power_down"
+    case EventPowerUp: return "power_up"
+    case EventOffCourse: return "off_course"
+    case EventSession: return "session"
+    case EventLap: return "lap"
+    case EventCoursePoint: return "course_point"
+    case EventBattery: return "battery"
+    case EventVirtualPartnerPace: return "virtual_partner_pace"
+    case EventHrHighAlert: return "hr_high_alert"
+    case EventHrLowAlert: return "hr_low_alert"
+    case EventSpeedHighAlert: return "speed_high_alert"
+    case EventSpeedLowAlert: return "speed_low_alert"
+    case EventCadHighAlert: return "cad_high_alert"
+    case EventCadLowAlert: return "cad_low_alert"
+    case EventPowerHighAlert: return "power_high_alert"
+    case EventPowerLowAlert: return "power_low_alert"
+    case EventRecoveryHr: return "recovery_hr"
+    case EventBatteryLow: return "battery_low"
+    case EventTimeDurationAlert: return "time_duration_alert"
+    case EventDistanceDurationAlert: return "distance_duration_alert"
+    case EventCalorieDurationAlert: return "calorie_duration_alert"
+    case EventActivity: return "activity"
+    case EventFitnessEquipment: return "fitness_equipment"
+    case EventLength: return "length"
+    case EventCalibration: return "calibration"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
+}
+
+func (val Event) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// event_type type
+
+type EventType byte
+
+const (
+    EventTypeStart EventType = 0
+    EventTypeStop EventType = 1
+    EventTypeConsecutiveDepreciated EventType = 2
+    EventTypeMarker EventType = 3
+    EventTypeStopAll EventType = 4
+    EventTypeBeginDepreciated EventType = 5
+    EventTypeEndDepreciated EventType = 6
+    EventTypeEndAllDepreciated EventType = 7
+    EventTypeStopDisable EventType = 8
+    EventTypeStopDisableAll EventType = 9
+)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 3: msg.version, pos = get_uint16_pos(data, pos)
-        case 5: msg.part_number, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad software field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+func (val EventType) String() string {
+    switch val {
+    case EventTypeStart: return "start"
+    case EventTypeStop: return "stop"
+    case EventTypeConsecutiveDepreciated: return "consecutive_depreciated"
+    case EventTypeMarker: return "marker"
+    case EventTypeStopAll: return "stop_all"
+    case EventTypeBeginDepreciated: return "begin_depreciated"
+    case EventTypeEndDepreciated: return "end_depreciated"
+    case EventTypeEndAllDepreciated: return "end_all_depreciated"
+    case EventTypeStopDisable: return "stop_disable"
+    case EventTypeStopDisableAll: return "stop_disable_all"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val EventType) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// file_capabilities message
 
-type MsgFileCapabilities struct {
-    message_index uint16
-    msgtype byte
-    flags uint8
-    directory string
-    max_count uint16
-    max_size uint32
-}
+// file type
 
-func (msg *MsgFileCapabilities) Name() string {
-    return "file_capabilities"
+type File byte
+
+const (
+    FileDevice File = 1
+    FileSettings File = 2
+    FileSport File = 3
+    FileActivity File = 4
+    FileWorkout File = 5
+    FileCourse File = 6
+    FileSchedules File = 7
+    FileWeight File = 9
+    FileTotals File = 10
+    FileGoals File = 11
+    FileBloodPressure File = 14
+    FileMonitoring File = 15
+    FileActivitySummary File = 20
+    FileMonitoringDaily File = 28
+)
+
+func (val File) String() string {
+    switch val {
+    case FileDevice: return "device"
+    case FileSettings: return "settings"
+    case FileSport: return "sport"
+    case FileActivity: return "activity"
+    case FileWorkout: return "workout"
+    case FileCourse: return "course"
+    case FileSchedules: return "schedules"
+    case FileWeight: return "weight"
+    case FileTotals: return "totals"
+    case FileGoals: return "goals"
+    case FileBloodPressure: return "blood_pressure"
+    case FileMonitoring: return "monitoring"
+    case FileActivitySummary: return "activity_summary"
+    case FileMonitoringDaily: return "monitoring_daily"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgFileCapabilities) Text() string {
-    return fmt.Sprintf("file_capabilities msgidx %d msgtyp %d flags %d" +
-        " directory %s maxcount %d maxsize %d", msg.message_index, msg.msgtype,
-        msg.flags, msg.directory, msg.max_count, msg.max_size)
+func (val File) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgFileCapabilities(def *FitDefinition, data []byte) (*MsgFileCapabilities, error) {
-    msg := new(MsgFileCapabilities)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.msgtype, pos = get_byte_pos(data, pos)
-        case 1: msg.flags, pos = get_uint8_pos(data, pos)
-        case 2: msg.directory, pos = get_string_pos(data, pos)
-        case 3: msg.max_count, pos = get_uint16_pos(data, pos)
-        case 4: msg.max_size, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad file_capabilities field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// fitness_equipment_state type
 
-    return msg, nil
-}
+type FitnessEquipmentState byte
 
-// mesg_capabilities message
+const (
+    FitnessEquipmentStateReady FitnessEquipmentState = 0
+    FitnessEquipmentStateInUse FitnessEquipmentState = 1
+    FitnessEquipmentStatePaused FitnessEquipmentState = 2
+    FitnessEquipmentStateUnknown FitnessEquipmentState = 3
+)
 
-type MsgMesgCapabilities struct {
-    message_index uint16
-    file byte
-    mesg_num uint16
-    count_type byte
-    count uint16
+func (val FitnessEquipmentState) String() string {
+    switch val {
+    case FitnessEquipmentStateReady: return "ready"
+    case FitnessEquipmentStateInUse: return "in_use"
+    case FitnessEquipmentStatePaused: return "paused"
+    case FitnessEquipmentStateUnknown: return "unknown"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgMesgCapabilities) Name() string {
-    return "mesg_capabilities"
+func (val FitnessEquipmentState) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgMesgCapabilities) Text() string {
-    return fmt.Sprintf("mesg_capabilities msgidx %d file %d mesgnum %d" +
-        " counttyp %d count %d", msg.message_index, msg.file, msg.mesg_num,
-        msg.count_type, msg.count)
-}
 
-func NewMsgMesgCapabilities(def *FitDefinition, data []byte) (*MsgMesgCapabilities, error) {
-    msg := new(MsgMesgCapabilities)
+// gender type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.file, pos = get_byte_pos(data, pos)
-        case 1: msg.mesg_num, pos = get_uint16_pos(data, pos)
-        case 2: msg.count_type, pos = get_byte_pos(data, pos)
-        case 3: msg.count, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad mesg_capabilities field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+type Gender byte
+
+const (
+    GenderFemale Gender = 0
+    GenderMale Gender = 1
+)
+
+func (val Gender) String() string {
+    switch val {
+    case GenderFemale: return "female"
+    case GenderMale: return "male"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val Gender) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// field_capabilities message
 
-type MsgFieldCapabilities struct {
-    message_index uint16
-    file byte
-    mesg_num uint16
-    field_num uint8
-    count uint16
-}
+// goal type
 
-func (msg *MsgFieldCapabilities) Name() string {
-    return "field_capabilities"
+type Goal byte
+
+const (
+    GoalTime Goal = 0
+    GoalDistance Goal = 1
+    GoalCalories Goal = 2
+    GoalFrequency Goal = 3
+    GoalSteps Goal = 4
+)
+
+func (val Goal) String() string {
+    switch val {
+    case GoalTime: return "time"
+    case GoalDistance: return "distance"
+    case GoalCalories: return "calories"
+    case GoalFrequency: return "frequency"
+    case GoalSteps: return "steps"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgFieldCapabilities) Text() string {
-    return fmt.Sprintf("field_capabilities msgidx %d file %d mesgnum %d" +
-        " fieldnum %d count %d", msg.message_index, msg.file, msg.mesg_num,
-        msg.field_num, msg.count)
+func (val Goal) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgFieldCapabilities(def *FitDefinition, data []byte) (*MsgFieldCapabilities, error) {
-    msg := new(MsgFieldCapabilities)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.file, pos = get_byte_pos(data, pos)
-        case 1: msg.mesg_num, pos = get_uint16_pos(data, pos)
-        case 2: msg.field_num, pos = get_uint8_pos(data, pos)
-        case 3: msg.count, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad field_capabilities field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// goal_recurrence type
 
-    return msg, nil
-}
+type GoalRecurrence byte
 
-// file_creator message
+const (
+    GoalRecurrenceOff GoalRecurrence = 0
+    GoalRecurrenceDaily GoalRecurrence = 1
+    GoalRecurrenceWeekly GoalRecurrence = 2
+    GoalRecurrenceMonthly GoalRecurrence = 3
+    GoalRecurrenceYearly GoalRecurrence = 4
+    GoalRecurrenceCustom GoalRecurrence = 5
+)
 
-type MsgFileCreator struct {
-    software_version uint16
-    hardware_version uint8
+func (val GoalRecurrence) String() string {
+    switch val {
+    case GoalRecurrenceOff: return "off"
+    case GoalRecurrenceDaily: return "daily"
+    case GoalRecurrenceWeekly: return "weekly"
+    case GoalRecurrenceMonthly: return "monthly"
+    case GoalRecurrenceYearly: return "yearly"
+    case GoalRecurrenceCustom: return "custom"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgFileCreator) Name() string {
-    return "file_creator"
+func (val GoalRecurrence) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgFileCreator) Text() string {
-    return fmt.Sprintf("file_creator soft %d hard %d", msg.software_version,
-        msg.hardware_version)
-}
 
-func NewMsgFileCreator(def *FitDefinition, data []byte) (*MsgFileCreator, error) {
-    msg := new(MsgFileCreator)
+// hr_type type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.software_version, pos = get_uint16_pos(data, pos)
-        case 1: msg.hardware_version, pos = get_uint8_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad file_creator field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+type HrType byte
+
+const (
+    HrTypeNormal HrType = 0
+    HrTypeIrregular HrType = 1
+)
+
+func (val HrType) String() string {
+    switch val {
+    case HrTypeNormal: return "normal"
+    case HrTypeIrregular: return "irregular"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val HrType) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// blood_pressure message
 
-type MsgBloodPressure struct {
-    timestamp uint32
-    systolic_pressure uint16
-    diastolic_pressure uint16
-    mean_arterial_pressure uint16
-    map_3_sample_mean uint16
-    map_morning_values uint16
-    map_evening_values uint16
-    heart_rate uint8
-    heart_rate_type byte
-    status byte
-    user_profile_index uint16
-}
+// hr_zone_calc type
 
-func (msg *MsgBloodPressure) Name() string {
-    return "blood_pressure"
+type HrZoneCalc byte
+
+const (
+    HrZoneCalcCustom HrZoneCalc = 0
+    HrZoneCalcPercentMaxHr HrZoneCalc = 1
+    HrZoneCalcPercentHrr HrZoneCalc = 2
+)
+
+func (val HrZoneCalc) String() string {
+    switch val {
+    case HrZoneCalcCustom: return "custom"
+    case HrZoneCalcPercentMaxHr: return "percent_max_hr"
+    case HrZoneCalcPercentHrr: return "percent_hrr"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgBloodPressure) Text() string {
-    return fmt.Sprintf("blood_pressure tstmp %d systolicpressure %d" +
-        " diastolicpressure %d meanarterialpressure %d map3samplemean %d" +
-        " mapmorningvalues %d mapeveningvalues %d heartrate %d" +
-        " heartratetyp %d stat %d userprofileidx %d", msg.timestamp,
-        msg.systolic_pressure, msg.diastolic_pressure,
-        msg.mean_arterial_pressure, msg.map_3_sample_mean,
-        msg.map_morning_values, msg.map_evening_values, msg.heart_rate,
-        msg.heart_rate_type, msg.status, msg.user_profile_index)
+func (val HrZoneCalc) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgBloodPressure(def *FitDefinition, data []byte) (*MsgBloodPressure, error) {
-    msg := new(MsgBloodPressure)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.systolic_pressure, pos = get_uint16_pos(data, pos)
-        case 1: msg.diastolic_pressure, pos = get_uint16_pos(data, pos)
-        case 2: msg.mean_arterial_pressure, pos = get_uint16_pos(data, pos)
-        case 3: msg.map_3_sample_mean, pos = get_uint16_pos(data, pos)
-        case 4: msg.map_morning_values, pos = get_uint16_pos(data, pos)
-        case 5: msg.map_evening_values, pos = get_uint16_pos(data, pos)
-        case 6: msg.heart_rate, pos = get_uint8_pos(data, pos)
-        case 7: msg.heart_rate_type, pos = get_byte_pos(data, pos)
-        case 8: msg.status, pos = get_byte_pos(data, pos)
-        case 9: msg.user_profile_index, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad blood_pressure field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// intensity type
 
-    return msg, nil
-}
+type Intensity byte
 
-// speed_zone message
+const (
+    IntensityActive Intensity = 0
+    IntensityRest Intensity = 1
+    IntensityWarmup Intensity = 2
+    IntensityCooldown Intensity = 3
+)
 
-type MsgSpeedZone struct {
-    message_index uint16
-    high_value uint16
-    name string
+func (val Intensity) String() string {
+    switch val {
+    case IntensityActive: return "active"
+    case IntensityRest: return "rest"
+    case IntensityWarmup: return "warmup"
+    case IntensityCooldown: return "cooldown"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgSpeedZone) Name() string {
-    return "speed_zone"
+func (val Intensity) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// language type
+
+type Language byte
+
+const (
+    LanguageEnglish Language = 0
+    LanguageFrench Language = 1
+    LanguageItalian Language = 2
+    LanguageGerman Language = 3
+    LanguageSpanish Language = 4
+    LanguageCroatian Language = 5
+    LanguageCzech Language = 6
+    LanguageDanish Language = 7
+    LanguageDutch Language = 8
+    LanguageFinnish Language = 9
+    LanguageGreek Language = 10
+    LanguageHungarian Language = 11
+    LanguageNorwegian Language = 12
+    LanguagePolish Language = 13
+    LanguagePortuguese Language = 14
+    LanguageSlovakian Language = 15
+    LanguageSlovenian Language = 16
+    LanguageSwedish Language = 17
+    LanguageRussian Language = 18
+    LanguageTurkish Language = 19
+    LanguageLatvian Language = 20
+    LanguageUkrainian Language = 21
+    LanguageArabic Language = 22
+    LanguageFarsi Language = 23
+    LanguageBulgarian Language = 24
+    LanguageRomanian Language = 25
+    LanguageCustom Language = 254
+)
+
+func (val Language) String() string {
+    switch val {
+    case LanguageEnglish: return "english"
+    case LanguageFrench: return "french"
+    case LanguageItalian: return "italian"
+    case LanguageGerman: return "german"
+    case LanguageSpanish: return "spanish"
+    case LanguageCroatian: return "croatian"
+    case LanguageCzech: return "czech"
+    case LanguageDanish: return "danish"
+    case LanguageDutch: return "dutch"
+    case LanguageFinnish: return "finnish"
+    case LanguageGreek: return "greek"
+    case LanguageHungarian: return "hungarian"
+    case LanguageNorwegian: return "norwegian"
+    case LanguagePolish: return "polish"
+    case LanguagePortuguese: return "portuguese"
+    case LanguageSlovakian: return "slovakian"
+    case LanguageSlovenian: return "slovenian"
+    case LanguageSwedish: return "swedish"
+    case LanguageRussian: return "russian"
+    case LanguageTurkish: return "turkish"
+    case LanguageLatvian: return "latvian"
+    case LanguageUkrainian: return "ukrainian"
+    case LanguageArabic: return "arabic"
+    case LanguageFarsi: return "farsi"
+    case LanguageBulgarian: return "bulgarian"
+    case LanguageRomanian: return "romanian"
+    case LanguageCustom: return "custom"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgSpeedZone) Text() string {
-    return fmt.Sprintf("speed_zone msgidx %d highvalue %d name %s",
-        msg.message_index, msg.high_value, msg.name)
+func (val Language) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgSpeedZone(def *FitDefinition, data []byte) (*MsgSpeedZone, error) {
-    msg := new(MsgSpeedZone)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.high_value, pos = get_uint16_pos(data, pos)
-        case 1: msg.name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad speed_zone field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// lap_trigger type
 
-    return msg, nil
-}
+type LapTrigger byte
 
-// monitoring message
+const (
+    LapTriggerManual LapTrigger = 0
+    LapTriggerTime LapTrigger = 1
+    LapTriggerDistance LapTrigger = 2
+    LapTriggerPositionStart LapTrigger = 3
+    LapTriggerPositionLap LapTrigger = 4
+    LapTriggerPositionWaypoint LapTrigger = 5
+    LapTriggerPositionMarked LapTrigger = 6
+    LapTriggerSessionEnd LapTrigger = 7
+    LapTriggerFitnessEquipment LapTrigger = 8
+)
 
-type MsgMonitoring struct {
-    timestamp uint32
-    device_index uint8
-    calories uint16
-    distance uint32
-    cycles uint32
-    active_time uint32
-    activity_type byte
-    activity_subtype byte
-    compressed_distance uint16
-    compressed_cycles uint16
-    compressed_active_time uint16
-    local_timestamp uint32
+func (val LapTrigger) String() string {
+    switch val {
+    case LapTriggerManual: return "manual"
+    case LapTriggerTime: return "time"
+    case LapTriggerDistance: return "distance"
+    case LapTriggerPositionStart: return "position_start"
+    case LapTriggerPositionLap: return "position_lap"
+    case LapTriggerPositionWaypoint: return "position_waypoint"
+    case LapTriggerPositionMarked: return "position_marked"
+    case LapTriggerSessionEnd: return "session_end"
+    case LapTriggerFitnessEquipment: return "fitness_equipment"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgMonitoring) Name() string {
-    return "monitoring"
+func (val LapTrigger) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgMonitoring) Text() string {
-    return fmt.Sprintf("monitoring tstmp %d devidx %d cals %d dist %d" +
-        " cycles %d activetime %d activitytyp %d activitysubtyp %d" +
-        " compresseddist %d compressedcycles %d compressedactivetime %d" +
-        " localtstmp %d", msg.timestamp, msg.device_index, msg.calories,
-        msg.distance, msg.cycles, msg.active_time, msg.activity_type,
-        msg.activity_subtype, msg.compressed_distance, msg.compressed_cycles,
-        msg.compressed_active_time, msg.local_timestamp)
-}
 
-func NewMsgMonitoring(def *FitDefinition, data []byte) (*MsgMonitoring, error) {
-    msg := new(MsgMonitoring)
+// length_type type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.device_index, pos = get_uint8_pos(data, pos)
-        case 1: msg.calories, pos = get_uint16_pos(data, pos)
-        case 2: msg.distance, pos = get_uint32_pos(data, pos)
-        case 3: msg.cycles, pos = get_uint32_pos(data, pos)
-        case 4: msg.active_time, pos = get_uint32_pos(data, pos)
-        case 5: msg.activity_type, pos = get_byte_pos(data, pos)
-        case 6: msg.activity_subtype, pos = get_byte_pos(data, pos)
-        case 8: msg.compressed_distance, pos = get_uint16_pos(data, pos)
-        case 9: msg.compressed_cycles, pos = get_uint16_pos(data, pos)
-        case 10: msg.compressed_active_time, pos = get_uint16_pos(data, pos)
-        case 11: msg.local_timestamp, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad monitoring field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+type LengthType byte
 
-    return msg, nil
+const (
+    LengthTypeIdle LengthType = 0
+    LengthTypeActive LengthType = 1
+)
+
+func (val LengthType) String() string {
+    switch val {
+    case LengthTypeIdle: return "idle"
+    case LengthTypeActive: return "active"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-// hrv message
+func (val LengthType) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// manufacturer type
+
+type Manufacturer uint16
+
+const (
+    ManufacturerGarmin Manufacturer = 1
+    ManufacturerGarminFr405Antfs Manufacturer = 2
+    ManufacturerZephyr Manufacturer = 3
+    ManufacturerDayton Manufacturer = 4
+    ManufacturerIdt Manufacturer = 5
+    ManufacturerSrm Manufacturer = 6
+    ManufacturerQuarq Manufacturer = 7
+    ManufacturerIbike Manufacturer = 8
+    ManufacturerSaris Manufacturer = 9
+    ManufacturerSparkHk Manufacturer = 10
+    ManufacturerTanita Manufacturer = 11
+    ManufacturerEchowell Manufacturer = 12
+    ManufacturerDynastreamOem Manufacturer = 13
+    ManufacturerNautilus Manufacturer = 14
+    ManufacturerDynastream Manufacturer = 15
+    ManufacturerTimex Manufacturer = 16
+    ManufacturerMetrigear Manufacturer = 17
+    ManufacturerXelic Manufacturer = 18
+    ManufacturerBeurer Manufacturer = 19
+    ManufacturerCardiosport Manufacturer = 20
+    ManufacturerAAndD Manufacturer = 21
+    ManufacturerHmm Manufacturer = 22
+    ManufacturerSuunto Manufacturer = 23
+    ManufacturerThitaElektronik Manufacturer = 24
+    ManufacturerGpulse Manufacturer = 25
+    ManufacturerCleanMobile Manufacturer = 26
+    ManufacturerPedalBrain Manufacturer = 27
+    ManufacturerPeaksware Manufacturer = 28
+    ManufacturerSaxonar Manufacturer = 29
+    ManufacturerLemondFitness Manufacturer = 30
+    ManufacturerDexcom Manufacturer = 31
+    ManufacturerWahooFitness Manufacturer = 32
+    ManufacturerOctaneFitness Manufacturer = 33
+    ManufacturerArchinoetics Manufacturer = 34
+    ManufacturerTheHurtBox Manufacturer = 35
+    ManufacturerCitizenSystems Manufacturer = 36
+    ManufacturerMagellan Manufacturer = 37
+    ManufacturerOsynce Manufacturer = 38
+    ManufacturerHolux Manufacturer = 39
+    ManufacturerConcept2 Manufacturer = 40
+    ManufacturerOneGiantLeap Manufacturer = 42
+    ManufacturerAceSensor Manufacturer = 43
+    ManufacturerBrimBrothers Manufacturer = 44
+    ManufacturerXplova Manufacturer = 45
+    ManufacturerPerceptionDigital Manufacturer = 46
+    ManufacturerBf1systems Manufacturer = 47
+    ManufacturerPioneer Manufacturer = 48
+    ManufacturerSpantec Manufacturer = 49
+    ManufacturerMetalogics Manufacturer = 50
+    Manufacturer4iiiis Manufacturer = 51
+    ManufacturerSeikoEpson Manufacturer = 52
+    ManufacturerSeikoEpsonOem Manufacturer = 53
+    ManufacturerIforPowell Manufacturer = 54
+    ManufacturerMaxwellGuider Manufacturer = 55
+    ManufacturerStarTrac Manufacturer = 56
+    ManufacturerBreakaway Manufacturer = 57
+    ManufacturerAlatechTechnologyLtd Manufacturer = 58
+    ManufacturerMioTechnologyEurope Manufacturer = 59
+    ManufacturerRotor Manufacturer = 60
+    ManufacturerGeonaute Manufacturer = 61
+    ManufacturerIdBike Manufacturer = 62
+    ManufacturerSpecialized Manufacturer = 63
+    ManufacturerWtek Manufacturer = 64
+    ManufacturerPhysicalEnterprises Manufacturer = 65
+    ManufacturerNorthPoleEngineering Manufacturer = 66
+    ManufacturerBkool Manufacturer = 67
+    ManufacturerCateye Manufacturer = 68
+    ManufacturerStagesCycling Manufacturer = 69
+    ManufacturerSigmasport Manufacturer = 70
+    ManufacturerTomtom Manufacturer = 71
+    ManufacturerPeripedal Manufacturer = 72
+    ManufacturerDevelopment Manufacturer = 255
+    ManufacturerActigraphcorp Manufacturer = 5759
+)
 
-type MsgHrv struct {
-    time uint16
+func (val Manufacturer) String() string {
+    switch val {
+    case ManufacturerGarmin: return "garmin"
+    case ManufacturerGarminFr405Antfs: return "garmin_fr405_antfs"
+    case ManufacturerZephyr: return "zephyr"
+    case ManufacturerDayton: return "dayton"
+    case ManufacturerIdt: return "idt"
+    case ManufacturerSrm: return "srm"
+    case ManufacturerQuarq: return "quarq"
+    case ManufacturerIbike: return "ibike"
+    case ManufacturerSaris: return "saris"
+    case ManufacturerSparkHk: return "spark_hk"
+    case ManufacturerTanita: return "tanita"
+    case ManufacturerEchowell: return "echowell"
+    case ManufacturerDynastreamOem: return "dynastream_oem"
+    case ManufacturerNautilus: return "nautilus"
+    case ManufacturerDynastream: return "dynastream"
+    case ManufacturerTimex: return "timex"
+    case ManufacturerMetrigear: return "metrigear"
+    case ManufacturerXelic: return "xelic"
+    case ManufacturerBeurer: return "beurer"
+    case ManufacturerCardiosport: return "cardiosport"
+    case ManufacturerAAndD: return "a_and_d"
+    case ManufacturerHmm: return "hmm"
+    case ManufacturerSuunto: return "suunto"
+    case ManufacturerThitaElektronik: return "thita_elektronik"
+    case ManufacturerGpulse: return "gpulse"
+    case ManufacturerCleanMobile: return "clean_mobile"
+    case ManufacturerPedalBrain: return "pedal_brain"
+    case ManufacturerPeaksware: return "peaksware"
+    case ManufacturerSaxonar: return "saxonar"
+    case ManufacturerLemondFitness: return "lemond_fitness"
+    case ManufacturerDexcom: return "dexcom"
+    case ManufacturerWahooFitness: return "wahoo_fitness"
+    case ManufacturerOctaneFitness: return "octane_fitness"
+    case ManufacturerArchinoetics: return "archinoetics"
+    case ManufacturerTheHurtBox: return "the_hurt_box"
+    case ManufacturerCitizenSystems: return "citizen_systems"
+    case ManufacturerMagellan: return "magellan"
+    case ManufacturerOsynce: return "osynce"
+    case ManufacturerHolux: return "holux"
+    case ManufacturerConcept2: return "concept2"
+    case ManufacturerOneGiantLeap: return "one_giant_leap"
+    case ManufacturerAceSensor: return "ace_sensor"
+    case ManufacturerBrimBrothers: return "brim_brothers"
+    case ManufacturerXplova: return "xplova"
+    case ManufacturerPerceptionDigital: return "perception_digital"
+    case ManufacturerBf1systems: return "bf1systems"
+    case ManufacturerPioneer: return "pioneer"
+    case ManufacturerSpantec: return "spantec"
+    case ManufacturerMetalogics: return "metalogics"
+    case Manufacturer4iiiis: return "_4iiiis"
+    case ManufacturerSeikoEpson: return "seiko_epson"
+    case ManufacturerSeikoEpsonOem: return "seiko_epson_oem"
+    case ManufacturerIforPowell: return "ifor_powell"
+    case ManufacturerMaxwellGuider: return "maxwell_guider"
+    case ManufacturerStarTrac: return "star_trac"
+    case ManufacturerBreakaway: return "breakaway"
+    case ManufacturerAlatechTechnologyLtd: return "alatech_technology_ltd"
+    case ManufacturerMioTechnologyEurope: return "mio_technology_europe"
+    case ManufacturerRotor: return "rotor"
+    case ManufacturerGeonaute: return "geonaute"
+    case ManufacturerIdBike: return "id_bike"
+    case ManufacturerSpecialized: return "specialized"
+    case ManufacturerWtek: return "wtek"
+    case ManufacturerPhysicalEnterprises: return "physical_enterprises"
+    case ManufacturerNorthPoleEngineering: return "north_pole_engineering"
+    case ManufacturerBkool: return "bkool"
+    case ManufacturerCateye: return "cateye"
+    case ManufacturerStagesCycling: return "stages_cycling"
+    case ManufacturerSigmasport: return "sigmasport"
+    case ManufacturerTomtom: return "tomtom"
+    case ManufacturerPeripedal: return "peripedal"
+    case ManufacturerDevelopment: return "development"
+    case ManufacturerActigraphcorp: return "actigraphcorp"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgHrv) Name() string {
-    return "hrv"
+func (val Manufacturer) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgHrv) Text() string {
-    return fmt.Sprintf("hrv time %d", msg.time)
-}
 
-func NewMsgHrv(def *FitDefinition, data []byte) (*MsgHrv, error) {
-    msg := new(MsgHrv)
+// mesg_count type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.time, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad hrv field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+type MesgCount byte
 
-    return msg, nil
+const (
+    MesgCountNumPerFile MesgCount = 0
+    MesgCountMaxPerFile MesgCount = 1
+    MesgCountMaxPerFileType MesgCount = 2
+)
+
+func (val MesgCount) String() string {
+    switch val {
+    case MesgCountNumPerFile: return "num_per_file"
+    case MesgCountMaxPerFile: return "max_per_file"
+    case MesgCountMaxPerFileType: return "max_per_file_type"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-// length message
+func (val MesgCount) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// mesg_num type
+
+type MesgNum uint16
+
+const (
+    MesgNumFileId MesgNum = 0
+    MesgNumCapabilities MesgNum = 1
+    MesgNumDeviceSettings MesgNum = 2
+    MesgNumUserProfile MesgNum = 3
+    MesgNumHrmProfile MesgNum = 4
+    MesgNumSdmProfile MesgNum = 5
+    MesgNumBikeProfile MesgNum = 6
+    MesgNumZonesTarget MesgNum = 7
+    MesgNumHrZone MesgNum = 8
+    MesgNumPowerZone MesgNum = 9
+    MesgNumMetZone MesgNum = 10
+    MesgNumSport MesgNum = 12
+    MesgNumGoal MesgNum = 15
+    MesgNumSession MesgNum = 18
+    MesgNumLap MesgNum = 19
+    MesgNumRecord MesgNum = 20
+    MesgNumEvent MesgNum = 21
+    MesgNumDeviceInfo MesgNum = 23
+    MesgNumWorkout MesgNum = 26
+    MesgNumWorkoutStep MesgNum = 27
+    MesgNumSchedule MesgNum = 28
+    MesgNumWeightScale MesgNum = 30
+    MesgNumCourse MesgNum = 31
+    MesgNumCoursePoint MesgNum = 32
+    MesgNumTotals MesgNum = 33
+    MesgNumActivity MesgNum = 34
+    MesgNumSoftware MesgNum = 35
+    MesgNumFileCapabilities MesgNum = 37
+    MesgNumMesgCapabilities MesgNum = 38
+    MesgNumFieldCapabilities MesgNum = 39
+    MesgNumFileCreator MesgNum = 49
+    MesgNumBloodPressure MesgNum = 51
+    MesgNumSpeedZone MesgNum = 53
+    MesgNumMonitoring MesgNum = 55
+    MesgNumHrv MesgNum = 78
+    MesgNumLength MesgNum = 101
+    MesgNumMonitoringInfo MesgNum = 103
+    MesgNumPad MesgNum = 105
+    MesgNumSlaveDevice MesgNum = 106
+    MesgNumCadenceZone MesgNum = 131
+    MesgNumMfgRangeMin MesgNum = 65280
+    MesgNumMfgRangeMax MesgNum = 65534
+)
 
-type MsgLength struct {
-    message_index uint16
-    timestamp uint32
-    event byte
-    event_type byte
-    start_time uint32
-    total_elapsed_time uint32
-    total_timer_time uint32
-    total_strokes uint16
-    avg_speed uint16
-    swim_stroke byte
-    avg_swimming_cadence uint8
-    event_group uint8
-    total_calories uint16
-    length_type byte
+func (val MesgNum) String() string {
+    switch val {
+    case MesgNumFileId: return "file_id"
+    case MesgNumCapabilities: return "capabilities"
+    case MesgNumDeviceSettings: return "device_settings"
+    case MesgNumUserProfile: return "user_profile"
+    case MesgNumHrmProfile: return "hrm_profile"
+    case MesgNumSdmProfile: return "sdm_profile"
+    case MesgNumBikeProfile: return "bike_profile"
+    case MesgNumZonesTarget: return "zones_target"
+    case MesgNumHrZone: return "hr_zone"
+    case MesgNumPowerZone: return "power_zone"
+    case MesgNumMetZone: return "met_zone"
+    case MesgNumSport: return "sport"
+    case MesgNumGoal: return "goal"
+    case MesgNumSession: return "session"
+    case MesgNumLap: return "lap"
+    case MesgNumRecord: return "record"
+    case MesgNumEvent: return "event"
+    case MesgNumDeviceInfo: return "device_info"
+    case MesgNumWorkout: return "workout"
+    case MesgNumWorkoutStep: return "workout_step"
+    case MesgNumSchedule: return "schedule"
+    case MesgNumWeightScale: return "weight_scale"
+    case MesgNumCourse: return "course"
+    case MesgNumCoursePoint: return "course_point"
+    case MesgNumTotals: return "totals"
+    case MesgNumActivity: return "activity"
+    case MesgNumSoftware: return "software"
+    case MesgNumFileCapabilities: return "file_capabilities"
+    case MesgNumMesgCapabilities: return "mesg_capabilities"
+    case MesgNumFieldCapabilities: return "field_capabilities"
+    case MesgNumFileCreator: return "file_creator"
+    case MesgNumBloodPressure: return "blood_pressure"
+    case MesgNumSpeedZone: return "speed_zone"
+    case MesgNumMonitoring: return "monitoring"
+    case MesgNumHrv: return "hrv"
+    case MesgNumLength: return "length"
+    case MesgNumMonitoringInfo: return "monitoring_info"
+    case MesgNumPad: return "pad"
+    case MesgNumSlaveDevice: return "slave_device"
+    case MesgNumCadenceZone: return "cadence_zone"
+    case MesgNumMfgRangeMin: return "mfg_range_min"
+    case MesgNumMfgRangeMax: return "mfg_range_max"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgLength) Name() string {
-    return "length"
+func (val MesgNum) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgLength) Text() string {
-    return fmt.Sprintf("length msgidx %d tstmp %d evt %d evttyp %d" +
-        " starttime %d totalelapsedtime %d totaltimertime %d totalstrokes %d" +
-        " avgspeed %d swimstroke %d avgswimmingcadence %d evtgrp %d" +
-        " totalcals %d lentyp %d", msg.message_index, msg.timestamp, msg.event,
-        msg.event_type, msg.start_time, msg.total_elapsed_time,
-        msg.total_timer_time, msg.total_strokes, msg.avg_speed, msg.swim_stroke,
-        msg.avg_swimming_cadence, msg.event_group, msg.total_calories,
-        msg.length_type)
-}
 
-func NewMsgLength(def *FitDefinition, data []byte) (*MsgLength, error) {
-    msg := new(MsgLength)
+// pwr_zone_calc type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.event, pos = get_byte_pos(data, pos)
-        case 1: msg.event_type, pos = get_byte_pos(data, pos)
-        case 2: msg.start_time, pos = get_uint32_pos(data, pos)
-        case 3: msg.total_elapsed_time, pos = get_uint32_pos(data, pos)
-        case 4: msg.total_timer_time, pos = get_uint32_pos(data, pos)
-        case 5: msg.total_strokes, pos = get_uint16_pos(data, pos)
-        case 6: msg.avg_speed, pos = get_uint16_pos(data, pos)
-        case 7: msg.swim_stroke, pos = get_byte_pos(data, pos)
-        case 9: msg.avg_swimming_cadence, pos = get_uint8_pos(data, pos)
-        case 10: msg.event_group, pos = get_uint8_pos(data, pos)
-        case 11: msg.total_calories, pos = get_uint16_pos(data, pos)
-        case 12: msg.length_type, pos = get_byte_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad length field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+type PwrZoneCalc byte
+
+const (
+    PwrZoneCalcCustom PwrZoneCalc = 0
+    PwrZoneCalcPercentFtp PwrZoneCalc = 1
+)
+
+func (val PwrZoneCalc) String() string {
+    switch val {
+    case PwrZoneCalcCustom: return "custom"
+    case PwrZoneCalcPercentFtp: return "percent_ftp"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val PwrZoneCalc) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// monitoring_info message
 
-type MsgMonitoringInfo struct {
-    timestamp uint32
-    local_timestamp uint32
-}
+// schedule type
 
-func (msg *MsgMonitoringInfo) Name() string {
-    return "monitoring_info"
+type Schedule byte
+
+const (
+    ScheduleWorkout Schedule = 0
+    ScheduleCourse Schedule = 1
+)
+
+func (val Schedule) String() string {
+    switch val {
+    case ScheduleWorkout: return "workout"
+    case ScheduleCourse: return "course"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgMonitoringInfo) Text() string {
-    return fmt.Sprintf("monitoring_info tstmp %d localtstmp %d", msg.timestamp,
-        msg.local_timestamp)
+func (val Schedule) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgMonitoringInfo(def *FitDefinition, data []byte) (*MsgMonitoringInfo, error) {
-    msg := new(MsgMonitoringInfo)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 253: msg.timestamp, pos = get_uint32_pos(data, pos)
-        case 0: msg.local_timestamp, pos = get_uint32_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad monitoring_info field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// session_trigger type
 
-    return msg, nil
-}
+type SessionTrigger byte
 
-// pad message
+const (
+    SessionTriggerActivityEnd SessionTrigger = 0
+    SessionTriggerManual SessionTrigger = 1
+    SessionTriggerAutoMultiSport SessionTrigger = 2
+    SessionTriggerFitnessEquipment SessionTrigger = 3
+)
 
-type MsgPad struct {
+func (val SessionTrigger) String() string {
+    switch val {
+    case SessionTriggerActivityEnd: return "activity_end"
+    case SessionTriggerManual: return "manual"
+    case SessionTriggerAutoMultiSport: return "auto_multi_sport"
+    case SessionTriggerFitnessEquipment: return "fitness_equipment"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgPad) Name() string {
-    return "pad"
-}
+func (val SessionTrigger) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// sport type
+
+type Sport byte
+
+const (
+    SportGeneric Sport = 0
+    SportRunning Sport = 1
+    SportCycling Sport = 2
+    SportTransition Sport = 3
+    SportFitnessEquipment Sport = 4
+    SportSwimming Sport = 5
+    SportBasketball Sport = 6
+    SportSoccer Sport = 7
+    SportTennis Sport = 8
+    SportAmericanFootball Sport = 9
+    SportTraining Sport = 10
+    SportWalking Sport = 11
+    SportCrossCountrySkiing Sport = 12
+    SportAlpineSkiing Sport = 13
+    SportSnowboarding Sport = 14
+    SportRowing Sport = 15
+    SportMountaineering Sport = 16
+    SportHiking Sport = 17
+    SportMultisport Sport = 18
+    SportPaddling Sport = 19
+    SportAll Sport = 254
+)
 
-func (msg *MsgPad) Text() string {
-    return fmt.Sprintf("pad")
+func (val Sport) String() string {
+    switch val {
+    case SportGeneric: return "generic"
+    case SportRunning: return "running"
+    case SportCycling: return "cycling"
+    case SportTransition: return "transition"
+    case SportFitnessEquipment: return "fitness_equipment"
+    case SportSwimming: return "swimming"
+    case SportBasketball: return "basketball"
+    case SportSoccer: return "soccer"
+    case SportTennis: return "tennis"
+    case SportAmericanFootball: return "american_football"
+    case SportTraining: return "training"
+    case SportWalking: return "walking"
+    case SportCrossCountrySkiing: return "cross_country_skiing"
+    case SportAlpineSkiing: return "alpine_skiing"
+    case SportSnowboarding: return "snowboarding"
+    case SportRowing: return "rowing"
+    case SportMountaineering: return "mountaineering"
+    case SportHiking: return "hiking"
+    case SportMultisport: return "multisport"
+    case SportPaddling: return "paddling"
+    case SportAll: return "all"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func NewMsgPad(def *FitDefinition, data []byte) (*MsgPad, error) {
-    msg := new(MsgPad)
+func (val Sport) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
+}
+
+
+// sub_sport type
+
+type SubSport byte
+
+const (
+    SubSportGeneric SubSport = 0
+    SubSportTreadmill SubSport = 1
+    SubSportStreet SubSport = 2
+    SubSportTrail SubSport = 3
+    SubSportTrack SubSport = 4
+    SubSportSpin SubSport = 5
+    SubSportIndoorCycling SubSport = 6
+    SubSportRoad SubSport = 7
+    SubSportMountain SubSport = 8
+    SubSportDownhill SubSport = 9
+    SubSportRecumbent SubSport = 10
+    SubSportCyclocross SubSport = 11
+    SubSportHandCycling SubSport = 12
+    SubSportTrackCycling SubSport = 13
+    SubSportIndoorRowing SubSport = 14
+    SubSportElliptical SubSport = 15
+    SubSportStairClimbing SubSport = 16
+    SubSportLapSwimming SubSport = 17
+    SubSportOpenWater SubSport = 18
+    SubSportFlexibilityTraining SubSport = 19
+    SubSportStrengthTraining SubSport = 20
+    SubSportWarmUp SubSport = 21
+    SubSportMatch SubSport = 22
+    SubSportExercise SubSport = 23
+    SubSportChallenge SubSport = 24
+    SubSportIndoorSkiing SubSport = 25
+    SubSportCardioTraining SubSport = 26
+    SubSportAll SubSport = 254
+)
 
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        default:
-            errmsg := fmt.Sprintf("Bad pad field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+func (val SubSport) String() string {
+    switch val {
+    case SubSportGeneric: return "generic"
+    case SubSportTreadmill: return "treadmill"
+    case SubSportStreet: return "street"
+    case SubSportTrail: return "trail"
+    case SubSportTrack: return "track"
+    case SubSportSpin: return "spin"
+    case SubSportIndoorCycling: return "indoor_cycling"
+    case SubSportRoad: return "road"
+    case SubSportMountain: return "mountain"
+    case SubSportDownhill: return "downhill"
+    case SubSportRecumbent: return "recumbent"
+    case SubSportCyclocross: return "cyclocross"
+    case SubSportHandCycling: return "hand_cycling"
+    case SubSportTrackCycling: return "track_cycling"
+    case SubSportIndoorRowing: return "indoor_rowing"
+    case SubSportElliptical: return "elliptical"
+    case SubSportStairClimbing: return "stair_climbing"
+    case SubSportLapSwimming: return "lap_swimming"
+    case SubSportOpenWater: return "open_water"
+    case SubSportFlexibilityTraining: return "flexibility_training"
+    case SubSportStrengthTraining: return "strength_training"
+    case SubSportWarmUp: return "warm_up"
+    case SubSportMatch: return "match"
+    case SubSportExercise: return "exercise"
+    case SubSportChallenge: return "challenge"
+    case SubSportIndoorSkiing: return "indoor_skiing"
+    case SubSportCardioTraining: return "cardio_training"
+    case SubSportAll: return "all"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val SubSport) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-// slave_device message
 
-type MsgSlaveDevice struct {
-    manufacturer uint16
-    product uint16
-}
+// swim_stroke type
 
-func (msg *MsgSlaveDevice) Name() string {
-    return "slave_device"
+type SwimStroke byte
+
+const (
+    SwimStrokeFreestyle SwimStroke = 0
+    SwimStrokeBackstroke SwimStroke = 1
+    SwimStrokeBreaststroke SwimStroke = 2
+    SwimStrokeButterfly SwimStroke = 3
+    SwimStrokeDrill SwimStroke = 4
+    SwimStrokeMixed SwimStroke = 5
+    SwimStrokeIm SwimStroke = 6
+)
+
+func (val SwimStroke) String() string {
+    switch val {
+    case SwimStrokeFreestyle: return "freestyle"
+    case SwimStrokeBackstroke: return "backstroke"
+    case SwimStrokeBreaststroke: return "breaststroke"
+    case SwimStrokeButterfly: return "butterfly"
+    case SwimStrokeDrill: return "drill"
+    case SwimStrokeMixed: return "mixed"
+    case SwimStrokeIm: return "im"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgSlaveDevice) Text() string {
-    return fmt.Sprintf("slave_device mfct %d prod %d", msg.manufacturer,
-        msg.product)
+func (val SwimStroke) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgSlaveDevice(def *FitDefinition, data []byte) (*MsgSlaveDevice, error) {
-    msg := new(MsgSlaveDevice)
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 0: msg.manufacturer, pos = get_uint16_pos(data, pos)
-        case 1: msg.product, pos = get_uint16_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad slave_device field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
-    }
+// timer_trigger type
 
-    return msg, nil
-}
+type TimerTrigger byte
 
-// cadence_zone message
+const (
+    TimerTriggerManual TimerTrigger = 0
+    TimerTriggerAuto TimerTrigger = 1
+    TimerTriggerFitnessEquipment TimerTrigger = 2
+)
 
-type MsgCadenceZone struct {
-    message_index uint16
-    high_value uint8
-    name string
+func (val TimerTrigger) String() string {
+    switch val {
+    case TimerTriggerManual: return "manual"
+    case TimerTriggerAuto: return "auto"
+    case TimerTriggerFitnessEquipment: return "fitness_equipment"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgCadenceZone) Name() string {
-    return "cadence_zone"
+func (val TimerTrigger) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func (msg *MsgCadenceZone) Text() string {
-    return fmt.Sprintf("cadence_zone msgidx %d highvalue %d name %s",
-        msg.message_index, msg.high_value, msg.name)
-}
 
-func NewMsgCadenceZone(def *FitDefinition, data []byte) (*MsgCadenceZone, error) {
-    msg := new(MsgCadenceZone)
+// wkt_step_duration type
 
-    pos := 0
-    for i := 0; i < len(def.fields); i++ {
-        switch def.fields[i].num {
-        case 254: msg.message_index, pos = get_uint16_pos(data, pos)
-        case 0: msg.high_value, pos = get_uint8_pos(data, pos)
-        case 1: msg.name, pos = get_string_pos(data, pos)
-        default:
-            errmsg := fmt.Sprintf("Bad cadence_zone field #%d", def.fields[i].num)
-            return nil, errors.New(errmsg)
-        }
+type WktStepDuration byte
+
+const (
+    WktStepDurationTime WktStepDuration = 0
+    WktStepDurationDistance WktStepDuration = 1
+    WktStepDurationHrLessThan WktStepDuration = 2
+    WktStepDurationHrGreaterThan WktStepDuration = 3
+    WktStepDurationCalories WktStepDuration = 4
+    WktStepDurationOpen WktStepDuration = 5
+    WktStepDurationRepeatUntilStepsCmplt WktStepDuration = 6
+    WktStepDurationRepeatUntilTime WktStepDuration = 7
+    WktStepDurationRepeatUntilDistance WktStepDuration = 8
+    WktStepDurationRepeatUntilCalories WktStepDuration = 9
+    WktStepDurationRepeatUntilHrLessThan WktStepDuration = 10
+    WktStepDurationRepeatUntilHrGreaterThan WktStepDuration = 11
+    WktStepDurationRepeatUntilPowerLessThan WktStepDuration = 12
+    WktStepDurationRepeatUntilPowerGreaterThan WktStepDuration = 13
+    WktStepDurationPowerLessThan WktStepDuration = 14
+    WktStepDurationPowerGreaterThan WktStepDuration = 15
+    WktStepDurationRepetitionTime WktStepDuration = 28
+)
+
+func (val WktStepDuration) String() string {
+    switch val {
+    case WktStepDurationTime: return "time"
+    case WktStepDurationDistance: return "distance"
+    case WktStepDurationHrLessThan: return "hr_less_than"
+    case WktStepDurationHrGreaterThan: return "hr_greater_than"
+    case WktStepDurationCalories: return "calories"
+    case WktStepDurationOpen: return "open"
+    case WktStepDurationRepeatUntilStepsCmplt: return "repeat_until_steps_cmplt"
+    case WktStepDurationRepeatUntilTime: return "repeat_until_time"
+    case WktStepDurationRepeatUntilDistance: return "repeat_until_distance"
+    case WktStepDurationRepeatUntilCalories: return "repeat_until_calories"
+    case WktStepDurationRepeatUntilHrLessThan: return "repeat_until_hr_less_than"
+    case WktStepDurationRepeatUntilHrGreaterThan: return "repeat_until_hr_greater_than"
+    case WktStepDurationRepeatUntilPowerLessThan: return "repeat_until_power_less_than"
+    case WktStepDurationRepeatUntilPowerGreaterThan: return "repeat_until_power_greater_than"
+    case WktStepDurationPowerLessThan: return "power_less_than"
+    case WktStepDurationPowerGreaterThan: return "power_greater_than"
+    case WktStepDurationRepetitionTime: return "repetition_time"
+    default: return fmt.Sprintf("unknown#%d", val)
     }
+}
 
-    return msg, nil
+func (val WktStepDuration) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
 
-// unknown message
+// wkt_step_target type
 
-type MsgUnknown struct {
-    global_num uint16
-    data []byte
-}
+type WktStepTarget byte
 
-func (msg *MsgUnknown) Name() string {
-    return fmt.Sprintf("unknown#%d", msg.global_num)
+const (
+    WktStepTargetSpeed WktStepTarget = 0
+    WktStepTargetHeartRate WktStepTarget = 1
+    WktStepTargetOpen WktStepTarget = 2
+    WktStepTargetCadence WktStepTarget = 3
+    WktStepTargetPower WktStepTarget = 4
+    WktStepTargetGrade WktStepTarget = 5
+    WktStepTargetResistance WktStepTarget = 6
+)
+
+func (val WktStepTarget) String() string {
+    switch val {
+    case WktStepTargetSpeed: return "speed"
+    case WktStepTargetHeartRate: return "heart_rate"
+    case WktStepTargetOpen: return "open"
+    case WktStepTargetCadence: return "cadence"
+    case WktStepTargetPower: return "power"
+    case WktStepTargetGrade: return "grade"
+    case WktStepTargetResistance: return "resistance"
+    default: return fmt.Sprintf("unknown#%d", val)
+    }
 }
 
-func (msg *MsgUnknown) Text() string {
-    return fmt.Sprintf("unknown#%d", msg.global_num)
+func (val WktStepTarget) MarshalText() ([]byte, error) {
+    return []byte(val.String()), nil
 }
 
-func NewMsgUnknown(def *FitDefinition, data []byte,
-    global_num uint16) (*MsgUnknown, error) {
-    msg := new(MsgUnknown)
 
-    msg.global_num = global_num
-    msg.data = make([]byte, len(data))
-    copy(msg.data, data)
+// fields which are unpacked into other fields, by global message number
+var profile_components = map[uint16]map[byte][]*fitComponent{
+    20: record_components,
+    21: event_components,
+    55: monitoring_components,
+}
 
-    return msg, nil
+// fields which hold running totals, by global message number
+var profile_accumulated = map[uint16][]byte{
+    20: []byte{5, 19, 29},
+    55: []byte{2, 3, 4},
 }
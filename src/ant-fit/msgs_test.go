@@ -0,0 +1,204 @@
+package ant_fit
+
+import (
+    "encoding/json"
+    "math"
+    "strings"
+    "testing"
+)
+
+// the value of a reference field picks which subfield a field holds
+func TestEventSubFields(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 21, false, testField{253, 4, 0x86}, testField{0, 1, 0x00},
+        testField{1, 1, 0x00}, testField{3, 4, 0x86})
+    // timer start, triggered automatically
+    w.data(0, 800000000, 0, 0, 1)
+    // battery level in millivolts
+    w.data(0, 800000001, 11, 3, 3700)
+    // a workout event, whose data has no subfield
+    w.data(0, 800000002, 3, 3, 7)
+
+    events := testMessages(mustDecode(t, w.build()), 21)
+    if len(events) != 3 {
+        t.Fatalf("%d events, not 3", len(events))
+    }
+
+    timer := events[0].(*MsgEvent)
+    if sub := timer.SubField("data"); sub != "timer_trigger" {
+        t.Errorf("timer event data is %q", sub)
+    }
+    if trig := timer.TimerTrigger(); trig != TimerTriggerAuto {
+        t.Errorf("timer trigger %v", trig)
+    }
+    if val := timer.Field("timer_trigger"); !val.Valid ||
+        val.Raw != TimerTriggerAuto || val.String() != "timer_trigger auto" {
+        t.Errorf("Field(\"timer_trigger\") is %+v", val)
+    }
+    if level := timer.BatteryLevel(); level != invalid_uint16 {
+        t.Errorf("timer event battery level %d", level)
+    }
+    if val := timer.Field("battery_level"); val.Valid ||
+        !math.IsNaN(val.Scaled) {
+        t.Errorf("timer event battery level is %+v", val)
+    }
+
+    battery := events[1].(*MsgEvent)
+    if sub := battery.SubField("data"); sub != "battery_level" {
+        t.Errorf("battery event data is %q", sub)
+    }
+    if level := battery.BatteryLevelScaled(); level != 3.7 {
+        t.Errorf("battery level %v, not 3.7", level)
+    }
+    if val := battery.Field("battery_level"); !val.Valid ||
+        val.Scaled != 3.7 || val.Units != "V" {
+        t.Errorf("Field(\"battery_level\") is %+v", val)
+    }
+    if trig := battery.TimerTrigger(); trig != TimerTrigger(invalid_enum) {
+        t.Errorf("battery event timer trigger %v", trig)
+    }
+    if val := battery.Field("timer_trigger"); val.Valid {
+        t.Errorf("battery event timer trigger is %+v", val)
+    }
+
+    // the main field is always available
+    other := events[2].(*MsgEvent)
+    if sub := other.SubField("data"); sub != "" {
+        t.Errorf("workout event data is %q", sub)
+    }
+    if val := other.Field("data"); !val.Valid || val.Scaled != 7 {
+        t.Errorf("workout event data is %+v", val)
+    }
+    if !math.IsNaN(other.BatteryLevelScaled()) ||
+        other.Field("timer_trigger").Valid {
+        t.Error("workout event has a subfield")
+    }
+    if sub := other.SubField("event_type"); sub != "" {
+        t.Errorf("event_type has subfield %q", sub)
+    }
+}
+
+// subfields have their own scale and units
+func TestWorkoutStepSubFields(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 27, false, testField{254, 2, 0x84}, testField{1, 1, 0x00},
+        testField{2, 4, 0x86})
+    w.data(0, 0, int(WktStepDurationTime), 300000)
+    w.data(0, 1, int(WktStepDurationDistance), 100000)
+    w.data(0, 2, int(WktStepDurationOpen), 5)
+    w.data(0, 3, int(WktStepDurationTime), 0xffffffff)
+
+    steps := testMessages(mustDecode(t, w.build()), 27)
+    if len(steps) != 4 {
+        t.Fatalf("%d workout steps, not 4", len(steps))
+    }
+
+    timed := steps[0].(*MsgWorkoutStep)
+    if timed.DurationTime() != 300000 || timed.DurationTimeScaled() != 300 {
+        t.Errorf("duration time %d, %v s", timed.DurationTime(),
+            timed.DurationTimeScaled())
+    }
+    if val := timed.Field("duration_time"); !val.Valid || val.Scaled != 300 ||
+        val.Units != "s" {
+        t.Errorf("Field(\"duration_time\") is %+v", val)
+    }
+    if !math.IsNaN(timed.DurationDistanceScaled()) ||
+        timed.DurationDistance() != invalid_uint32 {
+        t.Errorf("timed step has duration distance %d",
+            timed.DurationDistance())
+    }
+    if val := timed.Field("duration_distance"); val.Valid ||
+        !math.IsNaN(val.Scaled) {
+        t.Errorf("timed step duration distance is %+v", val)
+    }
+
+    dist := steps[1].(*MsgWorkoutStep)
+    if dist.SubField("duration_value") != "duration_distance" ||
+        dist.DurationDistanceScaled() != 1000 {
+        t.Errorf("duration distance %v m", dist.DurationDistanceScaled())
+    }
+    if val := dist.Field("duration_distance"); !val.Valid ||
+        val.Scaled != 1000 || val.Units != "m" {
+        t.Errorf("Field(\"duration_distance\") is %+v", val)
+    }
+    if val := dist.Field("duration_time"); val.Valid ||
+        !math.IsNaN(val.Scaled) || !math.IsNaN(dist.DurationTimeScaled()) {
+        t.Errorf("distance step duration time is %+v", val)
+    }
+
+    open := steps[2].(*MsgWorkoutStep)
+    if open.SubField("duration_value") != "" ||
+        open.Field("duration_time").Valid ||
+        open.Field("duration_distance").Valid {
+        t.Errorf("open step has a subfield: %s", open.Text())
+    }
+
+    // a matching reference doesn't make an invalid value valid
+    unset := steps[3].(*MsgWorkoutStep)
+    if val := unset.Field("duration_time"); val.Valid ||
+        !math.IsNaN(unset.DurationTimeScaled()) {
+        t.Errorf("unset duration time is %+v", val)
+    }
+}
+
+func TestEnumNames(t *testing.T) {
+    tests := []struct {
+        val interface {
+            String() string
+            MarshalText() ([]byte, error)
+        }
+        want string
+    }{
+        {MesgNumRecord, "record"},
+        {MesgNum(78), "hrv"},
+        {MesgNumMfgRangeMin, "mfg_range_min"},
+        {MesgNum(1234), "unknown#1234"},
+        {ManufacturerGarmin, "garmin"},
+        {ManufacturerDevelopment, "development"},
+        {Manufacturer(9999), "unknown#9999"},
+        {FileActivity, "activity"},
+        {Event(200), "unknown#200"},
+    }
+
+    for _, tst := range tests {
+        if got := tst.val.String(); got != tst.want {
+            t.Errorf("%T %d is %q, not %q", tst.val, tst.val, got, tst.want)
+        }
+        if text, err := tst.val.MarshalText(); err != nil ||
+            string(text) != tst.want {
+            t.Errorf("%T %d marshals to %q, %v", tst.val, tst.val, text, err)
+        }
+    }
+
+    // JSON uses the names too
+    data, err := json.Marshal(map[string]interface{}{
+        "mesg": MesgNumSession, "mfct": Manufacturer(9999)})
+    if err != nil || string(data) !=
+        `{"mesg":"session","mfct":"unknown#9999"}` {
+        t.Errorf("JSON %s, %v", data, err)
+    }
+}
+
+// messages print enum fields by name
+func TestEnumText(t *testing.T) {
+    fid := mustDecode(t, testActivity(false).build()).SubFiles()[0].FileId()
+    if fid == nil {
+        t.Fatal("no file_id")
+    }
+
+    txt := fid.Text()
+    if !strings.Contains(txt, " msgtyp activity ") ||
+        !strings.Contains(txt, " mfct garmin ") {
+        t.Errorf("file_id text %q", txt)
+    }
+
+    if val := fid.Field("manufacturer"); val.Raw != ManufacturerGarmin ||
+        val.String() != "manufacturer garmin" {
+        t.Errorf("manufacturer is %v", val)
+    }
+
+    fid.Manufacturer = 9999
+    if txt := fid.Text(); !strings.Contains(txt, " mfct unknown#9999 ") {
+        t.Errorf("file_id text %q", txt)
+    }
+}
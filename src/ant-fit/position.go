@@ -0,0 +1,88 @@
+package ant_fit
+
+import (
+    "fmt"
+    "math"
+)
+
+// mean radius of the Earth in metres
+const earth_radius = 6371008.8
+
+// Position is a point on the Earth's surface in degrees.  Positions built
+// from fields which were not set hold NaN.
+type Position struct {
+    Lat float64
+    Long float64
+}
+
+// SemicirclesToDegrees converts a FIT angle, where 2^31 semicircles is
+// 180 degrees, to degrees, or to NaN if it is FIT's invalid value
+func SemicirclesToDegrees(val int32) float64 {
+    if !is_valid_int32(val) {
+        return math.NaN()
+    }
+
+    return float64(val) * (180.0 / (1 << 31))
+}
+
+// PositionFromSemicircles converts a latitude and longitude in
+// semicircles to a Position
+func PositionFromSemicircles(lat int32, long int32) Position {
+    return Position{Lat: SemicirclesToDegrees(lat),
+        Long: SemicirclesToDegrees(long)}
+}
+
+// IsValid reports whether both coordinates are set and in range
+func (pos Position) IsValid() bool {
+    return pos.Lat >= -90 && pos.Lat <= 90 &&
+        pos.Long >= -180 && pos.Long <= 180
+}
+
+// Distance returns the great-circle distance in metres to 'other', using
+// the haversine formula, or NaN if either position is invalid
+func (pos Position) Distance(other Position) float64 {
+    if !pos.IsValid() || !other.IsValid() {
+        return math.NaN()
+    }
+
+    lat1, lat2 := toRadians(pos.Lat), toRadians(other.Lat)
+    dlat := lat2 - lat1
+    dlong := toRadians(other.Long - pos.Long)
+
+    a := math.Sin(dlat / 2) * math.Sin(dlat / 2) +
+        math.Cos(lat1) * math.Cos(lat2) *
+        math.Sin(dlong / 2) * math.Sin(dlong / 2)
+
+    return 2 * earth_radius * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// Bearing returns the initial bearing in degrees clockwise from north,
+// from 0 up to 360, of the great circle path to 'other', or NaN if either
+// position is invalid
+func (pos Position) Bearing(other Position) float64 {
+    if !pos.IsValid() || !other.IsValid() {
+        return math.NaN()
+    }
+
+    lat1, lat2 := toRadians(pos.Lat), toRadians(other.Lat)
+    dlong := toRadians(other.Long - pos.Long)
+
+    y := math.Sin(dlong) * math.Cos(lat2)
+    x := math.Cos(lat1) * math.Sin(lat2) -
+        math.Sin(lat1) * math.Cos(lat2) * math.Cos(dlong)
+
+    deg := math.Atan2(y, x) * 180 / math.Pi
+    return math.Mod(deg + 360, 360)
+}
+
+func (pos Position) String() string {
+    if !pos.IsValid() {
+        return "invalid"
+    }
+
+    return fmt.Sprintf("%.6f,%.6f", pos.Lat, pos.Long)
+}
+
+func toRadians(deg float64) float64 {
+    return deg * math.Pi / 180
+}
@@ -0,0 +1,130 @@
+package ant_fit
+
+import (
+    "math"
+    "testing"
+)
+
+func TestSemicirclesToDegrees(t *testing.T) {
+    tests := []struct {
+        val int32
+        want float64
+    }{
+        {0, 0},
+        {1 << 30, 90},
+        {-1 << 30, -90},
+        {-1 << 31, -180},
+        {614507218, 51.5074},
+    }
+
+    for _, tst := range tests {
+        if got := SemicirclesToDegrees(tst.val); math.Abs(got - tst.want) >
+            1e-7 {
+            t.Errorf("SemicirclesToDegrees(%d) = %v, not %v", tst.val, got,
+                tst.want)
+        }
+    }
+
+    if got := SemicirclesToDegrees(invalid_int32); !math.IsNaN(got) {
+        t.Errorf("SemicirclesToDegrees(invalid) = %v", got)
+    }
+}
+
+func TestPositionDistance(t *testing.T) {
+    london := Position{51.5074, -0.1278}
+    paris := Position{48.8566, 2.3522}
+
+    tests := []struct {
+        from Position
+        to Position
+        distance float64
+        bearing float64
+    }{
+        {london, paris, 343556.53, 148.1156},
+        {paris, london, 343556.53, 330.0211},
+        {london, london, 0, 0},
+        // a degree along the equator or a meridian
+        {Position{0, 0}, Position{0, 1}, 111195.08, 90},
+        {Position{0, 0}, Position{-1, 0}, 111195.08, 180},
+        {Position{10, 179.5}, Position{10, -179.5}, 109505.74, 89.9132},
+    }
+
+    for _, tst := range tests {
+        if got := tst.from.Distance(tst.to); math.Abs(got - tst.distance) >
+            0.01 {
+            t.Errorf("%v to %v: distance %.2f, not %.2f", tst.from, tst.to,
+                got, tst.distance)
+        }
+        if got := tst.from.Bearing(tst.to); math.Abs(got - tst.bearing) >
+            0.0001 {
+            t.Errorf("%v to %v: bearing %.4f, not %.4f", tst.from, tst.to,
+                got, tst.bearing)
+        }
+    }
+}
+
+func TestInvalidPosition(t *testing.T) {
+    valid := PositionFromSemicircles(614507218, -1524713)
+    if !valid.IsValid() || valid.String() != "51.507400,-0.127800" {
+        t.Errorf("position %v", valid)
+    }
+
+    for _, pos := range []Position{
+        PositionFromSemicircles(invalid_int32, -1524713),
+        PositionFromSemicircles(614507218, invalid_int32),
+        {91, 0},
+        {0, -180.5},
+    } {
+        if pos.IsValid() || pos.String() != "invalid" {
+            t.Errorf("%+v is valid", pos)
+        }
+        if d := pos.Distance(valid); !math.IsNaN(d) {
+            t.Errorf("distance from %+v is %v", pos, d)
+        }
+        if b := valid.Bearing(pos); !math.IsNaN(b) {
+            t.Errorf("bearing to %+v is %v", pos, b)
+        }
+    }
+}
+
+// the generated accessors pair up the latitude and longitude fields
+func TestMessagePositions(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{0, 4, 0x85},
+        testField{1, 4, 0x85})
+    w.data(0, 800000000, 614507218, -1524713)
+    w.data(0, 800000001, 0x7fffffff, 0x7fffffff)
+
+    w.define(1, 18, false, testField{253, 4, 0x86}, testField{3, 4, 0x85},
+        testField{4, 4, 0x85}, testField{29, 4, 0x85}, testField{30, 4, 0x85})
+    w.data(1, 800000002, 614507218, -1524713, 614600000, -1500000)
+
+    ffile := mustDecode(t, w.build())
+
+    recs := testMessages(ffile, 20)
+    if len(recs) != 2 {
+        t.Fatalf("%d records, not 2", len(recs))
+    }
+
+    pos := recs[0].(*MsgRecord).Position()
+    if math.Abs(pos.Lat - 51.5074) > 1e-7 ||
+        math.Abs(pos.Long + 0.1278) > 1e-7 {
+        t.Errorf("record position %v", pos)
+    }
+    if pos := recs[1].(*MsgRecord).Position(); pos.IsValid() ||
+        !math.IsNaN(pos.Lat) || !math.IsNaN(pos.Long) {
+        t.Errorf("unset record position %v", pos)
+    }
+
+    sess := testMessages(ffile, 18)[0].(*MsgSession)
+    if start := sess.StartPosition(); start != pos {
+        t.Errorf("session start %v, not %v", start, pos)
+    }
+    if nec := sess.NecPosition(); !nec.IsValid() || nec.Lat <= pos.Lat ||
+        nec.Long <= pos.Long {
+        t.Errorf("session north-east corner %v", nec)
+    }
+    if swc := sess.SwcPosition(); swc.IsValid() {
+        t.Errorf("unset south-west corner %v", swc)
+    }
+}
@@ -0,0 +1,263 @@
+package ant_fit
+
+import (
+    "fmt"
+    "io"
+)
+
+// SkippedRange describes bytes which were skipped in recovery mode
+type SkippedRange struct {
+    // byte offset from the start of the stream, and number of bytes
+    Offset int64
+    Size int64
+    // the error which caused the bytes to be skipped
+    Reason error
+}
+
+func (skip *SkippedRange) String() string {
+    return fmt.Sprintf("skipped %d bytes at offset %d: %s", skip.Size,
+        skip.Offset, skip.Reason)
+}
+
+// RecoverFromErrors turns recovery mode on or off.  In recovery mode,
+// ReadMessage() skips a garbled record by searching for the next plausible
+// record, ignores a bad file CRC, and treats a truncated file as if it
+// ended at the last complete record, so everything which can be decoded
+// is.  The bytes which were skipped are reported by Skipped().
+func (ffile *FitFile) RecoverFromErrors(on bool) {
+    ffile.recover_errs = on
+}
+
+// Skipped returns the byte ranges which were skipped in recovery mode
+func (ffile *FitFile) Skipped() []*SkippedRange {
+    return ffile.skipped
+}
+
+func (ffile *FitFile) skip(offset int64, size int64, reason error) {
+    ffile.skipped = append(ffile.skipped, &SkippedRange{Offset: offset,
+        Size: size, Reason: reason})
+}
+
+// carry on after 'err' if possible
+func (ffile *FitFile) recoverFrom(err error, verbose bool) (bool, error) {
+    switch rerr := err.(type) {
+    case *TruncatedError:
+        // nothing follows the end of the data
+        ffile.skip(rerr.Offset, ffile.offset - rerr.Offset, err)
+        ffile.done = true
+        return false, nil
+    case *HeaderError:
+        // there's no telling where a chained file's data ends
+        ffile.skip(rerr.Offset, ffile.offset - rerr.Offset, err)
+        ffile.done = true
+        return false, nil
+    case *CRCError:
+        ffile.skip(rerr.Offset, ffile.offset - rerr.Offset, err)
+        if rerr.Header {
+            ffile.done = true
+            return false, nil
+        }
+
+        more, nerr := ffile.nextFile(verbose)
+        if nerr != nil {
+            return ffile.recoverFrom(nerr, verbose)
+        }
+
+        return more, nil
+    case *UndefinedLocalTypeError, *RecordError:
+        return ffile.resync(err)
+    default:
+        return false, err
+    }
+}
+
+// skip the garbled record which started at ffile.ctx.Offset, up to the
+// next byte which looks like the start of a record.  The search moves
+// through the data a window at a time, so however far it has to go it
+// only holds the garbled record and the reader's buffer.
+func (ffile *FitFile) resync(reason error) (bool, error) {
+    // put the record back, then skip at least its first byte
+    pending := make([]byte, 0, len(ffile.rec_bytes) + len(ffile.pending))
+    pending = append(pending, ffile.rec_bytes...)
+    ffile.pending = append(pending, ffile.pending...)
+
+    ffile.crc = ffile.rec_crc
+    ffile.data_read = ffile.rec_data_read
+    ffile.offset = ffile.ctx.Offset
+
+    n := 1
+    for {
+        window, complete, err := ffile.peekData()
+        if err != nil {
+            return false, err
+        }
+
+        // a record near the end of a window may need the bytes after it
+        // to be recognized, so only the first half is searched before
+        // moving on to the next window
+        limit := len(window)
+        if !complete {
+            limit /= 2
+        }
+
+        for n < limit && !ffile.isPlausibleRecord(window[n:], 4, complete) {
+            n++
+        }
+        if n > len(window) {
+            n = len(window)
+        }
+
+        err = ffile.discard(window[:n])
+        if err != nil {
+            return false, err
+        } else if n < limit || complete {
+            break
+        }
+
+        n = 0
+    }
+
+    ffile.skip(ffile.ctx.Offset, ffile.offset - ffile.ctx.Offset, reason)
+
+    return true, nil
+}
+
+// return the current file's data which follows the read position, as
+// much as the pending bytes and the reader's buffer hold, and whether
+// that is all of it.  The bytes are only good until the next read.
+func (ffile *FitFile) peekData() ([]byte, bool, error) {
+    remaining := int(ffile.cur.datasize - ffile.data_read)
+
+    want := remaining - len(ffile.pending)
+    if want > ffile.rdr.Size() {
+        want = ffile.rdr.Size()
+    }
+
+    var peeked []byte
+    at_eof := false
+    if want > 0 {
+        var err error
+        peeked, err = ffile.rdr.Peek(want)
+        if err == io.EOF {
+            // the file is truncated
+            at_eof = true
+        } else if err != nil {
+            return nil, false, err
+        }
+    }
+
+    window := peeked
+    if len(ffile.pending) > 0 {
+        window = append(ffile.window[:0], ffile.pending...)
+        window = append(window, peeked...)
+        ffile.window = window
+    }
+
+    if len(window) > remaining {
+        window = window[:remaining]
+    }
+
+    return window, at_eof || len(window) == remaining, nil
+}
+
+// skip 'data', which starts at the read position; the skipped bytes
+// still count towards the data size and CRC
+func (ffile *FitFile) discard(data []byte) error {
+    ffile.crc = computeCRC(ffile.crc, data)
+    ffile.data_read += uint32(len(data))
+    ffile.offset += int64(len(data))
+
+    n := len(data)
+    if n <= len(ffile.pending) {
+        ffile.pending = ffile.pending[n:]
+        return nil
+    }
+
+    n -= len(ffile.pending)
+    ffile.pending = nil
+
+    _, err := ffile.rdr.Discard(n)
+    return err
+}
+
+// report whether 'data' starts with a record which could be decoded.  A
+// data record must be followed by the end of the data or by another
+// plausible record, checked to a depth of 'depth' records.  If 'data'
+// is not 'complete', a record which runs past its end is given the
+// benefit of the doubt.
+func (ffile *FitFile) isPlausibleRecord(data []byte, depth int,
+    complete bool) bool {
+    hdr := data[0]
+
+    var def *FitDefinition
+    if hdr & 0x80 == 0x80 {
+        // compressed timestamp header
+        def = ffile.local_defs[(hdr >> 5) & 0x3]
+    } else if hdr & 0x10 != 0 {
+        // reserved bit
+        return false
+    } else if hdr & 0x40 == 0x40 {
+        return isPlausibleDefinition(data, hdr & 0x20 == 0x20, complete)
+    } else if hdr & 0x20 == 0 {
+        def = ffile.local_defs[hdr & 0x0f]
+    }
+
+    if def == nil {
+        return false
+    }
+
+    size := 1 + def.TotalBytes
+    if size > len(data) {
+        return !complete
+    } else if size == len(data) || depth <= 1 {
+        return true
+    }
+
+    return ffile.isPlausibleRecord(data[size:], depth - 1, complete)
+}
+
+// report whether 'data' starts with a definition message whose fields all
+// have known base types and sizes
+func isPlausibleDefinition(data []byte, has_dev bool, complete bool) bool {
+    if len(data) < 6 {
+        return !complete
+    } else if data[1] != 0 || data[2] > 1 {
+        return false
+    }
+
+    pos := 6 + 3 * int(data[5])
+    if pos > len(data) {
+        return !complete
+    }
+
+    for i := 6; i < pos; i += 3 {
+        size := int(data[i + 1])
+        base_type := data[i + 2] & 0x1f
+        if int(base_type) >= len(base_type_sizes) || size == 0 ||
+            size % get_base_size(base_type) != 0 {
+            return false
+        }
+
+        // multi-byte types always have the endian flag set
+        canonical := base_type
+        if get_base_size(base_type) > 1 {
+            canonical |= 0x80
+        }
+        if data[i + 2] != canonical {
+            return false
+        }
+    }
+
+    if has_dev {
+        if pos >= len(data) {
+            return !complete
+        }
+
+        pos += 1 + 3 * int(data[pos])
+        if pos > len(data) {
+            return !complete
+        }
+    }
+
+    return true
+}
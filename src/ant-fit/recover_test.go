@@ -0,0 +1,139 @@
+package ant_fit
+
+import (
+    "bytes"
+    "testing"
+)
+
+// decode 'data' in recovery mode, failing the test on any error
+func mustRecover(t *testing.T, data []byte) *FitFile {
+    t.Helper()
+
+    ffile, err := NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ffile.RecoverFromErrors(true)
+
+    for {
+        more, err := ffile.ReadMessage(false)
+        if err != nil {
+            t.Fatalf("recovery failed: %v", err)
+        } else if !more {
+            return ffile
+        }
+    }
+}
+
+// check that the records decoded have the timestamps in 'want'
+func checkTimestamps(t *testing.T, ffile *FitFile, want []uint32) {
+    t.Helper()
+
+    recs := testMessages(ffile, 20)
+    if len(recs) != len(want) {
+        t.Fatalf("%d records, not %d", len(recs), len(want))
+    }
+
+    for i, msg := range recs {
+        if ts := msg.(*MsgRecord).Timestamp; ts != want[i] {
+            t.Errorf("record %d: timestamp %d, not %d", i, ts, want[i])
+        }
+    }
+}
+
+func TestRecoverCorruptedDataRecord(t *testing.T) {
+    w := testActivity(false)
+
+    // give the third record a local type with no definition
+    pos := len(w.recs) - 3 * 22
+    w.recs[pos] = 0x0e
+
+    ffile := mustRecover(t, w.build())
+    checkTimestamps(t, ffile, []uint32{800000000, 800000001, 800000003,
+        800000004})
+
+    skipped := ffile.Skipped()
+    if len(skipped) != 1 || skipped[0].Offset != int64(14 + pos) ||
+        skipped[0].Size != 22 {
+        t.Errorf("skipped %v", skipped)
+    }
+}
+
+func TestRecoverCorruptedDefinition(t *testing.T) {
+    w := testActivity(false)
+    start := 14 + len(w.recs)
+
+    // an event definition with an unknown architecture, and its data
+    w.raw(0x42, 0, 7, 21, 0, 4, 253, 4, 0x86, 0, 1, 0x00, 1, 1, 0x00,
+        4, 1, 0x02)
+    w.raw(0x02, 0x10, 0x20, 0x30, 0x40, 0x1a, 0x04, 0x07)
+    end := 14 + len(w.recs)
+
+    w.data(1, 800000010, 500001000, -900001000, 3010, 130, 6000, 3100)
+    w.data(1, 800000011, 500001100, -900001100, 3011, 131, 6500, 3101)
+
+    ffile := mustRecover(t, w.build())
+    checkTimestamps(t, ffile, []uint32{800000000, 800000001, 800000002,
+        800000003, 800000004, 800000010, 800000011})
+
+    var size int64
+    for _, skip := range ffile.Skipped() {
+        if skip.Offset < int64(start) || skip.Offset + skip.Size > int64(end) {
+            t.Errorf("skipped good data: %v", skip)
+        }
+        size += skip.Size
+    }
+    if size != int64(end - start) {
+        t.Errorf("skipped %d bytes, not %d", size, end - start)
+    }
+}
+
+// damage which runs on for several windows, and damage repeated all
+// through a long file, are skipped without holding the data in memory
+func TestRecoverLongDamage(t *testing.T) {
+    w := testActivity(false)
+    w.raw(bytes.Repeat([]byte{0xff}, 20000)...)
+
+    var want []uint32
+    for i := 0; i < 5; i++ {
+        want = append(want, uint32(800000000 + i))
+    }
+
+    // bytes which can't start a record: an undefined local type, the
+    // reserved bit, and a data header with the developer data bit
+    for i := 0; i < 2000; i++ {
+        ts := 800000100 + i
+        w.data(1, ts, 0, 0, 0, 0, 0, 0)
+        want = append(want, uint32(ts))
+        if i % 10 == 5 {
+            w.raw(0x0f, 0x1f, 0x3f)
+        }
+    }
+
+    ffile, err := NewDecoder(bytes.NewReader(w.build()))
+    if err != nil {
+        t.Fatal(err)
+    }
+    ffile.RecoverFromErrors(true)
+
+    pending := 0
+    for more := true; more; {
+        more, err = ffile.ReadMessage(false)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if cap(ffile.pending) > pending {
+            pending = cap(ffile.pending)
+        }
+    }
+
+    if pending > 64 {
+        t.Errorf("held %d pending bytes", pending)
+    }
+
+    checkTimestamps(t, ffile, want)
+    if n := len(ffile.Skipped()); n != 201 {
+        t.Errorf("%d ranges skipped, not 201", n)
+    }
+}
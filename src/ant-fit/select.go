@@ -0,0 +1,116 @@
+package ant_fit
+
+import (
+    "errors"
+)
+
+// messages which the decoder uses itself, and so decodes even if they are
+// not wanted: file_id for FitSubFile.FileId(), device_settings and
+// activity for FitSubFile.Time(), and field descriptions for developer
+// fields
+var internal_msgs = map[uint16]bool{
+    uint16(MesgNumFileId): true,
+    uint16(MesgNumDeviceSettings): true,
+    uint16(MesgNumActivity): true,
+    206: true,
+}
+
+// SelectMessages limits decoding to the listed message types.  Data
+// messages of other types are skipped without being decoded, so they are
+// not returned or retained.  Calling it with no types decodes everything.
+// The messages which the decoder uses itself, such as file_id, are still
+// decoded, but are only returned if they are selected.
+func (ffile *FitFile) SelectMessages(nums ...MesgNum) {
+    ffile.select_msgs = nil
+    if len(nums) > 0 {
+        ffile.select_msgs = make(map[uint16]bool)
+        for _, num := range nums {
+            ffile.select_msgs[uint16(num)] = true
+        }
+    }
+}
+
+// IgnoreMessages skips data messages of the listed types without decoding
+// them.  It is applied after SelectMessages().
+func (ffile *FitFile) IgnoreMessages(nums ...MesgNum) {
+    ffile.ignore_msgs = nil
+    if len(nums) > 0 {
+        ffile.ignore_msgs = make(map[uint16]bool)
+        for _, num := range nums {
+            ffile.ignore_msgs[uint16(num)] = true
+        }
+    }
+}
+
+// report whether data messages with global number 'num' are returned
+func (ffile *FitFile) isWanted(num uint16) bool {
+    if ffile.peeking {
+        return num == uint16(MesgNumFileId)
+    }
+
+    if ffile.select_msgs != nil && !ffile.select_msgs[num] {
+        return false
+    }
+
+    return !ffile.ignore_msgs[num]
+}
+
+// read past a data message without decoding it, keeping track of its
+// timestamp for any compressed-timestamp records which follow
+func (ffile *FitFile) skipData(def *FitDefinition, compressed bool,
+    time_offset uint32) error {
+    buf := reuseBuffer(&ffile.scratch, def.TotalBytes)
+
+    err := ffile.read(buf)
+    if err != nil {
+        return err
+    }
+
+    if compressed && ffile.have_timestamp {
+        ffile.last_timestamp = expandTimestamp(ffile.last_timestamp,
+            time_offset)
+    } else if !compressed {
+        ffile.trackTimestamp(def, buf)
+    }
+
+    return nil
+}
+
+// PeekFileId returns the file_id message of the current file, reading
+// only as far as that message and skipping any data messages before it.
+// It returns nil if the file has no file_id.
+func (ffile *FitFile) PeekFileId() (*MsgFileId, error) {
+    ffile.peeking = true
+    defer func() { ffile.peeking = false }()
+
+    sub := ffile.cur
+    for sub.file_id == nil && ffile.cur == sub {
+        more, _, _, err := ffile.readNext(false)
+        if err != nil {
+            return nil, err
+        } else if !more {
+            break
+        }
+    }
+
+    return sub.file_id, nil
+}
+
+// ReadFileId returns the file_id message of the named file, which is
+// enough to classify the file without decoding the rest of it
+func ReadFileId(filename string) (*MsgFileId, error) {
+    ffile, err := NewFitFile(filename)
+    if err != nil {
+        return nil, err
+    }
+    defer ffile.Close()
+
+    fid, err := ffile.PeekFileId()
+    if err != nil {
+        return nil, err
+    } else if fid == nil {
+        return nil, errors.New("No file_id message in " + filename)
+    }
+
+    return fid, nil
+}
@@ -0,0 +1,100 @@
+package ant_fit
+
+import (
+    "bytes"
+    "testing"
+)
+
+// an activity with a device_settings utc_offset and an activity message
+// two hours ahead of UTC
+func testTimesActivity() []byte {
+    w := testActivity(false)
+
+    // system time 1000 is 800000000 UTC
+    w.define(2, 2, false, testField{1, 4, 0x86})
+    w.data(2, 800000000 - 1000)
+    w.define(3, 21, false, testField{253, 4, 0x86}, testField{0, 1, 0x00})
+    w.data(3, 800000005, 0)
+    w.define(4, 34, false, testField{253, 4, 0x86}, testField{5, 4, 0x86})
+    w.data(4, 800000010, 800000010 + 7200)
+
+    return w.build()
+}
+
+func TestSelectMessages(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testTimesActivity()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ffile.SelectMessages(MesgNumRecord)
+
+    err = ffile.Decode(nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    msgs := ffile.Messages()
+    if len(msgs) != 5 || len(testMessages(ffile, 20)) != 5 {
+        t.Errorf("%d messages, not 5 records", len(msgs))
+    }
+
+    // the messages the decoder uses are still decoded
+    sub := ffile.SubFiles()[0]
+    if sub.FileId() == nil {
+        t.Error("no file_id")
+    }
+    if _, offset := sub.Time(1000).Zone(); offset != 7200 ||
+        !sub.Time(1000).Equal(DateTime(800000000)) {
+        t.Errorf("time zone %v, system time 1000 is %v", sub.Location(),
+            sub.Time(1000))
+    }
+}
+
+func TestIgnoreMessages(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testTimesActivity()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    ffile.IgnoreMessages(MesgNumFileId, MesgNumDeviceSettings,
+        MesgNumActivity, MesgNumRecord)
+
+    var names []string
+    err = ffile.Decode(&Handlers{OnMessage: func(msg FitMsg) error {
+        names = append(names, msg.Name())
+        return nil
+    }})
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if len(names) != 1 || names[0] != "event" {
+        t.Errorf("messages %v", names)
+    }
+
+    sub := ffile.SubFiles()[0]
+    if sub.FileId() == nil || sub.Location() == nil {
+        t.Errorf("file_id %v, time zone %v", sub.FileId(), sub.Location())
+    }
+}
+
+func TestPeekFileId(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testTimesActivity()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    fid, err := ffile.PeekFileId()
+    if err != nil || fid == nil || fid.SerialNumber != 3812345678 {
+        t.Fatalf("file_id %v, error %v", fid, err)
+    }
+
+    // decoding carries on after the file_id
+    if err := ffile.Decode(nil); err != nil {
+        t.Fatal(err)
+    }
+    if n := len(testMessages(ffile, 20)); n != 5 {
+        t.Errorf("%d records, not 5", n)
+    }
+}
@@ -0,0 +1,59 @@
+package ant_fit
+
+import (
+    "io"
+)
+
+// RetainMessages controls whether decoded data messages are kept in the
+// sub-files, where Messages() returns them.  Streaming callers of Next()
+// or Decode() which don't need them can turn this off so memory use stays
+// bounded however long the file is.  The file_id message is always kept.
+func (ffile *FitFile) RetainMessages(retain bool) {
+    ffile.no_retain = !retain
+}
+
+// Next returns the next data message, reading any definitions and
+// chained file headers which come before it.  It returns io.EOF after the
+// last message in the stream.
+func (ffile *FitFile) Next() (FitMsg, error) {
+    for {
+        more, _, msg, err := ffile.readNext(false)
+        if err != nil {
+            return nil, err
+        } else if msg != nil {
+            return msg, nil
+        } else if !more {
+            return nil, io.EOF
+        }
+    }
+}
+
+// Decode reads the rest of the stream, passing each definition and data
+// message to the matching callback in 'handlers', which may be nil.
+// Decoding stops at the first error, including an error returned by a
+// callback.
+func (ffile *FitFile) Decode(handlers *Handlers) error {
+    if handlers == nil {
+        handlers = &Handlers{}
+    }
+
+    for {
+        more, def, msg, err := ffile.readNext(false)
+        if err != nil {
+            return err
+        }
+
+        if def != nil && handlers.OnDefinition != nil {
+            err = handlers.OnDefinition(def)
+        } else if msg != nil {
+            err = handlers.dispatch(msg)
+        }
+        if err != nil {
+            return err
+        }
+
+        if !more {
+            return nil
+        }
+    }
+}
@@ -0,0 +1,166 @@
+package ant_fit
+
+import (
+    "bytes"
+    "errors"
+    "io"
+    "testing"
+)
+
+func TestNext(t *testing.T) {
+    data := append(testActivity(false).build(), testActivity(true).build()...)
+
+    ffile, err := NewDecoder(bytes.NewReader(data))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var names []string
+    for {
+        msg, err := ffile.Next()
+        if err == io.EOF {
+            break
+        } else if err != nil {
+            t.Fatal(err)
+        }
+
+        names = append(names, msg.Name())
+    }
+
+    if len(names) != 12 || names[0] != "file_id" || names[6] != "file_id" ||
+        names[11] != "record" {
+        t.Errorf("messages %v", names)
+    }
+
+    // the end of the stream is sticky
+    if _, err := ffile.Next(); err != io.EOF {
+        t.Errorf("Next() after the end gave %v", err)
+    }
+}
+
+func TestNextError(t *testing.T) {
+    data := testActivity(false).build()
+
+    ffile, err := NewDecoder(bytes.NewReader(data[:len(data) - 10]))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    count := 0
+    for {
+        _, err = ffile.Next()
+        if err != nil {
+            break
+        }
+        count++
+    }
+
+    var terr *TruncatedError
+    if count != 5 || !errors.As(err, &terr) {
+        t.Errorf("%d messages then %v", count, err)
+    }
+}
+
+func TestDecode(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testActivity(false).build()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    var defs, msgs, records int
+    var fid *MsgFileId
+    err = ffile.Decode(&Handlers{
+        OnDefinition: func(def *FitDefinition) error {
+            defs++
+            return nil
+        },
+        OnMessage: func(msg FitMsg) error {
+            msgs++
+            return nil
+        },
+        OnFileId: func(msg *MsgFileId) error {
+            fid = msg
+            return nil
+        },
+        OnRecord: func(msg *MsgRecord) error {
+            records++
+            return nil
+        },
+    })
+
+    // OnMessage only sees the messages without a callback of their own
+    if err != nil || defs != 2 || msgs != 0 || records != 5 || fid == nil {
+        t.Errorf("err %v, %d definitions, %d messages, %d records, file_id %v",
+            err, defs, msgs, records, fid)
+    }
+}
+
+func TestDecodeCallbackError(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testActivity(false).build()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    stop := errors.New("stop")
+    records := 0
+    err = ffile.Decode(&Handlers{
+        OnRecord: func(msg *MsgRecord) error {
+            records++
+            if records == 2 {
+                return stop
+            }
+            return nil
+        },
+    })
+
+    if err != stop || records != 2 {
+        t.Errorf("err %v after %d records", err, records)
+    }
+}
+
+func TestDecodeNilHandlers(t *testing.T) {
+    ffile, err := NewDecoder(bytes.NewReader(testActivity(false).build()))
+    if err != nil {
+        t.Fatal(err)
+    }
+
+    if err := ffile.Decode(nil); err != nil {
+        t.Fatal(err)
+    }
+    if n := len(ffile.Messages()); n != 6 {
+        t.Errorf("%d messages, not 6", n)
+    }
+}
+
+func TestRetainMessages(t *testing.T) {
+    for _, retain := range []bool{true, false} {
+        ffile, err := NewDecoder(bytes.NewReader(testActivity(false).build()))
+        if err != nil {
+            t.Fatal(err)
+        }
+
+        ffile.RetainMessages(retain)
+
+        count := 0
+        for {
+            _, err := ffile.Next()
+            if err == io.EOF {
+                break
+            } else if err != nil {
+                t.Fatal(err)
+            }
+            count++
+        }
+
+        kept := len(ffile.Messages())
+        if count != 6 || (retain && kept != 6) || (!retain && kept != 0) {
+            t.Errorf("retain %v: read %d messages, kept %d", retain, count,
+                kept)
+        }
+
+        // the file_id is kept regardless
+        if ffile.SubFiles()[0].FileId() == nil {
+            t.Errorf("retain %v: no file_id", retain)
+        }
+    }
+}
@@ -0,0 +1,83 @@
+package ant_fit
+
+import (
+    "fmt"
+    "time"
+)
+
+// FitSubFile holds one of the FIT files read from a stream.  Most streams
+// hold a single file, but some devices and tools chain several together.
+type FitSubFile struct {
+    proto byte
+    profile uint16
+    datasize uint32
+
+    defs []*FitDefinition
+    data []FitMsg
+    file_id *MsgFileId
+
+    // from device_settings, to convert system times to UTC
+    utc_offset uint32
+    have_utc_offset bool
+
+    // from the activity's local_timestamp
+    zone *time.Location
+}
+
+// SubFiles returns the files read from the stream so far
+func (ffile *FitFile) SubFiles() []*FitSubFile {
+    return ffile.files
+}
+
+// Messages returns the data messages read from the stream so far, from
+// every sub-file in the order they were read
+func (ffile *FitFile) Messages() []FitMsg {
+    if len(ffile.files) == 1 {
+        return ffile.files[0].data
+    }
+
+    var list []FitMsg
+    for _, sub := range ffile.files {
+        list = append(list, sub.data...)
+    }
+
+    return list
+}
+
+// Protocol returns the protocol version from the file header
+func (sub *FitSubFile) Protocol() byte {
+    return sub.proto
+}
+
+// Profile returns the profile version from the file header
+func (sub *FitSubFile) Profile() uint16 {
+    return sub.profile
+}
+
+// DataSize returns the size of the records from the file header
+func (sub *FitSubFile) DataSize() uint32 {
+    return sub.datasize
+}
+
+// Definitions returns every definition message read from the file if
+// FitFile.KeepDefinitions(true) was called before decoding, or nil if not
+func (sub *FitSubFile) Definitions() []*FitDefinition {
+    return sub.defs
+}
+
+// Messages returns the data messages read from the file, which is empty
+// if FitFile.RetainMessages(false) was called before decoding
+func (sub *FitSubFile) Messages() []FitMsg {
+    return sub.data
+}
+
+// FileId returns the file's file_id message, or nil if it has not been
+// read.  It is kept even if the other messages are not.
+func (sub *FitSubFile) FileId() *MsgFileId {
+    return sub.file_id
+}
+
+func (sub *FitSubFile) String() string {
+    return fmt.Sprintf("proto %d profile %d data %d", sub.proto, sub.profile,
+        sub.datasize)
+}
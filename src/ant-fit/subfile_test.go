@@ -0,0 +1,65 @@
+package ant_fit
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestChainedFiles(t *testing.T) {
+    first := testActivity(false).build()
+
+    second := testActivity(true)
+    second.define(2, 21, true, testField{253, 4, 0x86}, testField{0, 1, 0x00})
+    second.data(2, 800000100, 0)
+
+    data := append(append([]byte{}, first...), second.buildFile(false, false)...)
+    ffile := mustDecode(t, data)
+
+    subs := ffile.SubFiles()
+    if len(subs) != 2 {
+        t.Fatalf("%d sub-files, not 2", len(subs))
+    }
+
+    for i, sub := range subs {
+        if sub.FileId() == nil || sub.FileId().SerialNumber != 3812345678 {
+            t.Errorf("sub-file %d: file_id %v", i, sub.FileId())
+        }
+    }
+
+    if n := len(subs[0].Messages()); n != 6 {
+        t.Errorf("first sub-file has %d messages, not 6", n)
+    }
+    if n := len(subs[1].Messages()); n != 7 {
+        t.Errorf("second sub-file has %d messages, not 7", n)
+    }
+    if n := len(ffile.Messages()); n != 13 {
+        t.Errorf("%d messages in all, not 13", n)
+    }
+
+    if subs[0].DataSize() != uint32(len(first) - 16) ||
+        subs[1].DataSize() != uint32(len(second.recs)) {
+        t.Errorf("data sizes %d and %d", subs[0].DataSize(),
+            subs[1].DataSize())
+    }
+}
+
+func TestChainedFileTruncatedHeader(t *testing.T) {
+    first := testActivity(false).build()
+    second := testActivity(false).build()
+
+    data := append(append([]byte{}, first...), second[:7]...)
+    ffile, err := decodeTest(data)
+
+    var terr *TruncatedError
+    if !errors.As(err, &terr) || terr.Offset != int64(len(first)) ||
+        terr.File != 1 || terr.Read != 7 {
+        t.Fatalf("truncated second header gave %v", err)
+    }
+
+    // the first file is intact
+    subs := ffile.SubFiles()
+    if len(subs) != 1 || len(subs[0].Messages()) != 6 {
+        t.Errorf("%d sub-files and %d messages", len(subs),
+            len(ffile.Messages()))
+    }
+}
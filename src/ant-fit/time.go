@@ -0,0 +1,113 @@
+package ant_fit
+
+import (
+    "fmt"
+    "math"
+    "time"
+)
+
+// FIT date_time values count seconds from 1989-12-31 00:00:00 UTC
+const fit_epoch = 631065600
+
+// date_time values below this are seconds since the device was powered
+// on rather than since the FIT epoch
+const min_date_time = 0x10000000
+
+// DateTime converts a FIT date_time value to a time in UTC.  It returns
+// the zero time for an invalid value, and for a relative system time,
+// which FitSubFile.Time() can convert if the file holds a utc_offset.
+func DateTime(val uint32) time.Time {
+    if !is_valid_uint32(val) || IsSystemTime(val) {
+        return time.Time{}
+    }
+
+    return time.Unix(fit_epoch + int64(val), 0).UTC()
+}
+
+// LocalDateTime converts a FIT local_date_time value, which counts
+// seconds from the FIT epoch in the device's time zone.  The result has
+// the same clock reading in UTC; FitSubFile.Location() gives the zone.
+func LocalDateTime(val uint32) time.Time {
+    return DateTime(val)
+}
+
+// IsSystemTime reports whether a date_time value is relative to the
+// device being powered on rather than to the FIT epoch
+func IsSystemTime(val uint32) bool {
+    return val < min_date_time
+}
+
+// SystemTime returns a relative date_time value as the time since the
+// device was powered on
+func SystemTime(val uint32) time.Duration {
+    return time.Duration(val) * time.Second
+}
+
+// Time converts a date_time value from the file to a time in the file's
+// local time zone, or in UTC if the zone is not known.  Relative system
+// times are converted using the device_settings utc_offset; the zero time
+// is returned if the file does not have one.
+func (sub *FitSubFile) Time(val uint32) time.Time {
+    if !is_valid_uint32(val) {
+        return time.Time{}
+    }
+
+    if IsSystemTime(val) {
+        if !sub.have_utc_offset {
+            return time.Time{}
+        }
+
+        val += sub.utc_offset
+    }
+
+    tm := DateTime(val)
+    if loc := sub.Location(); loc != nil && !tm.IsZero() {
+        tm = tm.In(loc)
+    }
+
+    return tm
+}
+
+// Location returns the time zone of the activity, taken from the
+// difference between its local_timestamp and timestamp, or nil if the
+// file has no activity message with both
+func (sub *FitSubFile) Location() *time.Location {
+    return sub.zone
+}
+
+// Location returns the time zone in which the activity was recorded, or
+// nil if the message doesn't have both a timestamp and a local_timestamp
+func (msg *MsgActivity) Location() *time.Location {
+    if !is_valid_uint32(msg.Timestamp) ||
+        !is_valid_uint32(msg.LocalTimestamp) ||
+        IsSystemTime(msg.Timestamp) || IsSystemTime(msg.LocalTimestamp) {
+        return nil
+    }
+
+    // zones are offset from UTC by a whole number of quarter hours
+    offset := float64(msg.LocalTimestamp) - float64(msg.Timestamp)
+    secs := int(math.Floor(offset / 900 + 0.5)) * 900
+
+    sign, abs := '+', secs
+    if secs < 0 {
+        sign, abs = '-', -secs
+    }
+
+    return time.FixedZone(fmt.Sprintf("UTC%c%02d:%02d", sign, abs / 3600,
+        abs % 3600 / 60), secs)
+}
+
+// note the messages which describe the file's time zone and system time
+func (sub *FitSubFile) trackTime(msg FitMsg) {
+    switch tmsg := msg.(type) {
+    case *MsgDeviceSettings:
+        if is_valid_uint32(tmsg.UTCOffset) {
+            sub.utc_offset = tmsg.UTCOffset
+            sub.have_utc_offset = true
+        }
+    case *MsgActivity:
+        if loc := tmsg.Location(); loc != nil {
+            sub.zone = loc
+        }
+    }
+}
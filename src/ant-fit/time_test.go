@@ -0,0 +1,153 @@
+package ant_fit
+
+import (
+    "testing"
+    "time"
+)
+
+func TestDateTime(t *testing.T) {
+    tests := []struct {
+        val uint32
+        want time.Time
+    }{
+        {800000000, time.Date(2015, 5, 8, 6, 13, 20, 0, time.UTC)},
+        {min_date_time, time.Date(1998, 7, 3, 21, 24, 16, 0, time.UTC)},
+        // system times and invalid values have no date
+        {min_date_time - 1, time.Time{}},
+        {0, time.Time{}},
+        {invalid_uint32, time.Time{}},
+    }
+
+    for _, tst := range tests {
+        got := DateTime(tst.val)
+        if !got.Equal(tst.want) || got.Location() != time.UTC {
+            t.Errorf("DateTime(%d) = %v, not %v", tst.val, got, tst.want)
+        }
+    }
+
+    if !IsSystemTime(1000) || IsSystemTime(800000000) {
+        t.Error("IsSystemTime() is wrong")
+    }
+    if SystemTime(90) != 90 * time.Second {
+        t.Errorf("SystemTime(90) = %v", SystemTime(90))
+    }
+}
+
+// the zone is the difference between local_timestamp and timestamp,
+// rounded to a quarter hour
+func TestActivityLocation(t *testing.T) {
+    const ts = 800000000
+
+    tests := []struct {
+        timestamp uint32
+        local uint32
+        name string
+        offset int
+    }{
+        {ts, ts, "UTC+00:00", 0},
+        {ts, ts + 3600, "UTC+01:00", 3600},
+        {ts, ts - 5 * 3600, "UTC-05:00", -5 * 3600},
+        // the clocks are read a few seconds apart
+        {ts, ts + 19800 + 7, "UTC+05:30", 19800},
+        {ts, ts + 20700 - 400, "UTC+05:45", 20700},
+        {ts, ts - 12600 + 440, "UTC-03:30", -12600},
+        {ts, invalid_uint32, "", 0},
+        {invalid_uint32, ts, "", 0},
+        {1000, 4600, "", 0},
+    }
+
+    for _, tst := range tests {
+        msg := &MsgActivity{Timestamp: tst.timestamp,
+            LocalTimestamp: tst.local}
+
+        loc := msg.Location()
+        if tst.name == "" {
+            if loc != nil {
+                t.Errorf("%d/%d: zone %v, not nil", tst.timestamp, tst.local,
+                    loc)
+            }
+            continue
+        }
+
+        if loc == nil {
+            t.Errorf("%d/%d: no zone", tst.timestamp, tst.local)
+            continue
+        }
+
+        name, offset := time.Unix(0, 0).In(loc).Zone()
+        if name != tst.name || offset != tst.offset {
+            t.Errorf("%d/%d: zone %s %d, not %s %d", tst.timestamp,
+                tst.local, name, offset, tst.name, tst.offset)
+        }
+    }
+}
+
+// an activity recorded at UTC-05:00 whose first records have system times
+func testZoneActivity(utc_offset bool) []byte {
+    const start = 800000000
+
+    w := new(testWriter)
+    w.define(0, 0, false, testField{0, 1, 0x00}, testField{4, 4, 0x86})
+    w.data(0, 4, start)
+
+    if utc_offset {
+        w.define(1, 2, false, testField{1, 4, 0x86})
+        w.data(1, start - 1000)
+    }
+
+    w.define(2, 20, false, testField{253, 4, 0x86}, testField{3, 1, 0x02})
+    w.data(2, 900, 120)
+    w.data(2, start, 121)
+
+    w.define(3, 34, false, testField{253, 4, 0x86}, testField{5, 4, 0x86})
+    w.data(3, start + 60, start + 60 - 5 * 3600 + 2)
+
+    return w.build()
+}
+
+func TestSubFileTime(t *testing.T) {
+    sub := mustDecode(t, testZoneActivity(true)).SubFiles()[0]
+
+    loc := sub.Location()
+    if loc == nil {
+        t.Fatal("no zone")
+    } else if _, offset := time.Unix(0, 0).In(loc).Zone(); offset != -18000 {
+        t.Errorf("zone offset %d, not -18000", offset)
+    }
+
+    // 2015-05-08 06:13:20 UTC
+    tm := sub.Time(800000000)
+    if tm.Location() != loc || tm.Hour() != 1 || tm.Minute() != 13 ||
+        !tm.Equal(DateTime(800000000)) {
+        t.Errorf("Time(800000000) = %v", tm)
+    }
+
+    // system time 900 is 100 seconds before the start
+    tm = sub.Time(900)
+    if !tm.Equal(DateTime(800000000 - 100)) || tm.Location() != loc {
+        t.Errorf("Time(900) = %v", tm)
+    }
+
+    if tm = sub.Time(invalid_uint32); !tm.IsZero() {
+        t.Errorf("Time(invalid) = %v", tm)
+    }
+}
+
+// without device_settings system times can't be converted, and without
+// an activity times are in UTC
+func TestSubFileTimeUnknown(t *testing.T) {
+    sub := mustDecode(t, testZoneActivity(false)).SubFiles()[0]
+
+    if tm := sub.Time(900); !tm.IsZero() {
+        t.Errorf("Time(900) without utc_offset = %v", tm)
+    }
+
+    sub = mustDecode(t, testActivity(false).build()).SubFiles()[0]
+    if loc := sub.Location(); loc != nil {
+        t.Errorf("zone %v without an activity", loc)
+    }
+    if tm := sub.Time(800000000); tm.Location() != time.UTC ||
+        !tm.Equal(DateTime(800000000)) {
+        t.Errorf("Time(800000000) without a zone = %v", tm)
+    }
+}
@@ -0,0 +1,83 @@
+package ant_fit
+
+import (
+    "fmt"
+    "math"
+)
+
+// FitUnknownField holds a field which is not described by the profile,
+// exactly as it appeared in the file
+type FitUnknownField struct {
+    Num byte
+    BaseType byte
+    LittleEndian bool
+    Data []byte
+}
+
+func (fld *FitUnknownField) String() string {
+    return fmt.Sprintf("#%d %s %x", fld.Num,
+        get_base_type_name(fld.BaseType), fld.Data)
+}
+
+// state shared by all messages
+type msgBase struct {
+    unknown []*FitUnknownField
+    developer []*FitDeveloperField
+
+    // running totals of accumulated fields
+    totals []fieldTotal
+}
+
+// running total of an accumulated field
+type fieldTotal struct {
+    num byte
+    val uint64
+}
+
+func (base *msgBase) base() *msgBase {
+    return base
+}
+
+// UnknownFields returns the fields which were not described by the profile
+func (base *msgBase) UnknownFields() []*FitUnknownField {
+    return base.unknown
+}
+
+// DeveloperFields returns the FIT 2.0 developer fields attached to the message
+func (base *msgBase) DeveloperFields() []*FitDeveloperField {
+    return base.developer
+}
+
+// Accumulated returns NaN for messages without accumulated fields
+func (base *msgBase) Accumulated(name string) float64 {
+    return math.NaN()
+}
+
+// SubField returns an empty string for messages without subfields
+func (base *msgBase) SubField(name string) string {
+    return ""
+}
+
+// scaled running total for field 'num', or NaN if it was not set
+func (base *msgBase) total(num byte, scale float64, offset float64) float64 {
+    for _, tot := range base.totals {
+        if tot.num == num {
+            return float64(tot.val) / scale - offset
+        }
+    }
+
+    return math.NaN()
+}
+
+func (base *msgBase) addUnknown(def *FitDefinition, fld *FitFieldDefinition,
+    buf []byte) {
+    ufld := new(FitUnknownField)
+
+    ufld.Num = fld.Num
+    ufld.BaseType = fld.BaseType
+    ufld.LittleEndian = def.LittleEndian
+    ufld.Data = make([]byte, len(buf))
+    copy(ufld.Data, buf)
+
+    base.unknown = append(base.unknown, ufld)
+}
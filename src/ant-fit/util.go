@@ -1,15 +1,15 @@
 package ant_fit
 
 import (
-    "errors"
+    "encoding/binary"
     "fmt"
-    "io"
+    "math"
     //"sort"
 )
 
 // general utility functions
 
-var base_type_names = [14]string{
+var base_type_names = [17]string{
     "enum",
     "int8",
     "uint8",
@@ -24,21 +24,94 @@ var base_type_names = [14]string{
     "uint16z",
     "uint32z",
     "byte",
+    "int64",
+    "uint64",
+    "uint64z",
+}
+
+// FIT's "invalid" value for each base type, as an unsigned value of the
+// base type's size
+var base_type_invalid = [17]uint64{
+    0xff,
+    0x7f,
+    0xff,
+    0x7fff,
+    0xffff,
+    0x7fffffff,
+    0xffffffff,
+    0x00,
+    0xffffffff,
+    0xffffffffffffffff,
+    0x00,
+    0x0000,
+    0x00000000,
+    0xff,
+    0x7fffffffffffffff,
+    0xffffffffffffffff,
+    0x0000000000000000,
+}
+
+// invalid values used for fields which were not present in a message
+const (
+    invalid_enum byte = 0xff
+    invalid_int8 int8 = 0x7f
+    invalid_uint8 uint8 = 0xff
+    invalid_int16 int16 = 0x7fff
+    invalid_uint16 uint16 = 0xffff
+    invalid_int32 int32 = 0x7fffffff
+    invalid_uint32 uint32 = 0xffffffff
+    invalid_string string = ""
+    invalid_uint8z uint8 = 0x00
+    invalid_uint16z uint16 = 0x0000
+    invalid_uint32z uint32 = 0x00000000
+    invalid_byte byte = 0xff
+)
+
+var invalid_float32 = math.Float32frombits(0xffffffff)
+var invalid_float64 = math.Float64frombits(0xffffffffffffffff)
+
+func is_valid_enum(val byte) bool { return val != invalid_enum }
+func is_valid_int8(val int8) bool { return val != invalid_int8 }
+func is_valid_uint8(val uint8) bool { return val != invalid_uint8 }
+func is_valid_int16(val int16) bool { return val != invalid_int16 }
+func is_valid_uint16(val uint16) bool { return val != invalid_uint16 }
+func is_valid_int32(val int32) bool { return val != invalid_int32 }
+func is_valid_uint32(val uint32) bool { return val != invalid_uint32 }
+func is_valid_string(val string) bool { return val != invalid_string }
+func is_valid_uint8z(val uint8) bool { return val != invalid_uint8z }
+func is_valid_uint16z(val uint16) bool { return val != invalid_uint16z }
+func is_valid_uint32z(val uint32) bool { return val != invalid_uint32z }
+func is_valid_byte(val byte) bool { return val != invalid_byte }
+
+func is_valid_float32(val float32) bool {
+    return math.Float32bits(val) != math.Float32bits(invalid_float32)
+}
+
+func is_valid_float64(val float64) bool {
+    return math.Float64bits(val) != math.Float64bits(invalid_float64)
+}
+
+func get_base_type_name(base_type byte) string {
+    if int(base_type) < len(base_type_names) {
+        return base_type_names[base_type]
+    }
+
+    return fmt.Sprintf("unknown#%d", base_type)
 }
 
 func get_type_name(fld *FitFieldDefinition) string {
-    if fld.base_type >= 0 &&
-        int(fld.base_type) < len(base_type_names) {
-        return base_type_names[fld.base_type]
+    if fld.BaseType >= 0 &&
+        int(fld.BaseType) < len(base_type_names) {
+        return base_type_names[fld.BaseType]
     }
 
-    if fld.num == 253 && fld.base_type == 6 {
+    if fld.Num == 253 && fld.BaseType == 6 {
         return "timestamp"
-    } else if fld.num == 254 {
+    } else if fld.Num == 254 {
         return "message_index"
     }
 
-    return fmt.Sprintf("unknown#%d", fld.num)
+    return fmt.Sprintf("unknown#%d", fld.Num)
 }
 
 func addCRC(crc uint16, val byte) uint16 {
@@ -62,31 +135,25 @@ func addCRC(crc uint16, val byte) uint16 {
     return crc
 }
 
-func checkCRC(rdr io.Reader, data []byte) error {
-    buf := make([]byte, 2)
-
-    n, err := rdr.Read(buf)
-    if err != nil {
-        return err
-    } else if n != len(buf) {
-        errfmt := "Tried to read %d byte CRC, only read %d bytes"
-        return errors.New(fmt.Sprintf(errfmt, len(buf), n))
+func computeCRC(crc uint16, data []byte) uint16 {
+    for i := 0; i < len(data); i++ {
+        crc = addCRC(crc, data[i])
     }
 
-    goodCRC, _ := get_uint16_pos(buf, 0)
+    return crc
+}
+
+// verify the header CRC in 'crcbuf' against the header bytes in 'data'
+func checkCRC(data []byte, crcbuf []byte) error {
+    goodCRC, _ := get_uint16_pos(crcbuf, 0, binary.LittleEndian)
     if goodCRC == 0 {
         // CRC is not set, so we're done
         return nil
     }
 
-    var crc uint16
-    for i := 0; i < len(data); i++ {
-        crc = addCRC(crc, data[i])
-    }
-
+    crc := computeCRC(0, data)
     if goodCRC != crc {
-        errfmt := "Bad header CRC: %04x != %04x"
-        return errors.New(fmt.Sprintf(errfmt, crc, goodCRC))
+        return &CRCError{Header: true, Expected: goodCRC, Computed: crc}
     }
 
     return nil
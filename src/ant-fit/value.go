@@ -0,0 +1,133 @@
+package ant_fit
+
+import (
+    "encoding/binary"
+    "fmt"
+    "math"
+    "strconv"
+)
+
+// Value holds one field of a message, as returned by FitMsg.Field(),
+// FitMsg.FieldByNum() and FitMsg.Fields()
+type Value struct {
+    // profile name of the field, or empty for fields not in the profile
+    Name string
+    Num byte
+    BaseType byte
+
+    // the decoded value, with the same type as the message's struct field
+    // (e.g. uint16, []uint8, string or Manufacturer).  Fields which are not
+    // in the profile hold their bytes exactly as they appeared in the file.
+    Raw interface{}
+
+    // the value after applying the profile's scale and offset, or NaN
+    // if the field is not set or not numeric
+    Scaled float64
+    Units string
+
+    Valid bool
+
+    // true if Raw holds the field's bytes rather than decoded values
+    undecoded bool
+}
+
+func (val Value) String() string {
+    name := val.Name
+    if name == "" {
+        name = fmt.Sprintf("#%d", val.Num)
+    }
+
+    if !val.Valid {
+        return name + " unset"
+    }
+
+    var valstr string
+    if str, ok := val.Raw.(fmt.Stringer); ok {
+        // values of profile types are shown by name
+        valstr = str.String()
+    } else if !math.IsNaN(val.Scaled) {
+        valstr = strconv.FormatFloat(val.Scaled, 'f', -1, 64)
+    } else if data, ok := val.Raw.([]byte); ok &&
+        (val.BaseType == 13 || val.undecoded) {
+        // []uint8 is also []byte, so only byte fields are shown in hex
+        valstr = fmt.Sprintf("%x", data)
+    } else {
+        valstr = fmt.Sprintf("%v", val.Raw)
+    }
+
+    if val.Units == "" {
+        return fmt.Sprintf("%s %s", name, valstr)
+    }
+
+    return fmt.Sprintf("%s %s %s", name, valstr, val.Units)
+}
+
+// values of the fields numbered 'nums' which are set, followed by any
+// fields which are not in the profile
+func fieldValues(msg FitMsg, nums []byte) []Value {
+    var vals []Value
+    for _, num := range nums {
+        if val := msg.FieldByNum(num); val.Valid {
+            vals = append(vals, val)
+        }
+    }
+
+    for _, ufld := range msg.UnknownFields() {
+        vals = append(vals, ufld.value())
+    }
+
+    return vals
+}
+
+// value of a field which is not in the profile
+func (ufld *FitUnknownField) value() Value {
+    order := binary.ByteOrder(binary.BigEndian)
+    if ufld.LittleEndian {
+        order = binary.LittleEndian
+    }
+
+    val := Value{Num: ufld.Num, BaseType: ufld.BaseType, Raw: ufld.Data,
+        Scaled: math.NaN(), undecoded: true}
+
+    val.Valid = is_valid_raw(ufld.Data, ufld.BaseType, order)
+    if val.Valid && ufld.BaseType != 7 && ufld.BaseType != 13 &&
+        len(ufld.Data) == get_base_size(ufld.BaseType) {
+        val.Scaled = get_raw_float(ufld.Data, ufld.BaseType, order)
+    }
+
+    return val
+}
+
+// value of the unknown field numbered 'num', if the message held one
+func (base *msgBase) unknownValue(num byte) Value {
+    for _, ufld := range base.unknown {
+        if ufld.Num == num {
+            return ufld.value()
+        }
+    }
+
+    return Value{Num: num, Scaled: math.NaN()}
+}
+
+// value of the developer field called 'name', if the message held one
+func (base *msgBase) developerValue(name string) Value {
+    for _, dfld := range base.developer {
+        if dfld.Name != name {
+            continue
+        }
+
+        val := Value{Name: name, Num: dfld.Num, BaseType: dfld.BaseType,
+            Raw: dfld.Data, Scaled: dfld.Value(), Units: dfld.Units,
+            undecoded: true}
+        if dfld.BaseType == 7 {
+            val.Raw = dfld.StringValue()
+            val.Valid = dfld.StringValue() != ""
+        } else {
+            val.Valid = !math.IsNaN(val.Scaled)
+        }
+
+        return val
+    }
+
+    return Value{Name: name, Scaled: math.NaN()}
+}
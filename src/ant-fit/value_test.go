@@ -0,0 +1,123 @@
+package ant_fit
+
+import (
+    "math"
+    "testing"
+)
+
+func TestValueString(t *testing.T) {
+    tests := []struct {
+        val Value
+        want string
+    }{
+        {Value{Name: "speed_1s", BaseType: 2, Raw: []uint8{48, 32},
+            Scaled: math.NaN(), Units: "m/s", Valid: true},
+            "speed_1s [48 32] m/s"},
+        {Value{Name: "compressed_speed_distance", BaseType: 13,
+            Raw: []byte{0x31, 0xa1, 0x0f}, Scaled: math.NaN(), Valid: true},
+            "compressed_speed_distance 31a10f"},
+        {Value{Name: "event_type", BaseType: 0, Raw: EventType(1),
+            Scaled: 1, Valid: true}, "event_type stop"},
+        {Value{Name: "speed", BaseType: 4, Raw: uint16(3050),
+            Scaled: 3.05, Units: "m/s", Valid: true}, "speed 3.05 m/s"},
+        {Value{Name: "speed", BaseType: 4, Raw: uint16(0xffff),
+            Scaled: math.NaN(), Units: "m/s"}, "speed unset"},
+    }
+
+    for _, tst := range tests {
+        if got := tst.val.String(); got != tst.want {
+            t.Errorf("got %q, not %q", got, tst.want)
+        }
+    }
+}
+
+// fields outside the profile hold their bytes, which are shown in hex
+// whatever their base type
+func TestUnknownValueString(t *testing.T) {
+    ufld := &FitUnknownField{Num: 99, BaseType: 4, LittleEndian: true,
+        Data: []byte{0x01, 0x00, 0xff, 0xff}}
+
+    if got := ufld.value().String(); got != "#99 0100ffff" {
+        t.Errorf("got %q", got)
+    }
+}
+
+// the profile's scale, offset and units are applied to decoded fields
+func TestScaledFields(t *testing.T) {
+    w := new(testWriter)
+    w.define(0, 20, false, testField{253, 4, 0x86}, testField{2, 2, 0x84},
+        testField{3, 1, 0x02}, testField{5, 4, 0x86}, testField{6, 2, 0x84},
+        testField{9, 2, 0x83}, testField{11, 4, 0x85})
+    w.data(0, 800000000, 3000, 150, 123456, 3050, -250, -1500)
+    w.data(0, 800000001, 0, 0xff, 0, 0, 0x7fff, 0x7fffffff)
+
+    recs := testMessages(mustDecode(t, w.build()), 20)
+    if len(recs) != 2 {
+        t.Fatalf("%d records, not 2", len(recs))
+    }
+
+    rec := recs[0].(*MsgRecord)
+    tests := []struct {
+        name string
+        method float64
+        scaled float64
+        units string
+    }{
+        // altitude is in fifths of a metre from 500m below sea level
+        {"altitude", rec.AltitudeScaled(), 100, "m"},
+        // speed is in mm/s
+        {"speed", rec.SpeedScaled(), 3.05, "m/s"},
+        {"distance", rec.DistanceScaled(), 1234.56, "m"},
+        {"grade", rec.GradeScaled(), -2.5, "%"},
+        {"time_from_course", rec.TimeFromCourseScaled(), -1.5, "s"},
+        // fields without a scale are unchanged
+        {"heart_rate", 150, 150, "bpm"},
+    }
+
+    for _, tst := range tests {
+        if tst.method != tst.scaled {
+            t.Errorf("%s method gave %v, not %v", tst.name, tst.method,
+                tst.scaled)
+        }
+        if got := rec.Scaled(tst.name); got != tst.scaled {
+            t.Errorf("Scaled(%q) = %v, not %v", tst.name, got, tst.scaled)
+        }
+        if got := rec.Units(tst.name); got != tst.units {
+            t.Errorf("Units(%q) = %q, not %q", tst.name, got, tst.units)
+        }
+
+        val := rec.Field(tst.name)
+        if !val.Valid || val.Scaled != tst.scaled || val.Units != tst.units {
+            t.Errorf("Field(%q) is %+v", tst.name, val)
+        }
+    }
+
+    if got := rec.Field("altitude").String(); got != "altitude 100 m" {
+        t.Errorf("altitude is %q", got)
+    }
+
+    // zero is a valid raw value, but not after the offset
+    rec = recs[1].(*MsgRecord)
+    if got := rec.AltitudeScaled(); got != -500 {
+        t.Errorf("altitude of zero is %v, not -500", got)
+    }
+    if got := rec.SpeedScaled(); got != 0 {
+        t.Errorf("speed of zero is %v", got)
+    }
+    for _, name := range []string{"heart_rate", "grade", "time_from_course",
+        "power"} {
+        if got := rec.Scaled(name); !math.IsNaN(got) {
+            t.Errorf("unset %s scaled to %v", name, got)
+        }
+    }
+    if !math.IsNaN(rec.GradeScaled()) {
+        t.Errorf("unset grade scaled to %v", rec.GradeScaled())
+    }
+
+    if got := rec.Scaled("no_such_field"); !math.IsNaN(got) {
+        t.Errorf("unknown field scaled to %v", got)
+    }
+    if got := rec.Units("no_such_field"); got != "" {
+        t.Errorf("unknown field has units %q", got)
+    }
+}
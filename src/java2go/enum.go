@@ -0,0 +1,189 @@
+package java2go
+
+import (
+    "bufio"
+    "errors"
+    "fmt"
+    "os"
+    "path"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+    "unicode"
+)
+
+// profile types, from either Java enums or classes full of constants
+
+var enum_entry_pat = regexp.MustCompile(`^\s+(\w+)\(\(short\)(\d+)\),?\s*$`)
+var const_entry_pat = regexp.MustCompile(`^\s+public\s+static\s+final\s+` +
+    `int\s+(\w+)\s*=\s*(\w+);\s*$`)
+
+type NameEntry struct {
+    name string
+    num int
+}
+
+type Enum struct {
+    class string
+    gotype string
+    list []NameEntry
+}
+
+var enum_cache = make(map[string]*Enum)
+
+// convert a profile name such as "battery_status" to a Java class name
+func className(name string) string {
+    var class []rune
+
+    capitalize := true
+    for _, c := range name {
+        if c == '_' {
+            capitalize = true
+        } else if capitalize {
+            class = append(class, unicode.ToUpper(c))
+            capitalize = false
+        } else {
+            class = append(class, c)
+        }
+    }
+
+    return string(class)
+}
+
+// true if 'dir' holds a profile type class named 'cls'
+func isEnumClass(dir string, cls string) bool {
+    if cls == "" || strings.HasSuffix(cls, "Mesg") {
+        return false
+    }
+
+    _, err := os.Stat(path.Join(dir, cls + ".java"))
+    return err == nil
+}
+
+// load the profile type 'cls', whose values are stored in fields of Go
+// type 'gotype'
+func LoadEnum(dir string, cls string, gotype string) (*Enum, error) {
+    if enum, ok := enum_cache[cls]; ok {
+        // use the widest type any field stores the values in
+        if typeSize(gotype) > typeSize(enum.gotype) {
+            enum.gotype = gotype
+        }
+
+        return enum, nil
+    }
+
+    pathstr := path.Join(dir, cls + ".java")
+    fd, err := os.Open(pathstr)
+    if err != nil {
+        return nil, errors.New(fmt.Sprintf("Cannot open \"%s\"\n", pathstr))
+    }
+    defer fd.Close()
+
+    enum := new(Enum)
+    enum.class = cls
+    enum.gotype = gotype
+
+    scan := bufio.NewScanner(fd)
+    for scan.Scan() {
+        line := scan.Text()
+
+        m := enum_entry_pat.FindStringSubmatch(line)
+        if m == nil {
+            m = const_entry_pat.FindStringSubmatch(line)
+        }
+        if m == nil {
+            continue
+        }
+
+        // skip values defined in terms of other constants
+        val, err := strconv.ParseInt(m[2], 0, 64)
+        if err != nil {
+            continue
+        }
+
+        enum.list = append(enum.list, NameEntry{m[1], int(val)})
+    }
+
+    if err := scan.Err(); err != nil {
+        return nil, err
+    }
+
+    if len(enum.list) == 0 {
+        return nil, errors.New("No values found in " + pathstr)
+    }
+
+    enum_cache[cls] = enum
+    return enum, nil
+}
+
+// all the profile types loaded so far, sorted by name
+func Enums() []*Enum {
+    var list []*Enum
+    for _, enum := range enum_cache {
+        list = append(list, enum)
+    }
+
+    sort.Slice(list, func(i, j int) bool {
+        return list[i].class < list[j].class
+    })
+
+    return list
+}
+
+func typeSize(gotype string) int {
+    switch gotype {
+    case "uint16", "int16":
+        return 2
+    case "uint32", "int32":
+        return 4
+    }
+
+    return 1
+}
+
+func (enum *Enum) Name() string {
+    return enum.class
+}
+
+func (enum *Enum) constName(entry NameEntry) string {
+    return enum.class + className(strings.ToLower(entry.name))
+}
+
+func (enum *Enum) PrintType() {
+    fmt.Printf("// %s type\n", convertClass(enum.class))
+    fmt.Println()
+    fmt.Printf("type %s %s\n", enum.class, enum.gotype)
+    fmt.Println()
+
+    fmt.Println("const (")
+    for _, entry := range enum.list {
+        fmt.Printf("    %s %s = %d\n", enum.constName(entry), enum.class,
+            entry.num)
+    }
+    fmt.Println(")")
+    fmt.Println()
+
+    fmt.Printf("func (val %s) String() string {\n", enum.class)
+    fmt.Println("    switch val {")
+    seen := make(map[int]bool)
+    for _, entry := range enum.list {
+        // some values have more than one name
+        if seen[entry.num] {
+            continue
+        }
+        seen[entry.num] = true
+
+        fmt.Printf("    case %s: return \"%s\"\n", enum.constName(entry),
+            strings.ToLower(entry.name))
+    }
+    fmt.Printf("    default: return fmt.Sprintf(\"unknown#%%d\", val)\n")
+    fmt.Println("    }")
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Printf("func (val %s) MarshalText() ([]byte, error) {\n", enum.class)
+    fmt.Println("    return []byte(val.String()), nil")
+    fmt.Println("}")
+    fmt.Println()
+}
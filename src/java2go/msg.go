@@ -29,70 +29,6 @@ func convertClass(java string) string {
     return string(newname)
 }
 
-var name_entry_pat = regexp.MustCompile(`^\s+(.*)\(\(short\)(\d+)\),\s*$`)
-
-type NameEntry struct {
-    name string
-    num int
-}
-
-type NameFunc struct {
-    class string
-    name string
-    list []NameEntry
-}
-
-func NewNameFunc(cls string, dir string, file string,
-    name string) (*NameFunc, error) {
-    pathstr := path.Join(dir, file + ".java")
-    fd, err := os.Open(pathstr)
-    if err != nil {
-        return nil, errors.New(fmt.Sprintf("Cannot open \"%s\"\n", pathstr))
-    }
-    defer fd.Close()
-
-    namefunc := new(NameFunc)
-    namefunc.class = cls
-    namefunc.name = convertClass(name)
-
-    scan := bufio.NewScanner(fd)
-    for scan.Scan() {
-        line := scan.Text()
-
-        m := name_entry_pat.FindStringSubmatch(line)
-        if m != nil {
-            val, err := strconv.ParseInt(m[2], 0, 32)
-            if err != nil {
-                return nil, err
-            }
-
-            namefunc.list = append(namefunc.list, NameEntry{m[1], int(val)})
-        }
-    }
-
-    return namefunc, nil
-}
-
-func (nf *NameFunc) IsName(name string) bool {
-    return name == nf.name
-}
-
-func (nf *NameFunc) PrintFunc() {
-    lowcls := nf.name
-
-    fmt.Printf("func (msg *Msg%s) %s_name() string {\n", nf.class, lowcls)
-    fmt.Printf("    switch msg.%s {\n", lowcls)
-    for _, entry := range nf.list {
-        fmt.Printf("    case %d: return \"%s\"\n", entry.num,
-            strings.ToLower(entry.name))
-    }
-    fmt.Printf("    default: return fmt.Sprintf(\"unknown#%%d\", msg.%s)\n",
-        lowcls)
-    fmt.Println("    }")
-    fmt.Println("}")
-    fmt.Println()
-}
-
 var base_type_names = [][]string{
     []string{"enum", "byte"},
     []string{"int8", "int8"},
@@ -128,6 +64,16 @@ func fitType(num int, base_type int) string {
     return fmt.Sprintf("unknown#%d", base_type)
 }
 
+func baseTypeName(base_type int) string {
+    low_type := base_type & 0x7f
+
+    if low_type >= 0 && low_type < len(base_type_names) {
+        return base_type_names[low_type][0]
+    }
+
+    return fmt.Sprintf("unknown#%d", base_type)
+}
+
 func goType(num int, base_type int) string {
     low_type := base_type & 0x7f
 
@@ -138,14 +84,37 @@ func goType(num int, base_type int) string {
     return fmt.Sprintf("unknown#%d", base_type)
 }
 
+// part of a field which is unpacked into another field
+type Component struct {
+    num int
+    accumulate bool
+    bits int
+    scale float32
+    offset float32
+}
+
 type Field struct {
     name string
+    profile_name string
+    goname string
     num int
     ftype int
     scale float32
     offset float32
     units string
     accumulated bool
+    array bool
+    enum *Enum
+    components []*Component
+
+    // seconds since the FIT epoch, in UTC or in the device's local time
+    date_time bool
+    local_time bool
+
+    // alternate interpretations of the field, chosen by other fields
+    subfields []*Field
+    // for a subfield, the (field number, value) pairs which select it
+    refs [][2]int
 }
 
 var short_name_pairs = [][]string{
@@ -178,10 +147,60 @@ var short_name_pairs = [][]string{
     []string{"ware_vers", ""},
 }
 
+// abbreviations which stay in upper case in Go names
+var initialisms = map[string]string{
+    "ant": "ANT",
+    "gps": "GPS",
+    "hr": "HR",
+    "hrm": "HRM",
+    "hrv": "HRV",
+    "id": "ID",
+    "met": "MET",
+    "sdm": "SDM",
+    "utc": "UTC",
+}
+
+// convert a profile name such as "avg_heart_rate" to a Go name
+func exportName(name string) string {
+    var goname string
+    for _, word := range strings.Split(name, "_") {
+        if word == "" {
+            continue
+        }
+
+        if upper, ok := initialisms[word]; ok {
+            goname += upper
+        } else {
+            goname += strings.ToUpper(word[:1]) + word[1:]
+        }
+    }
+
+    return goname
+}
+
+// methods of every message, which fields can't be named after
+var msg_methods = map[string]bool{
+    "Name": true,
+    "Text": true,
+    "IsSet": true,
+    "Scaled": true,
+    "Units": true,
+    "Accumulated": true,
+    "SubField": true,
+    "UnknownFields": true,
+    "DeveloperFields": true,
+    "Field": true,
+    "FieldByNum": true,
+    "Fields": true,
+    "GlobalNum": true,
+}
+
 func NewField(flds []string) (*Field, error) {
     fld := new(Field)
 
     fld.name = strings.Trim(flds[0], `'"`)
+    fld.profile_name = fld.name
+    fld.goname = exportName(fld.name)
     if fld.name == "type" {
         fld.name = "msgtype"
     }
@@ -218,8 +237,8 @@ func NewField(flds []string) (*Field, error) {
     return fld, nil
 }
 
-func (fld *Field) FormatString(has_func bool) string {
-    if has_func || fld.ftype == 7 {
+func (fld *Field) FormatString() string {
+    if fld.enum != nil || fld.ftype == 7 {
         return "%s"
     } else if fld.ftype == 8 || fld.ftype == 9 {
         return "%f"
@@ -229,11 +248,179 @@ func (fld *Field) FormatString(has_func bool) string {
 }
 
 func (fld *Field) GoType() string {
+    if fld.array {
+        return "[]" + goType(fld.num, fld.ftype)
+    }
+
+    if fld.enum != nil {
+        return fld.enum.Name()
+    }
+
     return goType(fld.num, fld.ftype)
 }
 
+// expression which extracts the field's value from 'buf'
+func (fld *Field) GetExpr() string {
+    expr := fld.GetFunc() + "(buf, fld, order)"
+    if fld.enum != nil {
+        expr = fld.enum.Name() + "(" + expr + ")"
+    }
+
+    return expr
+}
+
+// name of the function which extracts this field's value from a buffer
+func (fld *Field) GetFunc() string {
+    name := "get_" + baseTypeName(fld.ftype)
+    if fld.array {
+        name += "_array"
+    }
+
+    return name
+}
+
+// name of the constant holding this field's invalid value, or an empty
+// string if the Go zero value is already invalid
+func (fld *Field) InvalidValue() string {
+    if fld.array {
+        return ""
+    }
+
+    switch baseTypeName(fld.ftype) {
+    case "string", "uint8z", "uint16z", "uint32z":
+        return ""
+    }
+
+    if fld.enum != nil {
+        return fld.enum.Name() + "(invalid_" + baseTypeName(fld.ftype) + ")"
+    }
+
+    return "invalid_" + baseTypeName(fld.ftype)
+}
+
+// expression which is true if 'attr' holds a valid value for this field
+func (fld *Field) ValidExpr(attr string) string {
+    if fld.array {
+        return "len(" + attr + ") > 0"
+    }
+
+    return fld.ElemValidExpr(attr)
+}
+
+// expression which is true if 'attr' holds a valid value of the field's
+// base type, for use on a single element of an array field
+func (fld *Field) ElemValidExpr(attr string) string {
+    if fld.enum != nil {
+        attr = goType(fld.num, fld.ftype) + "(" + attr + ")"
+    }
+
+    return "is_valid_" + baseTypeName(fld.ftype) + "(" + attr + ")"
+}
+
+// true if the field holds a number, rather than a string or raw bytes
+func (fld *Field) IsNumeric() bool {
+    switch baseTypeName(fld.ftype) {
+    case "string", "byte":
+        return false
+    }
+
+    return true
+}
+
+// true if the profile scales or offsets the field's raw value
+func (fld *Field) IsScaled() bool {
+    return fld.IsNumeric() && fld.scale != 0 &&
+        (fld.scale != 1 || fld.offset != 0)
+}
+
+// expression converting the raw value in 'attr' to its physical value.
+// This is raw / scale - offset, with the offset applied first so that
+// the result isn't left with rounding noise.
+func (fld *Field) ScaleExpr(attr string) string {
+    expr := "float64(" + attr + ")"
+    if fld.offset != 0 {
+        expr = fmt.Sprintf("(%s - %g)", expr, fld.offset * fld.scale)
+    }
+    if fld.scale != 1 {
+        expr += fmt.Sprintf(" / %g", fld.scale)
+    }
+
+    return expr
+}
+
+// true if the field holds a running total which may roll over
+func (fld *Field) IsAccumulated() bool {
+    return fld.accumulated && !fld.array && fld.IsNumeric()
+}
+
+func (fld *Field) Units() string {
+    return fld.units
+}
+
+// make the field's values belong to the profile type 'cls'
+func (fld *Field) setEnum(dir string, cls string) error {
+    if fld.array || !fld.IsNumeric() {
+        return nil
+    }
+
+    enum, err := LoadEnum(dir, cls, goType(fld.num, fld.ftype))
+    if err != nil {
+        return err
+    }
+
+    fld.enum = enum
+    return nil
+}
+
+// exported Go name of the field
 func (fld *Field) Name() string {
-    return fld.name
+    return fld.goname
+}
+
+// description of the field's raw value for the struct definition
+func (fld *Field) Comment() string {
+    var parts []string
+    if fld.units != "" {
+        parts = append(parts, fld.units)
+    }
+    if fld.IsScaled() {
+        if fld.scale != 1 {
+            parts = append(parts, fmt.Sprintf("scale %g", fld.scale))
+        }
+        if fld.offset != 0 {
+            parts = append(parts, fmt.Sprintf("offset %g", fld.offset))
+        }
+    }
+    if len(fld.subfields) > 0 && !fld.array {
+        parts = append(parts, fmt.Sprintf("see SubField(\"%s\")",
+            fld.profile_name))
+    }
+
+    return strings.Join(parts, ", ")
+}
+
+// name of the method which returns the field's physical value
+func (fld *Field) ScaledFunc() string {
+    return fld.goname + "Scaled"
+}
+
+// true if the field holds a date and time
+func (fld *Field) IsTime() bool {
+    return (fld.date_time || fld.local_time) && !fld.array
+}
+
+// name of the method which returns the field's value as a time.Time
+func (fld *Field) TimeFunc() string {
+    return fld.goname + "Time"
+}
+
+// name of the method which reports the selected subfield
+func (fld *Field) SubfieldFunc() string {
+    return fld.profile_name + "_subfield"
+}
+
+func (fld *Field) ProfileName() string {
+    return fld.profile_name
 }
 
 func (fld *Field) Number() int {
@@ -256,21 +443,67 @@ func (fld *Field) ShortName() string {
 }
 
 func (fld *Field) String() string {
-    return fmt.Sprintf("#%d %s %s scal %.1f off %.1f units %s acc %s",
+    return fmt.Sprintf("#%d %s %s scal %.1f off %.1f units %s acc %v",
         fld.num, fld.name, fitType(fld.num, fld.ftype), fld.scale, fld.offset,
         fld.units, fld.accumulated)
 }
 
 type Message struct {
     cls string
+    global_num int
     flds []*Field
-    namefuncs []*NameFunc
 }
 
 var msg_class_pat = regexp.MustCompile(`^public\s+class\s+(.*)Mesg\s+` +
     `extends\s+Mesg.*$`)
 var msg_field_pat = regexp.MustCompile(`^\s*.*Mesg\.addField\(new\s+` +
     `Field\((.*)\)\);\s*$`)
+var msg_array_pat = regexp.MustCompile(`^\s*return\s+getNumFieldValues\(` +
+    `(\d+),.*$`)
+var msg_subfield_pat = regexp.MustCompile(`^\s*.*\.subfields\.add\(new\s+` +
+    `SubField\((.*)\)\);\s*$`)
+var msg_submap_pat = regexp.MustCompile(`^\s*.*\.subfields\.get\(.*\)\.` +
+    `addMap\((\d+),\s*(\d+)\);\s*$`)
+var msg_getter_num_pat = regexp.MustCompile(`^\s*.*getField\w*Value\(` +
+    `(\d+),.*$`)
+var msg_getter_enum_pat = regexp.MustCompile(`^\s*return\s+(\w+)\.` +
+    `getByValue\(.*$`)
+var msg_getter_datetime_pat = regexp.MustCompile(`^\s*return\s+` +
+    `timestampToDateTime\(.*$`)
+var msg_component_pat = regexp.MustCompile(`^\s*.*\.components\.add\(new\s+` +
+    `FieldComponent\((.*)\)\);.*$`)
+
+func NewComponent(flds []string) (*Component, error) {
+    comp := new(Component)
+
+    num, err := strconv.ParseInt(flds[0], 0, 32)
+    if err != nil {
+        return nil, err
+    }
+    comp.num = int(num)
+
+    comp.accumulate = flds[1] == "true"
+
+    bits, err := strconv.ParseInt(flds[2], 0, 32)
+    if err != nil {
+        return nil, err
+    }
+    comp.bits = int(bits)
+
+    scale, err := strconv.ParseFloat(flds[3], 32)
+    if err != nil {
+        return nil, err
+    }
+    comp.scale = float32(scale)
+
+    offset, err := strconv.ParseFloat(flds[4], 32)
+    if err != nil {
+        return nil, err
+    }
+    comp.offset = float32(offset)
+
+    return comp, nil
+}
 
 func NewMessage(dir string, filename string) (*Message, error) {
     var fullpath string
@@ -305,6 +538,9 @@ func NewMessage(dir string, filename string) (*Message, error) {
 
     msg := new(Message)
 
+    // number of the field read by the most recent getter
+    getter_num := -1
+
     scan := bufio.NewScanner(fd)
     for scan.Scan() {
         line := scan.Text()
@@ -318,6 +554,120 @@ func NewMessage(dir string, filename string) (*Message, error) {
             continue
         }
 
+        // getters for fields of profile types return a Java enum
+        if m := msg_getter_num_pat.FindStringSubmatch(line); m != nil {
+            num, err := strconv.ParseInt(m[1], 0, 32)
+            if err == nil {
+                getter_num = int(num)
+            }
+        }
+        if m := msg_getter_enum_pat.FindStringSubmatch(line); m != nil {
+            if fld := msg.findField(getter_num); fld != nil {
+                err = fld.setEnum(path.Dir(fullpath), m[1])
+                if err != nil {
+                    return nil, err
+                }
+            }
+
+            continue
+        }
+
+        // getters for date_time fields return a Java DateTime
+        if m := msg_getter_datetime_pat.FindStringSubmatch(line); m != nil {
+            if fld := msg.findField(getter_num); fld != nil {
+                fld.date_time = true
+            }
+
+            continue
+        }
+
+        // only array fields have a getNum...() accessor
+        if m := msg_array_pat.FindStringSubmatch(line); m != nil {
+            num, err := strconv.ParseInt(m[1], 0, 32)
+            if err != nil {
+                fmt.Println("Unusable array line:", line)
+                continue
+            }
+
+            for _, fld := range msg.flds {
+                if fld.num == int(num) {
+                    fld.array = true
+                }
+            }
+
+            continue
+        }
+
+        // subfields belong to the most recently added field
+        if m := msg_subfield_pat.FindStringSubmatch(line); m != nil {
+            flds := strings.Split(m[1], ", ")
+            if len(flds) != 5 || len(msg.flds) == 0 {
+                fmt.Println("Bad SubField line:", line)
+                continue
+            }
+
+            fld := msg.flds[len(msg.flds) - 1]
+
+            // a subfield looks like a field without a number
+            sub, err := NewField([]string{flds[0], strconv.Itoa(fld.num),
+                flds[1], flds[2], flds[3], flds[4], "false"})
+            if err != nil {
+                fmt.Println("Unusable SubField line:", line)
+                continue
+            }
+
+            fld.subfields = append(fld.subfields, sub)
+
+            continue
+        }
+
+        if m := msg_submap_pat.FindStringSubmatch(line); m != nil {
+            var fld *Field
+            if len(msg.flds) > 0 {
+                fld = msg.flds[len(msg.flds) - 1]
+            }
+            if fld == nil || len(fld.subfields) == 0 {
+                fmt.Println("Bad addMap line:", line)
+                continue
+            }
+
+            num, err := strconv.Atoi(m[1])
+            if err != nil {
+                fmt.Println("Unusable addMap line:", line)
+                continue
+            }
+            val, err := strconv.Atoi(m[2])
+            if err != nil {
+                fmt.Println("Unusable addMap line:", line)
+                continue
+            }
+
+            sub := fld.subfields[len(fld.subfields) - 1]
+            sub.refs = append(sub.refs, [2]int{num, val})
+
+            continue
+        }
+
+        // components belong to the most recently added field
+        if m := msg_component_pat.FindStringSubmatch(line); m != nil {
+            flds := strings.Split(m[1], ", ")
+            if len(flds) != 5 || len(msg.flds) == 0 {
+                fmt.Println("Bad FieldComponent line:", line)
+                continue
+            }
+
+            comp, err := NewComponent(flds)
+            if err != nil {
+                fmt.Println("Unusable FieldComponent line:", line)
+                continue
+            }
+
+            fld := msg.flds[len(msg.flds) - 1]
+            fld.components = append(fld.components, comp)
+
+            continue
+        }
+
         m := msg_field_pat.FindStringSubmatch(line)
         if m == nil {
             continue
@@ -346,33 +696,129 @@ func NewMessage(dir string, filename string) (*Message, error) {
         return nil, errors.New("Cannot find class name in " + filename)
     }
 
-    if msg.cls == "Event" {
-        nf, err := NewNameFunc(msg.cls, path.Dir(fullpath), "Event", "event")
-        if err != nil {
-            return nil, err
+    for _, fld := range msg.flds {
+        if msg_methods[fld.goname] {
+            fld.goname = msg.cls + fld.goname
         }
-        msg.namefuncs = append(msg.namefuncs, nf)
+        for _, sub := range fld.subfields {
+            if msg_methods[sub.goname] {
+                sub.goname = msg.cls + sub.goname
+            }
+        }
+    }
 
-        nf, err = NewNameFunc(msg.cls, path.Dir(fullpath), "EventType",
-            "event_type")
-        if err != nil {
-            return nil, err
+    // the SDK returns local_date_time fields as plain numbers, so they
+    // can only be recognized by name
+    for _, fld := range msg.flds {
+        if fld.profile_name == "local_timestamp" && !fld.array &&
+            baseTypeName(fld.ftype) == "uint32" {
+            fld.local_time = true
         }
-        msg.namefuncs = append(msg.namefuncs, nf)
-    } else if msg.cls == "FileId" {
-        nf, err := NewNameFunc(msg.cls, path.Dir(fullpath), "File", "msgtype")
-        if err != nil {
-            return nil, err
+    }
+
+    // fields and subfields of the other profile types share their names
+    for _, fld := range msg.flds {
+        if fld.enum == nil && isEnumClass(path.Dir(fullpath),
+            className(fld.profile_name)) {
+            err = fld.setEnum(path.Dir(fullpath), className(fld.profile_name))
+            if err != nil {
+                return nil, err
+            }
+        }
+
+        for _, sub := range fld.subfields {
+            if isEnumClass(path.Dir(fullpath), className(sub.profile_name)) {
+                err = sub.setEnum(path.Dir(fullpath),
+                    className(sub.profile_name))
+                if err != nil {
+                    return nil, err
+                }
+            }
         }
-        msg.namefuncs = append(msg.namefuncs, nf)
     }
 
     return msg, nil
 }
 
-func (msg *Message) hasFunc(fld *Field) bool {
-    for _, nf := range msg.namefuncs {
-        if nf.IsName(fld.Name()) {
+// name of the Java class, without the "Mesg" suffix
+func (msg *Message) ClassName() string {
+    return msg.cls
+}
+
+// set the message's global message number, which is defined in MesgNum
+// rather than in the message's own class
+func (msg *Message) SetGlobalNum(num int) {
+    msg.global_num = num
+}
+
+func (msg *Message) findField(num int) *Field {
+    for _, fld := range msg.flds {
+        if fld.num == num {
+            return fld
+        }
+    }
+
+    return nil
+}
+
+// a latitude and longitude pair in semicircles
+type positionPair struct {
+    fname string
+    lat *Field
+    long *Field
+}
+
+// pairs of fields such as position_lat and position_long which hold a
+// position in semicircles
+func (msg *Message) positionPairs() []*positionPair {
+    var pairs []*positionPair
+    for _, lat := range msg.flds {
+        if lat.units != "semicircles" || lat.array ||
+            !strings.HasSuffix(lat.profile_name, "_lat") {
+            continue
+        }
+
+        prefix := strings.TrimSuffix(lat.profile_name, "_lat")
+
+        var long *Field
+        for _, f := range msg.flds {
+            if f.profile_name == prefix + "_long" &&
+                f.units == "semicircles" && !f.array {
+                long = f
+            }
+        }
+        if long == nil {
+            continue
+        }
+
+        fname := exportName(prefix)
+        if !strings.HasSuffix(fname, "Position") {
+            fname += "Position"
+        }
+
+        // don't hide a field of the same name
+        clash := false
+        for _, f := range msg.flds {
+            if f.goname == fname {
+                clash = true
+            }
+        }
+        if clash {
+            fmt.Fprintf(os.Stderr, "%s position %s clashes with a field\n",
+                msg.cls, fname)
+            continue
+        }
+
+        pairs = append(pairs, &positionPair{fname, lat, long})
+    }
+
+    return pairs
+}
+
+// true if any of the message's fields are unpacked into other fields
+func (msg *Message) HasComponents() bool {
+    for _, fld := range msg.flds {
+        if len(fld.components) > 0 {
             return true
         }
     }
@@ -380,21 +826,254 @@ func (msg *Message) hasFunc(fld *Field) bool {
     return false
 }
 
+// numbers of the fields which hold running totals
+func (msg *Message) AccumulatedFields() []int {
+    var nums []int
+    for _, fld := range msg.flds {
+        if fld.IsAccumulated() {
+            nums = append(nums, fld.num)
+        }
+    }
+
+    return nums
+}
+
+func (msg *Message) ComponentTable() string {
+    return convertClass(msg.cls) + "_components"
+}
+
+func (msg *Message) printComponents() {
+    fmt.Printf("// fields of %s messages which are unpacked into other fields\n",
+        convertClass(msg.cls))
+    fmt.Printf("var %s = map[byte][]*fitComponent{\n", msg.ComponentTable())
+    for _, f := range msg.flds {
+        if len(f.components) == 0 {
+            continue
+        }
+
+        fmt.Printf("    %d: []*fitComponent{\n", f.num)
+        for _, c := range f.components {
+            dest := msg.findField(c.num)
+            if dest == nil {
+                fmt.Fprintf(os.Stderr, "%s field %s has unknown component" +
+                    " #%d\n", msg.cls, f.name, c.num)
+                continue
+            }
+
+            dest_scale := dest.scale
+            if dest_scale == 0 {
+                dest_scale = 1
+            }
+
+            fmt.Printf("        &fitComponent{num: %d, base_type: %d," +
+                " accumulate: %v, bits: %d,\n", c.num, dest.ftype & 0x1f,
+                c.accumulate, c.bits)
+            fmt.Printf("            scale: %g, offset: %g, dest_scale: %g," +
+                " dest_offset: %g}, // %s\n", c.scale, c.offset, dest_scale,
+                dest.offset, dest.profile_name)
+        }
+        fmt.Println("    },")
+    }
+    fmt.Println("}")
+    fmt.Println()
+}
+
+// expression which is true if the message's other fields select 'sub'
+func (msg *Message) subfieldCond(sub *Field) string {
+    var conds []string
+    for _, ref := range sub.refs {
+        rfld := msg.findField(ref[0])
+        if rfld == nil {
+            fmt.Fprintf(os.Stderr, "%s subfield %s refers to unknown" +
+                " field #%d\n", msg.cls, sub.name, ref[0])
+            continue
+        }
+
+        conds = append(conds, fmt.Sprintf("msg.%s == %d", rfld.Name(),
+            ref[1]))
+    }
+
+    if len(conds) == 0 {
+        return "false"
+    }
+
+    return strings.Join(conds, " || ")
+}
+
+// expression which is true if 'sub' is selected and holds a valid value
+func (msg *Message) subfieldValidExpr(f *Field, sub *Field) string {
+    return fmt.Sprintf("msg.%s() == \"%s\" && %s", f.SubfieldFunc(),
+        sub.ProfileName(), f.ValidExpr("msg." + f.Name()))
+}
+
+// statement which returns 'inval' unless 'sub' is selected and valid
+func (msg *Message) printSubfieldCheck(f *Field, sub *Field, inval string) {
+    fmt.Printf("    if msg.%s() != \"%s\" ||\n", f.SubfieldFunc(),
+        sub.ProfileName())
+    fmt.Printf("        !%s {\n", f.ValidExpr("msg." + f.Name()))
+    fmt.Printf("        return %s\n", inval)
+    fmt.Println("    }")
+}
+
+func (msg *Message) printSubfields(f *Field) {
+    fmt.Printf("// name of the subfield which holds the value of %s, or an" +
+        " empty\n", f.ProfileName())
+    fmt.Println("// string if the message's other fields don't select one")
+    fmt.Printf("func (msg *Msg%s) %s() string {\n", msg.cls,
+        f.SubfieldFunc())
+    fmt.Println("    switch {")
+    for _, sub := range f.subfields {
+        fmt.Printf("    case %s: return \"%s\"\n", msg.subfieldCond(sub),
+            sub.ProfileName())
+    }
+    fmt.Println("    default: return \"\"")
+    fmt.Println("    }")
+    fmt.Println("}")
+    fmt.Println()
+
+    for _, sub := range f.subfields {
+        inval := sub.InvalidValue()
+        if inval == "" {
+            inval = "0"
+        }
+
+        fmt.Printf("// %s when it holds %s\n", f.ProfileName(),
+            sub.ProfileName())
+        fmt.Printf("func (msg *Msg%s) %s() %s {\n", msg.cls, sub.Name(),
+            sub.GoType())
+        msg.printSubfieldCheck(f, sub, inval)
+        fmt.Printf("    return %s(msg.%s)\n", sub.GoType(), f.Name())
+        fmt.Println("}")
+        fmt.Println()
+
+        if sub.IsScaled() {
+            if sub.Units() != "" {
+                fmt.Printf("// %s in %s\n", sub.ProfileName(), sub.Units())
+            }
+            fmt.Printf("func (msg *Msg%s) %s() float64 {\n", msg.cls,
+                sub.ScaledFunc())
+            msg.printSubfieldCheck(f, sub, "math.NaN()")
+            fmt.Printf("    return %s\n", sub.ScaleExpr("msg." + f.Name()))
+            fmt.Println("}")
+            fmt.Println()
+        }
+    }
+}
+
+// fields which have subfields
+func (msg *Message) subfieldOwners() []*Field {
+    var owners []*Field
+    for _, f := range msg.flds {
+        if len(f.subfields) > 0 && !f.array {
+            owners = append(owners, f)
+        }
+    }
+
+    return owners
+}
+
+// composite literal describing the value of field 'f'; 'raw', 'valid'
+// and 'scaled' are expressions for the value and its state
+func valueLiteral(f *Field, raw string, valid string, scaled string) string {
+    return fmt.Sprintf("Value{Name: \"%s\", Num: %d, BaseType: %d,\n" +
+        "            Raw: %s, Scaled: %s,\n" +
+        "            Units: \"%s\", Valid: %s}", f.ProfileName(), f.num,
+        f.ftype & 0x1f, raw, scaled, f.Units(), valid)
+}
+
+// expression for the value of field 'f' from the message's Scaled()
+func (fld *Field) scaledValueExpr() string {
+    if !fld.IsNumeric() || fld.array {
+        return "math.NaN()"
+    }
+
+    return fmt.Sprintf("msg.Scaled(\"%s\")", fld.ProfileName())
+}
+
+// print the methods of the generic field interface
+func (msg *Message) printValueFuncs() {
+    fmt.Println("// GlobalNum returns the message's global message number")
+    fmt.Printf("func (msg *Msg%s) GlobalNum() uint16 {\n", msg.cls)
+    fmt.Printf("    return %d\n", msg.global_num)
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Println("// FieldByNum returns the field with the profile's field number 'num'")
+    fmt.Printf("func (msg *Msg%s) FieldByNum(num byte) Value {\n", msg.cls)
+    fmt.Println("    switch num {")
+    for _, f := range msg.flds {
+        attr := "msg." + f.Name()
+
+        fmt.Printf("    case %d:\n", f.num)
+        fmt.Printf("        return %s\n", valueLiteral(f, attr,
+            f.ValidExpr(attr), f.scaledValueExpr()))
+    }
+    fmt.Println("    default: return msg.unknownValue(num)")
+    fmt.Println("    }")
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Println("// Field returns the named field or subfield, or the developer field")
+    fmt.Println("// with that name")
+    fmt.Printf("func (msg *Msg%s) Field(name string) Value {\n", msg.cls)
+    fmt.Println("    switch name {")
+    for _, f := range msg.flds {
+        fmt.Printf("    case \"%s\": return msg.FieldByNum(%d)\n",
+            f.ProfileName(), f.num)
+    }
+    for _, f := range msg.subfieldOwners() {
+        for _, sub := range f.subfields {
+            fmt.Printf("    case \"%s\":\n", sub.ProfileName())
+            fmt.Printf("        return %s\n", valueLiteral(sub,
+                "msg." + sub.Name() + "()", msg.subfieldValidExpr(f, sub),
+                sub.scaledValueExpr()))
+        }
+    }
+    fmt.Println("    default: return msg.developerValue(name)")
+    fmt.Println("    }")
+    fmt.Println("}")
+    fmt.Println()
+
+    var nums []string
+    for _, f := range msg.flds {
+        nums = append(nums, strconv.Itoa(f.num))
+    }
+
+    fmt.Println("// Fields returns the fields which are set, in profile order, followed")
+    fmt.Println("// by any fields which are not in the profile")
+    fmt.Printf("func (msg *Msg%s) Fields() []Value {\n", msg.cls)
+    fmt.Printf("    return fieldValues(msg, []byte{%s})\n",
+        strings.Join(nums, ", "))
+    fmt.Println("}")
+    fmt.Println()
+}
+
 func (msg *Message) PrintFuncs() {
     lowcls := convertClass(msg.cls)
 
     fmt.Printf("// %s message\n", lowcls)
     fmt.Println()
 
+    fmt.Printf("// Msg%s holds the fields of a %s message.  Fields which\n",
+        msg.cls, lowcls)
+    fmt.Println("// were not in the message hold FIT's invalid value; see IsSet().")
     fmt.Printf("type Msg%s struct {\n", msg.cls)
+    fmt.Println("    msgBase")
+    if len(msg.flds) > 0 {
+        fmt.Println()
+    }
     for _, f := range msg.flds {
-        fmt.Printf("    %s %s\n", f.Name(), f.GoType())
+        if cmt := f.Comment(); cmt != "" {
+            fmt.Printf("    %s %s // %s\n", f.Name(), f.GoType(), cmt)
+        } else {
+            fmt.Printf("    %s %s\n", f.Name(), f.GoType())
+        }
     }
     fmt.Println("}")
     fmt.Println()
 
-    for _, nf := range msg.namefuncs {
-        nf.PrintFunc()
+    if msg.HasComponents() {
+        msg.printComponents()
     }
 
     fmt.Printf("func (msg *Msg%s) Name() string {\n", msg.cls)
@@ -402,60 +1081,278 @@ func (msg *Message) PrintFuncs() {
     fmt.Println("}")
     fmt.Println()
 
-    fmt.Printf("func (msg *Msg%s) Text() string {\n", msg.cls)
-    fmtstr := "    return fmt.Sprintf(\"" + lowcls
+    // physical values of fields which the profile scales or offsets
     for _, f := range msg.flds {
-        fldfmt := " " + f.ShortName() + " " + f.FormatString(msg.hasFunc(f))
-        newfmt := fmtstr + fldfmt
-        if len(newfmt) <= 77 {
-            fmtstr = newfmt
+        if !f.IsScaled() {
+            continue
+        }
+
+        attr := "msg." + f.Name()
+
+        if f.Units() != "" {
+            fmt.Printf("// %s in %s\n", f.ProfileName(), f.Units())
+        }
+        if f.array {
+            fmt.Printf("func (msg *Msg%s) %s() []float64 {\n",
+                msg.cls, f.ScaledFunc())
+            fmt.Printf("    vals := make([]float64, len(%s))\n", attr)
+            fmt.Printf("    for i, v := range %s {\n", attr)
+            fmt.Printf("        if !%s {\n", f.ElemValidExpr("v"))
+            fmt.Println("            vals[i] = math.NaN()")
+            fmt.Println("        } else {")
+            fmt.Printf("            vals[i] = %s\n", f.ScaleExpr("v"))
+            fmt.Println("        }")
+            fmt.Println("    }")
+            fmt.Println("    return vals")
+        } else {
+            fmt.Printf("func (msg *Msg%s) %s() float64 {\n",
+                msg.cls, f.ScaledFunc())
+            fmt.Printf("    if !%s {\n", f.ValidExpr(attr))
+            fmt.Println("        return math.NaN()")
+            fmt.Println("    }")
+            fmt.Printf("    return %s\n", f.ScaleExpr(attr))
+        }
+        fmt.Println("}")
+        fmt.Println()
+    }
+
+    // date_time and local_date_time fields as times
+    for _, f := range msg.flds {
+        if !f.IsTime() {
+            continue
+        }
+
+        if f.local_time {
+            fmt.Printf("// %s as a time.Time; see LocalDateTime()\n",
+                f.ProfileName())
+        } else {
+            fmt.Printf("// %s as a time.Time; see DateTime()\n",
+                f.ProfileName())
+        }
+        fmt.Printf("func (msg *Msg%s) %s() time.Time {\n", msg.cls,
+            f.TimeFunc())
+        if f.local_time {
+            fmt.Printf("    return LocalDateTime(msg.%s)\n", f.Name())
         } else {
-            fmt.Println(fmtstr + "\" +")
-            fmtstr = "        \"" + fldfmt
+            fmt.Printf("    return DateTime(msg.%s)\n", f.Name())
         }
+        fmt.Println("}")
+        fmt.Println()
+    }
+
+    // positions from pairs of semicircle fields
+    for _, pair := range msg.positionPairs() {
+        fmt.Printf("// %s and %s in degrees\n", pair.lat.ProfileName(),
+            pair.long.ProfileName())
+        fmt.Printf("func (msg *Msg%s) %s() Position {\n", msg.cls,
+            pair.fname)
+        fmt.Printf("    return PositionFromSemicircles(msg.%s, msg.%s)\n",
+            pair.lat.Name(), pair.long.Name())
+        fmt.Println("}")
+        fmt.Println()
+    }
+
+    for _, f := range msg.subfieldOwners() {
+        msg.printSubfields(f)
     }
-    fmtstr += "\""
+
+    fmt.Printf("func (msg *Msg%s) Text() string {\n", msg.cls)
+    fmt.Printf("    txt := \"%s\"\n", lowcls)
     for _, f := range msg.flds {
         attr := "msg." + f.Name()
-        if msg.hasFunc(f) {
-            attr += "_name()"
+        format := f.FormatString()
+        if f.IsScaled() {
+            attr = "msg." + f.ScaledFunc() + "()"
+            format = "%g"
+        }
+
+        fmt.Printf("    if %s {\n", f.ValidExpr("msg." + f.Name()))
+        if len(f.subfields) > 0 && !f.array {
+            // show the field as whichever subfield is selected
+            fmt.Printf("        switch msg.%s() {\n", f.SubfieldFunc())
+            for _, sub := range f.subfields {
+                sattr := "msg." + sub.Name() + "()"
+                sformat := sub.FormatString()
+                if sub.IsScaled() {
+                    sattr = "msg." + sub.ScaledFunc() + "()"
+                    sformat = "%g"
+                }
+
+                fmt.Printf("        case \"%s\":\n", sub.ProfileName())
+                fmt.Printf("            txt += fmt.Sprintf(\" %s %s\", %s)\n",
+                    sub.ShortName(), sformat, sattr)
+            }
+            fmt.Println("        default:")
+            fmt.Printf("            txt += fmt.Sprintf(\" %s %s\", %s)\n",
+                f.ShortName(), format, attr)
+            fmt.Println("        }")
+        } else {
+            fmt.Printf("        txt += fmt.Sprintf(\" %s %s\", %s)\n",
+                f.ShortName(), format, attr)
         }
+        fmt.Println("    }")
+    }
+    fmt.Println("    return txt")
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Println("// IsSet reports whether the named field held a valid value")
+    fmt.Printf("func (msg *Msg%s) IsSet(name string) bool {\n", msg.cls)
+    fmt.Println("    switch name {")
+    for _, f := range msg.flds {
+        fmt.Printf("    case \"%s\": return %s\n", f.ProfileName(),
+            f.ValidExpr("msg." + f.Name()))
+    }
+    for _, f := range msg.subfieldOwners() {
+        for _, sub := range f.subfields {
+            fmt.Printf("    case \"%s\":\n", sub.ProfileName())
+            fmt.Printf("        return %s\n", msg.subfieldValidExpr(f, sub))
+        }
+    }
+    fmt.Println("    default: return false")
+    fmt.Println("    }")
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Println("// Scaled returns the named field's value after applying the profile's")
+    fmt.Println("// scale and offset, or NaN if the field is not set or not numeric")
+    fmt.Printf("func (msg *Msg%s) Scaled(name string) float64 {\n", msg.cls)
+    fmt.Println("    switch name {")
+    for _, f := range msg.flds {
+        if !f.IsNumeric() || f.array {
+            continue
+        }
+
+        attr := "msg." + f.Name()
 
-        newfld := ", " + attr
-        newfmt := fmtstr + newfld
-        if len(newfmt) <= 79 {
-            fmtstr = newfmt
+        fmt.Printf("    case \"%s\":\n", f.ProfileName())
+        if f.IsScaled() {
+            fmt.Printf("        return msg.%s()\n", f.ScaledFunc())
         } else {
-            fmt.Println(fmtstr + ",")
-            fmtstr = "        " + attr
+            fmt.Printf("        if %s {\n", f.ValidExpr(attr))
+            fmt.Printf("            return float64(%s)\n", attr)
+            fmt.Println("        }")
+        }
+    }
+    for _, f := range msg.subfieldOwners() {
+        for _, sub := range f.subfields {
+            if !sub.IsNumeric() {
+                continue
+            }
+
+            fmt.Printf("    case \"%s\":\n", sub.ProfileName())
+            if sub.IsScaled() {
+                fmt.Printf("        return msg.%s()\n", sub.ScaledFunc())
+            } else {
+                fmt.Printf("        if %s {\n", msg.subfieldValidExpr(f, sub))
+                fmt.Printf("            return float64(msg.%s)\n", f.Name())
+                fmt.Println("        }")
+            }
+        }
+    }
+    fmt.Println("    }")
+    fmt.Println("    return math.NaN()")
+    fmt.Println("}")
+    fmt.Println()
+
+    fmt.Println("// Units returns the units of the named field's scaled value")
+    fmt.Printf("func (msg *Msg%s) Units(name string) string {\n", msg.cls)
+    fmt.Println("    switch name {")
+    for _, f := range msg.flds {
+        if f.Units() != "" {
+            fmt.Printf("    case \"%s\": return \"%s\"\n", f.ProfileName(),
+                f.Units())
         }
     }
-    fmt.Println(fmtstr + ")")
+    for _, f := range msg.subfieldOwners() {
+        for _, sub := range f.subfields {
+            if sub.Units() != "" {
+                fmt.Printf("    case \"%s\": return \"%s\"\n",
+                    sub.ProfileName(), sub.Units())
+            }
+        }
+    }
+    fmt.Println("    default: return \"\"")
+    fmt.Println("    }")
     fmt.Println("}")
     fmt.Println()
 
+    if owners := msg.subfieldOwners(); len(owners) > 0 {
+        fmt.Println("// SubField returns the name of the subfield selected for the named")
+        fmt.Println("// field by the message's other fields, or an empty string if none is")
+        fmt.Printf("func (msg *Msg%s) SubField(name string) string {\n",
+            msg.cls)
+        fmt.Println("    switch name {")
+        for _, f := range owners {
+            fmt.Printf("    case \"%s\": return msg.%s()\n",
+                f.ProfileName(), f.SubfieldFunc())
+        }
+        fmt.Println("    default: return \"\"")
+        fmt.Println("    }")
+        fmt.Println("}")
+        fmt.Println()
+    }
+
+    if len(msg.AccumulatedFields()) > 0 {
+        fmt.Println("// Accumulated returns the named field's running total, which keeps")
+        fmt.Println("// increasing when the value in the file rolls over, or NaN if the")
+        fmt.Println("// field is not set or is not accumulated")
+        fmt.Printf("func (msg *Msg%s) Accumulated(name string) float64 {\n",
+            msg.cls)
+        fmt.Println("    switch name {")
+        for _, f := range msg.flds {
+            if !f.IsAccumulated() {
+                continue
+            }
+
+            scale := f.scale
+            if scale == 0 {
+                scale = 1
+            }
+
+            fmt.Printf("    case \"%s\": return msg.total(%d, %g, %g)\n",
+                f.ProfileName(), f.num, scale, f.offset)
+        }
+        fmt.Println("    default: return math.NaN()")
+        fmt.Println("    }")
+        fmt.Println("}")
+        fmt.Println()
+    }
+
+    msg.printValueFuncs()
+
     fmt.Printf("func NewMsg%s(def *FitDefinition, data []byte)" +
         " (*Msg%s, error) {\n", msg.cls, msg.cls)
     fmt.Printf("    msg := new(Msg%s)\n", msg.cls)
     fmt.Println()
+
+    // fields which aren't in the message keep their invalid values
+    need_blank := false
+    for _, f := range msg.flds {
+        if inval := f.InvalidValue(); inval != "" {
+            fmt.Printf("    msg.%s = %s\n", f.Name(), inval)
+            need_blank = true
+        }
+    }
+    if need_blank {
+        fmt.Println()
+    }
+    fmt.Println("    pos := 0")
+    fmt.Println("    for i := 0; i < len(def.Fields); i++ {")
+    fmt.Println("        fld := def.Fields[i]")
+    fmt.Println("        buf := data[pos:pos + int(fld.Size)]")
+    fmt.Println("        pos += int(fld.Size)")
+    fmt.Println()
     if len(msg.flds) > 0 {
-        fmt.Println("    pos := 0")
+        fmt.Println("        order := def.fieldOrder(fld)")
     }
-    fmt.Println("    for i := 0; i < len(def.fields); i++ {")
-    fmt.Println("        switch def.fields[i].num {")
+    fmt.Println("        switch fld.Num {")
     for _, f := range msg.flds {
-        fmt.Printf("        case %d: msg.%s, pos = get_%s_pos(data, pos)\n",
-            f.Number(), f.Name(), f.GoType())
+        fmt.Printf("        case %d: msg.%s = %s\n", f.Number(), f.Name(),
+            f.GetExpr())
     }
     fmt.Println("        default:")
-    if lowcls == "device_info" {
-        fmt.Printf("            fmt.Printf(\"Ignoring %s field #%%d\\n\"," +
-            " def.fields[i].num)\n", lowcls)
-    } else {
-        fmt.Printf("            errmsg := fmt.Sprintf(\"Bad %s field #%%d\"," +
-            " def.fields[i].num)\n", lowcls)
-        fmt.Println("            return nil, errors.New(errmsg)")
-    }
+    fmt.Println("            msg.addUnknown(def, fld, buf)")
     fmt.Println("        }")
     fmt.Println("    }")
     fmt.Println()